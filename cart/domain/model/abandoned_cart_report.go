@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// AbandonedCartReport 已上报的弃购记录，每个用户一条。
+// LastActiveAt 为上报时购物车的最后变动时间，购物车再次变动后才会重新上报。
+type AbandonedCartReport struct {
+	ID           int64       `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	UserID       int64       `gorm:"not_null;uniqueIndex" json:"user_id"`
+	LastActiveAt time.Time   `gorm:"not_null" json:"last_active_at"`
+	TotalValue   money.Money `gorm:"embedded;embeddedPrefix:total_value_" json:"total_value"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
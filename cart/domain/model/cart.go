@@ -1,9 +1,12 @@
 package model
 
+import "time"
+
 type Cart struct {
-	ID        int64 `gorm:"primary_key;not_null;auto_increment" json:"id"`
-	ProductID int64 `gorm:"not_null" json:"product_id"`
-	Num       int64 `gorm:"not_null" json:"num"`
-	SizeID    int64 `gorm:"not_null" json:"size_id"`
-	UserID    int64 `gorm:"not_null" json:"user_id"`
+	ID        int64     `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	ProductID int64     `gorm:"not_null" json:"product_id"`
+	Num       int64     `gorm:"not_null" json:"num"`
+	SizeID    int64     `gorm:"not_null" json:"size_id"`
+	UserID    int64     `gorm:"not_null" json:"user_id"`
+	UpdatedAt time.Time `json:"updated_at"` // 最后变动时间，用于弃购检测
 }
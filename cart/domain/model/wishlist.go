@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// Wishlist 心愿单（稍后购买）条目，保留加入时的商品与规格，便于移回购物车
+type Wishlist struct {
+	ID          int64       `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	ProductID   int64       `gorm:"not_null" json:"product_id"`
+	SizeID      int64       `gorm:"not_null" json:"size_id"`
+	UserID      int64       `gorm:"not_null;index" json:"user_id"`
+	Num         int64       `gorm:"not_null" json:"num"`
+	LastPrice   money.Money `gorm:"embedded;embeddedPrefix:last_price_" json:"last_price"` // 最近一次记录的商品价格，用于降价提醒
+	LastInStock bool        `json:"last_in_stock"`                                         // 最近一次记录的是否有货，用于到货提醒
+	CreatedAt   time.Time   `json:"created_at"`
+}
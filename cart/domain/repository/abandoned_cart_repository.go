@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"cart/domain/model"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdleCart 超过时长未变动且尚未上报的用户购物车
+type IdleCart struct {
+	UserID       int64
+	LastActiveAt time.Time
+}
+
+type IAbandonedCartRepository interface {
+	InitTable() error
+	FindUnreported(time.Time, int) ([]IdleCart, error)
+	MarkReported(*model.AbandonedCartReport) error
+	Stats() (int64, []money.Money, error)
+	FindReport(int64) (*model.AbandonedCartReport, error)
+	DeleteReport(int64) (int64, error)
+}
+
+// 创建abandonedCartRepository
+func NewAbandonedCartRepository(db *gorm.DB) IAbandonedCartRepository {
+	return &AbandonedCartRepository{mysqlDb: db}
+}
+
+type AbandonedCartRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (u *AbandonedCartRepository) InitTable() error {
+	if err := u.mysqlDb.AutoMigrate(&model.AbandonedCartReport{}); err != nil {
+		return err
+	}
+	return money.MigrateFloatColumn(u.mysqlDb, &model.AbandonedCartReport{}, "total_value", "total_value_", money.DefaultCurrency)
+}
+
+// lastActive 按用户汇总购物车的最后变动时间
+func (u *AbandonedCartRepository) lastActive() *gorm.DB {
+	return u.mysqlDb.Model(&model.Cart{}).
+		Select("user_id, MAX(updated_at) AS last_active_at").
+		Group("user_id")
+}
+
+// 查找最后变动早于 before 的购物车，已按同一最后变动时间上报过的不再返回
+func (u *AbandonedCartRepository) FindUnreported(before time.Time, limit int) (idle []IdleCart, err error) {
+	return idle, u.mysqlDb.Table("(?) AS t", u.lastActive()).
+		Select("t.user_id, t.last_active_at").
+		Joins("LEFT JOIN abandoned_cart_reports r ON r.user_id = t.user_id").
+		Where("t.last_active_at < ?", before).
+		Where("r.id IS NULL OR r.last_active_at < t.last_active_at").
+		Order("t.last_active_at").
+		Limit(limit).
+		Scan(&idle).Error
+}
+
+// 记录上报结果，同一用户覆盖上一次的记录
+func (u *AbandonedCartRepository) MarkReported(report *model.AbandonedCartReport) error {
+	return u.mysqlDb.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_active_at", "total_value_amount", "total_value_currency", "updated_at"}),
+	}).Create(report).Error
+}
+
+// 统计当前仍处于弃购状态的购物车数量与按币种汇总的金额：已上报且此后未再变动
+func (u *AbandonedCartRepository) Stats() (count int64, values []money.Money, err error) {
+	var stats []struct {
+		Count    int64
+		Amount   int64
+		Currency string
+	}
+	err = u.mysqlDb.Table("abandoned_cart_reports r").
+		Select("COUNT(*) AS count, COALESCE(SUM(r.total_value_amount), 0) AS amount, r.total_value_currency AS currency").
+		Joins("JOIN (?) AS t ON t.user_id = r.user_id AND t.last_active_at = r.last_active_at", u.lastActive()).
+		Group("r.total_value_currency").
+		Scan(&stats).Error
+	for _, s := range stats {
+		count += s.Count
+		values = append(values, money.New(s.Amount, s.Currency))
+	}
+	return count, values, err
+}
+
+// 查找用户的弃购上报记录，没有时返回 nil
+func (u *AbandonedCartRepository) FindReport(userID int64) (*model.AbandonedCartReport, error) {
+	var reports []model.AbandonedCartReport
+	if err := u.mysqlDb.Where("user_id = ?", userID).Limit(1).Find(&reports).Error; err != nil || len(reports) == 0 {
+		return nil, err
+	}
+	return &reports[0], nil
+}
+
+// 删除用户的弃购上报记录，返回删除条数
+func (u *AbandonedCartRepository) DeleteReport(userID int64) (int64, error) {
+	db := u.mysqlDb.Where("user_id = ?", userID).Delete(&model.AbandonedCartReport{})
+	return db.RowsAffected, db.Error
+}
@@ -9,17 +9,26 @@ import (
 
 type ICartRepository interface {
 	InitTable() error
-	FindCartByID(int64) (*model.Cart, error)
+	FindCartByID(int64, int64) (*model.Cart, error)
+	FindCartByProduct(int64, int64, int64) (*model.Cart, error)
 	CreateCart(*model.Cart) (int64, error)
-	DeleteCartByID(int64) error
+	DeleteCartByID(int64, int64) error
 	UpdateCart(*model.Cart) error
 	FindAll(int64) ([]model.Cart, error)
 
 	CleanCart(int64) error
-	IncrNum(int64, int64) error
-	DecrNum(int64, int64) error
+	IncrNum(int64, int64, int64, int64) error
+	DecrNum(int64, int64, int64) error
+	SumProductNum(int64, int64) (int64, error)
 }
 
+var (
+	// ErrNumOutOfRange 条件更新未命中，即变更后的数量超出允许范围
+	ErrNumOutOfRange = errors.New("购物车数量超出范围")
+	// ErrCartExists 用户购物车中已有相同商品规格的条目
+	ErrCartExists = errors.New("购物车已有该商品规格")
+)
+
 // 创建cartRepository
 func NewCartRepository(db *gorm.DB) ICartRepository {
 	return &CartRepository{mysqlDb: db}
@@ -31,35 +40,48 @@ type CartRepository struct {
 
 // 初始化表
 func (u *CartRepository) InitTable() error {
-	return u.mysqlDb.AutoMigrate(&model.Cart{}).Error
+	return u.mysqlDb.AutoMigrate(&model.Cart{})
+}
+
+// 根据ID查找用户的Cart信息，不属于该用户时返回 gorm.ErrRecordNotFound
+func (u *CartRepository) FindCartByID(cartID int64, userID int64) (cart *model.Cart, err error) {
+	cart = &model.Cart{}
+	return cart, u.mysqlDb.Where("id = ? AND user_id = ?", cartID, userID).First(cart).Error
 }
 
-// 根据ID查找Cart信息
-func (u *CartRepository) FindCartByID(cartID int64) (cart *model.Cart, err error) {
+// 查找用户购物车中指定商品规格的条目，不存在时返回 gorm.ErrRecordNotFound
+func (u *CartRepository) FindCartByProduct(userID int64, productID int64, sizeID int64) (cart *model.Cart, err error) {
 	cart = &model.Cart{}
-	return cart, u.mysqlDb.First(cart, cartID).Error
+	return cart, u.mysqlDb.Where("user_id = ? AND product_id = ? AND size_id = ?", userID, productID, sizeID).First(cart).Error
 }
 
-// 创建Cart信息
+// 创建Cart信息，已有相同商品规格的条目时返回 ErrCartExists
 func (u *CartRepository) CreateCart(cart *model.Cart) (int64, error) {
 	db := u.mysqlDb.FirstOrCreate(cart, model.Cart{ProductID: cart.ProductID, SizeID: cart.SizeID, UserID: cart.UserID})
 	if db.Error != nil {
 		return 0, db.Error
 	}
 	if db.RowsAffected == 0 {
-		return 0, errors.New("购物车插入失败")
+		return 0, ErrCartExists
 	}
 	return cart.ID, nil
 }
 
-// 根据ID删除Cart信息
-func (u *CartRepository) DeleteCartByID(cartID int64) error {
-	return u.mysqlDb.Where("id = ?", cartID).Delete(&model.Cart{}).Error
+// 根据ID删除用户的Cart信息，未命中时返回 gorm.ErrRecordNotFound
+func (u *CartRepository) DeleteCartByID(cartID int64, userID int64) error {
+	db := u.mysqlDb.Where("id = ? AND user_id = ?", cartID, userID).Delete(&model.Cart{})
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 // 更新Cart信息
 func (u *CartRepository) UpdateCart(cart *model.Cart) error {
-	return u.mysqlDb.Model(cart).Update(cart).Error
+	return u.mysqlDb.Model(cart).Updates(cart).Error
 }
 
 // 获取结果集
@@ -72,21 +94,35 @@ func (u *CartRepository) CleanCart(userID int64) error {
 	return u.mysqlDb.Where("user_id = ?", userID).Delete(&model.Cart{}).Error
 }
 
-// 添加商品数量
-func (u *CartRepository) IncrNum(cartID int64, num int64) error {
+// 添加商品数量，增加后的数量不得超过 max
+func (u *CartRepository) IncrNum(cartID int64, userID int64, num int64, max int64) error {
 	cart := &model.Cart{ID: cartID}
-	return u.mysqlDb.Model(cart).UpdateColumn("num", gorm.Expr("num + ?", num)).Error
+	db := u.mysqlDb.Model(cart).Where("user_id = ? AND num + ? <= ?", userID, num, max).Update("num", gorm.Expr("num + ?", num))
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return ErrNumOutOfRange
+	}
+	return nil
 }
 
 // 购物车减少商品
-func (u *CartRepository) DecrNum(cartID int64, num int64) error {
+func (u *CartRepository) DecrNum(cartID int64, userID int64, num int64) error {
 	cart := &model.Cart{ID: cartID}
-	db := u.mysqlDb.Model(cart).Where("num >= ?", num).UpdateColumn("num", gorm.Expr("num - ?", num))
+	db := u.mysqlDb.Model(cart).Where("user_id = ? AND num >= ?", userID, num).Update("num", gorm.Expr("num - ?", num))
 	if db.Error != nil {
 		return db.Error
 	}
 	if db.RowsAffected == 0 {
-		return errors.New("减少失败")
+		return ErrNumOutOfRange
 	}
 	return nil
 }
+
+// 统计用户购物车中某商品（所有规格）的总数量
+func (u *CartRepository) SumProductNum(userID int64, productID int64) (total int64, err error) {
+	return total, u.mysqlDb.Model(&model.Cart{}).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Select("COALESCE(SUM(num), 0)").Scan(&total).Error
+}
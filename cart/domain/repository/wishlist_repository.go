@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"cart/domain/model"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+)
+
+type IWishlistRepository interface {
+	InitTable() error
+	FindWishlistByID(int64, int64) (*model.Wishlist, error)
+	CreateWishlist(*model.Wishlist) (int64, error)
+	DeleteWishlistByID(int64, int64) (bool, error)
+	FindAll(int64) ([]model.Wishlist, error)
+	FindAllByProduct(int64) ([]model.Wishlist, error)
+	DeleteAll(int64) (int64, error)
+	UpdateSnapshot(int64, money.Money, bool) error
+
+	MoveToCart(int64, int64) (int64, error)
+	MoveToWishlist(int64, int64) (int64, error)
+}
+
+// 创建wishlistRepository
+func NewWishlistRepository(db *gorm.DB) IWishlistRepository {
+	return &WishlistRepository{mysqlDb: db}
+}
+
+type WishlistRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (u *WishlistRepository) InitTable() error {
+	if err := u.mysqlDb.AutoMigrate(&model.Wishlist{}); err != nil {
+		return err
+	}
+	return money.MigrateFloatColumn(u.mysqlDb, &model.Wishlist{}, "last_price", "last_price_", money.DefaultCurrency)
+}
+
+// 根据ID查找用户的心愿单条目
+func (u *WishlistRepository) FindWishlistByID(wishlistID int64, userID int64) (wishlist *model.Wishlist, err error) {
+	wishlist = &model.Wishlist{}
+	return wishlist, u.mysqlDb.Where("id = ? AND user_id = ?", wishlistID, userID).First(wishlist).Error
+}
+
+// 加入心愿单，同一用户同一商品规格只保留一条
+func (u *WishlistRepository) CreateWishlist(wishlist *model.Wishlist) (int64, error) {
+	err := u.mysqlDb.Where(model.Wishlist{ProductID: wishlist.ProductID, SizeID: wishlist.SizeID, UserID: wishlist.UserID}).
+		FirstOrCreate(wishlist).Error
+	return wishlist.ID, err
+}
+
+// 删除用户的心愿单条目，返回是否命中
+func (u *WishlistRepository) DeleteWishlistByID(wishlistID int64, userID int64) (bool, error) {
+	db := u.mysqlDb.Where("id = ? AND user_id = ?", wishlistID, userID).Delete(&model.Wishlist{})
+	return db.RowsAffected > 0, db.Error
+}
+
+// 获取用户心愿单
+func (u *WishlistRepository) FindAll(userID int64) (wishlistAll []model.Wishlist, err error) {
+	return wishlistAll, u.mysqlDb.Where("user_id = ?", userID).Find(&wishlistAll).Error
+}
+
+// 删除用户的全部心愿单条目，返回删除条数
+func (u *WishlistRepository) DeleteAll(userID int64) (int64, error) {
+	db := u.mysqlDb.Where("user_id = ?", userID).Delete(&model.Wishlist{})
+	return db.RowsAffected, db.Error
+}
+
+// 获取收藏了某商品的所有心愿单条目
+func (u *WishlistRepository) FindAllByProduct(productID int64) (wishlistAll []model.Wishlist, err error) {
+	return wishlistAll, u.mysqlDb.Where("product_id = ?", productID).Find(&wishlistAll).Error
+}
+
+// 更新价格与库存快照
+func (u *WishlistRepository) UpdateSnapshot(wishlistID int64, price money.Money, inStock bool) error {
+	return u.mysqlDb.Model(&model.Wishlist{ID: wishlistID}).
+		UpdateColumns(map[string]interface{}{
+			"last_price_amount":   price.Amount,
+			"last_price_currency": price.Currency,
+			"last_in_stock":       inStock,
+		}).Error
+}
+
+// 心愿单条目移入购物车：已有相同商品规格时累加数量，随后删除心愿单条目
+func (u *WishlistRepository) MoveToCart(wishlistID int64, userID int64) (cartID int64, err error) {
+	err = u.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		wishlist := &model.Wishlist{}
+		if err := tx.Where("id = ? AND user_id = ?", wishlistID, userID).First(wishlist).Error; err != nil {
+			return err
+		}
+		cart := &model.Cart{}
+		if err := tx.Where(model.Cart{ProductID: wishlist.ProductID, SizeID: wishlist.SizeID, UserID: userID}).
+			Attrs(model.Cart{Num: 0}).FirstOrCreate(cart).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(cart).Update("num", gorm.Expr("num + ?", wishlist.Num)).Error; err != nil {
+			return err
+		}
+		cartID = cart.ID
+		return tx.Delete(wishlist).Error
+	})
+	return cartID, err
+}
+
+// 购物车条目移入心愿单，保留商品、规格与数量，随后删除购物车条目
+func (u *WishlistRepository) MoveToWishlist(cartID int64, userID int64) (wishlistID int64, err error) {
+	err = u.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		cart := &model.Cart{}
+		if err := tx.Where("id = ? AND user_id = ?", cartID, userID).First(cart).Error; err != nil {
+			return err
+		}
+		wishlist := &model.Wishlist{}
+		if err := tx.Where(model.Wishlist{ProductID: cart.ProductID, SizeID: cart.SizeID, UserID: userID}).
+			Attrs(model.Wishlist{Num: cart.Num}).FirstOrCreate(wishlist).Error; err != nil {
+			return err
+		}
+		wishlistID = wishlist.ID
+		return tx.Delete(cart).Error
+	})
+	return wishlistID, err
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"cart/domain/model"
+	"cart/domain/repository"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// AbandonedCart 弃购事件内容
+type AbandonedCart struct {
+	UserID       int64
+	Items        []model.Cart
+	TotalValue   money.Money
+	LastActiveAt time.Time
+}
+
+// IAbandonedCartNotifier 发布弃购事件，由 main 中基于 micro.Event 的实现注入
+type IAbandonedCartNotifier interface {
+	Notify(ctx context.Context, cart *AbandonedCart) error
+}
+
+// IAbandonedCartMetrics 弃购指标记录
+type IAbandonedCartMetrics interface {
+	ObserveReported(value money.Money)
+	SetAbandoned(count int64, values []money.Money)
+}
+
+type IAbandonedCartService interface {
+	Scan(context.Context) (int, error)
+	Run(context.Context, time.Duration)
+}
+
+// 创建
+func NewAbandonedCartService(abandonedRepository repository.IAbandonedCartRepository, cartRepository repository.ICartRepository,
+	productChecker IProductChecker, notifier IAbandonedCartNotifier, metrics IAbandonedCartMetrics,
+	idleAfter time.Duration, batchSize int) IAbandonedCartService {
+	return &AbandonedCartService{
+		AbandonedRepository: abandonedRepository,
+		CartRepository:      cartRepository,
+		ProductChecker:      productChecker,
+		Notifier:            notifier,
+		Metrics:             metrics,
+		IdleAfter:           idleAfter,
+		BatchSize:           batchSize,
+	}
+}
+
+type AbandonedCartService struct {
+	AbandonedRepository repository.IAbandonedCartRepository
+	CartRepository      repository.ICartRepository
+	ProductChecker      IProductChecker
+	Notifier            IAbandonedCartNotifier
+	Metrics             IAbandonedCartMetrics
+	IdleAfter           time.Duration
+	BatchSize           int
+}
+
+// Run 按 interval 周期扫描，直到 ctx 结束
+func (u *AbandonedCartService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := u.Scan(ctx); err != nil {
+			slog.Error("弃购扫描失败", "error", err)
+		} else if n > 0 {
+			slog.Info("弃购扫描完成", "reported", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan 查找超过 IdleAfter 未变动且未上报的购物车，发布弃购事件并记录，返回本次上报数量。
+// 事件发布成功后才记录上报，发布失败的购物车会在下次扫描时重试。
+func (u *AbandonedCartService) Scan(ctx context.Context) (int, error) {
+	idle, err := u.AbandonedRepository.FindUnreported(time.Now().Add(-u.IdleAfter), u.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reported := 0
+	for _, c := range idle {
+		items, err := u.CartRepository.FindAll(c.UserID)
+		if err != nil {
+			return reported, err
+		}
+		if len(items) == 0 {
+			continue
+		}
+		abandoned := &AbandonedCart{
+			UserID:       c.UserID,
+			Items:        items,
+			TotalValue:   u.totalValue(ctx, items),
+			LastActiveAt: c.LastActiveAt,
+		}
+		if err := u.Notifier.Notify(ctx, abandoned); err != nil {
+			slog.Warn("发布弃购事件失败", "user_id", c.UserID, "error", err)
+			continue
+		}
+		if err := u.AbandonedRepository.MarkReported(&model.AbandonedCartReport{
+			UserID:       c.UserID,
+			LastActiveAt: c.LastActiveAt,
+			TotalValue:   abandoned.TotalValue,
+		}); err != nil {
+			return reported, err
+		}
+		u.Metrics.ObserveReported(abandoned.TotalValue)
+		reported++
+	}
+
+	count, values, err := u.AbandonedRepository.Stats()
+	if err != nil {
+		return reported, err
+	}
+	u.Metrics.SetAbandoned(count, values)
+	return reported, nil
+}
+
+// 按商品当前价格计算购物车金额，查询失败、币种与首个商品不一致或金额超出范围的商品不计入
+func (u *AbandonedCartService) totalValue(ctx context.Context, items []model.Cart) money.Money {
+	var total money.Money
+	for _, item := range items {
+		stock, err := u.ProductChecker.FindProductStock(ctx, item.ProductID, item.SizeID)
+		if err != nil {
+			slog.Warn("查询商品价格失败", "product_id", item.ProductID, "size_id", item.SizeID, "error", err)
+			continue
+		}
+		lineTotal, err := stock.Price.Multiply(item.Num)
+		if err != nil {
+			slog.Warn("商品金额超出范围", "product_id", item.ProductID, "num", item.Num, "error", err)
+			continue
+		}
+		sum, err := total.Add(lineTotal)
+		if err != nil {
+			slog.Warn("商品币种不一致或金额超出范围", "product_id", item.ProductID, "currency", stock.Price.Currency, "error", err)
+			continue
+		}
+		total = sum
+	}
+	return total
+}
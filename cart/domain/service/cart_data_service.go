@@ -3,37 +3,95 @@ package service
 import (
 	"cart/domain/model"
 	"cart/domain/repository"
+	"context"
+	"errors"
+
+	"github.com/Ben1524/GoMall/common/exchange"
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/Ben1524/GoMall/common/promotion"
+	"gorm.io/gorm"
 )
 
 type ICartDataService interface {
-	AddCart(*model.Cart) (int64, error)
-	DeleteCart(int64) error
+	AddCart(context.Context, *model.Cart) (int64, error)
+	DeleteCart(int64, int64) error
 	UpdateCart(*model.Cart) error
-	FindCartByID(int64) (*model.Cart, error)
+	FindCartByID(int64, int64) (*model.Cart, error)
 	FindAllCart(int64) ([]model.Cart, error)
 
 	CleanCart(int64) error
-	DecrNum(int64, int64) error
-	IncrNum(int64, int64) error
+	DecrNum(int64, int64, int64) error
+	IncrNum(context.Context, int64, int64, int64) error
+
+	PriceCart(context.Context, int64, string) (*promotion.Result, error)
+	DisplayPrice(context.Context, *promotion.Result, string) (*DisplayPrice, error)
+}
+
+// IPromotionPricer 按促销规则试算金额，由 promotion.Service 实现
+type IPromotionPricer interface {
+	Price(userID int64, couponCode string, lines []promotion.Line) (*promotion.Result, error)
 }
 
 // 创建
-func NewCartDataService(cartRepository repository.ICartRepository) ICartDataService {
-	return &CartDataService{cartRepository}
+func NewCartDataService(cartRepository repository.ICartRepository, productChecker IProductChecker,
+	promotionService IPromotionPricer, rates exchange.Provider) ICartDataService {
+	return &CartDataService{CartRepository: cartRepository, ProductChecker: productChecker,
+		PromotionService: promotionService, Rates: rates}
 }
 
 type CartDataService struct {
-	CartRepository repository.ICartRepository
+	CartRepository   repository.ICartRepository
+	ProductChecker   IProductChecker
+	PromotionService IPromotionPricer  // 与订单结算共用的促销规则
+	Rates            exchange.Provider // 展示币种换算
 }
 
-// 插入
-func (u *CartDataService) AddCart(cart *model.Cart) (int64, error) {
-	return u.CartRepository.CreateCart(cart)
+// DisplayPrice 试算结果换算为展示币种后的金额，仅用于展示，结算以订单币种为准
+type DisplayPrice struct {
+	Subtotal money.Money
+	Discount money.Money
+	Total    money.Money
+	Rate     exchange.Rate
 }
 
-// 删除
-func (u *CartDataService) DeleteCart(cartID int64) error {
-	return u.CartRepository.DeleteCartByID(cartID)
+// 插入，写入前校验商品、规格、库存与限购。
+// 购物车已有相同商品规格时合并数量，与增加数量一样按合并后的数量校验库存与限购。
+func (u *CartDataService) AddCart(ctx context.Context, cart *model.Cart) (int64, error) {
+	if cart.UserID <= 0 {
+		return 0, ErrInvalidUser
+	}
+	if cart.Num <= 0 {
+		return 0, ErrInvalidQuantity
+	}
+	existing, err := u.CartRepository.FindCartByProduct(cart.UserID, cart.ProductID, cart.SizeID)
+	if err == nil {
+		return existing.ID, u.IncrNum(ctx, existing.ID, cart.UserID, cart.Num)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	stock, err := u.ProductChecker.FindProductStock(ctx, cart.ProductID, cart.SizeID)
+	if err != nil {
+		return 0, err
+	}
+	if err := u.checkQuantity(stock, cart.UserID, cart.Num, cart.Num); err != nil {
+		return 0, err
+	}
+	cartID, err := u.CartRepository.CreateCart(cart)
+	if errors.Is(err, repository.ErrCartExists) {
+		// 并发请求已先加入同一商品规格
+		return 0, ErrCartConflict
+	}
+	return cartID, err
+}
+
+// 删除，只能删除自己的购物车记录
+func (u *CartDataService) DeleteCart(cartID int64, userID int64) error {
+	if userID <= 0 {
+		return ErrInvalidUser
+	}
+	return notFoundAsCartError(u.CartRepository.DeleteCartByID(cartID, userID))
 }
 
 // 更新
@@ -42,8 +100,9 @@ func (u *CartDataService) UpdateCart(cart *model.Cart) error {
 }
 
 // 查找
-func (u *CartDataService) FindCartByID(cartID int64) (*model.Cart, error) {
-	return u.CartRepository.FindCartByID(cartID)
+func (u *CartDataService) FindCartByID(cartID int64, userID int64) (*model.Cart, error) {
+	cart, err := u.CartRepository.FindCartByID(cartID, userID)
+	return cart, notFoundAsCartError(err)
 }
 
 // 查找
@@ -55,10 +114,131 @@ func (u *CartDataService) CleanCart(userID int64) error {
 	return u.CartRepository.CleanCart(userID)
 }
 
-func (u *CartDataService) DecrNum(cartID int64, num int64) error {
-	return u.CartRepository.DecrNum(cartID, num)
+// 减少数量，购物车记录必须属于该用户
+func (u *CartDataService) DecrNum(cartID int64, userID int64, num int64) error {
+	if userID <= 0 {
+		return ErrInvalidUser
+	}
+	if num <= 0 {
+		return ErrInvalidQuantity
+	}
+	if _, err := u.FindCartByID(cartID, userID); err != nil {
+		return err
+	}
+	if err := u.CartRepository.DecrNum(cartID, userID, num); err != nil {
+		if errors.Is(err, repository.ErrNumOutOfRange) {
+			return ErrDecrExceeded
+		}
+		return err
+	}
+	return nil
+}
+
+// 增加数量，同样受库存与限购约束
+func (u *CartDataService) IncrNum(ctx context.Context, cartID int64, userID int64, num int64) error {
+	if userID <= 0 {
+		return ErrInvalidUser
+	}
+	if num <= 0 {
+		return ErrInvalidQuantity
+	}
+	cart, err := u.FindCartByID(cartID, userID)
+	if err != nil {
+		return err
+	}
+	stock, err := u.ProductChecker.FindProductStock(ctx, cart.ProductID, cart.SizeID)
+	if err != nil {
+		return err
+	}
+	if err := u.checkQuantity(stock, cart.UserID, cart.Num+num, num); err != nil {
+		return err
+	}
+	// 以库存作为上限做条件更新，避免并发增加时越过库存
+	if err := u.CartRepository.IncrNum(cartID, userID, num, stock.Stock); err != nil {
+		if errors.Is(err, repository.ErrNumOutOfRange) {
+			return ErrStockInsufficient
+		}
+		return err
+	}
+	return nil
+}
+
+// PriceCart 按商品当前价格与促销规则试算用户购物车金额，只计算不核销
+func (u *CartDataService) PriceCart(ctx context.Context, userID int64, couponCode string) (*promotion.Result, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUser
+	}
+	carts, err := u.CartRepository.FindAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]promotion.Line, 0, len(carts))
+	for _, cart := range carts {
+		stock, err := u.ProductChecker.FindProductStock(ctx, cart.ProductID, cart.SizeID)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, promotion.Line{
+			ProductID:  cart.ProductID,
+			CategoryID: stock.CategoryID,
+			Price:      stock.Price,
+			Num:        cart.Num,
+		})
+	}
+	return u.PromotionService.Price(userID, couponCode, lines)
+}
+
+// DisplayPrice 按当前汇率将试算结果换算为展示币种
+func (u *CartDataService) DisplayPrice(ctx context.Context, result *promotion.Result, currency string) (*DisplayPrice, error) {
+	currency = money.Zero(currency).Currency
+	if !money.ValidCurrency(currency) {
+		return nil, money.ErrInvalidCurrency
+	}
+	rate, err := u.Rates.Rate(ctx, result.Total.Currency, currency)
+	if err != nil {
+		return nil, err
+	}
+	display := &DisplayPrice{Rate: rate}
+	for _, pair := range []struct {
+		from money.Money
+		to   *money.Money
+	}{
+		{result.Subtotal, &display.Subtotal},
+		{result.Discount, &display.Discount},
+		{result.Total, &display.Total},
+	} {
+		if *pair.to, err = exchange.Convert(pair.from, rate); err != nil {
+			return nil, err
+		}
+	}
+	return display, nil
+}
+
+// checkQuantity 校验商品可售、规格数量不超过库存、用户在该商品上的总数量不超过限购。
+// sizeNum 为操作后该规格的数量，added 为本次新增的数量。
+func (u *CartDataService) checkQuantity(stock *ProductStock, userID, sizeNum, added int64) error {
+	if !stock.Purchasable {
+		return ErrProductNotPurchasable
+	}
+	if sizeNum > stock.Stock {
+		return ErrStockInsufficient
+	}
+	if stock.PurchaseLimit > 0 {
+		total, err := u.CartRepository.SumProductNum(userID, stock.ProductID)
+		if err != nil {
+			return err
+		}
+		if total+added > stock.PurchaseLimit {
+			return ErrPurchaseLimitExceeded
+		}
+	}
+	return nil
 }
 
-func (u *CartDataService) IncrNum(cartID int64, num int64) error {
-	return u.CartRepository.IncrNum(cartID, num)
+// 记录不存在或不属于该用户时统一返回 ErrCartNotFound，不暴露记录是否存在
+func notFoundAsCartError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCartNotFound
+	}
+	return err
 }
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"cart/domain/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/Ben1524/GoMall/common/promotion"
+)
+
+type cartFixture struct {
+	carts    *fakeCarts
+	products fakeProducts
+	service  ICartDataService
+}
+
+// newCartFixture 商品 1 规格 10 库存 5、规格 11 库存 9，限购 6 件；用户 7 的购物车已有规格 10 共 3 件
+func newCartFixture(rules ...promotion.Rule) *cartFixture {
+	f := &cartFixture{
+		carts: newFakeCarts(model.Cart{ID: 1, UserID: 7, ProductID: 1, SizeID: 10, Num: 3}),
+		products: fakeProducts{
+			{1, 10}: {ProductID: 1, CategoryID: 100, SizeID: 10, Purchasable: true, Stock: 5, Price: money.New(2000, "USD"), PurchaseLimit: 6},
+			{1, 11}: {ProductID: 1, CategoryID: 100, SizeID: 11, Purchasable: true, Stock: 9, Price: money.New(2000, "USD"), PurchaseLimit: 6},
+			{2, 20}: {ProductID: 2, CategoryID: 200, SizeID: 20, Purchasable: false, Stock: 9, Price: money.New(500, "USD")},
+		},
+	}
+	f.service = NewCartDataService(f.carts, f.products, &fakePromotions{rules: rules}, nil)
+	return f
+}
+
+func (f *cartFixture) add(userID, productID, sizeID, num int64) (int64, error) {
+	return f.service.AddCart(context.Background(), &model.Cart{UserID: userID, ProductID: productID, SizeID: sizeID, Num: num})
+}
+
+// TestAddCartMergesSameSize 再次加入相同商品规格时合并到已有条目，而不是插入失败
+func TestAddCartMergesSameSize(t *testing.T) {
+	f := newCartFixture()
+
+	cartID, err := f.add(7, 1, 10, 2)
+	if err != nil {
+		t.Fatalf("AddCart: %v", err)
+	}
+	if cartID != 1 || len(f.carts.carts) != 1 || f.carts.carts[1].Num != 5 {
+		t.Fatalf("cart id = %d carts = %d num = %d, want merged into cart 1 with 5", cartID, len(f.carts.carts), f.carts.carts[1].Num)
+	}
+}
+
+// TestAddCartChecksStockWithExisting 库存按购物车中已有数量与新增数量之和校验
+func TestAddCartChecksStockWithExisting(t *testing.T) {
+	f := newCartFixture()
+
+	if _, err := f.add(7, 1, 10, 3); !errors.Is(err, ErrStockInsufficient) {
+		t.Fatalf("err = %v, want ErrStockInsufficient", err)
+	}
+	if f.carts.carts[1].Num != 3 {
+		t.Fatalf("num = %d, want unchanged 3", f.carts.carts[1].Num)
+	}
+}
+
+// TestAddCartPurchaseLimit 限购按用户在该商品所有规格上的总数量计算
+func TestAddCartPurchaseLimit(t *testing.T) {
+	f := newCartFixture()
+
+	if _, err := f.add(7, 1, 11, 4); !errors.Is(err, ErrPurchaseLimitExceeded) {
+		t.Fatalf("err = %v, want ErrPurchaseLimitExceeded", err)
+	}
+	if _, err := f.add(7, 1, 11, 3); err != nil {
+		t.Fatalf("AddCart within limit: %v", err)
+	}
+	// 其他用户不受用户 7 购物车的影响
+	if _, err := f.add(8, 1, 11, 6); err != nil {
+		t.Fatalf("AddCart for another user: %v", err)
+	}
+}
+
+// TestAddCartRejectsInvalidItems 下架商品、不存在的规格与非法数量
+func TestAddCartRejectsInvalidItems(t *testing.T) {
+	f := newCartFixture()
+
+	tests := []struct {
+		name                           string
+		userID, productID, sizeID, num int64
+		want                           error
+	}{
+		{"下架商品", 7, 2, 20, 1, ErrProductNotPurchasable},
+		{"规格不存在", 7, 1, 99, 1, ErrSizeNotFound},
+		{"数量为 0", 7, 1, 11, 0, ErrInvalidQuantity},
+		{"用户不合法", 0, 1, 11, 1, ErrInvalidUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.add(tt.userID, tt.productID, tt.sizeID, tt.num); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCartItemOwnership 只能修改、删除自己的购物车条目，他人的条目视为不存在
+func TestCartItemOwnership(t *testing.T) {
+	f := newCartFixture()
+	ctx := context.Background()
+
+	if err := f.service.IncrNum(ctx, 1, 8, 1); !errors.Is(err, ErrCartNotFound) {
+		t.Fatalf("IncrNum err = %v, want ErrCartNotFound", err)
+	}
+	if err := f.service.DecrNum(1, 8, 1); !errors.Is(err, ErrCartNotFound) {
+		t.Fatalf("DecrNum err = %v, want ErrCartNotFound", err)
+	}
+	if err := f.service.DeleteCart(1, 8); !errors.Is(err, ErrCartNotFound) {
+		t.Fatalf("DeleteCart err = %v, want ErrCartNotFound", err)
+	}
+	if f.carts.carts[1].Num != 3 {
+		t.Fatalf("num = %d, want unchanged 3", f.carts.carts[1].Num)
+	}
+}
+
+// TestIncrDecrBounds 增加不超过库存，减少不超过已有数量
+func TestIncrDecrBounds(t *testing.T) {
+	f := newCartFixture()
+	ctx := context.Background()
+
+	if err := f.service.IncrNum(ctx, 1, 7, 3); !errors.Is(err, ErrStockInsufficient) {
+		t.Fatalf("IncrNum err = %v, want ErrStockInsufficient", err)
+	}
+	if err := f.service.IncrNum(ctx, 1, 7, 2); err != nil {
+		t.Fatalf("IncrNum: %v", err)
+	}
+	if err := f.service.DecrNum(1, 7, 6); !errors.Is(err, ErrDecrExceeded) {
+		t.Fatalf("DecrNum err = %v, want ErrDecrExceeded", err)
+	}
+	if err := f.service.DecrNum(1, 7, 4); err != nil || f.carts.carts[1].Num != 1 {
+		t.Fatalf("DecrNum = %v num = %d, want 1", err, f.carts.carts[1].Num)
+	}
+}
+
+// TestPriceCartUsesCurrentPrice 试算按商品服务的当前价格与促销规则计算
+func TestPriceCartUsesCurrentPrice(t *testing.T) {
+	f := newCartFixture(promotion.Rule{ID: 1, Type: promotion.RulePercentOff, Percent: 10})
+	f.products[[2]int64{1, 10}].Price = money.New(2500, "USD")
+
+	result, err := f.service.PriceCart(context.Background(), 7, "")
+	if err != nil {
+		t.Fatalf("PriceCart: %v", err)
+	}
+	if result.Subtotal != money.New(7500, "USD") || result.Discount != money.New(750, "USD") || result.Total != money.New(6750, "USD") {
+		t.Fatalf("result = %v / %v / %v, want 75.00 / 7.50 / 67.50 USD", result.Subtotal, result.Discount, result.Total)
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import "net/http"
+
+// CartError 购物车业务校验错误，Code 与 HTTP 状态码保持一致，
+// handler 据此转换为 go-micro 错误，网关再映射为对应的 4xx 响应。
+type CartError struct {
+	Code int32
+	Msg  string
+}
+
+func (e *CartError) Error() string {
+	return e.Msg
+}
+
+var (
+	ErrInvalidUser           = &CartError{Code: http.StatusBadRequest, Msg: "用户ID不合法"}
+	ErrInvalidQuantity       = &CartError{Code: http.StatusBadRequest, Msg: "商品数量必须大于0"}
+	ErrProductNotFound       = &CartError{Code: http.StatusNotFound, Msg: "商品不存在"}
+	ErrSizeNotFound          = &CartError{Code: http.StatusBadRequest, Msg: "商品规格不存在或不属于该商品"}
+	ErrProductNotPurchasable = &CartError{Code: http.StatusBadRequest, Msg: "商品已下架，暂不可购买"}
+	ErrStockInsufficient     = &CartError{Code: http.StatusConflict, Msg: "商品库存不足"}
+	ErrPurchaseLimitExceeded = &CartError{Code: http.StatusConflict, Msg: "超过商品限购数量"}
+	ErrCartNotFound          = &CartError{Code: http.StatusNotFound, Msg: "购物车记录不存在"}
+	ErrCartConflict          = &CartError{Code: http.StatusConflict, Msg: "购物车已有该商品规格，请刷新后重试"}
+	ErrDecrExceeded          = &CartError{Code: http.StatusBadRequest, Msg: "减少数量超过购物车中的数量"}
+	ErrWishlistNotFound      = &CartError{Code: http.StatusNotFound, Msg: "心愿单记录不存在"}
+)
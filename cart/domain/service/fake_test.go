@@ -0,0 +1,174 @@
+package service
+
+import (
+	"cart/domain/model"
+	"cart/domain/repository"
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/Ben1524/GoMall/common/promotion"
+	"gorm.io/gorm"
+)
+
+// 以下为服务测试使用的内存实现，只模拟服务依赖的仓储语义
+
+type fakeCarts struct {
+	repository.ICartRepository
+	carts  map[int64]*model.Cart
+	nextID int64
+}
+
+func newFakeCarts(carts ...model.Cart) *fakeCarts {
+	f := &fakeCarts{carts: map[int64]*model.Cart{}}
+	for i := range carts {
+		f.carts[carts[i].ID] = &carts[i]
+		f.nextID = max(f.nextID, carts[i].ID)
+	}
+	return f
+}
+
+func (f *fakeCarts) FindCartByID(cartID int64, userID int64) (*model.Cart, error) {
+	cart, ok := f.carts[cartID]
+	if !ok || cart.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *cart
+	return &copied, nil
+}
+
+func (f *fakeCarts) FindCartByProduct(userID int64, productID int64, sizeID int64) (*model.Cart, error) {
+	for _, cart := range f.carts {
+		if cart.UserID == userID && cart.ProductID == productID && cart.SizeID == sizeID {
+			copied := *cart
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeCarts) CreateCart(cart *model.Cart) (int64, error) {
+	if _, err := f.FindCartByProduct(cart.UserID, cart.ProductID, cart.SizeID); err == nil {
+		return 0, repository.ErrCartExists
+	}
+	f.nextID++
+	cart.ID = f.nextID
+	copied := *cart
+	f.carts[cart.ID] = &copied
+	return cart.ID, nil
+}
+
+func (f *fakeCarts) DeleteCartByID(cartID int64, userID int64) error {
+	if _, err := f.FindCartByID(cartID, userID); err != nil {
+		return err
+	}
+	delete(f.carts, cartID)
+	return nil
+}
+
+func (f *fakeCarts) FindAll(userID int64) ([]model.Cart, error) {
+	var cartAll []model.Cart
+	for _, cart := range f.carts {
+		if cart.UserID == userID {
+			cartAll = append(cartAll, *cart)
+		}
+	}
+	slices.SortFunc(cartAll, func(a, b model.Cart) int { return cmp.Compare(a.ID, b.ID) })
+	return cartAll, nil
+}
+
+func (f *fakeCarts) IncrNum(cartID int64, userID int64, num int64, max int64) error {
+	cart, ok := f.carts[cartID]
+	if !ok || cart.UserID != userID || cart.Num+num > max {
+		return repository.ErrNumOutOfRange
+	}
+	cart.Num += num
+	return nil
+}
+
+func (f *fakeCarts) DecrNum(cartID int64, userID int64, num int64) error {
+	cart, ok := f.carts[cartID]
+	if !ok || cart.UserID != userID || cart.Num < num {
+		return repository.ErrNumOutOfRange
+	}
+	cart.Num -= num
+	return nil
+}
+
+func (f *fakeCarts) SumProductNum(userID int64, productID int64) (int64, error) {
+	var total int64
+	for _, cart := range f.carts {
+		if cart.UserID == userID && cart.ProductID == productID {
+			total += cart.Num
+		}
+	}
+	return total, nil
+}
+
+// fakeProducts 按商品与规格返回库存，未登记的规格视为不存在
+type fakeProducts map[[2]int64]*ProductStock
+
+func (f fakeProducts) FindProductStock(ctx context.Context, productID, sizeID int64) (*ProductStock, error) {
+	stock, ok := f[[2]int64{productID, sizeID}]
+	if !ok {
+		return nil, ErrSizeNotFound
+	}
+	copied := *stock
+	return &copied, nil
+}
+
+// fakePromotions 在固定时刻按给定规则试算，不读写数据库
+type fakePromotions struct {
+	rules []promotion.Rule
+}
+
+var testNow = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func (f *fakePromotions) Price(userID int64, couponCode string, lines []promotion.Line) (*promotion.Result, error) {
+	return promotion.Apply(lines, f.rules, testNow)
+}
+
+type fakeWishlists struct {
+	repository.IWishlistRepository
+	wishlists map[int64]*model.Wishlist
+}
+
+func (f *fakeWishlists) FindAllByProduct(productID int64) ([]model.Wishlist, error) {
+	var wishlistAll []model.Wishlist
+	for _, wishlist := range f.wishlists {
+		if wishlist.ProductID == productID {
+			wishlistAll = append(wishlistAll, *wishlist)
+		}
+	}
+	slices.SortFunc(wishlistAll, func(a, b model.Wishlist) int { return cmp.Compare(a.ID, b.ID) })
+	return wishlistAll, nil
+}
+
+func (f *fakeWishlists) UpdateSnapshot(wishlistID int64, price money.Money, inStock bool) error {
+	wishlist, ok := f.wishlists[wishlistID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	wishlist.LastPrice = price
+	wishlist.LastInStock = inStock
+	return nil
+}
+
+// fakeNotifier 记录已发布的提醒，failReasons 中的原因发布失败
+type fakeNotifier struct {
+	sent        []WishlistNotice
+	failReasons map[string]bool
+}
+
+var errNotifyFailed = errors.New("发布提醒失败")
+
+func (f *fakeNotifier) Notify(ctx context.Context, notice *WishlistNotice) error {
+	if f.failReasons[notice.Reason] {
+		return errNotifyFailed
+	}
+	f.sent = append(f.sent, *notice)
+	return nil
+}
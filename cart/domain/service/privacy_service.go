@@ -0,0 +1,77 @@
+package service
+
+import (
+	"cart/domain/model"
+	"cart/domain/repository"
+)
+
+// CartUserData 购物车服务中与用户相关的全部数据，供隐私导出
+type CartUserData struct {
+	Carts         []model.Cart               `json:"carts"`
+	Wishlists     []model.Wishlist           `json:"wishlists"`
+	AbandonedCart *model.AbandonedCartReport `json:"abandoned_cart,omitempty"`
+}
+
+// IPrivacyService 用户数据导出与删除，由用户服务的隐私请求流程调用，可重复执行
+type IPrivacyService interface {
+	ExportUserData(int64) (*CartUserData, error)
+	// EraseUserData 删除用户的购物车、心愿单与弃购记录，返回删除条数
+	EraseUserData(int64) (int64, error)
+}
+
+// 创建
+func NewPrivacyService(cartRepository repository.ICartRepository, wishlistRepository repository.IWishlistRepository,
+	abandonedRepository repository.IAbandonedCartRepository) IPrivacyService {
+	return &PrivacyService{
+		CartRepository:      cartRepository,
+		WishlistRepository:  wishlistRepository,
+		AbandonedRepository: abandonedRepository,
+	}
+}
+
+type PrivacyService struct {
+	CartRepository      repository.ICartRepository
+	WishlistRepository  repository.IWishlistRepository
+	AbandonedRepository repository.IAbandonedCartRepository
+}
+
+// 导出
+func (u *PrivacyService) ExportUserData(userID int64) (*CartUserData, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUser
+	}
+	data := &CartUserData{}
+	var err error
+	if data.Carts, err = u.CartRepository.FindAll(userID); err != nil {
+		return nil, err
+	}
+	if data.Wishlists, err = u.WishlistRepository.FindAll(userID); err != nil {
+		return nil, err
+	}
+	if data.AbandonedCart, err = u.AbandonedRepository.FindReport(userID); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// 删除
+func (u *PrivacyService) EraseUserData(userID int64) (int64, error) {
+	if userID <= 0 {
+		return 0, ErrInvalidUser
+	}
+	carts, err := u.CartRepository.FindAll(userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := u.CartRepository.CleanCart(userID); err != nil {
+		return 0, err
+	}
+	affected := int64(len(carts))
+	n, err := u.WishlistRepository.DeleteAll(userID)
+	affected += n
+	if err != nil {
+		return affected, err
+	}
+	n, err = u.AbandonedRepository.DeleteReport(userID)
+	return affected + n, err
+}
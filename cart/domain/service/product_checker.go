@@ -0,0 +1,73 @@
+package service
+
+import (
+	pb "cart/proto/product"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Ben1524/GoMall/common/money"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// ProductStock 购物车校验所需的商品与规格信息
+type ProductStock struct {
+	ProductID     int64
+	CategoryID    int64
+	SizeID        int64
+	Purchasable   bool
+	Stock         int64       // 规格可售库存
+	Price         money.Money // 商品当前价格
+	PurchaseLimit int64       // 单个用户限购数量，0 表示不限购
+}
+
+// IProductChecker 向商品服务查询商品状态、规格与库存
+type IProductChecker interface {
+	FindProductStock(ctx context.Context, productID, sizeID int64) (*ProductStock, error)
+}
+
+// 商品上架状态，与商品服务 model.ProductStatusOnSale 保持一致
+const productStatusOnSale int32 = 1
+
+// 创建基于商品服务 RPC 的校验器
+func NewProductChecker(productService pb.ProductService) IProductChecker {
+	return &ProductChecker{productService: productService}
+}
+
+type ProductChecker struct {
+	productService pb.ProductService
+}
+
+// 查询商品并确认 sizeID 属于该商品
+func (p *ProductChecker) FindProductStock(ctx context.Context, productID, sizeID int64) (*ProductStock, error) {
+	productInfo, err := p.productService.FindProductByID(ctx, &pb.RequestID{ProductId: productID})
+	if err != nil {
+		if microerrors.FromError(err).Code == http.StatusNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("查询商品信息失败: %w", err)
+	}
+
+	for _, size := range productInfo.GetProductSize() {
+		if size.GetId() == sizeID {
+			return &ProductStock{
+				ProductID:     productID,
+				CategoryID:    productInfo.GetProductCategoryId(),
+				SizeID:        sizeID,
+				Purchasable:   productInfo.GetProductStatus() == productStatusOnSale,
+				Stock:         size.GetSizeStock(),
+				Price:         productPrice(productInfo),
+				PurchaseLimit: productInfo.GetProductPurchaseLimit(),
+			}, nil
+		}
+	}
+	return nil, ErrSizeNotFound
+}
+
+// productPrice 优先使用 price，未升级的商品服务只返回 product_price 时按默认币种换算
+func productPrice(productInfo *pb.ProductInfo) money.Money {
+	if productInfo.GetPrice() != nil {
+		return money.FromProto(productInfo.GetPrice())
+	}
+	return money.FromFloat(productInfo.GetProductPrice(), money.DefaultCurrency)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"cart/domain/model"
+	"cart/domain/repository"
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+)
+
+// 心愿单提醒原因
+const (
+	WishlistReasonPriceDrop   = "price_drop"
+	WishlistReasonBackInStock = "back_in_stock"
+)
+
+// WishlistNotice 心愿单商品降价或到货时发布的提醒
+type WishlistNotice struct {
+	WishlistID int64
+	UserID     int64
+	ProductID  int64
+	SizeID     int64
+	Reason     string
+	OldPrice   money.Money
+	NewPrice   money.Money
+}
+
+// IWishlistNotifier 发布心愿单提醒，由 main 中基于 micro.Event 的实现注入
+type IWishlistNotifier interface {
+	Notify(ctx context.Context, notice *WishlistNotice) error
+}
+
+type IWishlistDataService interface {
+	AddWishlist(context.Context, *model.Wishlist) (int64, error)
+	DeleteWishlist(int64, int64) error
+	FindAllWishlist(int64) ([]model.Wishlist, error)
+
+	MoveToCart(context.Context, int64, int64) (int64, error)
+	MoveToWishlist(int64, int64) (int64, error)
+
+	NotifyProductChanged(context.Context, int64) error
+}
+
+// 创建
+func NewWishlistDataService(wishlistRepository repository.IWishlistRepository, cartRepository repository.ICartRepository,
+	productChecker IProductChecker, notifier IWishlistNotifier) IWishlistDataService {
+	return &WishlistDataService{
+		WishlistRepository: wishlistRepository,
+		CartRepository:     cartRepository,
+		ProductChecker:     productChecker,
+		Notifier:           notifier,
+	}
+}
+
+type WishlistDataService struct {
+	WishlistRepository repository.IWishlistRepository
+	CartRepository     repository.ICartRepository
+	ProductChecker     IProductChecker
+	Notifier           IWishlistNotifier
+}
+
+// 加入心愿单，只要求商品与规格存在，不校验库存；同时记录当前价格与库存快照
+func (u *WishlistDataService) AddWishlist(ctx context.Context, wishlist *model.Wishlist) (int64, error) {
+	if wishlist.UserID <= 0 {
+		return 0, ErrInvalidUser
+	}
+	if wishlist.Num <= 0 {
+		return 0, ErrInvalidQuantity
+	}
+	stock, err := u.ProductChecker.FindProductStock(ctx, wishlist.ProductID, wishlist.SizeID)
+	if err != nil {
+		return 0, err
+	}
+	wishlist.LastPrice = stock.Price
+	wishlist.LastInStock = inStock(stock)
+	return u.WishlistRepository.CreateWishlist(wishlist)
+}
+
+// 删除
+func (u *WishlistDataService) DeleteWishlist(wishlistID int64, userID int64) error {
+	ok, err := u.WishlistRepository.DeleteWishlistByID(wishlistID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrWishlistNotFound
+	}
+	return nil
+}
+
+// 查找
+func (u *WishlistDataService) FindAllWishlist(userID int64) ([]model.Wishlist, error) {
+	return u.WishlistRepository.FindAll(userID)
+}
+
+// 移入购物车，与加购相同，需通过可售、库存与限购校验
+func (u *WishlistDataService) MoveToCart(ctx context.Context, wishlistID int64, userID int64) (int64, error) {
+	wishlist, err := u.WishlistRepository.FindWishlistByID(wishlistID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrWishlistNotFound
+		}
+		return 0, err
+	}
+	stock, err := u.ProductChecker.FindProductStock(ctx, wishlist.ProductID, wishlist.SizeID)
+	if err != nil {
+		return 0, err
+	}
+	if !stock.Purchasable {
+		return 0, ErrProductNotPurchasable
+	}
+
+	carts, err := u.CartRepository.FindAll(userID)
+	if err != nil {
+		return 0, err
+	}
+	var sizeNum, total int64
+	for _, cart := range carts {
+		if cart.ProductID != wishlist.ProductID {
+			continue
+		}
+		total += cart.Num
+		if cart.SizeID == wishlist.SizeID {
+			sizeNum += cart.Num
+		}
+	}
+	if sizeNum+wishlist.Num > stock.Stock {
+		return 0, ErrStockInsufficient
+	}
+	if stock.PurchaseLimit > 0 && total+wishlist.Num > stock.PurchaseLimit {
+		return 0, ErrPurchaseLimitExceeded
+	}
+
+	cartID, err := u.WishlistRepository.MoveToCart(wishlistID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, ErrWishlistNotFound
+	}
+	return cartID, err
+}
+
+// 购物车条目移入心愿单
+func (u *WishlistDataService) MoveToWishlist(cartID int64, userID int64) (int64, error) {
+	wishlistID, err := u.WishlistRepository.MoveToWishlist(cartID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, ErrCartNotFound
+	}
+	return wishlistID, err
+}
+
+// NotifyProductChanged 商品变更后比对心愿单快照：价格低于快照时发布降价提醒，
+// 由无货变为有货时发布到货提醒，随后刷新快照，同一次变化只提醒一次。
+func (u *WishlistDataService) NotifyProductChanged(ctx context.Context, productID int64) error {
+	wishlists, err := u.WishlistRepository.FindAllByProduct(productID)
+	if err != nil {
+		return err
+	}
+	for _, wishlist := range wishlists {
+		stock, err := u.ProductChecker.FindProductStock(ctx, wishlist.ProductID, wishlist.SizeID)
+		if err != nil {
+			// 商品或规格已删除，保留条目，等待用户自行处理
+			slog.Warn("查询心愿单商品失败", "wishlist_id", wishlist.ID, "product_id", productID, "error", err)
+			continue
+		}
+		nowInStock := inStock(stock)
+
+		var reasons []string
+		// 币种变化时无法比较，只刷新快照
+		if cmp, err := stock.Price.Cmp(wishlist.LastPrice); err == nil && cmp < 0 {
+			reasons = append(reasons, WishlistReasonPriceDrop)
+		}
+		if nowInStock && !wishlist.LastInStock {
+			reasons = append(reasons, WishlistReasonBackInStock)
+		}
+		// 提醒发布失败时，未发出的原因保留旧快照，事件重投时再次提醒；已发出的原因照常刷新，避免重复提醒
+		price, stocked := stock.Price, nowInStock
+		var notifyErr error
+		for _, reason := range reasons {
+			if notifyErr == nil {
+				notifyErr = u.Notifier.Notify(ctx, &WishlistNotice{
+					WishlistID: wishlist.ID,
+					UserID:     wishlist.UserID,
+					ProductID:  wishlist.ProductID,
+					SizeID:     wishlist.SizeID,
+					Reason:     reason,
+					OldPrice:   wishlist.LastPrice,
+					NewPrice:   stock.Price,
+				})
+				if notifyErr == nil {
+					continue
+				}
+			}
+			switch reason {
+			case WishlistReasonPriceDrop:
+				price = wishlist.LastPrice
+			case WishlistReasonBackInStock:
+				stocked = wishlist.LastInStock
+			}
+		}
+
+		if price != wishlist.LastPrice || stocked != wishlist.LastInStock {
+			if err := u.WishlistRepository.UpdateSnapshot(wishlist.ID, price, stocked); err != nil {
+				return err
+			}
+		}
+		if notifyErr != nil {
+			return notifyErr
+		}
+	}
+	return nil
+}
+
+// 可售且有库存才视为有货
+func inStock(stock *ProductStock) bool {
+	return stock.Purchasable && stock.Stock > 0
+}
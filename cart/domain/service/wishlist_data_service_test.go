@@ -0,0 +1,82 @@
+package service
+
+import (
+	"cart/domain/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+type wishlistFixture struct {
+	wishlists *fakeWishlists
+	products  fakeProducts
+	notifier  *fakeNotifier
+	service   IWishlistDataService
+}
+
+// newWishlistFixture 用户 7 的心愿单条目 1 记录商品 1 规格 10 价格 20.00 USD、无货；商品现价 15.00 USD 且到货
+func newWishlistFixture() *wishlistFixture {
+	f := &wishlistFixture{
+		wishlists: &fakeWishlists{wishlists: map[int64]*model.Wishlist{
+			1: {ID: 1, UserID: 7, ProductID: 1, SizeID: 10, Num: 1, LastPrice: money.New(2000, "USD"), LastInStock: false},
+		}},
+		products: fakeProducts{
+			{1, 10}: {ProductID: 1, SizeID: 10, Purchasable: true, Stock: 3, Price: money.New(1500, "USD")},
+		},
+		notifier: &fakeNotifier{},
+	}
+	f.service = NewWishlistDataService(f.wishlists, newFakeCarts(), f.products, f.notifier)
+	return f
+}
+
+func (f *wishlistFixture) reasons() []string {
+	var reasons []string
+	for _, notice := range f.notifier.sent {
+		reasons = append(reasons, notice.Reason)
+	}
+	return reasons
+}
+
+// TestNotifyProductChangedOnce 降价与到货各提醒一次，刷新快照后重复的事件不再提醒
+func TestNotifyProductChangedOnce(t *testing.T) {
+	f := newWishlistFixture()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := f.service.NotifyProductChanged(ctx, 1); err != nil {
+			t.Fatalf("NotifyProductChanged: %v", err)
+		}
+	}
+	if got := f.reasons(); len(got) != 2 || got[0] != WishlistReasonPriceDrop || got[1] != WishlistReasonBackInStock {
+		t.Fatalf("reasons = %v, want price drop then back in stock", got)
+	}
+	wishlist := f.wishlists.wishlists[1]
+	if wishlist.LastPrice != money.New(1500, "USD") || !wishlist.LastInStock {
+		t.Fatalf("snapshot = %v %v, want 15.00 USD and in stock", wishlist.LastPrice, wishlist.LastInStock)
+	}
+}
+
+// TestNotifyProductChangedPartialFailure 到货提醒发布失败时，已发出的降价提醒记入快照，重投事件只补发到货提醒
+func TestNotifyProductChangedPartialFailure(t *testing.T) {
+	f := newWishlistFixture()
+	ctx := context.Background()
+	f.notifier.failReasons = map[string]bool{WishlistReasonBackInStock: true}
+
+	if err := f.service.NotifyProductChanged(ctx, 1); !errors.Is(err, errNotifyFailed) {
+		t.Fatalf("err = %v, want errNotifyFailed", err)
+	}
+	wishlist := f.wishlists.wishlists[1]
+	if wishlist.LastPrice != money.New(1500, "USD") || wishlist.LastInStock {
+		t.Fatalf("snapshot = %v %v, want 15.00 USD and still out of stock", wishlist.LastPrice, wishlist.LastInStock)
+	}
+
+	f.notifier.failReasons = nil
+	if err := f.service.NotifyProductChanged(ctx, 1); err != nil {
+		t.Fatalf("redelivered NotifyProductChanged: %v", err)
+	}
+	if got := f.reasons(); len(got) != 2 || got[0] != WishlistReasonPriceDrop || got[1] != WishlistReasonBackInStock {
+		t.Fatalf("reasons = %v, want one price drop and one back in stock", got)
+	}
+}
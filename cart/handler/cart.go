@@ -6,6 +6,7 @@ import (
 	cart "cart/proto/cart"
 	"context"
 
+	"github.com/Ben1524/GoMall/common/money"
 	common "github.com/Ben1524/GoMall/common/utils"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
@@ -17,13 +18,15 @@ const (
 )
 
 type Cart struct {
-	CartDataService service.ICartDataService
-	tracer          trace.Tracer // 新增：用于创建span的tracer
+	CartDataService     service.ICartDataService
+	WishlistDataService service.IWishlistDataService
+	tracer              trace.Tracer // 新增：用于创建span的tracer
 }
 
-func NewCartHandler(service service.ICartDataService) *Cart {
+func NewCartHandler(cartService service.ICartDataService, wishlistService service.IWishlistDataService) *Cart {
 	return &Cart{
-		CartDataService: service,
+		CartDataService:     cartService,
+		WishlistDataService: wishlistService,
 		// 定义tracer名称（建议包含服务名和组件名，确保唯一）
 		tracer: otel.Tracer(tracerName, trace.WithInstrumentationVersion(version)),
 	}
@@ -31,14 +34,20 @@ func NewCartHandler(service service.ICartDataService) *Cart {
 
 // 添加购物车
 func (h *Cart) AddCart(ctx context.Context, request *cart.CartInfo, response *cart.ResponseAdd) (err error) {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
 	cart := &model.Cart{}
 	common.SwapTo(request, cart)
-	response.CartId, err = h.CartDataService.AddCart(cart)
-	return err
+	response.CartId, err = h.CartDataService.AddCart(ctx, cart)
+	return toMicroError(err)
 }
 
 // 清空购物车
 func (h *Cart) CleanCart(ctx context.Context, request *cart.Clean, response *cart.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
 	if err := h.CartDataService.CleanCart(request.UserId); err != nil {
 		return err
 	}
@@ -48,33 +57,45 @@ func (h *Cart) CleanCart(ctx context.Context, request *cart.Clean, response *car
 
 // 添加购物车数量
 func (h *Cart) Incr(ctx context.Context, request *cart.Item, response *cart.Response) error {
-	if err := h.CartDataService.IncrNum(request.Id, request.ChangeNum); err != nil {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
 		return err
 	}
+	if err := h.CartDataService.IncrNum(ctx, request.Id, request.UserId, request.ChangeNum); err != nil {
+		return toMicroError(err)
+	}
 	response.Meg = "购物车添加成功"
 	return nil
 }
 
 // 购物车减少商品数量
 func (h *Cart) Decr(ctx context.Context, request *cart.Item, response *cart.Response) error {
-	if err := h.CartDataService.DecrNum(request.Id, request.ChangeNum); err != nil {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
 		return err
 	}
+	if err := h.CartDataService.DecrNum(request.Id, request.UserId, request.ChangeNum); err != nil {
+		return toMicroError(err)
+	}
 	response.Meg = "购物程减少成功"
 	return nil
 }
 
 // 删除购物车
 func (h *Cart) DeleteItemByID(ctx context.Context, request *cart.CartID, response *cart.Response) error {
-	if err := h.CartDataService.DeleteCart(request.Id); err != nil {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
 		return err
 	}
+	if err := h.CartDataService.DeleteCart(request.Id, request.UserId); err != nil {
+		return toMicroError(err)
+	}
 	response.Meg = "购物车删除成功"
 	return nil
 }
 
 // 查询用户所有的购物车信息
 func (h *Cart) GetAll(ctx context.Context, request *cart.CartFindAll, response *cart.CartAll) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
 	cartAll, err := h.CartDataService.FindAllCart(request.UserId)
 	if err != nil {
 		return err
@@ -89,3 +110,52 @@ func (h *Cart) GetAll(ctx context.Context, request *cart.CartFindAll, response *
 	}
 	return nil
 }
+
+// 按促销规则试算购物车金额
+func (h *Cart) PriceCart(ctx context.Context, request *cart.PriceRequest, response *cart.CartPrice) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	result, err := h.CartDataService.PriceCart(ctx, request.UserId, request.CouponCode)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.SubtotalAmount = toMoney(result.Subtotal)
+	response.DiscountAmount = toMoney(result.Discount)
+	response.TotalAmount = toMoney(result.Total)
+	// 同时填充废弃的浮点字段，兼容未升级的调用方
+	response.Subtotal = result.Subtotal.Float64()
+	response.Discount = result.Discount.Float64()
+	response.Total = result.Total.Float64()
+	response.FreeShipping = result.FreeShipping
+	for _, applied := range result.Applied {
+		response.Applied = append(response.Applied, &cart.AppliedPromotion{
+			PromotionId:    applied.RuleID,
+			Name:           applied.Name,
+			CouponCode:     applied.CouponCode,
+			Discount:       applied.Discount.Float64(),
+			FreeShipping:   applied.FreeShipping,
+			DiscountAmount: toMoney(applied.Discount),
+		})
+	}
+	if request.DisplayCurrency == "" {
+		return nil
+	}
+	display, err := h.CartDataService.DisplayPrice(ctx, result, request.DisplayCurrency)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.Display = &cart.DisplayPrice{
+		Subtotal:   toMoney(display.Subtotal),
+		Discount:   toMoney(display.Discount),
+		Total:      toMoney(display.Total),
+		Rate:       display.Rate.String(),
+		RateSource: display.Rate.Source,
+		RateAsOf:   display.Rate.AsOf.Unix(),
+	}
+	return nil
+}
+
+func toMoney(m money.Money) *cart.Money {
+	return &cart.Money{Amount: m.Amount, Currency: m.Currency}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"cart/domain/service"
+	"errors"
+
+	"github.com/Ben1524/GoMall/common/exchange"
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/Ben1524/GoMall/common/promotion"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// 返回给调用方的 go-micro 错误 ID
+const serviceID = "go.micro.service.cart"
+
+// toMicroError 将购物车业务错误、优惠码、金额与汇率错误转换为带状态码的 go-micro 错误，其余错误原样返回
+func toMicroError(err error) error {
+	var cartErr *service.CartError
+	switch {
+	case errors.As(err, &cartErr):
+		return microerrors.New(serviceID, cartErr.Msg, cartErr.Code)
+	case errors.Is(err, promotion.ErrCouponNotFound):
+		return microerrors.NotFound(serviceID, "%s", err.Error())
+	case errors.Is(err, promotion.ErrCouponNotApplicable):
+		return microerrors.BadRequest(serviceID, "%s", err.Error())
+	case errors.Is(err, promotion.ErrCouponExhausted):
+		return microerrors.Conflict(serviceID, "%s", err.Error())
+	case errors.Is(err, money.ErrCurrencyMismatch), errors.Is(err, money.ErrInvalidCurrency),
+		errors.Is(err, money.ErrOverflow), errors.Is(err, exchange.ErrRateNotFound):
+		return microerrors.BadRequest(serviceID, "%s", err.Error())
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// Policy 购物车服务的访问策略，未列入的端点一律拒绝。购物车与心愿单接口由网关转发用户令牌，
+// 隐私请求流程由用户服务以服务令牌调用；购物车与心愿单端点在处理器中再用 authorizeUser 校验是否为本人
+var Policy = auth.Policy{
+	"Cart.AddCart":        auth.PermAuthenticated,
+	"Cart.CleanCart":      auth.PermAuthenticated,
+	"Cart.Incr":           auth.PermAuthenticated,
+	"Cart.Decr":           auth.PermAuthenticated,
+	"Cart.DeleteItemByID": auth.PermAuthenticated,
+	"Cart.GetAll":         auth.PermAuthenticated,
+	"Cart.PriceCart":      auth.PermAuthenticated,
+
+	"Cart.AddWishlist":        auth.PermAuthenticated,
+	"Cart.GetWishlist":        auth.PermAuthenticated,
+	"Cart.DeleteWishlistItem": auth.PermAuthenticated,
+	"Cart.MoveToCart":         auth.PermAuthenticated,
+	"Cart.MoveToWishlist":     auth.PermAuthenticated,
+
+	"Privacy.ExportUserData": auth.PermPrivacyProcess,
+	"Privacy.EraseUserData":  auth.PermPrivacyProcess,
+}
+
+// authorizeUser 只允许本人或拥有 user:manage 权限的客服操作该用户的购物车与心愿单
+func authorizeUser(ctx context.Context, userID int64) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return microerrors.Unauthorized(serviceID, "%s", auth.ErrUnauthenticated.Error())
+	}
+	if claims.UserID() == userID || claims.Can(auth.PermUserManage) {
+		return nil
+	}
+	slog.WarnContext(ctx, "拒绝访问", "audit", "access_denied", "target", "cart", "owner_id", userID,
+		"user_id", claims.UserID(), "session_id", claims.SessionID, "roles", claims.Roles)
+	return microerrors.Forbidden(serviceID, "%s", auth.ErrForbidden.Error())
+}
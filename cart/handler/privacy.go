@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"cart/domain/service"
+	"context"
+	"encoding/json"
+
+	"github.com/Ben1524/GoMall/common/proto/privacy"
+)
+
+// Privacy 隐私请求流程的购物车步骤，只接受带 privacy:process 权限的服务令牌（见 Policy）
+type Privacy struct {
+	PrivacyService service.IPrivacyService
+}
+
+// 导出用户的购物车、心愿单与弃购记录
+func (h *Privacy) ExportUserData(ctx context.Context, request *privacy.UserDataRequest, response *privacy.UserDataResponse) error {
+	data, err := h.PrivacyService.ExportUserData(request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.Data, err = json.Marshal(data)
+	return err
+}
+
+// 删除用户的购物车、心愿单与弃购记录
+func (h *Privacy) EraseUserData(ctx context.Context, request *privacy.UserDataRequest, response *privacy.UserDataResponse) (err error) {
+	response.Affected, err = h.PrivacyService.EraseUserData(request.UserId)
+	return toMicroError(err)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"cart/domain/service"
+	cart "cart/proto/cart"
+	productpb "cart/proto/product"
+	"context"
+
+	"go-micro.dev/v5"
+)
+
+// 心愿单提醒发布的主题
+const WishlistNoticeTopic = "go.micro.topic.cart.wishlist.notice"
+
+// 弃购事件发布的主题
+const AbandonedCartTopic = "go.micro.topic.cart.abandoned"
+
+// 商品服务发布商品变更的主题
+const ProductChangedTopic = "go.micro.topic.product.changed"
+
+// ProductSubscriber 订阅商品变更，触发心愿单降价/到货提醒
+type ProductSubscriber struct {
+	WishlistDataService service.IWishlistDataService
+}
+
+func (s *ProductSubscriber) Handle(ctx context.Context, event *productpb.ProductChanged) error {
+	return s.WishlistDataService.NotifyProductChanged(ctx, event.GetProductId())
+}
+
+// 创建基于 micro.Event 的心愿单提醒发布器
+func NewWishlistNotifier(event micro.Event) service.IWishlistNotifier {
+	return &wishlistNotifier{event: event}
+}
+
+type wishlistNotifier struct {
+	event micro.Event
+}
+
+func (n *wishlistNotifier) Notify(ctx context.Context, notice *service.WishlistNotice) error {
+	return n.event.Publish(ctx, &cart.WishlistNotice{
+		WishlistId:     notice.WishlistID,
+		UserId:         notice.UserID,
+		ProductId:      notice.ProductID,
+		SizeId:         notice.SizeID,
+		Reason:         notice.Reason,
+		OldPrice:       notice.OldPrice.Float64(),
+		NewPrice:       notice.NewPrice.Float64(),
+		OldPriceAmount: toMoney(notice.OldPrice),
+		NewPriceAmount: toMoney(notice.NewPrice),
+	})
+}
+
+// 创建基于 micro.Event 的弃购事件发布器
+func NewAbandonedCartNotifier(event micro.Event) service.IAbandonedCartNotifier {
+	return &abandonedCartNotifier{event: event}
+}
+
+type abandonedCartNotifier struct {
+	event micro.Event
+}
+
+func (n *abandonedCartNotifier) Notify(ctx context.Context, abandoned *service.AbandonedCart) error {
+	msg := &cart.AbandonedCart{
+		UserId:           abandoned.UserID,
+		TotalValue:       abandoned.TotalValue.Float64(),
+		LastActiveAt:     abandoned.LastActiveAt.Unix(),
+		TotalValueAmount: toMoney(abandoned.TotalValue),
+	}
+	for _, item := range abandoned.Items {
+		msg.CartInfo = append(msg.CartInfo, &cart.CartInfo{
+			Id:        item.ID,
+			UserId:    item.UserID,
+			ProductId: item.ProductID,
+			SizeId:    item.SizeID,
+			Num:       item.Num,
+		})
+	}
+	return n.event.Publish(ctx, msg)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"cart/domain/model"
+	cart "cart/proto/cart"
+	"context"
+)
+
+// 加入心愿单
+func (h *Cart) AddWishlist(ctx context.Context, request *cart.WishlistInfo, response *cart.ResponseWishlist) (err error) {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	// 价格与库存快照由服务端记录，忽略请求中的值
+	wishlist := &model.Wishlist{
+		UserID:    request.UserId,
+		ProductID: request.ProductId,
+		SizeID:    request.SizeId,
+		Num:       request.Num,
+	}
+	response.WishlistId, err = h.WishlistDataService.AddWishlist(ctx, wishlist)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "已加入心愿单"
+	return nil
+}
+
+// 查询用户心愿单
+func (h *Cart) GetWishlist(ctx context.Context, request *cart.CartFindAll, response *cart.WishlistAll) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	wishlistAll, err := h.WishlistDataService.FindAllWishlist(request.UserId)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range wishlistAll {
+		response.WishlistInfo = append(response.WishlistInfo, &cart.WishlistInfo{
+			Id:              v.ID,
+			UserId:          v.UserID,
+			ProductId:       v.ProductID,
+			SizeId:          v.SizeID,
+			Num:             v.Num,
+			LastPrice:       v.LastPrice.Float64(),
+			LastInStock:     v.LastInStock,
+			LastPriceAmount: toMoney(v.LastPrice),
+		})
+	}
+	return nil
+}
+
+// 删除心愿单条目
+func (h *Cart) DeleteWishlistItem(ctx context.Context, request *cart.WishlistID, response *cart.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	if err := h.WishlistDataService.DeleteWishlist(request.Id, request.UserId); err != nil {
+		return toMicroError(err)
+	}
+	response.Meg = "心愿单删除成功"
+	return nil
+}
+
+// 心愿单条目移入购物车
+func (h *Cart) MoveToCart(ctx context.Context, request *cart.MoveItem, response *cart.ResponseAdd) (err error) {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	response.CartId, err = h.WishlistDataService.MoveToCart(ctx, request.Id, request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "已移入购物车"
+	return nil
+}
+
+// 购物车条目移入心愿单
+func (h *Cart) MoveToWishlist(ctx context.Context, request *cart.MoveItem, response *cart.ResponseWishlist) (err error) {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	response.WishlistId, err = h.WishlistDataService.MoveToWishlist(request.Id, request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "已移入心愿单"
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"cart/domain/repository"
 	srv "cart/domain/service"
 	"cart/handler"
+	"cart/metrics"
 	"context"
 	"fmt"
 	"log/slog"
@@ -12,9 +13,14 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/microauth"
 	config "github.com/Ben1524/GoMall/common/config"
 	"github.com/Ben1524/GoMall/common/db"
+	"github.com/Ben1524/GoMall/common/exchange"
 	"github.com/Ben1524/GoMall/common/otel"
+	"github.com/Ben1524/GoMall/common/promotion"
+	"github.com/Ben1524/GoMall/common/proto/privacy"
 	"go-micro.dev/v5"
 	"go-micro.dev/v5/registry"
 	"go-micro.dev/v5/registry/consul"
@@ -24,6 +30,7 @@ import (
 	"golang.org/x/time/rate"
 
 	pb "cart/proto/cart"
+	productpb "cart/proto/product"
 
 	// 限流器（Uber 令牌桶）
 	ratelimit "github.com/micro/plugins/v5/wrapper/ratelimiter/uber"
@@ -56,6 +63,11 @@ func main() {
 	}
 	slog.Info("config加载成功", "path", "cart/config.example.yaml")
 
+	promMetrics := metrics.New(cfg.Server.ServiceName, cfg.Metrics.Enabled)
+	if cfg.Metrics.Enabled {
+		promMetrics.StartHTTPServer(cfg.Metrics.Host, cfg.Metrics.Port, cfg.Metrics.Path)
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 
@@ -82,7 +94,11 @@ func main() {
 		os.Exit(1)
 	}
 	defer func() {
-		if err := mysqlDB.Close(); err != nil {
+		sqlDB, err := mysqlDB.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
 			slog.Warn("关闭MySQL连接失败", "error", err)
 		} else {
 			slog.Info("MySQL连接已关闭")
@@ -96,7 +112,36 @@ func main() {
 		panic(err)
 	}
 
-	cartService := srv.NewCartDataService(cartRepository)
+	wishlistRepository := repository.NewWishlistRepository(mysqlDB)
+	if err := wishlistRepository.InitTable(); err != nil {
+		slog.Error("init wishlist table error")
+		panic(err)
+	}
+
+	// 促销规则与订单服务共用，购物车只试算不核销
+	promotionService := promotion.NewService(mysqlDB)
+	if err := promotionService.InitTable(); err != nil {
+		slog.Error("init promotion table error")
+		panic(err)
+	}
+
+	abandonedRepository := repository.NewAbandonedCartRepository(mysqlDB)
+	if err := abandonedRepository.InitTable(); err != nil {
+		slog.Error("init abandoned cart table error")
+		panic(err)
+	}
+
+	// 校验网关转发的访问令牌，与用户服务共享会话存储
+	sessionStore, err := auth.NewStore(cfg)
+	if err != nil {
+		slog.Error("初始化会话存储失败", "error", err)
+		os.Exit(1)
+	}
+	tokenManager, err := auth.NewManager(cfg.JWT, sessionStore)
+	if err != nil {
+		slog.Error("初始化令牌校验失败", "error", err)
+		os.Exit(1)
+	}
 
 	consulRegistry := consul.NewConsulRegistry(registry.Addrs("127.0.0.1:8500"))
 
@@ -110,6 +155,7 @@ func main() {
 			//NewNonBlockingLimiter(qps),
 			ratelimit.NewHandlerWrapper(qps, ratelimit3.WithSlack(3*qps)),
 			opentelemetry.NewHandlerWrapper(),
+			microauth.NewHandlerWrapper(tokenManager, cfg.Server.ServiceName, handler.Policy),
 		),
 		micro.WrapClient(
 			ratelimit.NewClientWrapper(qps, ratelimit3.WithSlack(3*qps)), // 客户端限流，避免自身成为 “流量攻击源”
@@ -117,11 +163,50 @@ func main() {
 		),
 	)
 	service.Init()
-	if err := pb.RegisterCartHandler(service.Server(), handler.NewCartHandler(cartService)); err != nil {
+
+	// 加购前通过商品服务校验商品、规格与库存
+	productService := productpb.NewProductService("go.micro.service.product", service.Client())
+	productChecker := srv.NewProductChecker(productService)
+	// 购物车金额可按展示币种换算
+	rates, err := exchange.New(cfg.ExchangeRate)
+	if err != nil {
+		slog.Error("初始化汇率来源失败", "error", err)
+		panic(err)
+	}
+	cartService := srv.NewCartDataService(cartRepository, productChecker, promotionService, rates)
+
+	// 心愿单降价/到货提醒通过事件发布，由通知类服务订阅
+	wishlistNotice := micro.NewEvent(handler.WishlistNoticeTopic, service.Client())
+	wishlistService := srv.NewWishlistDataService(wishlistRepository, cartRepository, productChecker,
+		handler.NewWishlistNotifier(wishlistNotice))
+
+	if err := pb.RegisterCartHandler(service.Server(), handler.NewCartHandler(cartService, wishlistService)); err != nil {
 		slog.Error("注册Cart处理器失败", "error", err)
 		os.Exit(1)
 	}
 
+	// 隐私请求流程的购物车步骤，只供用户服务以服务令牌调用
+	privacyService := srv.NewPrivacyService(cartRepository, wishlistRepository, abandonedRepository)
+	if err := privacy.RegisterPrivacyHandler(service.Server(), &handler.Privacy{PrivacyService: privacyService}); err != nil {
+		slog.Error("注册Privacy处理器失败", "error", err)
+		os.Exit(1)
+	}
+
+	if err := micro.RegisterSubscriber(handler.ProductChangedTopic, service.Server(),
+		&handler.ProductSubscriber{WishlistDataService: wishlistService}); err != nil {
+		slog.Error("订阅商品变更事件失败", "error", err)
+		os.Exit(1)
+	}
+
+	// 定时扫描弃购购物车，发布事件供营销/通知服务发送提醒
+	if cfg.AbandonedCart.Enabled {
+		abandonedCart := micro.NewEvent(handler.AbandonedCartTopic, service.Client())
+		abandonedService := srv.NewAbandonedCartService(abandonedRepository, cartRepository, productChecker,
+			handler.NewAbandonedCartNotifier(abandonedCart), promMetrics,
+			cfg.AbandonedCart.IdleAfter, cfg.AbandonedCart.BatchSize)
+		go abandonedService.Run(ctx, cfg.AbandonedCart.ScanInterval)
+	}
+
 	if err := service.Run(); err != nil {
 		slog.Error("服务运行失败", "error", err)
 	}
@@ -3,7 +3,7 @@ module cartApi
 go 1.25.1
 
 require (
-	github.com/Ben1524/GoMall/common v0.0.0-20251004050335-c66635f5ac67
+	github.com/Ben1524/GoMall/common v0.0.0-00010101000000-000000000000
 	github.com/gin-gonic/gin v1.10.0
 	github.com/micro/plugins/v5/wrapper/breaker/gobreaker v1.0.2
 	github.com/prometheus/client_golang v1.11.1
@@ -12,6 +12,8 @@ require (
 	google.golang.org/protobuf v1.36.10
 )
 
+replace github.com/Ben1524/GoMall/common => ../common
+
 require (
 	github.com/armon/go-metrics v0.4.1 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
@@ -2,14 +2,15 @@ package handler
 
 import (
 	"cartApi/proto/cart"
-	pb "cartApi/proto/cartApi"
 	"context"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/Ben1524/GoMall/common/auth/ginauth"
 	"github.com/gin-gonic/gin"
+	microerrors "go-micro.dev/v5/errors"
 )
 
 type CartApiHandler struct {
@@ -24,11 +25,6 @@ func NewCartApiHandler(cli cart.CartService) *CartApiHandler {
 	}
 }
 
-func (c *CartApiHandler) FindAll(ctx context.Context, req *pb.Request, res *pb.Response) error {
-
-	return nil
-}
-
 // RegisterRoutes 将购物车相关路由注册到给定路由组。
 func (c *CartApiHandler) RegisterRoutes(group *gin.RouterGroup) {
 	group.POST("/carts", c.handleAddCart)
@@ -37,14 +33,27 @@ func (c *CartApiHandler) RegisterRoutes(group *gin.RouterGroup) {
 	group.PATCH("/carts/:id/decrease", c.handleDecreaseItem)
 	group.DELETE("/carts/:id", c.handleDeleteItem)
 	group.GET("/carts/user/:userID", c.handleGetAll)
+	group.GET("/carts/price", c.handlePriceCart)
+	group.POST("/carts/:id/move-to-wishlist", c.handleMoveToWishlist)
+
+	group.POST("/wishlist", c.handleAddWishlist)
+	group.GET("/wishlist/user/:userID", c.handleGetWishlist)
+	group.DELETE("/wishlist/:id", c.handleDeleteWishlistItem)
+	group.POST("/wishlist/:id/move-to-cart", c.handleMoveToCart)
 }
 
 func (c *CartApiHandler) handleAddCart(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
 	var payload cart.CartInfo
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
 		respondBadRequest(ctx, "invalid request payload", err)
 		return
 	}
+	// 购物车归属以令牌中的用户为准，忽略请求体中的 user_id
+	payload.UserId = userID
 
 	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
 	defer cancel()
@@ -62,7 +71,7 @@ func (c *CartApiHandler) handleAddCart(ctx *gin.Context) {
 }
 
 func (c *CartApiHandler) handleCleanCart(ctx *gin.Context) {
-	userID, ok := parseIDParam(ctx, "userID")
+	userID, ok := requireOwnUserID(ctx)
 	if !ok {
 		return
 	}
@@ -88,6 +97,10 @@ func (c *CartApiHandler) handleDecreaseItem(ctx *gin.Context) {
 }
 
 func (c *CartApiHandler) handleDeleteItem(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
 	id, ok := parseIDParam(ctx, "id")
 	if !ok {
 		return
@@ -96,7 +109,7 @@ func (c *CartApiHandler) handleDeleteItem(ctx *gin.Context) {
 	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
 	defer cancel()
 
-	resp, err := c.cli.DeleteItemByID(requestCtx, &cart.CartID{Id: id})
+	resp, err := c.cli.DeleteItemByID(requestCtx, &cart.CartID{Id: id, UserId: userID})
 	if err != nil {
 		respondServiceError(ctx, err)
 		return
@@ -106,7 +119,7 @@ func (c *CartApiHandler) handleDeleteItem(ctx *gin.Context) {
 }
 
 func (c *CartApiHandler) handleGetAll(ctx *gin.Context) {
-	userID, ok := parseIDParam(ctx, "userID")
+	userID, ok := requireOwnUserID(ctx)
 	if !ok {
 		return
 	}
@@ -123,7 +136,67 @@ func (c *CartApiHandler) handleGetAll(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"items": resp.GetCartInfo()})
 }
 
+// handlePriceCart 按促销规则试算当前用户购物车金额，可通过 coupon_code 查询参数使用优惠码，
+// display_currency 查询参数指定展示币种时附带换算后的金额
+func (c *CartApiHandler) handlePriceCart(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := c.cli.PriceCart(requestCtx, &cart.PriceRequest{
+		UserId:          userID,
+		CouponCode:      ctx.Query("coupon_code"),
+		DisplayCurrency: ctx.Query("display_currency"),
+	})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+
+	applied := make([]gin.H, 0, len(resp.GetApplied()))
+	for _, promotion := range resp.GetApplied() {
+		applied = append(applied, gin.H{
+			"promotion_id":  promotion.GetPromotionId(),
+			"name":          promotion.GetName(),
+			"coupon_code":   promotion.GetCouponCode(),
+			"discount":      moneyJSON(promotion.GetDiscountAmount()),
+			"free_shipping": promotion.GetFreeShipping(),
+		})
+	}
+	body := gin.H{
+		"subtotal":      moneyJSON(resp.GetSubtotalAmount()),
+		"discount":      moneyJSON(resp.GetDiscountAmount()),
+		"total":         moneyJSON(resp.GetTotalAmount()),
+		"free_shipping": resp.GetFreeShipping(),
+		"applied":       applied,
+	}
+	if display := resp.GetDisplay(); display != nil {
+		body["display"] = gin.H{
+			"subtotal":    moneyJSON(display.GetSubtotal()),
+			"discount":    moneyJSON(display.GetDiscount()),
+			"total":       moneyJSON(display.GetTotal()),
+			"rate":        display.GetRate(),
+			"rate_source": display.GetRateSource(),
+			"rate_as_of":  display.GetRateAsOf(),
+		}
+	}
+	ctx.JSON(http.StatusOK, body)
+}
+
+// moneyJSON 金额以最小货币单位输出，零值也保留 amount 字段
+func moneyJSON(m *cart.Money) gin.H {
+	return gin.H{"amount": m.GetAmount(), "currency": m.GetCurrency()}
+}
+
 func (c *CartApiHandler) handleChangeItem(ctx *gin.Context, increase bool) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
 	id, ok := parseIDParam(ctx, "id")
 	if !ok {
 		return
@@ -146,7 +219,7 @@ func (c *CartApiHandler) handleChangeItem(ctx *gin.Context, increase bool) {
 	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
 	defer cancel()
 
-	item := &cart.Item{Id: id, ChangeNum: body.Change}
+	item := &cart.Item{Id: id, ChangeNum: body.Change, UserId: userID}
 	var (
 		resp *cart.Response
 		err  error
@@ -181,6 +254,33 @@ func parseIDParam(ctx *gin.Context, key string) (int64, bool) {
 	return value, true
 }
 
+// requireUserID 读取访问令牌中的当前登录用户ID，未经认证时返回 401
+func requireUserID(ctx *gin.Context) (int64, bool) {
+	userID, ok := ginauth.UserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid user identity"})
+		return 0, false
+	}
+	return userID, true
+}
+
+// requireOwnUserID 路径中的 userID 必须是当前登录用户，否则返回 403
+func requireOwnUserID(ctx *gin.Context) (int64, bool) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return 0, false
+	}
+	pathUserID, ok := parseIDParam(ctx, "userID")
+	if !ok {
+		return 0, false
+	}
+	if pathUserID != userID {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "cannot access another user's data"})
+		return 0, false
+	}
+	return userID, true
+}
+
 func respondBadRequest(ctx *gin.Context, message string, err error) {
 	payload := gin.H{"error": message}
 	if err != nil {
@@ -189,6 +289,11 @@ func respondBadRequest(ctx *gin.Context, message string, err error) {
 	ctx.JSON(http.StatusBadRequest, payload)
 }
 
+// respondServiceError 购物车服务返回的 4xx 业务错误（如商品不存在、库存不足）原样透出状态码，其余按 500 处理
 func respondServiceError(ctx *gin.Context, err error) {
+	if merr := microerrors.FromError(err); merr.Code >= http.StatusBadRequest && merr.Code < http.StatusInternalServerError {
+		ctx.JSON(int(merr.Code), gin.H{"error": merr.Detail})
+		return
+	}
 	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"cartApi/proto/cart"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (c *CartApiHandler) handleAddWishlist(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+	var payload cart.WishlistInfo
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		respondBadRequest(ctx, "invalid request payload", err)
+		return
+	}
+	payload.UserId = userID
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := c.cli.AddWishlist(requestCtx, &payload)
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"wishlist_id": resp.GetWishlistId(),
+		"message":     resp.GetMsg(),
+	})
+}
+
+func (c *CartApiHandler) handleGetWishlist(ctx *gin.Context) {
+	userID, ok := requireOwnUserID(ctx)
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := c.cli.GetWishlist(requestCtx, &cart.CartFindAll{UserId: userID})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"items": resp.GetWishlistInfo()})
+}
+
+func (c *CartApiHandler) handleDeleteWishlistItem(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := c.cli.DeleteWishlistItem(requestCtx, &cart.WishlistID{Id: id, UserId: userID})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": resp.GetMeg()})
+}
+
+func (c *CartApiHandler) handleMoveToCart(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := c.cli.MoveToCart(requestCtx, &cart.MoveItem{Id: id, UserId: userID})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"cart_id": resp.GetCartId(),
+		"message": resp.GetMsg(),
+	})
+}
+
+func (c *CartApiHandler) handleMoveToWishlist(ctx *gin.Context) {
+	userID, ok := requireUserID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := c.cli.MoveToWishlist(requestCtx, &cart.MoveItem{Id: id, UserId: userID})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"wishlist_id": resp.GetWishlistId(),
+		"message":     resp.GetMsg(),
+	})
+}
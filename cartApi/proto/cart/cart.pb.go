@@ -242,6 +242,7 @@ type Item struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	ChangeNum     int64                  `protobuf:"varint,2,opt,name=change_num,json=changeNum,proto3" json:"change_num,omitempty"`
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -290,9 +291,17 @@ func (x *Item) GetChangeNum() int64 {
 	return 0
 }
 
+func (x *Item) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 type CartID struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -334,6 +343,13 @@ func (x *CartID) GetId() int64 {
 	return 0
 }
 
+func (x *CartID) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 type CartFindAll struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -422,44 +438,1003 @@ func (x *CartAll) GetCartInfo() []*CartInfo {
 	return nil
 }
 
-var File_proto_cart_cart_proto protoreflect.FileDescriptor
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_cart_cart_proto_rawDesc = "" +
-	"\n" +
-	"\x15proto/cart/cart.proto\x12\x04cart\"}\n" +
-	"\bCartInfo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x03 \x01(\x03R\tproductId\x12\x17\n" +
-	"\asize_id\x18\x04 \x01(\x03R\x06sizeId\x12\x10\n" +
-	"\x03num\x18\x05 \x01(\x03R\x03num\"8\n" +
-	"\vResponseAdd\x12\x17\n" +
-	"\acart_id\x18\x01 \x01(\x03R\x06cartId\x12\x10\n" +
-	"\x03msg\x18\x02 \x01(\tR\x03msg\" \n" +
-	"\x05Clean\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x1c\n" +
-	"\bResponse\x12\x10\n" +
-	"\x03meg\x18\x01 \x01(\tR\x03meg\"5\n" +
-	"\x04Item\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
-	"\n" +
-	"change_num\x18\x02 \x01(\x03R\tchangeNum\"\x18\n" +
-	"\x06CartID\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
-	"\vCartFindAll\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"6\n" +
-	"\aCartAll\x12+\n" +
-	"\tcart_info\x18\x01 \x03(\v2\x0e.cart.CartInfoR\bcartInfo2\x8e\x02\n" +
-	"\x04Cart\x12.\n" +
-	"\aAddCart\x12\x0e.cart.CartInfo\x1a\x11.cart.ResponseAdd\"\x00\x12*\n" +
-	"\tCleanCart\x12\v.cart.Clean\x1a\x0e.cart.Response\"\x00\x12$\n" +
-	"\x04Incr\x12\n" +
-	".cart.Item\x1a\x0e.cart.Response\"\x00\x12$\n" +
-	"\x04Decr\x12\n" +
-	".cart.Item\x1a\x0e.cart.Response\"\x00\x120\n" +
-	"\x0eDeleteItemByID\x12\f.cart.CartID\x1a\x0e.cart.Response\"\x00\x12,\n" +
-	"\x06GetAll\x12\x11.cart.CartFindAll\x1a\r.cart.CartAll\"\x00B\x0eZ\f./proto;cartb\x06proto3"
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_cart_cart_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type WishlistInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId       int64                  `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SizeId          int64                  `protobuf:"varint,4,opt,name=size_id,json=sizeId,proto3" json:"size_id,omitempty"`
+	Num             int64                  `protobuf:"varint,5,opt,name=num,proto3" json:"num,omitempty"`
+	LastPrice       float64                `protobuf:"fixed64,6,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	LastInStock     bool                   `protobuf:"varint,7,opt,name=last_in_stock,json=lastInStock,proto3" json:"last_in_stock,omitempty"`
+	LastPriceAmount *Money                 `protobuf:"bytes,8,opt,name=last_price_amount,json=lastPriceAmount,proto3" json:"last_price_amount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WishlistInfo) Reset() {
+	*x = WishlistInfo{}
+	mi := &file_proto_cart_cart_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistInfo) ProtoMessage() {}
+
+func (x *WishlistInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistInfo.ProtoReflect.Descriptor instead.
+func (*WishlistInfo) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WishlistInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WishlistInfo) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WishlistInfo) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *WishlistInfo) GetSizeId() int64 {
+	if x != nil {
+		return x.SizeId
+	}
+	return 0
+}
+
+func (x *WishlistInfo) GetNum() int64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *WishlistInfo) GetLastPrice() float64 {
+	if x != nil {
+		return x.LastPrice
+	}
+	return 0
+}
+
+func (x *WishlistInfo) GetLastInStock() bool {
+	if x != nil {
+		return x.LastInStock
+	}
+	return false
+}
+
+func (x *WishlistInfo) GetLastPriceAmount() *Money {
+	if x != nil {
+		return x.LastPriceAmount
+	}
+	return nil
+}
+
+type ResponseWishlist struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WishlistId    int64                  `protobuf:"varint,1,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseWishlist) Reset() {
+	*x = ResponseWishlist{}
+	mi := &file_proto_cart_cart_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseWishlist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseWishlist) ProtoMessage() {}
+
+func (x *ResponseWishlist) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseWishlist.ProtoReflect.Descriptor instead.
+func (*ResponseWishlist) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResponseWishlist) GetWishlistId() int64 {
+	if x != nil {
+		return x.WishlistId
+	}
+	return 0
+}
+
+func (x *ResponseWishlist) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+type WishlistID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WishlistID) Reset() {
+	*x = WishlistID{}
+	mi := &file_proto_cart_cart_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistID) ProtoMessage() {}
+
+func (x *WishlistID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistID.ProtoReflect.Descriptor instead.
+func (*WishlistID) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WishlistID) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WishlistID) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type WishlistAll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WishlistInfo  []*WishlistInfo        `protobuf:"bytes,1,rep,name=wishlist_info,json=wishlistInfo,proto3" json:"wishlist_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WishlistAll) Reset() {
+	*x = WishlistAll{}
+	mi := &file_proto_cart_cart_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistAll) ProtoMessage() {}
+
+func (x *WishlistAll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistAll.ProtoReflect.Descriptor instead.
+func (*WishlistAll) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WishlistAll) GetWishlistInfo() []*WishlistInfo {
+	if x != nil {
+		return x.WishlistInfo
+	}
+	return nil
+}
+
+type MoveItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveItem) Reset() {
+	*x = MoveItem{}
+	mi := &file_proto_cart_cart_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveItem) ProtoMessage() {}
+
+func (x *MoveItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveItem.ProtoReflect.Descriptor instead.
+func (*MoveItem) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MoveItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveItem) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type WishlistNotice struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WishlistId     int64                  `protobuf:"varint,1,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductId      int64                  `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SizeId         int64                  `protobuf:"varint,4,opt,name=size_id,json=sizeId,proto3" json:"size_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	OldPrice       float64                `protobuf:"fixed64,6,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice       float64                `protobuf:"fixed64,7,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	OldPriceAmount *Money                 `protobuf:"bytes,8,opt,name=old_price_amount,json=oldPriceAmount,proto3" json:"old_price_amount,omitempty"`
+	NewPriceAmount *Money                 `protobuf:"bytes,9,opt,name=new_price_amount,json=newPriceAmount,proto3" json:"new_price_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WishlistNotice) Reset() {
+	*x = WishlistNotice{}
+	mi := &file_proto_cart_cart_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistNotice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistNotice) ProtoMessage() {}
+
+func (x *WishlistNotice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistNotice.ProtoReflect.Descriptor instead.
+func (*WishlistNotice) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WishlistNotice) GetWishlistId() int64 {
+	if x != nil {
+		return x.WishlistId
+	}
+	return 0
+}
+
+func (x *WishlistNotice) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WishlistNotice) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *WishlistNotice) GetSizeId() int64 {
+	if x != nil {
+		return x.SizeId
+	}
+	return 0
+}
+
+func (x *WishlistNotice) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *WishlistNotice) GetOldPrice() float64 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *WishlistNotice) GetNewPrice() float64 {
+	if x != nil {
+		return x.NewPrice
+	}
+	return 0
+}
+
+func (x *WishlistNotice) GetOldPriceAmount() *Money {
+	if x != nil {
+		return x.OldPriceAmount
+	}
+	return nil
+}
+
+func (x *WishlistNotice) GetNewPriceAmount() *Money {
+	if x != nil {
+		return x.NewPriceAmount
+	}
+	return nil
+}
+
+type AbandonedCart struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CartInfo         []*CartInfo            `protobuf:"bytes,2,rep,name=cart_info,json=cartInfo,proto3" json:"cart_info,omitempty"`
+	TotalValue       float64                `protobuf:"fixed64,3,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	LastActiveAt     int64                  `protobuf:"varint,4,opt,name=last_active_at,json=lastActiveAt,proto3" json:"last_active_at,omitempty"`
+	TotalValueAmount *Money                 `protobuf:"bytes,5,opt,name=total_value_amount,json=totalValueAmount,proto3" json:"total_value_amount,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AbandonedCart) Reset() {
+	*x = AbandonedCart{}
+	mi := &file_proto_cart_cart_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbandonedCart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonedCart) ProtoMessage() {}
+
+func (x *AbandonedCart) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonedCart.ProtoReflect.Descriptor instead.
+func (*AbandonedCart) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AbandonedCart) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AbandonedCart) GetCartInfo() []*CartInfo {
+	if x != nil {
+		return x.CartInfo
+	}
+	return nil
+}
+
+func (x *AbandonedCart) GetTotalValue() float64 {
+	if x != nil {
+		return x.TotalValue
+	}
+	return 0
+}
+
+func (x *AbandonedCart) GetLastActiveAt() int64 {
+	if x != nil {
+		return x.LastActiveAt
+	}
+	return 0
+}
+
+func (x *AbandonedCart) GetTotalValueAmount() *Money {
+	if x != nil {
+		return x.TotalValueAmount
+	}
+	return nil
+}
+
+type PriceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CouponCode      string                 `protobuf:"bytes,2,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	DisplayCurrency string                 `protobuf:"bytes,3,opt,name=display_currency,json=displayCurrency,proto3" json:"display_currency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PriceRequest) Reset() {
+	*x = PriceRequest{}
+	mi := &file_proto_cart_cart_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceRequest) ProtoMessage() {}
+
+func (x *PriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceRequest.ProtoReflect.Descriptor instead.
+func (*PriceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PriceRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PriceRequest) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
+func (x *PriceRequest) GetDisplayCurrency() string {
+	if x != nil {
+		return x.DisplayCurrency
+	}
+	return ""
+}
+
+type AppliedPromotion struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId    int64                  `protobuf:"varint,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CouponCode     string                 `protobuf:"bytes,3,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	Discount       float64                `protobuf:"fixed64,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	FreeShipping   bool                   `protobuf:"varint,5,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"`
+	DiscountAmount *Money                 `protobuf:"bytes,6,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AppliedPromotion) Reset() {
+	*x = AppliedPromotion{}
+	mi := &file_proto_cart_cart_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedPromotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedPromotion) ProtoMessage() {}
+
+func (x *AppliedPromotion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedPromotion.ProtoReflect.Descriptor instead.
+func (*AppliedPromotion) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AppliedPromotion) GetPromotionId() int64 {
+	if x != nil {
+		return x.PromotionId
+	}
+	return 0
+}
+
+func (x *AppliedPromotion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AppliedPromotion) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
+func (x *AppliedPromotion) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *AppliedPromotion) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *AppliedPromotion) GetDiscountAmount() *Money {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return nil
+}
+
+type CartPrice struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Subtotal       float64                `protobuf:"fixed64,1,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount       float64                `protobuf:"fixed64,2,opt,name=discount,proto3" json:"discount,omitempty"`
+	Total          float64                `protobuf:"fixed64,3,opt,name=total,proto3" json:"total,omitempty"`
+	FreeShipping   bool                   `protobuf:"varint,4,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"`
+	Applied        []*AppliedPromotion    `protobuf:"bytes,5,rep,name=applied,proto3" json:"applied,omitempty"`
+	Display        *DisplayPrice          `protobuf:"bytes,6,opt,name=display,proto3" json:"display,omitempty"`
+	SubtotalAmount *Money                 `protobuf:"bytes,7,opt,name=subtotal_amount,json=subtotalAmount,proto3" json:"subtotal_amount,omitempty"`
+	DiscountAmount *Money                 `protobuf:"bytes,8,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	TotalAmount    *Money                 `protobuf:"bytes,9,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CartPrice) Reset() {
+	*x = CartPrice{}
+	mi := &file_proto_cart_cart_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartPrice) ProtoMessage() {}
+
+func (x *CartPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartPrice.ProtoReflect.Descriptor instead.
+func (*CartPrice) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CartPrice) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *CartPrice) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *CartPrice) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CartPrice) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *CartPrice) GetApplied() []*AppliedPromotion {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+func (x *CartPrice) GetDisplay() *DisplayPrice {
+	if x != nil {
+		return x.Display
+	}
+	return nil
+}
+
+func (x *CartPrice) GetSubtotalAmount() *Money {
+	if x != nil {
+		return x.SubtotalAmount
+	}
+	return nil
+}
+
+func (x *CartPrice) GetDiscountAmount() *Money {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return nil
+}
+
+func (x *CartPrice) GetTotalAmount() *Money {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return nil
+}
+
+type DisplayPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtotal      *Money                 `protobuf:"bytes,1,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount      *Money                 `protobuf:"bytes,2,opt,name=discount,proto3" json:"discount,omitempty"`
+	Total         *Money                 `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	Rate          string                 `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	RateSource    string                 `protobuf:"bytes,5,opt,name=rate_source,json=rateSource,proto3" json:"rate_source,omitempty"`
+	RateAsOf      int64                  `protobuf:"varint,6,opt,name=rate_as_of,json=rateAsOf,proto3" json:"rate_as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisplayPrice) Reset() {
+	*x = DisplayPrice{}
+	mi := &file_proto_cart_cart_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisplayPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisplayPrice) ProtoMessage() {}
+
+func (x *DisplayPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_cart_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisplayPrice.ProtoReflect.Descriptor instead.
+func (*DisplayPrice) Descriptor() ([]byte, []int) {
+	return file_proto_cart_cart_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DisplayPrice) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *DisplayPrice) GetDiscount() *Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *DisplayPrice) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *DisplayPrice) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *DisplayPrice) GetRateSource() string {
+	if x != nil {
+		return x.RateSource
+	}
+	return ""
+}
+
+func (x *DisplayPrice) GetRateAsOf() int64 {
+	if x != nil {
+		return x.RateAsOf
+	}
+	return 0
+}
+
+var File_proto_cart_cart_proto protoreflect.FileDescriptor
+
+const file_proto_cart_cart_proto_rawDesc = "" +
+	"\n" +
+	"\x15proto/cart/cart.proto\x12\x04cart\"}\n" +
+	"\bCartInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x03R\tproductId\x12\x17\n" +
+	"\asize_id\x18\x04 \x01(\x03R\x06sizeId\x12\x10\n" +
+	"\x03num\x18\x05 \x01(\x03R\x03num\"8\n" +
+	"\vResponseAdd\x12\x17\n" +
+	"\acart_id\x18\x01 \x01(\x03R\x06cartId\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\" \n" +
+	"\x05Clean\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x1c\n" +
+	"\bResponse\x12\x10\n" +
+	"\x03meg\x18\x01 \x01(\tR\x03meg\"N\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"change_num\x18\x02 \x01(\x03R\tchangeNum\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\"1\n" +
+	"\x06CartID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"&\n" +
+	"\vCartFindAll\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"6\n" +
+	"\aCartAll\x12+\n" +
+	"\tcart_info\x18\x01 \x03(\v2\x0e.cart.CartInfoR\bcartInfo\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xfd\x01\n" +
+	"\fWishlistInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x03R\tproductId\x12\x17\n" +
+	"\asize_id\x18\x04 \x01(\x03R\x06sizeId\x12\x10\n" +
+	"\x03num\x18\x05 \x01(\x03R\x03num\x12\x1d\n" +
+	"\n" +
+	"last_price\x18\x06 \x01(\x01R\tlastPrice\x12\"\n" +
+	"\rlast_in_stock\x18\a \x01(\bR\vlastInStock\x127\n" +
+	"\x11last_price_amount\x18\b \x01(\v2\v.cart.MoneyR\x0flastPriceAmount\"E\n" +
+	"\x10ResponseWishlist\x12\x1f\n" +
+	"\vwishlist_id\x18\x01 \x01(\x03R\n" +
+	"wishlistId\x12\x10\n" +
+	"\x03msg\x18\x02 \x01(\tR\x03msg\"5\n" +
+	"\n" +
+	"WishlistID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"F\n" +
+	"\vWishlistAll\x127\n" +
+	"\rwishlist_info\x18\x01 \x03(\v2\x12.cart.WishlistInfoR\fwishlistInfo\"3\n" +
+	"\bMoveItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xc2\x02\n" +
+	"\x0eWishlistNotice\x12\x1f\n" +
+	"\vwishlist_id\x18\x01 \x01(\x03R\n" +
+	"wishlistId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x03R\tproductId\x12\x17\n" +
+	"\asize_id\x18\x04 \x01(\x03R\x06sizeId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1b\n" +
+	"\told_price\x18\x06 \x01(\x01R\boldPrice\x12\x1b\n" +
+	"\tnew_price\x18\a \x01(\x01R\bnewPrice\x125\n" +
+	"\x10old_price_amount\x18\b \x01(\v2\v.cart.MoneyR\x0eoldPriceAmount\x125\n" +
+	"\x10new_price_amount\x18\t \x01(\v2\v.cart.MoneyR\x0enewPriceAmount\"\xd7\x01\n" +
+	"\rAbandonedCart\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12+\n" +
+	"\tcart_info\x18\x02 \x03(\v2\x0e.cart.CartInfoR\bcartInfo\x12\x1f\n" +
+	"\vtotal_value\x18\x03 \x01(\x01R\n" +
+	"totalValue\x12$\n" +
+	"\x0elast_active_at\x18\x04 \x01(\x03R\flastActiveAt\x129\n" +
+	"\x12total_value_amount\x18\x05 \x01(\v2\v.cart.MoneyR\x10totalValueAmount\"s\n" +
+	"\fPriceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
+	"\vcoupon_code\x18\x02 \x01(\tR\n" +
+	"couponCode\x12)\n" +
+	"\x10display_currency\x18\x03 \x01(\tR\x0fdisplayCurrency\"\xe1\x01\n" +
+	"\x10AppliedPromotion\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\x03R\vpromotionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcoupon_code\x18\x03 \x01(\tR\n" +
+	"couponCode\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x01R\bdiscount\x12#\n" +
+	"\rfree_shipping\x18\x05 \x01(\bR\ffreeShipping\x124\n" +
+	"\x0fdiscount_amount\x18\x06 \x01(\v2\v.cart.MoneyR\x0ediscountAmount\"\xfa\x02\n" +
+	"\tCartPrice\x12\x1a\n" +
+	"\bsubtotal\x18\x01 \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\x02 \x01(\x01R\bdiscount\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x01R\x05total\x12#\n" +
+	"\rfree_shipping\x18\x04 \x01(\bR\ffreeShipping\x120\n" +
+	"\aapplied\x18\x05 \x03(\v2\x16.cart.AppliedPromotionR\aapplied\x12,\n" +
+	"\adisplay\x18\x06 \x01(\v2\x12.cart.DisplayPriceR\adisplay\x124\n" +
+	"\x0fsubtotal_amount\x18\a \x01(\v2\v.cart.MoneyR\x0esubtotalAmount\x124\n" +
+	"\x0fdiscount_amount\x18\b \x01(\v2\v.cart.MoneyR\x0ediscountAmount\x12.\n" +
+	"\ftotal_amount\x18\t \x01(\v2\v.cart.MoneyR\vtotalAmount\"\xd6\x01\n" +
+	"\fDisplayPrice\x12'\n" +
+	"\bsubtotal\x18\x01 \x01(\v2\v.cart.MoneyR\bsubtotal\x12'\n" +
+	"\bdiscount\x18\x02 \x01(\v2\v.cart.MoneyR\bdiscount\x12!\n" +
+	"\x05total\x18\x03 \x01(\v2\v.cart.MoneyR\x05total\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\x12\x1f\n" +
+	"\vrate_source\x18\x05 \x01(\tR\n" +
+	"rateSource\x12\x1c\n" +
+	"\n" +
+	"rate_as_of\x18\x06 \x01(\x03R\brateAsOf2\xdf\x04\n" +
+	"\x04Cart\x12.\n" +
+	"\aAddCart\x12\x0e.cart.CartInfo\x1a\x11.cart.ResponseAdd\"\x00\x12*\n" +
+	"\tCleanCart\x12\v.cart.Clean\x1a\x0e.cart.Response\"\x00\x12$\n" +
+	"\x04Incr\x12\n" +
+	".cart.Item\x1a\x0e.cart.Response\"\x00\x12$\n" +
+	"\x04Decr\x12\n" +
+	".cart.Item\x1a\x0e.cart.Response\"\x00\x120\n" +
+	"\x0eDeleteItemByID\x12\f.cart.CartID\x1a\x0e.cart.Response\"\x00\x12,\n" +
+	"\x06GetAll\x12\x11.cart.CartFindAll\x1a\r.cart.CartAll\"\x00\x12;\n" +
+	"\vAddWishlist\x12\x12.cart.WishlistInfo\x1a\x16.cart.ResponseWishlist\"\x00\x125\n" +
+	"\vGetWishlist\x12\x11.cart.CartFindAll\x1a\x11.cart.WishlistAll\"\x00\x128\n" +
+	"\x12DeleteWishlistItem\x12\x10.cart.WishlistID\x1a\x0e.cart.Response\"\x00\x121\n" +
+	"\n" +
+	"MoveToCart\x12\x0e.cart.MoveItem\x1a\x11.cart.ResponseAdd\"\x00\x12:\n" +
+	"\x0eMoveToWishlist\x12\x0e.cart.MoveItem\x1a\x16.cart.ResponseWishlist\"\x00\x122\n" +
+	"\tPriceCart\x12\x12.cart.PriceRequest\x1a\x0f.cart.CartPrice\"\x00B\x0eZ\f./proto;cartb\x06proto3"
 
 var (
 	file_proto_cart_cart_proto_rawDescOnce sync.Once
@@ -473,36 +1448,75 @@ func file_proto_cart_cart_proto_rawDescGZIP() []byte {
 	return file_proto_cart_cart_proto_rawDescData
 }
 
-var file_proto_cart_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_cart_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_cart_cart_proto_goTypes = []any{
-	(*CartInfo)(nil),    // 0: cart.CartInfo
-	(*ResponseAdd)(nil), // 1: cart.ResponseAdd
-	(*Clean)(nil),       // 2: cart.Clean
-	(*Response)(nil),    // 3: cart.Response
-	(*Item)(nil),        // 4: cart.Item
-	(*CartID)(nil),      // 5: cart.CartID
-	(*CartFindAll)(nil), // 6: cart.CartFindAll
-	(*CartAll)(nil),     // 7: cart.CartAll
+	(*CartInfo)(nil),         // 0: cart.CartInfo
+	(*ResponseAdd)(nil),      // 1: cart.ResponseAdd
+	(*Clean)(nil),            // 2: cart.Clean
+	(*Response)(nil),         // 3: cart.Response
+	(*Item)(nil),             // 4: cart.Item
+	(*CartID)(nil),           // 5: cart.CartID
+	(*CartFindAll)(nil),      // 6: cart.CartFindAll
+	(*CartAll)(nil),          // 7: cart.CartAll
+	(*Money)(nil),            // 8: cart.Money
+	(*WishlistInfo)(nil),     // 9: cart.WishlistInfo
+	(*ResponseWishlist)(nil), // 10: cart.ResponseWishlist
+	(*WishlistID)(nil),       // 11: cart.WishlistID
+	(*WishlistAll)(nil),      // 12: cart.WishlistAll
+	(*MoveItem)(nil),         // 13: cart.MoveItem
+	(*WishlistNotice)(nil),   // 14: cart.WishlistNotice
+	(*AbandonedCart)(nil),    // 15: cart.AbandonedCart
+	(*PriceRequest)(nil),     // 16: cart.PriceRequest
+	(*AppliedPromotion)(nil), // 17: cart.AppliedPromotion
+	(*CartPrice)(nil),        // 18: cart.CartPrice
+	(*DisplayPrice)(nil),     // 19: cart.DisplayPrice
 }
 var file_proto_cart_cart_proto_depIdxs = []int32{
-	0, // 0: cart.CartAll.cart_info:type_name -> cart.CartInfo
-	0, // 1: cart.Cart.AddCart:input_type -> cart.CartInfo
-	2, // 2: cart.Cart.CleanCart:input_type -> cart.Clean
-	4, // 3: cart.Cart.Incr:input_type -> cart.Item
-	4, // 4: cart.Cart.Decr:input_type -> cart.Item
-	5, // 5: cart.Cart.DeleteItemByID:input_type -> cart.CartID
-	6, // 6: cart.Cart.GetAll:input_type -> cart.CartFindAll
-	1, // 7: cart.Cart.AddCart:output_type -> cart.ResponseAdd
-	3, // 8: cart.Cart.CleanCart:output_type -> cart.Response
-	3, // 9: cart.Cart.Incr:output_type -> cart.Response
-	3, // 10: cart.Cart.Decr:output_type -> cart.Response
-	3, // 11: cart.Cart.DeleteItemByID:output_type -> cart.Response
-	7, // 12: cart.Cart.GetAll:output_type -> cart.CartAll
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: cart.CartAll.cart_info:type_name -> cart.CartInfo
+	8,  // 1: cart.WishlistInfo.last_price_amount:type_name -> cart.Money
+	9,  // 2: cart.WishlistAll.wishlist_info:type_name -> cart.WishlistInfo
+	8,  // 3: cart.WishlistNotice.old_price_amount:type_name -> cart.Money
+	8,  // 4: cart.WishlistNotice.new_price_amount:type_name -> cart.Money
+	0,  // 5: cart.AbandonedCart.cart_info:type_name -> cart.CartInfo
+	8,  // 6: cart.AbandonedCart.total_value_amount:type_name -> cart.Money
+	8,  // 7: cart.AppliedPromotion.discount_amount:type_name -> cart.Money
+	17, // 8: cart.CartPrice.applied:type_name -> cart.AppliedPromotion
+	19, // 9: cart.CartPrice.display:type_name -> cart.DisplayPrice
+	8,  // 10: cart.CartPrice.subtotal_amount:type_name -> cart.Money
+	8,  // 11: cart.CartPrice.discount_amount:type_name -> cart.Money
+	8,  // 12: cart.CartPrice.total_amount:type_name -> cart.Money
+	8,  // 13: cart.DisplayPrice.subtotal:type_name -> cart.Money
+	8,  // 14: cart.DisplayPrice.discount:type_name -> cart.Money
+	8,  // 15: cart.DisplayPrice.total:type_name -> cart.Money
+	0,  // 16: cart.Cart.AddCart:input_type -> cart.CartInfo
+	2,  // 17: cart.Cart.CleanCart:input_type -> cart.Clean
+	4,  // 18: cart.Cart.Incr:input_type -> cart.Item
+	4,  // 19: cart.Cart.Decr:input_type -> cart.Item
+	5,  // 20: cart.Cart.DeleteItemByID:input_type -> cart.CartID
+	6,  // 21: cart.Cart.GetAll:input_type -> cart.CartFindAll
+	9,  // 22: cart.Cart.AddWishlist:input_type -> cart.WishlistInfo
+	6,  // 23: cart.Cart.GetWishlist:input_type -> cart.CartFindAll
+	11, // 24: cart.Cart.DeleteWishlistItem:input_type -> cart.WishlistID
+	13, // 25: cart.Cart.MoveToCart:input_type -> cart.MoveItem
+	13, // 26: cart.Cart.MoveToWishlist:input_type -> cart.MoveItem
+	16, // 27: cart.Cart.PriceCart:input_type -> cart.PriceRequest
+	1,  // 28: cart.Cart.AddCart:output_type -> cart.ResponseAdd
+	3,  // 29: cart.Cart.CleanCart:output_type -> cart.Response
+	3,  // 30: cart.Cart.Incr:output_type -> cart.Response
+	3,  // 31: cart.Cart.Decr:output_type -> cart.Response
+	3,  // 32: cart.Cart.DeleteItemByID:output_type -> cart.Response
+	7,  // 33: cart.Cart.GetAll:output_type -> cart.CartAll
+	10, // 34: cart.Cart.AddWishlist:output_type -> cart.ResponseWishlist
+	12, // 35: cart.Cart.GetWishlist:output_type -> cart.WishlistAll
+	3,  // 36: cart.Cart.DeleteWishlistItem:output_type -> cart.Response
+	1,  // 37: cart.Cart.MoveToCart:output_type -> cart.ResponseAdd
+	10, // 38: cart.Cart.MoveToWishlist:output_type -> cart.ResponseWishlist
+	18, // 39: cart.Cart.PriceCart:output_type -> cart.CartPrice
+	28, // [28:40] is the sub-list for method output_type
+	16, // [16:28] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_proto_cart_cart_proto_init() }
@@ -516,7 +1530,7 @@ func file_proto_cart_cart_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_cart_cart_proto_rawDesc), len(file_proto_cart_cart_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
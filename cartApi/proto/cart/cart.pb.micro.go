@@ -36,6 +36,12 @@ type CartService interface {
 	Decr(ctx context.Context, in *Item, opts ...client.CallOption) (*Response, error)
 	DeleteItemByID(ctx context.Context, in *CartID, opts ...client.CallOption) (*Response, error)
 	GetAll(ctx context.Context, in *CartFindAll, opts ...client.CallOption) (*CartAll, error)
+	AddWishlist(ctx context.Context, in *WishlistInfo, opts ...client.CallOption) (*ResponseWishlist, error)
+	GetWishlist(ctx context.Context, in *CartFindAll, opts ...client.CallOption) (*WishlistAll, error)
+	DeleteWishlistItem(ctx context.Context, in *WishlistID, opts ...client.CallOption) (*Response, error)
+	MoveToCart(ctx context.Context, in *MoveItem, opts ...client.CallOption) (*ResponseAdd, error)
+	MoveToWishlist(ctx context.Context, in *MoveItem, opts ...client.CallOption) (*ResponseWishlist, error)
+	PriceCart(ctx context.Context, in *PriceRequest, opts ...client.CallOption) (*CartPrice, error)
 }
 
 type cartService struct {
@@ -110,6 +116,66 @@ func (c *cartService) GetAll(ctx context.Context, in *CartFindAll, opts ...clien
 	return out, nil
 }
 
+func (c *cartService) AddWishlist(ctx context.Context, in *WishlistInfo, opts ...client.CallOption) (*ResponseWishlist, error) {
+	req := c.c.NewRequest(c.name, "Cart.AddWishlist", in)
+	out := new(ResponseWishlist)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartService) GetWishlist(ctx context.Context, in *CartFindAll, opts ...client.CallOption) (*WishlistAll, error) {
+	req := c.c.NewRequest(c.name, "Cart.GetWishlist", in)
+	out := new(WishlistAll)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartService) DeleteWishlistItem(ctx context.Context, in *WishlistID, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.name, "Cart.DeleteWishlistItem", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartService) MoveToCart(ctx context.Context, in *MoveItem, opts ...client.CallOption) (*ResponseAdd, error) {
+	req := c.c.NewRequest(c.name, "Cart.MoveToCart", in)
+	out := new(ResponseAdd)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartService) MoveToWishlist(ctx context.Context, in *MoveItem, opts ...client.CallOption) (*ResponseWishlist, error) {
+	req := c.c.NewRequest(c.name, "Cart.MoveToWishlist", in)
+	out := new(ResponseWishlist)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cartService) PriceCart(ctx context.Context, in *PriceRequest, opts ...client.CallOption) (*CartPrice, error) {
+	req := c.c.NewRequest(c.name, "Cart.PriceCart", in)
+	out := new(CartPrice)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cart service
 
 type CartHandler interface {
@@ -119,6 +185,12 @@ type CartHandler interface {
 	Decr(context.Context, *Item, *Response) error
 	DeleteItemByID(context.Context, *CartID, *Response) error
 	GetAll(context.Context, *CartFindAll, *CartAll) error
+	AddWishlist(context.Context, *WishlistInfo, *ResponseWishlist) error
+	GetWishlist(context.Context, *CartFindAll, *WishlistAll) error
+	DeleteWishlistItem(context.Context, *WishlistID, *Response) error
+	MoveToCart(context.Context, *MoveItem, *ResponseAdd) error
+	MoveToWishlist(context.Context, *MoveItem, *ResponseWishlist) error
+	PriceCart(context.Context, *PriceRequest, *CartPrice) error
 }
 
 func RegisterCartHandler(s server.Server, hdlr CartHandler, opts ...server.HandlerOption) error {
@@ -129,6 +201,12 @@ func RegisterCartHandler(s server.Server, hdlr CartHandler, opts ...server.Handl
 		Decr(ctx context.Context, in *Item, out *Response) error
 		DeleteItemByID(ctx context.Context, in *CartID, out *Response) error
 		GetAll(ctx context.Context, in *CartFindAll, out *CartAll) error
+		AddWishlist(ctx context.Context, in *WishlistInfo, out *ResponseWishlist) error
+		GetWishlist(ctx context.Context, in *CartFindAll, out *WishlistAll) error
+		DeleteWishlistItem(ctx context.Context, in *WishlistID, out *Response) error
+		MoveToCart(ctx context.Context, in *MoveItem, out *ResponseAdd) error
+		MoveToWishlist(ctx context.Context, in *MoveItem, out *ResponseWishlist) error
+		PriceCart(ctx context.Context, in *PriceRequest, out *CartPrice) error
 	}
 	type Cart struct {
 		cart
@@ -164,3 +242,27 @@ func (h *cartHandler) DeleteItemByID(ctx context.Context, in *CartID, out *Respo
 func (h *cartHandler) GetAll(ctx context.Context, in *CartFindAll, out *CartAll) error {
 	return h.CartHandler.GetAll(ctx, in, out)
 }
+
+func (h *cartHandler) AddWishlist(ctx context.Context, in *WishlistInfo, out *ResponseWishlist) error {
+	return h.CartHandler.AddWishlist(ctx, in, out)
+}
+
+func (h *cartHandler) GetWishlist(ctx context.Context, in *CartFindAll, out *WishlistAll) error {
+	return h.CartHandler.GetWishlist(ctx, in, out)
+}
+
+func (h *cartHandler) DeleteWishlistItem(ctx context.Context, in *WishlistID, out *Response) error {
+	return h.CartHandler.DeleteWishlistItem(ctx, in, out)
+}
+
+func (h *cartHandler) MoveToCart(ctx context.Context, in *MoveItem, out *ResponseAdd) error {
+	return h.CartHandler.MoveToCart(ctx, in, out)
+}
+
+func (h *cartHandler) MoveToWishlist(ctx context.Context, in *MoveItem, out *ResponseWishlist) error {
+	return h.CartHandler.MoveToWishlist(ctx, in, out)
+}
+
+func (h *cartHandler) PriceCart(ctx context.Context, in *PriceRequest, out *CartPrice) error {
+	return h.CartHandler.PriceCart(ctx, in, out)
+}
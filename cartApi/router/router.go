@@ -6,18 +6,23 @@ import (
 
 	"cartApi/handler"
 
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/ginauth"
 	"github.com/Ben1524/GoMall/common/config"
 	"github.com/gin-gonic/gin"
 )
 
 const defaultAPIPrefix = "/api/v1"
 
-// New 创建并返回配置好的 gin.Engine。
+// New 创建并返回配置好的 gin.Engine，API 路由组均需携带有效的访问令牌。
 // cfg 可为 nil，此时使用默认模式和 API 前缀。
-func New(cfg *config.Config, cartHandler *handler.CartApiHandler) *gin.Engine {
+func New(cfg *config.Config, verifier auth.Verifier, cartHandler *handler.CartApiHandler) *gin.Engine {
 	if cartHandler == nil {
 		panic("cart handler must not be nil")
 	}
+	if verifier == nil {
+		panic("token verifier must not be nil")
+	}
 
 	gin.SetMode(resolveGinMode(cfg))
 
@@ -28,7 +33,7 @@ func New(cfg *config.Config, cartHandler *handler.CartApiHandler) *gin.Engine {
 
 	apiPrefix := defaultAPIPrefix
 
-	api := engine.Group(apiPrefix)
+	api := engine.Group(apiPrefix, ginauth.RequireUser(verifier))
 	cartHandler.RegisterRoutes(api)
 
 	engine.GET("/healthz", func(ctx *gin.Context) {
@@ -0,0 +1,91 @@
+// Package auth 用户身份认证：签发与校验 JWT（HS256），刷新令牌轮换与服务端吊销。
+//
+// 每次登录创建一个会话，会话内同一时刻只有一个有效的刷新令牌；刷新时签发新令牌并作废旧令牌，
+// 已作废的刷新令牌再次出现视为泄露，整个会话随即吊销。访问令牌携带会话ID，
+// 校验时检查会话是否仍有效，因此吊销（登出、改密、锁定账号）在所有共享会话存储的服务上立即生效。
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidToken = errors.New("令牌无效")
+	ErrTokenExpired = errors.New("令牌已过期")
+	ErrTokenRevoked = errors.New("令牌已吊销")
+	ErrTokenReused  = errors.New("刷新令牌已被使用，会话已吊销")
+	// ErrUnauthenticated 受保护的端点未携带访问令牌
+	ErrUnauthenticated = errors.New("缺少访问令牌")
+)
+
+// MetadataKey 网关转发访问令牌时使用的 HTTP 头与 go-micro 元数据键，值为 "Bearer <token>"
+const MetadataKey = "Authorization"
+
+// 令牌类型
+const (
+	TokenAccess  = "access"
+	TokenRefresh = "refresh"
+	TokenService = "service" // 服务间调用令牌，Subject 为服务名，不关联会话
+)
+
+// Claims JWT 声明，Subject 为用户ID，Roles 为签发时用户的角色
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	ID        string   `json:"jti"`
+	SessionID string   `json:"sid"`
+	Type      string   `json:"typ"`
+	Roles     []string `json:"roles,omitempty"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// UserID 返回声明中的用户ID，服务令牌返回 0
+func (c *Claims) UserID() int64 {
+	if c.IsService() {
+		return 0
+	}
+	userID, _ := strconv.ParseInt(c.Subject, 10, 64)
+	return userID
+}
+
+// IsService 是否为服务间调用令牌
+func (c *Claims) IsService() bool {
+	return c.Type == TokenService
+}
+
+// Verifier 校验访问令牌，网关中间件与服务端包装器依赖此接口
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+type claimsKey struct{}
+
+// NewContext 将已校验的声明放入 context
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext 取出已校验的声明，未认证时返回 false
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// BearerToken 从 "Bearer <token>" 中取出令牌
+func BearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// IsAuthError 未携带令牌或令牌无效、过期、已吊销，调用方应返回 401；其余错误为会话存储不可用等内部错误
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrUnauthenticated) || errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrTokenExpired) ||
+		errors.Is(err, ErrTokenRevoked) || errors.Is(err, ErrTokenReused)
+}
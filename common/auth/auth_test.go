@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+func newTestManager(t *testing.T) (*Manager, *MemoryStore, *time.Time) {
+	t.Helper()
+	store := NewMemoryStore()
+	manager, err := NewManager(config.JWTConfig{
+		Secret:            "0123456789abcdef0123456789abcdef",
+		Issuer:            "gomall",
+		AccessExpiration:  15 * time.Minute,
+		RefreshExpiration: 24 * time.Hour,
+	}, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	store.now = manager.now
+	return manager, store, &now
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	manager, _, now := newTestManager(t)
+	ctx := context.Background()
+	pair, err := manager.Issue(ctx, 42, []string{RoleCustomer}, ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := manager.Verify(ctx, pair.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID() != 42 || claims.SessionID != pair.SessionID || !claims.Can(PermAuthenticated) || claims.Can(PermOrderManage) {
+		t.Errorf("claims = %+v", claims)
+	}
+	if _, err := manager.Verify(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("刷新令牌不能当作访问令牌: %v", err)
+	}
+	if _, err := manager.Verify(ctx, pair.AccessToken+"x"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("篡改的令牌: %v", err)
+	}
+	*now = now.Add(16 * time.Minute)
+	if _, err := manager.Verify(ctx, pair.AccessToken); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("过期的令牌: %v", err)
+	}
+}
+
+func TestRefreshRotationAndReuse(t *testing.T) {
+	manager, _, _ := newTestManager(t)
+	ctx := context.Background()
+	first, _ := manager.Issue(ctx, 7, []string{RoleFinance}, ClientInfo{})
+	second, err := manager.Refresh(ctx, first.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.SessionID != first.SessionID || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("刷新应在同一会话内轮换令牌")
+	}
+	claims, err := manager.Verify(ctx, second.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !claims.Can(PermPaymentManage) {
+		t.Errorf("刷新后应沿用角色: %v", claims.Roles)
+	}
+
+	if _, err := manager.Refresh(ctx, first.RefreshToken, ClientInfo{}); !errors.Is(err, ErrTokenReused) {
+		t.Fatalf("重复使用旧刷新令牌: %v", err)
+	}
+	if _, err := manager.Verify(ctx, second.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("重复使用后会话应被吊销: %v", err)
+	}
+	if _, err := manager.Refresh(ctx, second.RefreshToken, ClientInfo{}); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("吊销后刷新: %v", err)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	manager, _, _ := newTestManager(t)
+	ctx := context.Background()
+	a, _ := manager.Issue(ctx, 1, nil, ClientInfo{})
+	b, _ := manager.Issue(ctx, 1, nil, ClientInfo{})
+	other, _ := manager.Issue(ctx, 2, nil, ClientInfo{})
+
+	if err := manager.Revoke(ctx, a.RefreshToken); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Verify(ctx, a.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("登出后: %v", err)
+	}
+	if _, err := manager.Verify(ctx, b.AccessToken); err != nil {
+		t.Errorf("其他会话不受影响: %v", err)
+	}
+
+	if err := manager.RevokeUser(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Verify(ctx, b.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("RevokeUser 后: %v", err)
+	}
+	if _, err := manager.Verify(ctx, other.AccessToken); err != nil {
+		t.Errorf("其他用户不受影响: %v", err)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	manager, _, now := newTestManager(t)
+	ctx := context.Background()
+	phone, _ := manager.Issue(ctx, 1, nil, ClientInfo{Device: "iPhone", IP: "10.0.0.1", UserAgent: "app/1.0"})
+	*now = now.Add(5 * time.Minute)
+	laptop, _ := manager.Issue(ctx, 1, nil, ClientInfo{Device: "MacBook", IP: "10.0.0.2", UserAgent: "Safari"})
+	tablet, _ := manager.Issue(ctx, 1, nil, ClientInfo{Device: "iPad"})
+	other, _ := manager.Issue(ctx, 2, nil, ClientInfo{})
+
+	// 刷新时更新客户端信息与最后活跃时间
+	*now = now.Add(5 * time.Minute)
+	if _, err := manager.Refresh(ctx, phone.RefreshToken, ClientInfo{IP: "10.0.0.9"}); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := manager.Sessions(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 3 || sessions[0].ID != phone.SessionID {
+		t.Fatalf("sessions = %+v", sessions)
+	}
+	if s := sessions[0]; s.Device != "iPhone" || s.IP != "10.0.0.9" || s.UserAgent != "app/1.0" || !s.LastSeenAt.Equal(*now) {
+		t.Errorf("刷新后的会话 = %+v", s)
+	}
+
+	// 校验访问令牌时更新最后活跃时间
+	*now = now.Add(2 * time.Minute)
+	if _, err := manager.Verify(ctx, laptop.AccessToken); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, _ = manager.Sessions(ctx, 1); sessions[0].ID != laptop.SessionID {
+		t.Errorf("最近活跃的会话 = %s, want %s", sessions[0].ID, laptop.SessionID)
+	}
+
+	if err := manager.RevokeSession(ctx, 2, laptop.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("吊销其他用户的会话: %v", err)
+	}
+	if err := manager.RevokeSession(ctx, 1, laptop.SessionID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Verify(ctx, laptop.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("吊销后: %v", err)
+	}
+
+	revoked, err := manager.RevokeUserExcept(ctx, 1, tablet.SessionID)
+	if err != nil || revoked != 1 {
+		t.Fatalf("revoked = %d, err = %v, want 1", revoked, err)
+	}
+	if sessions, _ = manager.Sessions(ctx, 1); len(sessions) != 1 || sessions[0].ID != tablet.SessionID {
+		t.Errorf("保留当前会话后 = %+v", sessions)
+	}
+	if _, err := manager.Verify(ctx, other.AccessToken); err != nil {
+		t.Errorf("其他用户不受影响: %v", err)
+	}
+}
+
+func TestServiceToken(t *testing.T) {
+	manager, _, now := newTestManager(t)
+	ctx := context.Background()
+	token, err := manager.IssueService("go.micro.service.user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := manager.Verify(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !claims.IsService() || claims.UserID() != 0 || claims.Subject != "go.micro.service.user" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if !claims.Can(PermPrivacyProcess) || claims.Can(PermUserManage) {
+		t.Errorf("服务令牌只拥有服务权限")
+	}
+	if _, err := manager.Refresh(ctx, token, ClientInfo{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("服务令牌不能用于刷新: %v", err)
+	}
+	*now = now.Add(2 * time.Minute)
+	if _, err := manager.Verify(ctx, token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("过期的服务令牌: %v", err)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	cases := map[string]string{
+		"Bearer abc":  "abc",
+		"bearer  abc": "abc",
+		"Basic abc":   "",
+		"Bearer ":     "",
+		"abc":         "",
+	}
+	for header, want := range cases {
+		if got, _ := BearerToken(header); got != want {
+			t.Errorf("BearerToken(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	policy := Policy{
+		"Product.DeleteProductByID":  PermProductWrite,
+		"Order.GetAllOrder":          PermAuthenticated,
+		"Privacy.EraseUserData":      PermPrivacyProcess,
+		"Order.UpdateOrderPayStatus": PermServiceCall,
+		"User.Login":                 PermPublic,
+	}
+	ctx := context.Background()
+	merchandiser := &Claims{Subject: "1", Roles: []string{RoleMerchandiser}}
+	support := &Claims{Subject: "2", Roles: []string{RoleCustomer, RoleSupport}}
+	admin := &Claims{Subject: "3", Roles: []string{RoleAdmin}}
+	service := &Claims{Subject: "go.micro.service.user", Type: TokenService}
+
+	cases := []struct {
+		target string
+		claims *Claims
+		want   error
+	}{
+		{"Product.DeleteProductByID", merchandiser, nil},
+		{"Product.DeleteProductByID", admin, nil},
+		{"Product.DeleteProductByID", support, ErrForbidden},
+		{"Product.DeleteProductByID", nil, ErrUnauthenticated},
+		{"Order.GetAllOrder", support, nil},
+		{"Order.GetAllOrder", nil, ErrUnauthenticated},
+		{"Product.FindProductByID", nil, ErrForbidden},
+		{"Product.FindProductByID", admin, ErrForbidden},
+		{"User.Login", nil, nil},
+		{"Order.UpdateOrderPayStatus", nil, ErrUnauthenticated},
+		{"Order.UpdateOrderPayStatus", admin, ErrForbidden},
+		{"Order.UpdateOrderPayStatus", service, nil},
+		{"Privacy.EraseUserData", service, nil},
+		{"Privacy.EraseUserData", admin, ErrForbidden},
+		{"Product.DeleteProductByID", service, ErrForbidden},
+	}
+	for _, c := range cases {
+		if err := policy.Check(ctx, c.target, c.claims); !errors.Is(err, c.want) {
+			t.Errorf("Check(%s, %v) = %v, want %v", c.target, c.claims, err, c.want)
+		}
+	}
+}
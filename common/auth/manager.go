@@ -0,0 +1,281 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+const (
+	// minSecretLength HS256 密钥建议不少于 32 字节
+	minSecretLength = 32
+	// touchInterval 校验访问令牌时最多每隔这么久更新一次会话的最后活跃时间
+	touchInterval = time.Minute
+	// serviceTokenTTL 服务令牌每次调用时签发，只需覆盖一次 RPC
+	serviceTokenTTL = time.Minute
+)
+
+// TokenPair 登录或刷新后签发的一组令牌
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	UserID           int64
+	SessionID        string
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
+
+// Manager 签发、刷新、校验与吊销令牌
+type Manager struct {
+	secret     []byte
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	store      Store
+	now        func() time.Time
+}
+
+// NewManager 按 JWT 配置创建，store 保存会话；密钥为空时返回错误
+func NewManager(cfg config.JWTConfig, store Store) (*Manager, error) {
+	if cfg.Secret == "" {
+		return nil, errors.New("jwt.secret 不能为空")
+	}
+	if len(cfg.Secret) < minSecretLength {
+		slog.Warn("jwt.secret 过短，生产环境请使用至少 32 字节的随机密钥", "length", len(cfg.Secret))
+	}
+	if cfg.AccessExpiration <= 0 || cfg.RefreshExpiration <= 0 {
+		return nil, errors.New("jwt 令牌有效期必须大于 0")
+	}
+	return &Manager{
+		secret:     []byte(cfg.Secret),
+		issuer:     cfg.Issuer,
+		accessTTL:  cfg.AccessExpiration,
+		refreshTTL: cfg.RefreshExpiration,
+		store:      store,
+		now:        time.Now,
+	}, nil
+}
+
+// Issue 为用户创建新会话并签发令牌，登录成功后调用；roles 写入声明，刷新时沿用，角色变更后须吊销用户会话；
+// client 记录在会话上，供用户查看登录设备
+func (m *Manager) Issue(ctx context.Context, userID int64, roles []string, client ClientInfo) (*TokenPair, error) {
+	now := m.now()
+	session := &Session{
+		ID:         newTokenID(),
+		UserID:     userID,
+		RefreshID:  newTokenID(),
+		Device:     client.Device,
+		IP:         client.IP,
+		UserAgent:  client.UserAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(m.refreshTTL),
+	}
+	if err := m.store.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return m.pair(session.UserID, roles, session.ID, session.RefreshID, now)
+}
+
+// Refresh 用刷新令牌换取新的一组令牌，旧刷新令牌随即作废；
+// 已作废的刷新令牌再次使用时吊销整个会话并返回 ErrTokenReused；client 中非空的字段更新到会话上
+func (m *Manager) Refresh(ctx context.Context, refreshToken string, client ClientInfo) (*TokenPair, error) {
+	now := m.now()
+	claims, err := parse(refreshToken, m.secret, m.issuer, now)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != TokenRefresh {
+		return nil, ErrInvalidToken
+	}
+	refreshID := newTokenID()
+	err = m.store.RotateRefresh(ctx, claims.SessionID, claims.ID, refreshID, now.Add(m.refreshTTL))
+	switch {
+	case errors.Is(err, ErrTokenReused):
+		slog.Warn("检测到刷新令牌重复使用，吊销会话", "user_id", claims.UserID(), "session_id", claims.SessionID)
+		if revokeErr := m.store.RevokeSession(ctx, claims.SessionID); revokeErr != nil {
+			return nil, revokeErr
+		}
+		return nil, ErrTokenReused
+	case errors.Is(err, ErrSessionNotFound):
+		return nil, ErrTokenRevoked
+	case err != nil:
+		return nil, err
+	}
+	if err := m.store.TouchSession(ctx, claims.SessionID, client, now); err != nil {
+		slog.Warn("更新会话客户端信息失败", "session_id", claims.SessionID, "error", err)
+	}
+	return m.pair(claims.UserID(), claims.Roles, claims.SessionID, refreshID, now)
+}
+
+// IssueService 以服务身份签发短期服务令牌，用于后台任务、回调与隐私流程等不代表用户的服务间调用
+func (m *Manager) IssueService(service string) (string, error) {
+	if service == "" {
+		return "", errors.New("服务名不能为空")
+	}
+	now := m.now()
+	token, err := sign(&Claims{
+		Issuer:    m.issuer,
+		Subject:   service,
+		ID:        newTokenID(),
+		Type:      TokenService,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(serviceTokenTTL).Unix(),
+	}, m.secret)
+	if err != nil {
+		return "", fmt.Errorf("签发服务令牌失败: %w", err)
+	}
+	return token, nil
+}
+
+// Verify 校验访问令牌或服务令牌：签名、有效期与所属会话是否已吊销。
+// 每次都查询会话存储，会话吊销后下一个请求即被拒绝；顺带更新会话的最后活跃时间
+func (m *Manager) Verify(ctx context.Context, token string) (*Claims, error) {
+	now := m.now()
+	claims, err := parse(token, m.secret, m.issuer, now)
+	if err != nil {
+		return nil, err
+	}
+	if claims.IsService() {
+		return claims, nil
+	}
+	if claims.Type != TokenAccess {
+		return nil, ErrInvalidToken
+	}
+	session, err := m.store.FindSession(ctx, claims.SessionID)
+	if errors.Is(err, ErrSessionNotFound) {
+		return nil, ErrTokenRevoked
+	}
+	if err != nil {
+		return nil, err
+	}
+	if session.Revoked || session.UserID != claims.UserID() {
+		return nil, ErrTokenRevoked
+	}
+	if now.Sub(session.LastSeenAt) >= touchInterval {
+		if err := m.store.TouchSession(ctx, session.ID, ClientInfo{}, now); err != nil {
+			slog.Warn("更新会话最后活跃时间失败", "session_id", session.ID, "error", err)
+		}
+	}
+	return claims, nil
+}
+
+// Sessions 返回用户未吊销、未过期的会话，最近活跃的在前
+func (m *Manager) Sessions(ctx context.Context, userID int64) ([]Session, error) {
+	sessions, err := m.store.FindUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	active := sessions[:0]
+	for _, session := range sessions {
+		if !session.Revoked {
+			active = append(active, session)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool { return active[i].LastSeenAt.After(active[j].LastSeenAt) })
+	return active, nil
+}
+
+// RevokeSession 吊销用户的一个会话，会话不属于该用户时返回 ErrSessionNotFound
+func (m *Manager) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	session, err := m.store.FindSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	if session.Revoked {
+		return nil
+	}
+	return m.store.RevokeSession(ctx, sessionID)
+}
+
+// Revoke 吊销令牌所属的会话（登出），令牌可以是访问令牌或刷新令牌，已过期的令牌同样可用于登出
+func (m *Manager) Revoke(ctx context.Context, token string) error {
+	claims, err := parse(token, m.secret, m.issuer, time.Time{})
+	if err != nil {
+		return err
+	}
+	err = m.store.RevokeSession(ctx, claims.SessionID)
+	if errors.Is(err, ErrSessionNotFound) {
+		return nil
+	}
+	return err
+}
+
+// RevokeUser 吊销用户的全部会话，用于修改密码、锁定或注销账号
+func (m *Manager) RevokeUser(ctx context.Context, userID int64) error {
+	_, err := m.RevokeUserExcept(ctx, userID, "")
+	return err
+}
+
+// RevokeUserExcept 吊销用户除 keepSessionID 外的全部会话，返回吊销的会话数；keepSessionID 为空时全部吊销
+func (m *Manager) RevokeUserExcept(ctx context.Context, userID int64, keepSessionID string) (int, error) {
+	sessions, err := m.store.FindUserSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	revoked := 0
+	for _, session := range sessions {
+		if session.Revoked || session.ID == keepSessionID {
+			continue
+		}
+		err := m.store.RevokeSession(ctx, session.ID)
+		if errors.Is(err, ErrSessionNotFound) {
+			continue
+		}
+		if err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+func (m *Manager) pair(userID int64, roles []string, sessionID, refreshID string, now time.Time) (*TokenPair, error) {
+	pair := &TokenPair{
+		UserID:           userID,
+		SessionID:        sessionID,
+		AccessExpiresAt:  now.Add(m.accessTTL),
+		RefreshExpiresAt: now.Add(m.refreshTTL),
+	}
+	claims := Claims{
+		Issuer:    m.issuer,
+		Subject:   strconv.FormatInt(userID, 10),
+		SessionID: sessionID,
+		Roles:     roles,
+		IssuedAt:  now.Unix(),
+	}
+
+	access := claims
+	access.ID, access.Type, access.ExpiresAt = newTokenID(), TokenAccess, pair.AccessExpiresAt.Unix()
+	refresh := claims
+	refresh.ID, refresh.Type, refresh.ExpiresAt = refreshID, TokenRefresh, pair.RefreshExpiresAt.Unix()
+
+	var err error
+	if pair.AccessToken, err = sign(&access, m.secret); err != nil {
+		return nil, fmt.Errorf("签发访问令牌失败: %w", err)
+	}
+	if pair.RefreshToken, err = sign(&refresh, m.secret); err != nil {
+		return nil, fmt.Errorf("签发刷新令牌失败: %w", err)
+	}
+	return pair, nil
+}
+
+// newTokenID 128 位随机ID
+func newTokenID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,35 @@
+package microauth
+
+import (
+	"context"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"go-micro.dev/v5/client"
+	microerrors "go-micro.dev/v5/errors"
+	"go-micro.dev/v5/metadata"
+)
+
+// ServiceIssuer 签发服务令牌，由 *auth.Manager 实现
+type ServiceIssuer interface {
+	IssueService(service string) (string, error)
+}
+
+// NewServiceClient 包装 go-micro 客户端：每次调用都以 serviceID 的身份签发服务令牌，
+// 替换 context 中转发的用户令牌。只用于不代表用户的调用，如后台任务、渠道回调与隐私流程
+func NewServiceClient(c client.Client, issuer ServiceIssuer, serviceID string) client.Client {
+	return &serviceClient{Client: c, issuer: issuer, serviceID: serviceID}
+}
+
+type serviceClient struct {
+	client.Client
+	issuer    ServiceIssuer
+	serviceID string
+}
+
+func (c *serviceClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	token, err := c.issuer.IssueService(c.serviceID)
+	if err != nil {
+		return microerrors.InternalServerError(c.serviceID, "签发服务令牌失败: %v", err)
+	}
+	return c.Client.Call(metadata.Set(ctx, auth.MetadataKey, "Bearer "+token), req, rsp, opts...)
+}
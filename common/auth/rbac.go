@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+)
+
+// ErrForbidden 已认证但缺少所需权限，网关返回 403，服务端返回 go-micro Forbidden
+var ErrForbidden = errors.New("无权访问")
+
+// 角色，写入用户记录与令牌声明
+const (
+	RoleCustomer     = "customer"     // 普通顾客，注册时默认授予
+	RoleSupport      = "support"      // 客服：处理订单、退款、锁定账号
+	RoleMerchandiser = "merchandiser" // 商品运营：维护商品
+	RoleFinance      = "finance"      // 财务：支付通道、路由规则、对账与退款
+	RoleAdmin        = "admin"        // 管理员：拥有全部权限
+)
+
+// Permission 受保护操作所需的权限
+type Permission string
+
+const (
+	PermPublic        Permission = "public"         // 无需令牌，如注册、登录与渠道回调
+	PermAuthenticated Permission = ""               // 只要求已登录
+	PermProductWrite  Permission = "product:write"  // 新增、修改、删除商品
+	PermOrderManage   Permission = "order:manage"   // 查看全部订单，修改、删除订单与发货
+	PermPaymentManage Permission = "payment:manage" // 支付通道、路由规则与对账
+	PermPaymentRefund Permission = "payment:refund" // 发起退款
+	PermUserManage    Permission = "user:manage"    // 查询用户、变更用户状态与重置密码
+	PermRoleAssign    Permission = "role:assign"    // 授予与收回角色
+	PermAuditRead     Permission = "audit:read"     // 查看管理操作审计记录，只授予管理员
+
+	PermPrivacyProcess Permission = "privacy:process" // 导出与删除用户数据的隐私步骤，只授予服务令牌
+	PermServiceCall    Permission = "service:call"    // 服务间内部接口（如更新订单支付状态），只授予服务令牌
+)
+
+// servicePermissions 只授予服务令牌的权限，任何用户角色（包括管理员）都不具备
+var servicePermissions = []Permission{PermPrivacyProcess, PermServiceCall}
+
+// rolePermissions 角色权限表，管理员不在表中，拥有全部权限
+var rolePermissions = map[string][]Permission{
+	RoleCustomer:     nil,
+	RoleSupport:      {PermOrderManage, PermPaymentRefund, PermUserManage},
+	RoleMerchandiser: {PermProductWrite},
+	RoleFinance:      {PermPaymentManage, PermPaymentRefund},
+}
+
+// ValidRole 是否为已定义的角色
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok || role == RoleAdmin
+}
+
+// Can 声明中的角色是否拥有权限；服务令牌只拥有 servicePermissions
+func (c *Claims) Can(permission Permission) bool {
+	if permission == PermPublic || permission == PermAuthenticated {
+		return true
+	}
+	if c.IsService() || slices.Contains(servicePermissions, permission) {
+		return c.IsService() && slices.Contains(servicePermissions, permission)
+	}
+	for _, role := range c.Roles {
+		if role == RoleAdmin || slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy 声明式访问策略，键为 go-micro 端点（如 "Order.DeleteOrderByID"）
+// 或 gin 路由（如 "DELETE /api/v1/payment-routing-rules/:id"），值为所需权限；
+// 默认拒绝，未列入的目标一律返回 ErrForbidden，无需令牌的目标须显式声明为 PermPublic
+type Policy map[string]Permission
+
+// Check 按策略校验调用方，claims 为 nil 表示未携带令牌。
+// 未认证返回 ErrUnauthenticated，权限不足或目标未列入策略返回 ErrForbidden，拒绝都会写入审计日志
+func (p Policy) Check(ctx context.Context, target string, claims *Claims) error {
+	required, ok := p[target]
+	if !ok {
+		auditDenial(ctx, target, "unlisted", claims)
+		return ErrForbidden
+	}
+	if required == PermPublic {
+		return nil
+	}
+	if claims == nil {
+		auditDenial(ctx, target, required, nil)
+		return ErrUnauthenticated
+	}
+	if !claims.Can(required) {
+		auditDenial(ctx, target, required, claims)
+		return ErrForbidden
+	}
+	return nil
+}
+
+// auditDenial 以固定的 audit 字段输出拒绝记录，便于日志平台检索与告警
+func auditDenial(ctx context.Context, target string, required Permission, claims *Claims) {
+	attrs := []any{"audit", "access_denied", "target", target, "permission", string(required)}
+	if claims != nil && claims.IsService() {
+		attrs = append(attrs, "service", claims.Subject)
+	} else if claims != nil {
+		attrs = append(attrs, "user_id", claims.UserID(), "session_id", claims.SessionID, "roles", claims.Roles)
+	}
+	slog.WarnContext(ctx, "拒绝访问", attrs...)
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	sessionKeyPrefix      = "auth:session:"
+	userSessionsKeyPrefix = "auth:user_sessions:"
+)
+
+// 当前刷新令牌匹配时替换并延长会话及用户会话索引的有效期：1 成功，0 不存在，-1 已吊销，-2 令牌不匹配
+var rotateScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then return 0 end
+if redis.call('HGET', KEYS[1], 'revoked') == '1' then return -1 end
+if redis.call('HGET', KEYS[1], 'refresh_id') ~= ARGV[1] then return -2 end
+redis.call('HSET', KEYS[1], 'refresh_id', ARGV[2], 'expires_at', ARGV[3])
+redis.call('PEXPIREAT', KEYS[1], ARGV[3])
+redis.call('PEXPIREAT', ARGV[4] .. redis.call('HGET', KEYS[1], 'user_id'), ARGV[3])
+return 1
+`)
+
+// 只更新仍存在的会话；ARGV[1] 为最后活跃时间，其后为成对的字段与值
+var touchScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then return 0 end
+redis.call('HSET', KEYS[1], 'last_seen_at', ARGV[1])
+for i = 2, #ARGV, 2 do
+  redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+return 1
+`)
+
+// 只吊销仍存在的会话，避免写入没有过期时间的键
+var revokeScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then return 0 end
+redis.call('HSET', KEYS[1], 'revoked', '1')
+return 1
+`)
+
+// RedisStore 基于 Redis 的会话存储，多个服务共享后吊销立即全局生效。
+// 会话为 Hash，过期时间与刷新令牌一致；用户的会话ID集合用于列出与批量吊销。
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) CreateSession(ctx context.Context, session *Session) error {
+	key := sessionKeyPrefix + session.ID
+	userKey := userSessionsKeyPrefix + strconv.FormatInt(session.UserID, 10)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"user_id", session.UserID,
+			"refresh_id", session.RefreshID,
+			"device", session.Device,
+			"ip", session.IP,
+			"user_agent", session.UserAgent,
+			"created_at", session.CreatedAt.UnixMilli(),
+			"last_seen_at", session.LastSeenAt.UnixMilli(),
+			"expires_at", session.ExpiresAt.UnixMilli(),
+			"revoked", boolFlag(session.Revoked))
+		pipe.PExpireAt(ctx, key, session.ExpiresAt)
+		pipe.SAdd(ctx, userKey, session.ID)
+		pipe.PExpireAt(ctx, userKey, session.ExpiresAt)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) FindSession(ctx context.Context, sessionID string) (*Session, error) {
+	values, err := s.client.HGetAll(ctx, sessionKeyPrefix+sessionID).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return decodeSession(sessionID, values)
+}
+
+func (s *RedisStore) RotateRefresh(ctx context.Context, sessionID, oldRefreshID, newRefreshID string, expiresAt time.Time) error {
+	result, err := rotateScript.Run(ctx, s.client, []string{sessionKeyPrefix + sessionID},
+		oldRefreshID, newRefreshID, expiresAt.UnixMilli(), userSessionsKeyPrefix).Int()
+	if err != nil {
+		return err
+	}
+	switch result {
+	case 0:
+		return ErrSessionNotFound
+	case -1:
+		return ErrTokenRevoked
+	case -2:
+		return ErrTokenReused
+	}
+	return nil
+}
+
+func (s *RedisStore) TouchSession(ctx context.Context, sessionID string, client ClientInfo, seenAt time.Time) error {
+	args := []interface{}{seenAt.UnixMilli()}
+	for field, value := range map[string]string{"device": client.Device, "ip": client.IP, "user_agent": client.UserAgent} {
+		if value != "" {
+			args = append(args, field, value)
+		}
+	}
+	result, err := touchScript.Run(ctx, s.client, []string{sessionKeyPrefix + sessionID}, args...).Int()
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *RedisStore) RevokeSession(ctx context.Context, sessionID string) error {
+	result, err := revokeScript.Run(ctx, s.client, []string{sessionKeyPrefix + sessionID}).Int()
+	if err != nil {
+		return err
+	}
+	if result == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// FindUserSessions 顺带从索引中移除已过期的会话
+func (s *RedisStore) FindUserSessions(ctx context.Context, userID int64) ([]Session, error) {
+	userKey := userSessionsKeyPrefix + strconv.FormatInt(userID, 10)
+	ids, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	var sessions []Session
+	for _, id := range ids {
+		session, err := s.FindSession(ctx, id)
+		if errors.Is(err, ErrSessionNotFound) {
+			s.client.SRem(ctx, userKey, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.Before(sessions[j].CreatedAt) })
+	return sessions, nil
+}
+
+func decodeSession(sessionID string, values map[string]string) (*Session, error) {
+	userID, err := strconv.ParseInt(values["user_id"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, _ := strconv.ParseInt(values["created_at"], 10, 64)
+	lastSeenAt, _ := strconv.ParseInt(values["last_seen_at"], 10, 64)
+	if lastSeenAt == 0 {
+		// 记录客户端信息之前创建的会话
+		lastSeenAt = createdAt
+	}
+	expiresAt, _ := strconv.ParseInt(values["expires_at"], 10, 64)
+	return &Session{
+		ID:         sessionID,
+		UserID:     userID,
+		RefreshID:  values["refresh_id"],
+		Device:     values["device"],
+		IP:         values["ip"],
+		UserAgent:  values["user_agent"],
+		CreatedAt:  time.UnixMilli(createdAt),
+		LastSeenAt: time.UnixMilli(lastSeenAt),
+		ExpiresAt:  time.UnixMilli(expiresAt),
+		Revoked:    values["revoked"] == "1",
+	}, nil
+}
+
+func boolFlag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/cache"
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+// ErrSessionNotFound 会话不存在或已过期
+var ErrSessionNotFound = errors.New("会话不存在")
+
+// Session 一次登录，RefreshID 为当前唯一有效的刷新令牌ID。
+// 吊销后保留到 ExpiresAt，期间携带该会话ID的令牌均被拒绝。
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"user_id"`
+	RefreshID  string    `json:"refresh_id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Revoked    bool      `json:"revoked"`
+}
+
+// ClientInfo 登录或刷新令牌的客户端，记录在会话上供用户识别登录设备
+type ClientInfo struct {
+	Device    string // 客户端自报的设备名，如 "iPhone 15"
+	IP        string
+	UserAgent string
+}
+
+// Store 会话存储
+type Store interface {
+	CreateSession(ctx context.Context, session *Session) error
+	// FindSession 查找会话，不存在或已过期时返回 ErrSessionNotFound
+	FindSession(ctx context.Context, sessionID string) (*Session, error)
+	// RotateRefresh 原子地将当前刷新令牌由 oldRefreshID 替换为 newRefreshID 并延长有效期；
+	// 当前令牌不是 oldRefreshID 时返回 ErrTokenReused，会话已吊销时返回 ErrTokenRevoked
+	RotateRefresh(ctx context.Context, sessionID, oldRefreshID, newRefreshID string, expiresAt time.Time) error
+	// TouchSession 更新最后活跃时间，并以 client 中非空的字段覆盖会话的客户端信息；会话不存在时返回 ErrSessionNotFound
+	TouchSession(ctx context.Context, sessionID string, client ClientInfo, seenAt time.Time) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	// FindUserSessions 返回用户未过期的会话（含已吊销），按创建时间排列
+	FindUserSessions(ctx context.Context, userID int64) ([]Session, error)
+}
+
+// MemoryStore 进程内会话存储，吊销只对本进程可见，仅用于开发与测试
+type MemoryStore struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, sessions: map[string]*Session{}}
+}
+
+func (s *MemoryStore) CreateSession(_ context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *session
+	s.sessions[session.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) FindSession(_ context.Context, sessionID string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, err := s.find(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	found := *session
+	return &found, nil
+}
+
+func (s *MemoryStore) RotateRefresh(_ context.Context, sessionID, oldRefreshID, newRefreshID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, err := s.find(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.Revoked {
+		return ErrTokenRevoked
+	}
+	if session.RefreshID != oldRefreshID {
+		return ErrTokenReused
+	}
+	session.RefreshID = newRefreshID
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+func (s *MemoryStore) TouchSession(_ context.Context, sessionID string, client ClientInfo, seenAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, err := s.find(sessionID)
+	if err != nil {
+		return err
+	}
+	session.LastSeenAt = seenAt
+	if client.Device != "" {
+		session.Device = client.Device
+	}
+	if client.IP != "" {
+		session.IP = client.IP
+	}
+	if client.UserAgent != "" {
+		session.UserAgent = client.UserAgent
+	}
+	return nil
+}
+
+func (s *MemoryStore) RevokeSession(_ context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, err := s.find(sessionID)
+	if err != nil {
+		return err
+	}
+	session.Revoked = true
+	return nil
+}
+
+func (s *MemoryStore) FindUserSessions(_ context.Context, userID int64) ([]Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sessions []Session
+	for id, session := range s.sessions {
+		if _, err := s.find(id); err == nil && session.UserID == userID {
+			sessions = append(sessions, *session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.Before(sessions[j].CreatedAt) })
+	return sessions, nil
+}
+
+// find 查找未过期的会话，过期的会话顺带清除；调用方须持有锁
+func (s *MemoryStore) find(sessionID string) (*Session, error) {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	if !s.now().Before(session.ExpiresAt) {
+		delete(s.sessions, sessionID)
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// NewStore 按 jwt.store 创建会话存储：redis（默认）或 memory
+func NewStore(cfg *config.Config) (Store, error) {
+	switch strings.ToLower(cfg.JWT.Store) {
+	case "memory":
+		slog.Warn("会话存储为 memory，吊销只对本进程生效")
+		return NewMemoryStore(), nil
+	case "", "redis":
+		client, err := cache.NewRedisClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisStore(client), nil
+	default:
+		return nil, fmt.Errorf("不支持的会话存储: %s", cfg.JWT.Store)
+	}
+}
@@ -0,0 +1,32 @@
+// Package cache Redis 客户端，供会话、计数等共享状态使用。
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"net"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"github.com/go-redis/redis/v8"
+)
+
+// NewRedisClient 按配置创建 Redis 客户端并 PING 一次，连接失败时返回客户端与错误，由调用方决定是否降级
+func NewRedisClient(cfg *config.Config) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         net.JoinHostPort(cfg.Redis.Host, cfg.Redis.Port),
+		Password:     cfg.Redis.Password,
+		DB:           cfg.Redis.Database,
+		PoolSize:     cfg.Redis.PoolSize,
+		MinIdleConns: cfg.Redis.MinIdleConns,
+		DialTimeout:  cfg.Redis.DialTimeout,
+		ReadTimeout:  cfg.Redis.ReadTimeout,
+		WriteTimeout: cfg.Redis.WriteTimeout,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Redis.DialTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		slog.Error("redis connect err", slog.String("addr", client.Options().Addr), slog.String("error", err.Error()))
+		return client, err
+	}
+	return client, nil
+}
@@ -36,6 +36,18 @@ type Config struct {
 	Jaeger   JaegerConfig   `json:"jaeger" yaml:"jaeger" mapstructure:"jaeger"`
 	Metrics  MetricsConfig  `json:"metrics" yaml:"metrics" mapstructure:"metrics"`
 	Security SecurityConfig `json:"security" yaml:"security" mapstructure:"security"`
+
+	AbandonedCart   AbandonedCartConfig   `json:"abandoned_cart" yaml:"abandoned_cart" mapstructure:"abandoned_cart"`
+	PaymentProvider PaymentProviderConfig `json:"payment_provider" yaml:"payment_provider" mapstructure:"payment_provider"`
+	SecretKeys      SecretKeyConfig       `json:"secret_keys" yaml:"secret_keys" mapstructure:"secret_keys"`
+	Reconciliation  ReconciliationConfig  `json:"reconciliation" yaml:"reconciliation" mapstructure:"reconciliation"`
+	ExchangeRate    ExchangeRateConfig    `json:"exchange_rate" yaml:"exchange_rate" mapstructure:"exchange_rate"`
+	PaymentRouting  PaymentRoutingConfig  `json:"payment_routing" yaml:"payment_routing" mapstructure:"payment_routing"`
+	Notify          NotifyConfig          `json:"notify" yaml:"notify" mapstructure:"notify"`
+	Account         AccountConfig         `json:"account" yaml:"account" mapstructure:"account"`
+	OTP             OTPConfig             `json:"otp" yaml:"otp" mapstructure:"otp"`
+	LoginGuard      LoginGuardConfig      `json:"login_guard" yaml:"login_guard" mapstructure:"login_guard"`
+	Privacy         PrivacyConfig         `json:"privacy" yaml:"privacy" mapstructure:"privacy"`
 }
 
 // ServerConfig 服务器配置
@@ -75,12 +87,14 @@ type RedisConfig struct {
 	WriteTimeout time.Duration `json:"write_timeout" yaml:"write_timeout" mapstructure:"write_timeout"`
 }
 
-// JWTConfig JWT配置
+// JWTConfig JWT配置。Store 为登录会话（刷新令牌与吊销状态）的存储，
+// redis 供多个服务共享，吊销后各网关立即生效；memory 仅用于单进程开发与测试。
 type JWTConfig struct {
 	Secret            string        `json:"secret" yaml:"secret" mapstructure:"secret"`
 	AccessExpiration  time.Duration `json:"access_expiration" yaml:"access_expiration" mapstructure:"access_expiration"`
 	RefreshExpiration time.Duration `json:"refresh_expiration" yaml:"refresh_expiration" mapstructure:"refresh_expiration"`
 	Issuer            string        `json:"issuer" yaml:"issuer" mapstructure:"issuer"`
+	Store             string        `json:"store" yaml:"store" mapstructure:"store"`
 }
 
 // LogConfig 日志配置
@@ -141,6 +155,136 @@ type SecurityConfig struct {
 	AllowCredentials bool     `json:"allow_credentials" yaml:"allow_credentials" mapstructure:"allow_credentials"`
 }
 
+// AbandonedCartConfig 购物车弃购检测配置
+type AbandonedCartConfig struct {
+	Enabled      bool          `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	IdleAfter    time.Duration `json:"idle_after" yaml:"idle_after" mapstructure:"idle_after"`          // 购物车超过该时长未变动视为弃购
+	ScanInterval time.Duration `json:"scan_interval" yaml:"scan_interval" mapstructure:"scan_interval"` // 扫描周期
+	BatchSize    int           `json:"batch_size" yaml:"batch_size" mapstructure:"batch_size"`          // 单次扫描处理的最大用户数
+}
+
+// PaymentProviderConfig 支付渠道配置，渠道密钥不放在配置中，而是保存在支付通道的加密存储里
+type PaymentProviderConfig struct {
+	PayPal PayPalConfig       `json:"paypal" yaml:"paypal" mapstructure:"paypal"`
+	Mock   MockProviderConfig `json:"mock" yaml:"mock" mapstructure:"mock"`
+}
+
+// PayPalConfig PayPal 渠道配置
+type PayPalConfig struct {
+	ClientID  string `json:"client_id" yaml:"client_id" mapstructure:"client_id"`
+	WebhookID string `json:"webhook_id" yaml:"webhook_id" mapstructure:"webhook_id"` // 校验回调签名所用的 webhook ID
+	ReturnURL string `json:"return_url" yaml:"return_url" mapstructure:"return_url"`
+	CancelURL string `json:"cancel_url" yaml:"cancel_url" mapstructure:"cancel_url"`
+}
+
+// MockProviderConfig 本地模拟渠道配置，仅用于测试与开发环境
+type MockProviderConfig struct {
+	Enabled       bool   `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	WebhookSecret string `json:"webhook_secret" yaml:"webhook_secret" mapstructure:"webhook_secret"`
+	SettlementDir string `json:"settlement_dir" yaml:"settlement_dir" mapstructure:"settlement_dir"` // 对账单目录，为空时不生成对账单
+}
+
+// ReconciliationConfig 支付对账任务配置，每天 RunAt（HH:MM，本地时间）对账前一天的数据
+type ReconciliationConfig struct {
+	Enabled bool   `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	RunAt   string `json:"run_at" yaml:"run_at" mapstructure:"run_at"`
+}
+
+// PaymentRoutingConfig 支付通道路由的健康判定：连续失败 FailureThreshold 次的通道在 Cooldown 内视为不健康，
+// 路由时排在健康通道之后。路由规则本身保存在数据库中，可在运行时修改。
+type PaymentRoutingConfig struct {
+	FailureThreshold int           `json:"failure_threshold" yaml:"failure_threshold" mapstructure:"failure_threshold"`
+	Cooldown         time.Duration `json:"cooldown" yaml:"cooldown" mapstructure:"cooldown"`
+}
+
+// NotifyConfig 通知发送配置。Email 为 smtp 时经 SMTP 发送邮件，为 outbox 时写入 OutboxDir 下的文件，仅用于开发与测试；
+// SMS 目前只支持 outbox。
+type NotifyConfig struct {
+	Email     string     `json:"email" yaml:"email" mapstructure:"email"`
+	SMS       string     `json:"sms" yaml:"sms" mapstructure:"sms"`
+	SMTP      SMTPConfig `json:"smtp" yaml:"smtp" mapstructure:"smtp"`
+	OutboxDir string     `json:"outbox_dir" yaml:"outbox_dir" mapstructure:"outbox_dir"`
+}
+
+// SMTPConfig SMTP 服务器配置，Username 为空时不认证
+type SMTPConfig struct {
+	Host     string `json:"host" yaml:"host" mapstructure:"host"`
+	Port     string `json:"port" yaml:"port" mapstructure:"port"`
+	Username string `json:"username" yaml:"username" mapstructure:"username"`
+	Password string `json:"password" yaml:"password" mapstructure:"password"`
+	From     string `json:"from" yaml:"from" mapstructure:"from"`
+}
+
+// AccountConfig 邮箱验证与密码重置。URL 模板中的 %s 替换为一次性令牌；
+// 同一邮箱两次发送至少间隔 EmailResendInterval，每小时最多 EmailsPerHour 封。
+type AccountConfig struct {
+	VerifyEmailURL      string        `json:"verify_email_url" yaml:"verify_email_url" mapstructure:"verify_email_url"`
+	ResetPasswordURL    string        `json:"reset_password_url" yaml:"reset_password_url" mapstructure:"reset_password_url"`
+	VerifyTokenTTL      time.Duration `json:"verify_token_ttl" yaml:"verify_token_ttl" mapstructure:"verify_token_ttl"`
+	ResetTokenTTL       time.Duration `json:"reset_token_ttl" yaml:"reset_token_ttl" mapstructure:"reset_token_ttl"`
+	EmailsPerHour       int           `json:"emails_per_hour" yaml:"emails_per_hour" mapstructure:"emails_per_hour"`
+	EmailResendInterval time.Duration `json:"email_resend_interval" yaml:"email_resend_interval" mapstructure:"email_resend_interval"`
+	// BootstrapAdminEmail 系统中还没有管理员时，用户服务启动时将该邮箱对应的账号授予 admin 角色，已有管理员后不再生效
+	BootstrapAdminEmail string `json:"bootstrap_admin_email" yaml:"bootstrap_admin_email" mapstructure:"bootstrap_admin_email"`
+}
+
+// OTPConfig 短信验证码登录。每个验证码最多尝试 MaxAttempts 次，用尽后该手机号锁定 Lockout；
+// 同一手机号两次发送至少间隔 ResendInterval，每小时最多 SendsPerHour 条。
+type OTPConfig struct {
+	Length         int           `json:"length" yaml:"length" mapstructure:"length"`
+	TTL            time.Duration `json:"ttl" yaml:"ttl" mapstructure:"ttl"`
+	MaxAttempts    int           `json:"max_attempts" yaml:"max_attempts" mapstructure:"max_attempts"`
+	Lockout        time.Duration `json:"lockout" yaml:"lockout" mapstructure:"lockout"`
+	ResendInterval time.Duration `json:"resend_interval" yaml:"resend_interval" mapstructure:"resend_interval"`
+	SendsPerHour   int           `json:"sends_per_hour" yaml:"sends_per_hour" mapstructure:"sends_per_hour"`
+}
+
+// LoginGuardConfig 密码登录防暴力破解，按账号与来源 IP 分别统计失败次数，Window 内没有新的失败时清零。
+// 账号失败超过 DelayAfter 次后，下次尝试须等待 BaseDelay 起逐次翻倍（不超过 MaxDelay）的时间；
+// 账号达到 AccountLockThreshold 次、IP 达到 IPLockThreshold 次时临时锁定 Lockout。
+// 账号在 PersistentWindow 内被临时锁定 PermanentLockAfter 次后状态改为已锁定，需管理员解锁。
+// Store 为 redis 时多个实例共享计数，连接失败时退回进程内存储。
+type LoginGuardConfig struct {
+	Store                string        `json:"store" yaml:"store" mapstructure:"store"`
+	Window               time.Duration `json:"window" yaml:"window" mapstructure:"window"`
+	DelayAfter           int           `json:"delay_after" yaml:"delay_after" mapstructure:"delay_after"`
+	BaseDelay            time.Duration `json:"base_delay" yaml:"base_delay" mapstructure:"base_delay"`
+	MaxDelay             time.Duration `json:"max_delay" yaml:"max_delay" mapstructure:"max_delay"`
+	AccountLockThreshold int           `json:"account_lock_threshold" yaml:"account_lock_threshold" mapstructure:"account_lock_threshold"`
+	IPLockThreshold      int           `json:"ip_lock_threshold" yaml:"ip_lock_threshold" mapstructure:"ip_lock_threshold"`
+	Lockout              time.Duration `json:"lockout" yaml:"lockout" mapstructure:"lockout"`
+	PermanentLockAfter   int           `json:"permanent_lock_after" yaml:"permanent_lock_after" mapstructure:"permanent_lock_after"`
+	PersistentWindow     time.Duration `json:"persistent_window" yaml:"persistent_window" mapstructure:"persistent_window"`
+}
+
+// PrivacyConfig 用户数据导出与删除请求的处理。每隔 Interval 处理待处理与失败的请求，
+// 自动重试至多 MaxAttempts 次，之后需管理员手动重试；处理中超过 StaleAfter 未完成的请求视为实例中断，重新处理。
+// 导出文件保留 ExportRetention 后清除。
+type PrivacyConfig struct {
+	Interval        time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+	MaxAttempts     int           `json:"max_attempts" yaml:"max_attempts" mapstructure:"max_attempts"`
+	StaleAfter      time.Duration `json:"stale_after" yaml:"stale_after" mapstructure:"stale_after"`
+	ExportRetention time.Duration `json:"export_retention" yaml:"export_retention" mapstructure:"export_retention"`
+}
+
+// ExchangeRateConfig 汇率来源配置。Provider 为 static 时从 File 读取固定汇率，
+// 为 http 时从 URL 拉取并缓存 CacheTTL，URL 可指向本地桩服务。File 与 URL 均为空时只支持同币种。
+type ExchangeRateConfig struct {
+	Provider string        `json:"provider" yaml:"provider" mapstructure:"provider"`
+	File     string        `json:"file" yaml:"file" mapstructure:"file"`
+	URL      string        `json:"url" yaml:"url" mapstructure:"url"`
+	CacheTTL time.Duration `json:"cache_ttl" yaml:"cache_ttl" mapstructure:"cache_ttl"`
+	Timeout  time.Duration `json:"timeout" yaml:"timeout" mapstructure:"timeout"`
+}
+
+// SecretKeyConfig 字段级加密密钥配置。Keys 为密钥ID到 base64 编码的 32 字节密钥，
+// KeyFile 为本地密钥文件（每行 <key_id>:<base64 密钥>），PrimaryKeyID 为加密使用的密钥。
+type SecretKeyConfig struct {
+	PrimaryKeyID string            `json:"primary_key_id" yaml:"primary_key_id" mapstructure:"primary_key_id"`
+	Keys         map[string]string `json:"keys" yaml:"keys" mapstructure:"keys"`
+	KeyFile      string            `json:"key_file" yaml:"key_file" mapstructure:"key_file"`
+}
+
 // Load 从 YAML 配置文件加载配置，并允许环境变量覆盖。paths 可以显式指定配置文件，若为空则按顺序尝试默认路径。
 func Load(paths ...string) (*Config, error) {
 	v := viper.New()
@@ -249,6 +393,7 @@ func applyDefaults(v *viper.Viper) {
 	v.SetDefault("jwt.access_expiration", 15*time.Minute)
 	v.SetDefault("jwt.refresh_expiration", 24*time.Hour)
 	v.SetDefault("jwt.issuer", "ecommerce-service")
+	v.SetDefault("jwt.store", "redis")
 
 	v.SetDefault("log.level", "info")
 	v.SetDefault("log.format", "json")
@@ -288,6 +433,73 @@ func applyDefaults(v *viper.Viper) {
 	v.SetDefault("security.allowed_headers", []string{"*"})
 	v.SetDefault("security.expose_headers", []string{})
 	v.SetDefault("security.allow_credentials", true)
+
+	v.SetDefault("abandoned_cart.enabled", true)
+	v.SetDefault("abandoned_cart.idle_after", 24*time.Hour)
+	v.SetDefault("abandoned_cart.scan_interval", 10*time.Minute)
+	v.SetDefault("abandoned_cart.batch_size", 500)
+
+	v.SetDefault("payment_provider.paypal.client_id", "")
+	v.SetDefault("payment_provider.paypal.webhook_id", "")
+	v.SetDefault("payment_provider.paypal.return_url", "")
+	v.SetDefault("payment_provider.paypal.cancel_url", "")
+	v.SetDefault("payment_provider.mock.enabled", false)
+	v.SetDefault("payment_provider.mock.webhook_secret", "")
+	v.SetDefault("payment_provider.mock.settlement_dir", "")
+
+	v.SetDefault("secret_keys.primary_key_id", "")
+	v.SetDefault("secret_keys.key_file", "")
+
+	v.SetDefault("exchange_rate.provider", "static")
+	v.SetDefault("exchange_rate.file", "")
+	v.SetDefault("exchange_rate.url", "")
+	v.SetDefault("exchange_rate.cache_ttl", time.Hour)
+	v.SetDefault("exchange_rate.timeout", 5*time.Second)
+
+	v.SetDefault("reconciliation.enabled", false)
+	v.SetDefault("reconciliation.run_at", "02:00")
+
+	v.SetDefault("payment_routing.failure_threshold", 3)
+	v.SetDefault("payment_routing.cooldown", 30*time.Second)
+
+	v.SetDefault("notify.email", "outbox")
+	v.SetDefault("notify.sms", "outbox")
+	v.SetDefault("notify.outbox_dir", "outbox")
+	v.SetDefault("notify.smtp.host", "")
+	v.SetDefault("notify.smtp.port", "587")
+	v.SetDefault("notify.smtp.username", "")
+	v.SetDefault("notify.smtp.password", "")
+	v.SetDefault("notify.smtp.from", "")
+
+	v.SetDefault("account.verify_email_url", "http://localhost:3000/verify-email?token=%s")
+	v.SetDefault("account.reset_password_url", "http://localhost:3000/reset-password?token=%s")
+	v.SetDefault("account.verify_token_ttl", 24*time.Hour)
+	v.SetDefault("account.reset_token_ttl", 30*time.Minute)
+	v.SetDefault("account.emails_per_hour", 5)
+	v.SetDefault("account.email_resend_interval", time.Minute)
+
+	v.SetDefault("otp.length", 6)
+	v.SetDefault("otp.ttl", 5*time.Minute)
+	v.SetDefault("otp.max_attempts", 5)
+	v.SetDefault("otp.lockout", 15*time.Minute)
+	v.SetDefault("otp.resend_interval", time.Minute)
+	v.SetDefault("otp.sends_per_hour", 5)
+
+	v.SetDefault("login_guard.store", "redis")
+	v.SetDefault("login_guard.window", 15*time.Minute)
+	v.SetDefault("login_guard.delay_after", 3)
+	v.SetDefault("login_guard.base_delay", time.Second)
+	v.SetDefault("login_guard.max_delay", 30*time.Second)
+	v.SetDefault("login_guard.account_lock_threshold", 10)
+	v.SetDefault("login_guard.ip_lock_threshold", 50)
+	v.SetDefault("login_guard.lockout", 15*time.Minute)
+	v.SetDefault("login_guard.permanent_lock_after", 3)
+	v.SetDefault("login_guard.persistent_window", 24*time.Hour)
+
+	v.SetDefault("privacy.interval", time.Minute)
+	v.SetDefault("privacy.max_attempts", 5)
+	v.SetDefault("privacy.stale_after", 10*time.Minute)
+	v.SetDefault("privacy.export_retention", 7*24*time.Hour)
 }
 
 func attachConfigFile(v *viper.Viper, explicitPaths ...string) (bool, []string, error) {
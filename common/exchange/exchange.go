@@ -0,0 +1,117 @@
+// Package exchange 汇率：Provider 接口、固定汇率文件实现与带缓存的 HTTP 实现。
+//
+// 汇率以十进制字符串表示并用 big.Rat 精确计算，换算结果按目标币种的小数位四舍五入（远离零）。
+// 展示换算（商品、购物车）与结算（订单）都通过 Convert 完成，结算时所用汇率记录在订单上。
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+var (
+	ErrRateNotFound = errors.New("汇率不存在")
+	ErrInvalidRate  = errors.New("汇率必须为正的十进制数")
+)
+
+// Rate 汇率：1 单位 From 兑换 Value 单位 To
+type Rate struct {
+	From   string
+	To     string
+	Value  *big.Rat
+	Source string    // 汇率来源，如文件路径或 URL
+	AsOf   time.Time // 汇率生效时间
+}
+
+// Provider 汇率来源
+type Provider interface {
+	// Rate 返回 from 兑 to 的汇率，同币种返回 1
+	Rate(ctx context.Context, from, to string) (Rate, error)
+}
+
+// ParseRate 解析十进制汇率字符串，如 "0.9213"
+func ParseRate(value string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, value)
+	}
+	return rate, nil
+}
+
+func one() *big.Rat {
+	return big.NewRat(1, 1)
+}
+
+// String 返回汇率的十进制字符串，最多保留 10 位小数
+func (r Rate) String() string {
+	if r.Value == nil {
+		return ""
+	}
+	value := r.Value.FloatString(10)
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
+
+// Convert 按汇率换算金额，m 的币种须与 rate.From 一致
+func Convert(m money.Money, rate Rate) (money.Money, error) {
+	if m.Currency != rate.From {
+		return money.Money{}, money.ErrCurrencyMismatch
+	}
+	if m.Currency == rate.To {
+		return m, nil
+	}
+	// 最小单位换算：amount * rate * 10^(目标小数位 - 原小数位)
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), rate.Value)
+	shift := int64(money.Exponent(rate.To) - money.Exponent(m.Currency))
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(abs(shift)), nil)
+	if shift >= 0 {
+		value.Mul(value, new(big.Rat).SetInt(scale))
+	} else {
+		value.Quo(value, new(big.Rat).SetInt(scale))
+	}
+	amount, err := round(value)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return money.New(amount, rate.To), nil
+}
+
+// ConvertTo 从 provider 获取汇率并换算，同币种直接返回
+func ConvertTo(ctx context.Context, provider Provider, m money.Money, to string) (money.Money, Rate, error) {
+	rate, err := provider.Rate(ctx, m.Currency, to)
+	if err != nil {
+		return money.Money{}, Rate{}, err
+	}
+	converted, err := Convert(m, rate)
+	return converted, rate, err
+}
+
+// round 四舍五入（远离零）为整数
+func round(value *big.Rat) (int64, error) {
+	num, den := value.Num(), value.Denom()
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Abs(new(big.Int).Mul(remainder, big.NewInt(2))).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	if !quotient.IsInt64() {
+		return 0, money.ErrInvalidAmount
+	}
+	return quotient.Int64(), nil
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+func testTable(t *testing.T) *Table {
+	t.Helper()
+	table, err := ReadTable(strings.NewReader(`{"base":"USD","rates":{"EUR":"0.8","JPY":"150","KWD":"0.3075"}}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestConvert(t *testing.T) {
+	table := testTable(t)
+	ctx := context.Background()
+	cases := []struct {
+		from money.Money
+		to   string
+		want money.Money
+	}{
+		{money.New(1999, "USD"), "EUR", money.New(1599, "EUR")},   // 15.992 -> 15.99
+		{money.New(1999, "USD"), "JPY", money.New(2999, "JPY")},   // 19.99 * 150 = 2998.5 -> 2999
+		{money.New(1000, "JPY"), "USD", money.New(667, "USD")},    // 6.666.. -> 6.67
+		{money.New(1000, "EUR"), "JPY", money.New(1875, "JPY")},   // 交叉汇率 150/0.8
+		{money.New(1000, "USD"), "KWD", money.New(3075, "KWD")},   // 3 位小数
+		{money.New(-1999, "USD"), "JPY", money.New(-2999, "JPY")}, // 负数远离零舍入
+		{money.New(500, "USD"), "USD", money.New(500, "USD")},
+	}
+	for _, c := range cases {
+		got, _, err := ConvertTo(ctx, table, c.from, c.to)
+		if err != nil || got != c.want {
+			t.Errorf("ConvertTo(%v, %s) = %v, %v, want %v", c.from, c.to, got, err, c.want)
+		}
+	}
+
+	if _, _, err := ConvertTo(ctx, table, money.New(100, "USD"), "GBP"); !errors.Is(err, ErrRateNotFound) {
+		t.Fatalf("err = %v, want ErrRateNotFound", err)
+	}
+	rate, _ := table.Rate(ctx, "EUR", "JPY")
+	if rate.String() != "187.5" {
+		t.Fatalf("rate = %s, want 187.5", rate)
+	}
+}
+
+func TestNewTableInvalid(t *testing.T) {
+	if _, err := NewTable(Document{Base: "USD", Rates: map[string]string{"EUR": "-1"}}, ""); !errors.Is(err, ErrInvalidRate) {
+		t.Fatalf("err = %v, want ErrInvalidRate", err)
+	}
+	if _, err := NewTable(Document{Base: "US", Rates: nil}, ""); !errors.Is(err, money.ErrInvalidCurrency) {
+		t.Fatalf("err = %v, want ErrInvalidCurrency", err)
+	}
+}
+
+// TestHTTPProvider 缓存期内不重复拉取，刷新失败时沿用缓存
+func TestHTTPProvider(t *testing.T) {
+	table := testTable(t)
+	var requests int
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		table.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	provider := NewHTTPProvider(server.URL, time.Hour, server.Client())
+	provider.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		rate, err := provider.Rate(ctx, "USD", "EUR")
+		if err != nil || rate.String() != "0.8" {
+			t.Fatalf("Rate = %v, %v", rate, err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("requests = %d, want 1", requests)
+	}
+
+	now = now.Add(2 * time.Hour)
+	failing = true
+	if rate, err := provider.Rate(ctx, "USD", "JPY"); err != nil || rate.String() != "150" {
+		t.Fatalf("Rate = %v, %v", rate, err)
+	}
+	if requests != 2 {
+		t.Fatalf("requests = %d, want 2", requests)
+	}
+
+	empty := NewHTTPProvider(server.URL, time.Hour, server.Client())
+	if _, err := empty.Rate(ctx, "USD", "EUR"); err == nil {
+		t.Fatal("首次拉取失败时应返回错误")
+	}
+}
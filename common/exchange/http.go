@@ -0,0 +1,98 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+// HTTPProvider 从 URL 拉取汇率文档并缓存 ttl，拉取失败时沿用已缓存的汇率表
+type HTTPProvider struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	table     *Table
+	fetchedAt time.Time
+}
+
+// NewHTTPProvider 创建 HTTP 汇率来源，client 为空时使用 http.DefaultClient
+func NewHTTPProvider(url string, ttl time.Duration, client *http.Client) *HTTPProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPProvider{url: url, ttl: ttl, client: client, now: time.Now}
+}
+
+// Rate 实现 Provider
+func (p *HTTPProvider) Rate(ctx context.Context, from, to string) (Rate, error) {
+	if strings.EqualFold(from, to) {
+		return Rate{From: strings.ToUpper(from), To: strings.ToUpper(to), Value: one(), Source: p.url}, nil
+	}
+	table, err := p.current(ctx)
+	if err != nil {
+		return Rate{}, err
+	}
+	return table.Rate(ctx, from, to)
+}
+
+// current 返回未过期的汇率表，过期时重新拉取
+func (p *HTTPProvider) current(ctx context.Context) (*Table, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.table != nil && p.now().Sub(p.fetchedAt) < p.ttl {
+		return p.table, nil
+	}
+	table, err := p.fetch(ctx)
+	if err != nil {
+		if p.table != nil {
+			slog.Warn("刷新汇率失败，继续使用缓存的汇率", "url", p.url, "fetched_at", p.fetchedAt, "error", err)
+			return p.table, nil
+		}
+		return nil, err
+	}
+	p.table, p.fetchedAt = table, p.now()
+	return table, nil
+}
+
+func (p *HTTPProvider) fetch(ctx context.Context) (*Table, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("拉取汇率失败: %w", err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("拉取汇率失败: HTTP %d", rsp.StatusCode)
+	}
+	return ReadTable(rsp.Body, p.url)
+}
+
+// New 按配置创建汇率来源：http 使用带缓存的 HTTP 来源，static 读取固定汇率文件，文件未配置时只支持同币种
+func New(cfg config.ExchangeRateConfig) (Provider, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("汇率来源为 http 时必须配置 url")
+		}
+		return NewHTTPProvider(cfg.URL, cfg.CacheTTL, &http.Client{Timeout: cfg.Timeout}), nil
+	case "", "static":
+		if cfg.File == "" {
+			return NewTable(Document{}, "identity")
+		}
+		return LoadFile(cfg.File)
+	default:
+		return nil, fmt.Errorf("不支持的汇率来源: %s", cfg.Provider)
+	}
+}
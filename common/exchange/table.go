@@ -0,0 +1,115 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// Document 汇率文件与 HTTP 接口共用的 JSON 格式，rates 为 1 单位 base 兑换的各币种数量：
+//
+//	{"base":"USD","as_of":"2024-05-01T00:00:00Z","rates":{"EUR":"0.92","JPY":"155.3"}}
+type Document struct {
+	Base  string            `json:"base"`
+	AsOf  time.Time         `json:"as_of"`
+	Rates map[string]string `json:"rates"`
+}
+
+// Table 以 base 为基准的一组汇率，任意两种币种之间按交叉汇率计算
+type Table struct {
+	base   string
+	asOf   time.Time
+	source string
+	rates  map[string]*big.Rat
+}
+
+// NewTable 由汇率文档创建汇率表，source 记录在返回的汇率上
+func NewTable(doc Document, source string) (*Table, error) {
+	base := strings.ToUpper(strings.TrimSpace(doc.Base))
+	if base == "" {
+		base = money.DefaultCurrency
+	}
+	if !money.ValidCurrency(base) {
+		return nil, fmt.Errorf("基准币种 %q: %w", doc.Base, money.ErrInvalidCurrency)
+	}
+	table := &Table{base: base, asOf: doc.AsOf, source: source, rates: map[string]*big.Rat{base: one()}}
+	for currency, value := range doc.Rates {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if !money.ValidCurrency(currency) {
+			return nil, fmt.Errorf("币种 %q: %w", currency, money.ErrInvalidCurrency)
+		}
+		rate, err := ParseRate(value)
+		if err != nil {
+			return nil, fmt.Errorf("币种 %s: %w", currency, err)
+		}
+		table.rates[currency] = rate
+	}
+	return table, nil
+}
+
+// ReadTable 从 JSON 读取汇率表
+func ReadTable(r io.Reader, source string) (*Table, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("解析汇率失败: %w", err)
+	}
+	return NewTable(doc, source)
+}
+
+// LoadFile 读取固定汇率文件
+func LoadFile(path string) (*Table, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取汇率文件失败: %w", err)
+	}
+	defer file.Close()
+	return ReadTable(file, path)
+}
+
+// Rate 实现 Provider，from 与 to 之间按 rate(to)/rate(from) 交叉换算
+func (t *Table) Rate(_ context.Context, from, to string) (Rate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return Rate{From: from, To: to, Value: one(), Source: t.source, AsOf: t.asOf}, nil
+	}
+	fromRate, ok := t.rates[from]
+	if !ok {
+		return Rate{}, fmt.Errorf("%w: %s", ErrRateNotFound, from)
+	}
+	toRate, ok := t.rates[to]
+	if !ok {
+		return Rate{}, fmt.Errorf("%w: %s", ErrRateNotFound, to)
+	}
+	return Rate{
+		From:   from,
+		To:     to,
+		Value:  new(big.Rat).Quo(toRate, fromRate),
+		Source: t.source,
+		AsOf:   t.asOf,
+	}, nil
+}
+
+// Document 导出为汇率文档
+func (t *Table) Document() Document {
+	doc := Document{Base: t.base, AsOf: t.asOf, Rates: make(map[string]string, len(t.rates))}
+	for currency, rate := range t.rates {
+		if currency != t.base {
+			doc.Rates[currency] = Rate{Value: rate}.String()
+		}
+	}
+	return doc
+}
+
+// ServeHTTP 以 JSON 返回汇率表，可作为 HTTP 汇率来源的本地桩服务
+func (t *Table) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t.Document())
+}
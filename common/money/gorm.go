@@ -0,0 +1,59 @@
+package money
+
+import (
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// MigrateFloatColumn 将历史的浮点金额列 legacyColumn 换算为最小单位，
+// 写入 embedded 字段对应的 <prefix>amount 与 <prefix>currency 列，只处理尚未迁移（币种为空）且旧值非 NULL 的行，
+// 旧值为 NULL 的行保持零值。换算完成后删除旧列，之后启动时旧列不存在直接返回，迁移只执行一次。
+func MigrateFloatColumn(db *gorm.DB, model interface{}, legacyColumn, prefix, currency string) error {
+	if !db.Migrator().HasColumn(model, legacyColumn) {
+		return nil
+	}
+	if err := migrateFloat(db.Model(model), legacyColumn, prefix, currency); err != nil {
+		return err
+	}
+	return db.Migrator().DropColumn(model, legacyColumn)
+}
+
+// MigrateFloatColumnByCurrency 与 MigrateFloatColumn 相同，但币种取自同一行的 currencyColumn，
+// 各币种按各自的小数位换算，currencyColumn 为空的行使用 DefaultCurrency。
+// currencyColumn 可能被多个旧列共用，不随旧金额列删除，由调用方在全部迁移后删除。
+func MigrateFloatColumnByCurrency(db *gorm.DB, model interface{}, legacyColumn, currencyColumn, prefix string) error {
+	if !db.Migrator().HasColumn(model, legacyColumn) || !db.Migrator().HasColumn(model, currencyColumn) {
+		return nil
+	}
+	var currencies []string
+	if err := unmigrated(db.Model(model), legacyColumn, prefix).
+		Distinct().Pluck(currencyColumn, &currencies).Error; err != nil {
+		return err
+	}
+	for _, currency := range currencies {
+		query := db.Model(model).Where(currencyColumn+" = ?", currency)
+		if currency == "" {
+			currency = DefaultCurrency
+		}
+		if err := migrateFloat(query, legacyColumn, prefix, currency); err != nil {
+			return err
+		}
+	}
+	return db.Migrator().DropColumn(model, legacyColumn)
+}
+
+// unmigrated 筛选尚未迁移且旧值非 NULL 的行，ROUND(NULL) 为 NULL，写入 NOT NULL 的金额列会失败
+func unmigrated(query *gorm.DB, legacyColumn, prefix string) *gorm.DB {
+	return query.Where("("+prefix+"currency = ? OR "+prefix+"currency IS NULL) AND "+legacyColumn+" IS NOT NULL", "")
+}
+
+func migrateFloat(query *gorm.DB, legacyColumn, prefix, currency string) error {
+	currency = normalize(currency)
+	scale := math.Pow10(int(Exponent(currency)))
+	return unmigrated(query, legacyColumn, prefix).
+		Updates(map[string]interface{}{
+			prefix + "amount":   gorm.Expr("ROUND("+legacyColumn+" * ?)", scale),
+			prefix + "currency": currency,
+		}).Error
+}
@@ -0,0 +1,305 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency 未指定币种时使用的币种，历史数据均按该币种迁移
+const DefaultCurrency = "USD"
+
+var (
+	ErrCurrencyMismatch = errors.New("币种不一致")
+	ErrInvalidCurrency  = errors.New("币种必须为3位ISO 4217代码")
+	ErrInvalidAmount    = errors.New("金额格式不合法")
+	ErrInvalidRatios    = errors.New("分配比例不合法")
+	ErrOverflow         = errors.New("金额超出可表示范围")
+)
+
+// Money 金额，以币种最小单位（如美元的分）的整数表示，避免浮点运算的舍入误差。
+// JSON 编码为 {"amount":1234,"currency":"USD"}；落库时以 gorm embedded 映射为 <前缀>amount、<前缀>currency 两列。
+type Money struct {
+	Amount   int64  `gorm:"not null;default:0" json:"amount"`
+	Currency string `gorm:"size:3" json:"currency"`
+}
+
+// 币种的小数位数，未列出的币种为 2 位
+var exponents = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Exponent 返回币种的小数位数
+func Exponent(currency string) int32 {
+	if exp, ok := exponents[normalize(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// 创建，amount 为最小单位
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: normalize(currency)}
+}
+
+// Zero 返回该币种的零金额
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Parse 按十进制字符串精确解析金额，如 "12.34"；小数位超过币种精度时返回错误而不是舍入
+func Parse(value, currency string) (Money, error) {
+	currency = normalize(currency)
+	if !ValidCurrency(currency) {
+		return Money{}, ErrInvalidCurrency
+	}
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	exp := int(Exponent(currency))
+	if whole == "" && fraction == "" || len(fraction) > exp || !digits(whole) || !digits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// FromFloat 将浮点金额四舍五入为最小单位，仅用于兼容历史的浮点字段
+func FromFloat(value float64, currency string) Money {
+	currency = normalize(currency)
+	return Money{Amount: int64(math.Round(value * math.Pow10(int(Exponent(currency))))), Currency: currency}
+}
+
+// Float64 返回以主单位表示的浮点金额，仅用于填充历史的浮点字段
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(int(Exponent(m.Currency)))
+}
+
+// Decimal 返回十进制字符串，如 "12.34"
+func (m Money) Decimal() string {
+	exp := int(Exponent(m.Currency))
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absUint(amount), 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Neg 取反
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Add 相加，币种不一致时返回 ErrCurrencyMismatch，结果超出 int64 时返回 ErrOverflow；零值 Money 的币种视为与对方一致
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.sameCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %d + %d", ErrOverflow, m.Amount, other.Amount)
+	}
+	return Money{Amount: sum, Currency: currency}, nil
+}
+
+// Sub 相减
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("%w: %d - %d", ErrOverflow, m.Amount, other.Amount)
+	}
+	return m.Add(other.Neg())
+}
+
+// Cmp 比较大小，返回 -1、0、1
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Multiply 乘以数量，结果超出 int64 时返回 ErrOverflow
+func (m Money) Multiply(quantity int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(quantity))
+	if !product.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %d × %d", ErrOverflow, m.Amount, quantity)
+	}
+	return Money{Amount: product.Int64(), Currency: m.Currency}, nil
+}
+
+// MulRatio 乘以 numerator/denominator，结果四舍五入（远离零），如按百分比折扣；结果超出 int64 时返回 ErrOverflow
+func (m Money) MulRatio(numerator, denominator int64) (Money, error) {
+	if denominator == 0 {
+		return Money{Currency: m.Currency}, nil
+	}
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(numerator))
+	den := big.NewInt(denominator)
+	quotient, remainder := new(big.Int).QuoRem(product, den, new(big.Int))
+	// |remainder| * 2 >= |denominator| 时进位
+	if new(big.Int).Abs(new(big.Int).Mul(remainder, big.NewInt(2))).Cmp(new(big.Int).Abs(den)) >= 0 {
+		if (product.Sign() < 0) != (den.Sign() < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	if !quotient.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %d × %d / %d", ErrOverflow, m.Amount, numerator, denominator)
+	}
+	return Money{Amount: quotient.Int64(), Currency: m.Currency}, nil
+}
+
+// Allocate 按比例拆分金额，各份之和严格等于原金额，余数按最大余数法分给靠前的份额
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 || total > math.MaxInt64-ratio {
+			return nil, ErrInvalidRatios
+		}
+		total += ratio
+	}
+	if total == 0 {
+		return nil, ErrInvalidRatios
+	}
+
+	parts := make([]Money, len(ratios))
+	remainders := make([]int64, len(ratios))
+	var allocated int64
+	for i, ratio := range ratios {
+		product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(ratio))
+		quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(total), new(big.Int))
+		// 每份不超过原金额、余数小于比例之和，正常不会越界，校验只为防御
+		if !quotient.IsInt64() || !remainder.IsInt64() {
+			return nil, fmt.Errorf("%w: %d × %d / %d", ErrOverflow, m.Amount, ratio, total)
+		}
+		parts[i] = Money{Amount: quotient.Int64(), Currency: m.Currency}
+		remainders[i] = remainder.Int64()
+		allocated += parts[i].Amount
+	}
+
+	step := int64(1)
+	if m.Amount < 0 {
+		step = -1
+	}
+	for left := m.Amount - allocated; left != 0; left -= step {
+		best := -1
+		for i := range remainders {
+			if ratios[i] > 0 && (best < 0 || abs(remainders[i]) > abs(remainders[best])) {
+				best = i
+			}
+		}
+		parts[best].Amount += step
+		remainders[best] = 0
+	}
+	return parts, nil
+}
+
+// Sum 累加同一币种的多个金额，列表为空时返回该币种的零金额
+func Sum(currency string, values ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, value := range values {
+		var err error
+		if total, err = total.Add(value); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// ValidCurrency 币种是否为3位大写字母代码
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// sameCurrency 校验币种一致并返回结果币种
+func (m Money) sameCurrency(other Money) (string, error) {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.Amount == 0:
+		return other.Currency, nil
+	case other.Currency == "" && other.Amount == 0:
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s 与 %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+}
+
+func normalize(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+func digits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func absUint(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
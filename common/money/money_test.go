@@ -0,0 +1,158 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestParse 十进制字符串精确解析
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		amount   int64
+		ok       bool
+	}{
+		{"12.34", "usd", 1234, true},
+		{"12.3", "USD", 1230, true},
+		{"12", "USD", 1200, true},
+		{".5", "USD", 50, true},
+		{"-0.01", "USD", -1, true},
+		{"1500", "JPY", 1500, true},
+		{"1.234", "KWD", 1234, true},
+		{"12.345", "USD", 0, false},
+		{"1.5", "JPY", 0, false},
+		{"abc", "USD", 0, false},
+		{"", "USD", 0, false},
+		{"1", "US", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.currency, func(t *testing.T) {
+			m, err := Parse(tt.value, tt.currency)
+			if (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok %v", err, tt.ok)
+			}
+			if tt.ok && m.Amount != tt.amount {
+				t.Fatalf("amount = %d, want %d", m.Amount, tt.amount)
+			}
+		})
+	}
+}
+
+// TestDecimal 格式化为十进制字符串
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{New(1234, "USD"), "12.34"},
+		{New(5, "USD"), "0.05"},
+		{New(-105, "USD"), "-1.05"},
+		{New(1500, "JPY"), "1500"},
+		{New(1, "KWD"), "0.001"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.Decimal(); got != tt.want {
+			t.Fatalf("%v Decimal() = %q, want %q", tt.money.Amount, got, tt.want)
+		}
+	}
+}
+
+// TestArithmetic 加减与币种校验
+func TestArithmetic(t *testing.T) {
+	sum, err := New(1010, "USD").Add(New(20, "USD"))
+	if err != nil || sum.Amount != 1030 {
+		t.Fatalf("Add = %v %v, want 1030", sum, err)
+	}
+	if _, err := New(1, "USD").Add(New(1, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("err = %v, want ErrCurrencyMismatch", err)
+	}
+	if zero, err := (Money{}).Add(New(5, "EUR")); err != nil || zero.Currency != "EUR" {
+		t.Fatalf("zero value Add = %v %v, want EUR", zero, err)
+	}
+	if got, err := New(333, "USD").Multiply(3); err != nil || got.Amount != 999 {
+		t.Fatalf("Multiply = %v %v, want 999", got, err)
+	}
+	// 0.1 + 0.2 在浮点下不等于 0.3，最小单位整数运算不受影响
+	a, _ := Parse("0.1", "USD")
+	b, _ := Parse("0.2", "USD")
+	c, _ := a.Add(b)
+	if c.Decimal() != "0.30" {
+		t.Fatalf("0.1 + 0.2 = %s, want 0.30", c.Decimal())
+	}
+}
+
+// TestMulRatio 按比例计算并四舍五入
+func TestMulRatio(t *testing.T) {
+	tests := []struct {
+		amount, num, den, want int64
+	}{
+		{1999, 15, 100, 300}, // 299.85 -> 300
+		{1000, 1, 3, 333},
+		{2000, 1, 3, 667},
+		{-2000, 1, 3, -667},
+		{5, 1, 2, 3},
+	}
+	for _, tt := range tests {
+		if got, err := New(tt.amount, "USD").MulRatio(tt.num, tt.den); err != nil || got.Amount != tt.want {
+			t.Fatalf("%d * %d/%d = %v %v, want %d", tt.amount, tt.num, tt.den, got, err, tt.want)
+		}
+	}
+}
+
+// TestOverflow 结果超出 int64 时返回 ErrOverflow 而不是回绕
+func TestOverflow(t *testing.T) {
+	largest := New(math.MaxInt64, "USD")
+	if _, err := largest.Add(New(1, "USD")); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("Add err = %v, want ErrOverflow", err)
+	}
+	if _, err := New(math.MinInt64, "USD").Add(New(-1, "USD")); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("Add negative err = %v, want ErrOverflow", err)
+	}
+	if _, err := New(0, "USD").Sub(New(math.MinInt64, "USD")); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("Sub err = %v, want ErrOverflow", err)
+	}
+	if _, err := New(math.MaxInt64/2+1, "USD").Multiply(2); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("Multiply err = %v, want ErrOverflow", err)
+	}
+	if _, err := largest.MulRatio(3, 2); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("MulRatio err = %v, want ErrOverflow", err)
+	}
+	// 中间乘积越界但结果在范围内时正常计算
+	if got, err := largest.MulRatio(2, 2); err != nil || got.Amount != math.MaxInt64 {
+		t.Fatalf("MulRatio = %v %v, want MaxInt64", got, err)
+	}
+	if _, err := New(10, "USD").Allocate(math.MaxInt64, 1); !errors.Is(err, ErrInvalidRatios) {
+		t.Fatalf("Allocate err = %v, want ErrInvalidRatios", err)
+	}
+}
+
+// TestAllocate 拆分后各份之和等于原金额
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		amount int64
+		ratios []int64
+		want   []int64
+	}{
+		{100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{5, []int64{3, 7}, []int64{2, 3}},
+		{-100, []int64{1, 1, 1}, []int64{-34, -33, -33}},
+		{10, []int64{0, 1}, []int64{0, 10}},
+	}
+	for _, tt := range tests {
+		parts, err := New(tt.amount, "USD").Allocate(tt.ratios...)
+		if err != nil {
+			t.Fatalf("Allocate: %v", err)
+		}
+		for i := range parts {
+			if parts[i].Amount != tt.want[i] {
+				t.Fatalf("Allocate(%d, %v) = %v, want %v", tt.amount, tt.ratios, parts, tt.want)
+			}
+		}
+	}
+	if _, err := New(10, "USD").Allocate(0, 0); !errors.Is(err, ErrInvalidRatios) {
+		t.Fatalf("err = %v, want ErrInvalidRatios", err)
+	}
+}
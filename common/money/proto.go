@@ -0,0 +1,20 @@
+package money
+
+// Message 各服务 proto 中 Money 消息生成的类型均实现该接口：
+//
+//	message Money {
+//	  int64 amount = 1; // 币种最小单位
+//	  string currency = 2;
+//	}
+type Message interface {
+	GetAmount() int64
+	GetCurrency() string
+}
+
+// FromProto 由 proto 消息转换，消息为 nil 时返回零值
+func FromProto(message Message) Money {
+	if message == nil {
+		return Money{}
+	}
+	return New(message.GetAmount(), message.GetCurrency())
+}
@@ -0,0 +1,77 @@
+// Package notify 向用户发送邮件与短信。邮件生产环境经 SMTP 发送；outbox 将通知写入本地文件，
+// 用于开发与测试，不会真正发出。
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+// ErrInvalidMessage 收件人或内容为空，或收件人、主题含换行（防止邮件头注入）
+var ErrInvalidMessage = errors.New("通知内容不合法")
+
+// Email 纯文本邮件
+type Email struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// EmailSender 发送邮件
+type EmailSender interface {
+	SendEmail(ctx context.Context, email Email) error
+}
+
+// SMS 短信，To 为 E.164 格式的手机号
+type SMS struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+// SMSSender 发送短信
+type SMSSender interface {
+	SendSMS(ctx context.Context, sms SMS) error
+}
+
+func (e Email) validate() error {
+	if strings.TrimSpace(e.To) == "" || strings.ContainsAny(e.To, "\r\n") || strings.ContainsAny(e.Subject, "\r\n") {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+// NewEmailSender 按 notify.email 创建：outbox（默认）或 smtp
+func NewEmailSender(cfg config.NotifyConfig) (EmailSender, error) {
+	switch strings.ToLower(cfg.Email) {
+	case "", "outbox":
+		slog.Warn("邮件发送方式为 outbox，邮件只写入本地文件", "dir", cfg.OutboxDir)
+		return NewOutbox(cfg.OutboxDir)
+	case "smtp":
+		return NewSMTPSender(cfg.SMTP)
+	default:
+		return nil, fmt.Errorf("不支持的邮件发送方式: %s", cfg.Email)
+	}
+}
+
+func (s SMS) validate() error {
+	if strings.TrimSpace(s.To) == "" || strings.ContainsAny(s.To, "\r\n") || s.Body == "" {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+// NewSMSSender 按 notify.sms 创建，目前只有 outbox（默认）；接入短信服务商时在此增加实现
+func NewSMSSender(cfg config.NotifyConfig) (SMSSender, error) {
+	switch strings.ToLower(cfg.SMS) {
+	case "", "outbox":
+		slog.Warn("短信发送方式为 outbox，短信只写入本地文件", "dir", cfg.OutboxDir)
+		return NewOutbox(cfg.OutboxDir)
+	default:
+		return nil, fmt.Errorf("不支持的短信发送方式: %s", cfg.SMS)
+	}
+}
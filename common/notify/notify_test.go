@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+func TestOutbox(t *testing.T) {
+	outbox, err := NewOutbox(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, to := range []string{"alice@example.com", "bob@example.com"} {
+		if err := outbox.SendEmail(ctx, Email{To: to, Subject: "验证邮箱", Body: "链接：\nhttp://localhost/verify"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	emails, err := outbox.Emails()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(emails) != 2 || emails[1].To != "bob@example.com" || !strings.Contains(emails[0].Body, "\nhttp://localhost/verify") {
+		t.Fatalf("emails = %+v", emails)
+	}
+
+	if err := outbox.SendEmail(ctx, Email{To: "alice@example.com", Subject: "hi\r\nBcc: eve@example.com"}); !errors.Is(err, ErrInvalidMessage) {
+		t.Fatalf("err = %v, want ErrInvalidMessage", err)
+	}
+
+	if err := outbox.SendSMS(ctx, SMS{To: "+8613800000000", Body: "验证码 123456"}); err != nil {
+		t.Fatal(err)
+	}
+	if messages, err := outbox.SMS(); err != nil || len(messages) != 1 || messages[0].Body != "验证码 123456" {
+		t.Fatalf("sms = %+v, %v", messages, err)
+	}
+	if err := outbox.SendSMS(ctx, SMS{To: "+8613800000000"}); !errors.Is(err, ErrInvalidMessage) {
+		t.Fatalf("err = %v, want ErrInvalidMessage", err)
+	}
+}
+
+func TestSMTPMessage(t *testing.T) {
+	sender, err := NewSMTPSender(config.SMTPConfig{Host: "smtp.example.com", Port: "587", From: "GoMall <no-reply@example.com>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	message := string(sender.message(Email{To: "alice@example.com", Subject: "验证邮箱", Body: "你好"}))
+	for _, want := range []string{
+		"From: GoMall <no-reply@example.com>\r\n",
+		"To: alice@example.com\r\n",
+		"Subject: =?UTF-8?b?6aqM6K+B6YKu566x?=\r\n",
+		"Date: Tue, 02 Jan 2024 03:04:05 +0000\r\n",
+		"\r\n\r\n5L2g5aW9\r\n",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("邮件缺少 %q:\n%s", want, message)
+		}
+	}
+
+	if _, err := NewSMTPSender(config.SMTPConfig{Host: "smtp.example.com"}); err == nil {
+		t.Error("缺少发件人时应返回错误")
+	}
+}
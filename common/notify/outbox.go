@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	emailOutboxFile = "emails.jsonl"
+	smsOutboxFile   = "sms.jsonl"
+)
+
+// Outbox 将通知逐行以 JSON 追加到目录下的文件并记录日志，不真正发送，用于开发与测试
+type Outbox struct {
+	dir string
+	now func() time.Time
+
+	mu sync.Mutex
+}
+
+// outboxEntry 写入文件的一行，Message 为 Email 或 SMS
+type outboxEntry[T any] struct {
+	Message T         `json:"message"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// NewOutbox 目录不存在时创建，dir 为空时使用当前目录下的 outbox
+func NewOutbox(dir string) (*Outbox, error) {
+	if dir == "" {
+		dir = "outbox"
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Outbox{dir: dir, now: time.Now}, nil
+}
+
+func (o *Outbox) SendEmail(ctx context.Context, email Email) error {
+	if err := email.validate(); err != nil {
+		return err
+	}
+	if err := o.append(emailOutboxFile, outboxEntry[Email]{Message: email, SentAt: o.now()}); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "邮件已写入 outbox", "to", email.To, "subject", email.Subject)
+	return nil
+}
+
+// SendSMS 短信内容常含验证码，日志中不记录正文
+func (o *Outbox) SendSMS(ctx context.Context, sms SMS) error {
+	if err := sms.validate(); err != nil {
+		return err
+	}
+	if err := o.append(smsOutboxFile, outboxEntry[SMS]{Message: sms, SentAt: o.now()}); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "短信已写入 outbox", "to", sms.To)
+	return nil
+}
+
+// Emails 按写入顺序返回 outbox 中的全部邮件
+func (o *Outbox) Emails() ([]Email, error) {
+	return readOutbox[Email](o, emailOutboxFile)
+}
+
+// SMS 按写入顺序返回 outbox 中的全部短信
+func (o *Outbox) SMS() ([]SMS, error) {
+	return readOutbox[SMS](o, smsOutboxFile)
+}
+
+func (o *Outbox) append(name string, entry any) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	file, err := os.OpenFile(filepath.Join(o.dir, name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func readOutbox[T any](o *Outbox, name string) ([]T, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	file, err := os.Open(filepath.Join(o.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var messages []T
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry outboxEntry[T]
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, err
+		}
+		messages = append(messages, entry.Message)
+	}
+	return messages, scanner.Err()
+}
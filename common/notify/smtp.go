@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+// SMTPSender 经 SMTP 发送邮件，服务器支持时使用 STARTTLS
+type SMTPSender struct {
+	addr string
+	host string
+	from string
+	auth smtp.Auth
+	now  func() time.Time
+}
+
+// NewSMTPSender 主机与发件人不能为空；配置了用户名时使用 PLAIN 认证，net/smtp 只在 TLS 连接或本机上发送密码
+func NewSMTPSender(cfg config.SMTPConfig) (*SMTPSender, error) {
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, errors.New("notify.smtp.host 与 notify.smtp.from 不能为空")
+	}
+	if _, err := mail.ParseAddress(cfg.From); err != nil {
+		return nil, fmt.Errorf("notify.smtp.from 格式不正确: %w", err)
+	}
+	sender := &SMTPSender{addr: net.JoinHostPort(cfg.Host, cfg.Port), host: cfg.Host, from: cfg.From, now: time.Now}
+	if cfg.Username != "" {
+		sender.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return sender, nil
+}
+
+// SendEmail net/smtp 不支持 context，ctx 已取消时直接返回
+func (s *SMTPSender) SendEmail(ctx context.Context, email Email) error {
+	if err := email.validate(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	from, _ := mail.ParseAddress(s.from)
+	if err := smtp.SendMail(s.addr, s.auth, from.Address, []string{email.To}, s.message(email)); err != nil {
+		return fmt.Errorf("发送邮件失败: %w", err)
+	}
+	return nil
+}
+
+// message 组装 MIME 邮件，主题按 RFC 2047 编码，正文 base64 编码
+func (s *SMTPSender) message(email Email) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", email.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", email.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	body := base64.StdEncoding.EncodeToString([]byte(email.Body))
+	for len(body) > 76 {
+		buf.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	buf.WriteString(body + "\r\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,233 @@
+package promotion
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// RuleType 促销规则类型
+type RuleType string
+
+const (
+	RulePercentOff   RuleType = "percent_off"   // 按比例折扣
+	RuleFixedAmount  RuleType = "fixed_amount"  // 满减固定金额
+	RuleBuyXGetY     RuleType = "buy_x_get_y"   // 买 X 送 Y，送最便宜的 Y 件
+	RuleFreeShipping RuleType = "free_shipping" // 免运费
+)
+
+// Rule 促销规则。CouponCode 为空的规则自动生效，否则只有输入对应优惠码时才参与计算。
+type Rule struct {
+	ID         int64
+	Name       string
+	Type       RuleType
+	CouponCode string
+
+	Percent float64     // RulePercentOff：折扣比例，0-100
+	Amount  money.Money // RuleFixedAmount：减免金额
+	BuyQty  int64       // RuleBuyXGetY：购买数量 X
+	GetQty  int64       // RuleBuyXGetY：赠送数量 Y
+
+	ProductIDs  []int64     // 限定商品，为空表示不限
+	CategoryIDs []int64     // 限定分类，为空表示不限
+	MinSpend    money.Money // 适用商品的最低消费金额，币种须与商品行一致
+	StartAt     time.Time
+	EndAt       time.Time // 零值表示不过期
+
+	UsageLimit    int64 // 全局可用次数，0 表示不限
+	PerUserLimit  int64 // 单用户可用次数，0 表示不限
+	UsedCount     int64 // 已使用次数
+	UserUsedCount int64 // 当前用户已使用次数，由调用方按用户填充
+}
+
+// Line 参与计算的商品行
+type Line struct {
+	ProductID  int64
+	CategoryID int64
+	Price      money.Money
+	Num        int64
+}
+
+// Applied 单条规则的计算结果
+type Applied struct {
+	RuleID       int64
+	Name         string
+	CouponCode   string
+	Discount     money.Money
+	FreeShipping bool
+}
+
+// Result 整单计算结果，金额币种与商品行一致
+type Result struct {
+	Subtotal     money.Money
+	Discount     money.Money
+	Total        money.Money
+	FreeShipping bool
+	Applied      []Applied
+}
+
+// Active 判断规则在 now 时刻是否处于有效期且未超过使用次数
+func (r *Rule) Active(now time.Time) bool {
+	if !r.StartAt.IsZero() && now.Before(r.StartAt) {
+		return false
+	}
+	if !r.EndAt.IsZero() && !now.Before(r.EndAt) {
+		return false
+	}
+	if r.UsageLimit > 0 && r.UsedCount >= r.UsageLimit {
+		return false
+	}
+	if r.PerUserLimit > 0 && r.UserUsedCount >= r.PerUserLimit {
+		return false
+	}
+	return true
+}
+
+// matches 判断商品行是否在规则限定的商品/分类范围内
+func (r *Rule) matches(line Line) bool {
+	if len(r.ProductIDs) > 0 && !containsID(r.ProductIDs, line.ProductID) {
+		return false
+	}
+	if len(r.CategoryIDs) > 0 && !containsID(r.CategoryIDs, line.CategoryID) {
+		return false
+	}
+	return true
+}
+
+// Evaluate 计算单条规则在给定商品行上的优惠，不适用时返回 false。
+// 商品行须为同一币种，规则金额与商品行币种不一致时视为不适用。
+func (r *Rule) Evaluate(lines []Line, now time.Time) (Applied, bool) {
+	currency := linesCurrency(lines)
+	applied := Applied{RuleID: r.ID, Name: r.Name, CouponCode: r.CouponCode, Discount: money.Zero(currency)}
+	if !r.Active(now) {
+		return applied, false
+	}
+
+	var eligible []Line
+	eligibleTotal := money.Zero(currency)
+	for _, line := range lines {
+		if line.Num > 0 && r.matches(line) {
+			lineTotal, err := line.Price.Multiply(line.Num)
+			if err != nil {
+				return applied, false
+			}
+			total, err := eligibleTotal.Add(lineTotal)
+			if err != nil {
+				return applied, false
+			}
+			eligible = append(eligible, line)
+			eligibleTotal = total
+		}
+	}
+	if len(eligible) == 0 {
+		return applied, false
+	}
+	if cmp, err := eligibleTotal.Cmp(r.MinSpend); err != nil || cmp < 0 {
+		return applied, false
+	}
+
+	switch r.Type {
+	case RulePercentOff:
+		// 折扣比例精确到万分位
+		discount, err := eligibleTotal.MulRatio(int64(math.Round(r.Percent*100)), 10000)
+		if err != nil {
+			return applied, false
+		}
+		applied.Discount = discount
+	case RuleFixedAmount:
+		cmp, err := r.Amount.Cmp(eligibleTotal)
+		if err != nil {
+			return applied, false
+		}
+		applied.Discount = r.Amount
+		if cmp > 0 {
+			applied.Discount = eligibleTotal
+		}
+	case RuleBuyXGetY:
+		applied.Discount = buyXGetYDiscount(eligible, r.BuyQty, r.GetQty, currency)
+	case RuleFreeShipping:
+		applied.FreeShipping = true
+		return applied, true
+	default:
+		return applied, false
+	}
+	return applied, applied.Discount.IsPositive()
+}
+
+// Apply 依次计算所有规则，累计优惠不超过商品总额。
+// 商品行币种不一致时返回 money.ErrCurrencyMismatch，金额超出可表示范围时返回 money.ErrOverflow。
+// 优惠已被前序规则用尽、封顶后为 0 的规则不计入 Applied（免运费除外），核销时不占用次数。
+func Apply(lines []Line, rules []Rule, now time.Time) (*Result, error) {
+	currency := linesCurrency(lines)
+	result := &Result{Subtotal: money.Zero(currency), Discount: money.Zero(currency)}
+	for _, line := range lines {
+		lineTotal, err := line.Price.Multiply(line.Num)
+		if err != nil {
+			return nil, err
+		}
+		if result.Subtotal, err = result.Subtotal.Add(lineTotal); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range rules {
+		applied, ok := rules[i].Evaluate(lines, now)
+		if !ok {
+			continue
+		}
+		remaining := result.Subtotal.Amount - result.Discount.Amount
+		if applied.Discount.Amount > remaining {
+			applied.Discount.Amount = remaining
+		}
+		if applied.Discount.IsZero() && !applied.FreeShipping {
+			continue
+		}
+		result.Discount.Amount += applied.Discount.Amount
+		result.FreeShipping = result.FreeShipping || applied.FreeShipping
+		result.Applied = append(result.Applied, applied)
+	}
+	result.Total = money.New(result.Subtotal.Amount-result.Discount.Amount, currency)
+	return result, nil
+}
+
+// 每满 X+Y 件，赠送其中最便宜的 Y 件
+func buyXGetYDiscount(lines []Line, buyQty, getQty int64, currency string) money.Money {
+	if buyQty <= 0 || getQty <= 0 {
+		return money.Zero(currency)
+	}
+	var prices []int64
+	for _, line := range lines {
+		for i := int64(0); i < line.Num; i++ {
+			prices = append(prices, line.Price.Amount)
+		}
+	}
+	free := int64(len(prices)) / (buyQty + getQty) * getQty
+	sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+
+	var discount int64
+	for i := int64(0); i < free; i++ {
+		discount += prices[i]
+	}
+	return money.New(discount, currency)
+}
+
+// 商品行的币种，取第一个带币种的商品行
+func linesCurrency(lines []Line) string {
+	for _, line := range lines {
+		if line.Price.Currency != "" {
+			return line.Price.Currency
+		}
+	}
+	return ""
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
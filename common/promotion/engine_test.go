@@ -0,0 +1,143 @@
+package promotion
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+var now = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func sampleLines() []Line {
+	return []Line{
+		{ProductID: 1, CategoryID: 10, Price: usd(10000), Num: 2},
+		{ProductID: 2, CategoryID: 20, Price: usd(3000), Num: 3},
+	}
+}
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+// TestRuleTypes 各类规则的优惠计算
+func TestRuleTypes(t *testing.T) {
+	tests := []struct {
+		name         string
+		rule         Rule
+		discount     int64
+		freeShipping bool
+		applied      bool
+	}{
+		{"percent", Rule{Type: RulePercentOff, Percent: 10}, 2900, false, true},
+		{"percent by category", Rule{Type: RulePercentOff, Percent: 10, CategoryIDs: []int64{20}}, 900, false, true},
+		{"percent rounded", Rule{Type: RulePercentOff, Percent: 12.5, ProductIDs: []int64{2}}, 1125, false, true},
+		{"fixed", Rule{Type: RuleFixedAmount, Amount: usd(5000)}, 5000, false, true},
+		{"fixed capped by eligible", Rule{Type: RuleFixedAmount, Amount: usd(50000), ProductIDs: []int64{2}}, 9000, false, true},
+		{"min spend not met", Rule{Type: RuleFixedAmount, Amount: usd(2000), MinSpend: usd(10000), ProductIDs: []int64{2}}, 0, false, false},
+		{"currency mismatch", Rule{Type: RuleFixedAmount, Amount: money.New(2000, "EUR")}, 0, false, false},
+		{"buy 2 get 1", Rule{Type: RuleBuyXGetY, BuyQty: 2, GetQty: 1}, 3000, false, true},
+		{"free shipping", Rule{Type: RuleFreeShipping, MinSpend: usd(20000)}, 0, true, true},
+		{"product not matched", Rule{Type: RulePercentOff, Percent: 10, ProductIDs: []int64{99}}, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied, ok := tt.rule.Evaluate(sampleLines(), now)
+			if ok != tt.applied {
+				t.Fatalf("applied = %v, want %v", ok, tt.applied)
+			}
+			if ok && (applied.Discount.Amount != tt.discount || applied.FreeShipping != tt.freeShipping) {
+				t.Fatalf("got discount %v free shipping %v, want %v %v",
+					applied.Discount.Amount, applied.FreeShipping, tt.discount, tt.freeShipping)
+			}
+		})
+	}
+}
+
+// TestRuleActive 有效期与使用次数限制
+func TestRuleActive(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   Rule
+		active bool
+	}{
+		{"no limits", Rule{}, true},
+		{"not started", Rule{StartAt: now.Add(time.Hour)}, false},
+		{"expired", Rule{EndAt: now}, false},
+		{"global limit reached", Rule{UsageLimit: 5, UsedCount: 5}, false},
+		{"per user limit reached", Rule{PerUserLimit: 1, UserUsedCount: 1}, false},
+		{"within limits", Rule{UsageLimit: 5, UsedCount: 4, PerUserLimit: 2, UserUsedCount: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Active(now); got != tt.active {
+				t.Fatalf("Active() = %v, want %v", got, tt.active)
+			}
+		})
+	}
+}
+
+// TestApplyCapsDiscount 多条规则叠加时优惠不超过商品总额
+func TestApplyCapsDiscount(t *testing.T) {
+	rules := []Rule{
+		{ID: 1, Type: RulePercentOff, Percent: 50},
+		{ID: 2, Type: RuleFixedAmount, Amount: usd(20000)},
+		{ID: 3, Type: RuleFreeShipping},
+	}
+
+	result, err := Apply(sampleLines(), rules, now)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if result.Subtotal != usd(29000) {
+		t.Fatalf("subtotal = %v, want 290.00 USD", result.Subtotal)
+	}
+	if result.Discount != usd(29000) || result.Total != usd(0) {
+		t.Fatalf("discount = %v total = %v, want 290.00 USD and 0.00 USD", result.Discount, result.Total)
+	}
+	if !result.FreeShipping || len(result.Applied) != 3 {
+		t.Fatalf("free shipping = %v applied = %d, want true and 3", result.FreeShipping, len(result.Applied))
+	}
+	if result.Applied[1].Discount != usd(14500) {
+		t.Fatalf("second rule discount = %v, want 145.00 USD", result.Applied[1].Discount)
+	}
+}
+
+// TestApplyOverflow 商品行金额超出可表示范围时返回错误而不是回绕
+func TestApplyOverflow(t *testing.T) {
+	lines := []Line{{ProductID: 1, Price: usd(math.MaxInt64 / 2), Num: 3}}
+	if _, err := Apply(lines, nil, now); !errors.Is(err, money.ErrOverflow) {
+		t.Fatalf("err = %v, want ErrOverflow", err)
+	}
+}
+
+// TestApplySkipsExhaustedDiscount 优惠已用尽时后续规则不计入 Applied，免运费规则仍然生效
+func TestApplySkipsExhaustedDiscount(t *testing.T) {
+	rules := []Rule{
+		{ID: 1, Type: RulePercentOff, Percent: 100},
+		{ID: 2, Type: RuleFixedAmount, Amount: usd(1000), CouponCode: "SAVE10"},
+		{ID: 3, Type: RuleFreeShipping},
+	}
+	result, err := Apply(sampleLines(), rules, now)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if result.Total != usd(0) || !result.FreeShipping {
+		t.Fatalf("total = %v free shipping = %v, want 0.00 USD and true", result.Total, result.FreeShipping)
+	}
+	if len(result.Applied) != 2 || result.Applied[0].RuleID != 1 || result.Applied[1].RuleID != 3 {
+		t.Fatalf("applied = %+v, want rules 1 and 3", result.Applied)
+	}
+}
+
+// TestApplyRejectsMixedCurrency 商品行币种不一致时返回错误而不是静默丢弃
+func TestApplyRejectsMixedCurrency(t *testing.T) {
+	lines := append(sampleLines(), Line{ProductID: 3, Price: money.New(500, "EUR"), Num: 1})
+	if _, err := Apply(lines, nil, now); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Fatalf("err = %v, want ErrCurrencyMismatch", err)
+	}
+}
@@ -0,0 +1,69 @@
+package promotion
+
+import (
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// Promotion 促销规则持久化模型
+type Promotion struct {
+	ID         int64       `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	Name       string      `gorm:"not_null" json:"name"`
+	Type       RuleType    `gorm:"not_null" json:"type"`
+	CouponCode string      `gorm:"index" json:"coupon_code"` // 为空表示自动生效
+	Enabled    bool        `gorm:"not_null" json:"enabled"`  // 零值即停用，创建时须显式设置
+	Percent    float64     `json:"percent"`
+	Amount     money.Money `gorm:"embedded;embeddedPrefix:amount_" json:"amount"`
+	BuyQty     int64       `json:"buy_qty"`
+	GetQty     int64       `json:"get_qty"`
+	MinSpend   money.Money `gorm:"embedded;embeddedPrefix:min_spend_" json:"min_spend"`
+	StartAt    *time.Time  `json:"start_at"`
+	EndAt      *time.Time  `json:"end_at"`
+
+	ProductIDs  []int64 `gorm:"serializer:json" json:"product_ids"`
+	CategoryIDs []int64 `gorm:"serializer:json" json:"category_ids"`
+
+	UsageLimit   int64     `json:"usage_limit"`
+	PerUserLimit int64     `json:"per_user_limit"`
+	UsedCount    int64     `gorm:"not_null;default:0" json:"used_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Redemption 促销使用记录，用于单用户次数限制与对账
+type Redemption struct {
+	ID          int64       `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	PromotionID int64       `gorm:"not_null;index" json:"promotion_id"`
+	UserID      int64       `gorm:"not_null;index" json:"user_id"`
+	OrderID     int64       `gorm:"not_null;index" json:"order_id"`
+	Discount    money.Money `gorm:"embedded;embeddedPrefix:discount_" json:"discount"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// Rule 转换为规则引擎使用的结构
+func (p *Promotion) Rule() Rule {
+	rule := Rule{
+		ID:           p.ID,
+		Name:         p.Name,
+		Type:         p.Type,
+		CouponCode:   p.CouponCode,
+		Percent:      p.Percent,
+		Amount:       p.Amount,
+		BuyQty:       p.BuyQty,
+		GetQty:       p.GetQty,
+		ProductIDs:   p.ProductIDs,
+		CategoryIDs:  p.CategoryIDs,
+		MinSpend:     p.MinSpend,
+		UsageLimit:   p.UsageLimit,
+		PerUserLimit: p.PerUserLimit,
+		UsedCount:    p.UsedCount,
+	}
+	if p.StartAt != nil {
+		rule.StartAt = *p.StartAt
+	}
+	if p.EndAt != nil {
+		rule.EndAt = *p.EndAt
+	}
+	return rule
+}
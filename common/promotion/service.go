@@ -0,0 +1,192 @@
+package promotion
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCouponNotFound      = errors.New("优惠码不存在")
+	ErrCouponNotApplicable = errors.New("优惠码不满足使用条件或已过期")
+	ErrCouponExhausted     = errors.New("优惠码已达使用上限")
+)
+
+// Service 促销计算与核销，购物车试算与订单结算共用同一套规则
+type Service struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// 创建促销服务
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db, now: time.Now}
+}
+
+// 初始化表，并将历史的浮点金额列迁移为最小单位
+func (s *Service) InitTable() error {
+	if err := s.db.AutoMigrate(&Promotion{}, &Redemption{}); err != nil {
+		return err
+	}
+	for _, column := range []struct {
+		model  interface{}
+		legacy string
+		prefix string
+	}{
+		{&Promotion{}, "amount", "amount_"},
+		{&Promotion{}, "min_spend", "min_spend_"},
+		{&Redemption{}, "discount", "discount_"},
+	} {
+		if err := money.MigrateFloatColumn(s.db, column.model, column.legacy, column.prefix, money.DefaultCurrency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 创建促销规则
+func (s *Service) CreatePromotion(promotion *Promotion) (int64, error) {
+	promotion.CouponCode = normalizeCode(promotion.CouponCode)
+	if err := s.db.Create(promotion).Error; err != nil {
+		return 0, err
+	}
+	return promotion.ID, nil
+}
+
+// Price 计算用户商品行的优惠，couponCode 为空时只计算自动生效的促销。
+// 输入了优惠码但不可用时返回对应错误，而不是静默忽略；商品金额超出可表示范围时返回 money.ErrOverflow。
+func (s *Service) Price(userID int64, couponCode string, lines []Line) (*Result, error) {
+	couponCode = normalizeCode(couponCode)
+	rules, err := s.activeRules(s.db, userID, couponCode)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Apply(lines, rules, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if couponCode != "" {
+		coupon := findCoupon(rules, couponCode)
+		if coupon == nil {
+			return nil, ErrCouponNotFound
+		}
+		if (coupon.UsageLimit > 0 && coupon.UsedCount >= coupon.UsageLimit) ||
+			(coupon.PerUserLimit > 0 && coupon.UserUsedCount >= coupon.PerUserLimit) {
+			return nil, ErrCouponExhausted
+		}
+		// 不满足使用条件，或优惠已被自动促销抵扣完，优惠码均未生效
+		if !containsApplied(result.Applied, coupon.ID) {
+			return nil, ErrCouponNotApplicable
+		}
+	}
+	return result, nil
+}
+
+// Redeem 在调用方事务 tx 中核销 result 中已应用的规则。
+// 逐条对规则行加锁后再校验全局与单用户次数，并发下单时同一优惠码不会超额使用。
+func (s *Service) Redeem(tx *gorm.DB, userID int64, orderID int64, result *Result) error {
+	for _, applied := range result.Applied {
+		promotion := &Promotion{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND enabled = ?", applied.RuleID, true).First(promotion).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCouponNotApplicable
+			}
+			return err
+		}
+		if promotion.UsageLimit > 0 && promotion.UsedCount >= promotion.UsageLimit {
+			return ErrCouponExhausted
+		}
+		if promotion.PerUserLimit > 0 {
+			var used int64
+			if err := tx.Model(&Redemption{}).
+				Where("promotion_id = ? AND user_id = ?", promotion.ID, userID).Count(&used).Error; err != nil {
+				return err
+			}
+			if used >= promotion.PerUserLimit {
+				return ErrCouponExhausted
+			}
+		}
+
+		if err := tx.Create(&Redemption{
+			PromotionID: promotion.ID,
+			UserID:      userID,
+			OrderID:     orderID,
+			Discount:    applied.Discount,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(promotion).UpdateColumn("used_count", gorm.Expr("used_count + 1")).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 查询自动生效的促销与 couponCode 对应的优惠码规则，并填充用户已使用次数
+func (s *Service) activeRules(db *gorm.DB, userID int64, couponCode string) ([]Rule, error) {
+	var promotions []Promotion
+	query := db.Where("enabled = ?", true)
+	if couponCode != "" {
+		query = query.Where("coupon_code = '' OR coupon_code = ?", couponCode)
+	} else {
+		query = query.Where("coupon_code = ''")
+	}
+	if err := query.Order("id").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+
+	var usages []struct {
+		PromotionID int64
+		Used        int64
+	}
+	if userID > 0 && len(promotions) > 0 {
+		if err := db.Model(&Redemption{}).
+			Select("promotion_id, COUNT(*) AS used").
+			Where("user_id = ?", userID).
+			Group("promotion_id").Scan(&usages).Error; err != nil {
+			return nil, err
+		}
+	}
+	used := make(map[int64]int64, len(usages))
+	for _, u := range usages {
+		used[u.PromotionID] = u.Used
+	}
+
+	rules := make([]Rule, 0, len(promotions))
+	for i := range promotions {
+		rule := promotions[i].Rule()
+		rule.UserUsedCount = used[rule.ID]
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func findCoupon(rules []Rule, couponCode string) *Rule {
+	for i := range rules {
+		if rules[i].CouponCode == couponCode {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// 规则是否在计算结果中实际生效
+func containsApplied(applied []Applied, ruleID int64) bool {
+	for _, a := range applied {
+		if a.RuleID == ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+// 优惠码不区分大小写，统一按大写存储
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,192 @@
+// Package secret 字段级加密：AES-256-GCM，密文中带有密钥ID，支持密钥轮换。
+//
+// 密文格式为 enc:v1:<key_id>:<base64(nonce|ciphertext)>，解密时按 key_id 选择密钥，
+// 加密总是使用主密钥。轮换时新增密钥并设为主密钥，旧密钥保留到存量数据重新加密完成。
+package secret
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+const (
+	prefix  = "enc:v1:"
+	keySize = 32
+)
+
+var (
+	ErrNoKeys        = errors.New("未配置加密密钥")
+	ErrUnknownKey    = errors.New("密文使用的密钥不存在")
+	ErrNotEncrypted  = errors.New("值未加密")
+	ErrMalformed     = errors.New("密文格式错误")
+	ErrInvalidKey    = errors.New("密钥必须为 base64 编码的 32 字节")
+	ErrInvalidKeyID  = errors.New("密钥ID不能为空且不能包含冒号")
+	ErrPrimaryAbsent = errors.New("主密钥不存在")
+)
+
+// Keyring 按密钥ID保存的一组密钥，primary 用于加密
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// 创建密钥环，keys 为密钥ID到 32 字节密钥的映射
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	k := &Keyring{primary: primary, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, ErrInvalidKeyID
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("密钥 %s: %w", id, ErrInvalidKey)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[id] = aead
+	}
+	if _, ok := k.keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrPrimaryAbsent, primary)
+	}
+	return k, nil
+}
+
+// LoadKeyring 由配置创建密钥环，密钥来自配置项与本地密钥文件，同一ID以密钥文件为准
+func LoadKeyring(cfg config.SecretKeyConfig) (*Keyring, error) {
+	encoded := make(map[string]string, len(cfg.Keys))
+	for id, key := range cfg.Keys {
+		encoded[id] = key
+	}
+	if cfg.KeyFile != "" {
+		if err := readKeyFile(cfg.KeyFile, encoded); err != nil {
+			return nil, err
+		}
+	}
+	keys := make(map[string][]byte, len(encoded))
+	for id, value := range encoded {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("密钥 %s: %w", id, ErrInvalidKey)
+		}
+		keys[id] = key
+	}
+	return NewKeyring(cfg.PrimaryKeyID, keys)
+}
+
+// readKeyFile 读取密钥文件，每行一个 <key_id>:<base64 密钥>，# 开头为注释
+func readKeyFile(path string, keys map[string]string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		id, key, ok := strings.Cut(text, ":")
+		if !ok {
+			return fmt.Errorf("密钥文件第 %d 行格式错误", line)
+		}
+		keys[strings.TrimSpace(id)] = key
+	}
+	return scanner.Err()
+}
+
+// PrimaryKeyID 当前用于加密的密钥ID
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+// Encrypt 使用主密钥加密
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	aead := k.keys[k.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.primary))
+	return prefix + k.primary + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 按密文中的密钥ID解密
+func (k *Keyring) Decrypt(value string) (string, error) {
+	id, payload, err := split(value)
+	if err != nil {
+		return "", err
+	}
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return "", ErrMalformed
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation 值未加密或不是由主密钥加密时返回 true
+func (k *Keyring) NeedsRotation(value string) bool {
+	id, _, err := split(value)
+	return err != nil || id != k.primary
+}
+
+// Rotate 用主密钥重新加密，未加密的历史明文直接加密
+func (k *Keyring) Rotate(value string) (string, error) {
+	plaintext := value
+	if IsEncrypted(value) {
+		var err error
+		if plaintext, err = k.Decrypt(value); err != nil {
+			return "", err
+		}
+	}
+	return k.Encrypt(plaintext)
+}
+
+// IsEncrypted 值是否为本包生成的密文
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// KeyID 返回密文使用的密钥ID
+func KeyID(value string) (string, error) {
+	id, _, err := split(value)
+	return id, err
+}
+
+func split(value string) (id, payload string, err error) {
+	if !IsEncrypted(value) {
+		return "", "", ErrNotEncrypted
+	}
+	id, payload, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok || id == "" {
+		return "", "", ErrMalformed
+	}
+	return id, payload, nil
+}
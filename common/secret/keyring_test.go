@@ -0,0 +1,113 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, keySize)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": testKey(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := keyring.Encrypt("paypal-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(ciphertext) || bytes.Contains([]byte(ciphertext), []byte("paypal-secret")) {
+		t.Fatalf("ciphertext = %q", ciphertext)
+	}
+	if id, _ := KeyID(ciphertext); id != "k1" {
+		t.Fatalf("key id = %q, want k1", id)
+	}
+	plaintext, err := keyring.Decrypt(ciphertext)
+	if err != nil || plaintext != "paypal-secret" {
+		t.Fatalf("Decrypt = %q, %v", plaintext, err)
+	}
+
+	again, _ := keyring.Encrypt("paypal-secret")
+	if again == ciphertext {
+		t.Fatal("同一明文两次加密的密文不应相同")
+	}
+}
+
+// TestRotation 新主密钥加密，旧密钥仍可解密存量密文
+func TestRotation(t *testing.T) {
+	old, _ := NewKeyring("k1", map[string][]byte{"k1": testKey(1)})
+	legacy, _ := old.Encrypt("s1")
+
+	rotated, err := NewKeyring("k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rotated.NeedsRotation(legacy) || !rotated.NeedsRotation("plain") {
+		t.Fatal("旧密钥密文与明文都需要轮换")
+	}
+	for _, value := range []string{legacy, "s1"} {
+		next, err := rotated.Rotate(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rotated.NeedsRotation(next) {
+			t.Fatalf("轮换后仍需轮换: %q", next)
+		}
+		if plaintext, _ := rotated.Decrypt(next); plaintext != "s1" {
+			t.Fatalf("Decrypt = %q, want s1", plaintext)
+		}
+	}
+
+	onlyNew, _ := NewKeyring("k2", map[string][]byte{"k2": testKey(2)})
+	if _, err := onlyNew.Decrypt(legacy); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("err = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	keyring, _ := NewKeyring("k1", map[string][]byte{"k1": testKey(1)})
+	ciphertext, _ := keyring.Encrypt("s1")
+	// 篡改密钥ID，附加数据不一致时解密失败
+	other, _ := NewKeyring("k1", map[string][]byte{"k1": testKey(1), "k9": testKey(1)})
+	tampered := prefix + "k9" + ciphertext[len(prefix+"k1"):]
+	if _, err := other.Decrypt(tampered); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("err = %v, want ErrMalformed", err)
+	}
+	if _, err := keyring.Decrypt("plain"); !errors.Is(err, ErrNotEncrypted) {
+		t.Fatalf("err = %v, want ErrNotEncrypted", err)
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	content := "# 本地密钥\nk2:" + base64.StdEncoding.EncodeToString(testKey(2)) + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keyring, err := LoadKeyring(config.SecretKeyConfig{
+		PrimaryKeyID: "k2",
+		Keys:         map[string]string{"k1": base64.StdEncoding.EncodeToString(testKey(1))},
+		KeyFile:      path,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyring.PrimaryKeyID() != "k2" || len(keyring.keys) != 2 {
+		t.Fatalf("primary = %q, keys = %d", keyring.PrimaryKeyID(), len(keyring.keys))
+	}
+
+	if _, err := LoadKeyring(config.SecretKeyConfig{PrimaryKeyID: "k1"}); !errors.Is(err, ErrNoKeys) {
+		t.Fatalf("err = %v, want ErrNoKeys", err)
+	}
+	if _, err := LoadKeyring(config.SecretKeyConfig{PrimaryKeyID: "k1", Keys: map[string]string{"k1": "c2hvcnQ="}}); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("err = %v, want ErrInvalidKey", err)
+	}
+}
@@ -1,14 +1,59 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 订单支付状态
+const (
+	PayStatusUnpaid            int32 = 0 // 未支付
+	PayStatusPaid              int32 = 1 // 已支付
+	PayStatusRefunding         int32 = 2 // 退款中
+	PayStatusRefunded          int32 = 3 // 已全额退款
+	PayStatusPartiallyRefunded int32 = 4 // 已部分退款
+)
 
 type Order struct {
-	ID          int64         `gorm:"primary_key;not_null;auto_increment",json:"id"`
-	OrderCode   string        `gorm:"unique_index;not_null",json:"order_code"`
-	PayStatus   int32         `json:"pay_status"`
-	ShipStatus  int32         `json:"ship_status"`
-	Price       float64       `json:"price"`
-	OrderDetail []OrderDetail `gorm:"ForeignKey:OrderID" json:"order_detail"`
-	CreateAt    time.Time
-	UpdateAt    time.Time
+	ID            int64          `gorm:"primary_key;not_null;auto_increment",json:"id"`
+	OrderCode     string         `gorm:"unique_index;not_null",json:"order_code"`
+	UserID        int64          `json:"user_id"`
+	Currency      string         `gorm:"size:3" json:"currency"` // 结算币种，应付金额与支付均使用该币种
+	PayStatus     int32          `json:"pay_status"`
+	ShipStatus    int32          `json:"ship_status"`
+	Price         money.Money    `gorm:"embedded;embeddedPrefix:price_" json:"amount"`                   // 优惠后应付金额
+	OriginalPrice money.Money    `gorm:"embedded;embeddedPrefix:original_price_" json:"original_amount"` // 优惠前商品总额
+	Discount      money.Money    `gorm:"embedded;embeddedPrefix:discount_" json:"discount_amount"`
+	FreeShipping  bool           `json:"free_shipping"`
+	CouponCode    string         `json:"coupon_code"`
+	OrderDetail   []OrderDetail  `gorm:"ForeignKey:OrderID" json:"order_detail"`
+	ExchangeRates []ExchangeRate `gorm:"serializer:json" json:"-"` // 下单时换算明细价格所用的汇率
+	CreateAt      time.Time
+	UpdateAt      time.Time
+	PaidAt        *time.Time `gorm:"index" json:"paid_at"` // 首次支付时间，之后退款不清除，供支付对账按日期筛选
+
+	// 下单时收货地址的快照，地址簿之后的修改不影响已有订单
+	ShippingAddress ShippingAddress `gorm:"embedded;embeddedPrefix:ship_" json:"shipping_address"`
+}
+
+// ShippingAddress 收货地址快照，AddressID 为用户服务地址簿中的来源地址
+type ShippingAddress struct {
+	AddressID  int64  `json:"address_id"`
+	Recipient  string `gorm:"size:50" json:"recipient"`
+	Phone      string `gorm:"size:20" json:"phone"`
+	Country    string `gorm:"size:2" json:"country"`
+	Region     string `gorm:"size:100" json:"region"`
+	City       string `gorm:"size:100" json:"city"`
+	Detail     string `gorm:"size:255" json:"detail"`
+	PostalCode string `gorm:"size:10" json:"postal_code"`
+}
+
+// ExchangeRate 下单时使用的汇率：1 单位 From 兑换 Rate 单位 To，Rate 为十进制字符串
+type ExchangeRate struct {
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Rate   string    `json:"rate"`
+	Source string    `json:"source"`
+	AsOf   time.Time `json:"as_of"`
 }
@@ -1,10 +1,14 @@
 package model
 
+import "github.com/Ben1524/GoMall/common/money"
+
 type OrderDetail struct {
-	ID            int64   `grom:"primary_key;not_null;auto_increment",json:"id"`
-	ProductID     int64   `json:"product_id"`
-	ProductNum    int64   `json:"product_num"`
-	ProductSizeID int64   `json:"product_size_id"`
-	ProductPrice  float64 `json:"product_price"`
-	OrderID       int64   `json:"order_id"`
+	ID                int64       `grom:"primary_key;not_null;auto_increment",json:"id"`
+	ProductID         int64       `json:"product_id"`
+	ProductCategoryID int64       `json:"product_category_id"` // 用于按分类限定的促销规则
+	ProductNum        int64       `json:"product_num"`
+	ProductSizeID     int64       `json:"product_size_id"`
+	ProductPrice      money.Money `gorm:"embedded;embeddedPrefix:product_price_" json:"unit_price"` // 结算币种的单价
+	ListPrice         money.Money `gorm:"embedded;embeddedPrefix:list_price_" json:"list_price"`    // 商品标价，币种与订单不同时按订单记录的汇率换算为单价
+	OrderID           int64       `json:"order_id"`
 }
@@ -3,17 +3,30 @@ package repository
 import (
 	"errors"
 	"order/domain/model"
+	"time"
 
+	"github.com/Ben1524/GoMall/common/money"
 	"gorm.io/gorm"
 )
 
+// PayStatusQuery 按支付状态查询订单的条件，PaidFrom、PaidTo 为零值时不限首次支付时间，OrderIDs 为空时不限订单
+type PayStatusQuery struct {
+	PayStatus []int32
+	PaidFrom  time.Time
+	PaidTo    time.Time
+	OrderIDs  []int64
+}
+
 type IOrderRepository interface {
 	InitTable() error
 	FindOrderByID(int64) (*model.Order, error)
-	CreateOrder(*model.Order) (int64, error)
+	CreateOrder(*model.Order, func(*gorm.DB) error) (int64, error)
 	DeleteOrderByID(int64) error
 	UpdateOrder(*model.Order) error
 	FindAll() ([]model.Order, error)
+	FindAllByPayStatus(PayStatusQuery) ([]model.Order, error)
+	FindAllByUser(int64) ([]model.Order, error)
+	AnonymizeShipping(int64) (int64, error)
 	UpdateShipStatus(int64, int32) error
 	UpdatePayStatus(int64, int32) error
 }
@@ -29,7 +42,33 @@ type OrderRepository struct {
 
 // 初始化表
 func (u *OrderRepository) InitTable() error {
-	return u.mysqlDb.AutoMigrate(&model.Order{}, &model.OrderDetail{}).Error
+	if err := u.mysqlDb.AutoMigrate(&model.Order{}, &model.OrderDetail{}); err != nil {
+		return err
+	}
+	for _, column := range []struct {
+		model  interface{}
+		legacy string
+		prefix string
+	}{
+		{&model.Order{}, "price", "price_"},
+		{&model.Order{}, "original_price", "original_price_"},
+		{&model.Order{}, "discount", "discount_"},
+		{&model.OrderDetail{}, "product_price", "product_price_"},
+	} {
+		if err := money.MigrateFloatColumn(u.mysqlDb, column.model, column.legacy, column.prefix, money.DefaultCurrency); err != nil {
+			return err
+		}
+	}
+	// 历史订单的结算币种即应付金额的币种，标价即单价
+	if err := u.mysqlDb.Model(&model.Order{}).Where("currency = ? OR currency IS NULL", "").
+		Update("currency", gorm.Expr("price_currency")).Error; err != nil {
+		return err
+	}
+	return u.mysqlDb.Model(&model.OrderDetail{}).Where("list_price_currency = ? OR list_price_currency IS NULL", "").
+		Updates(map[string]interface{}{
+			"list_price_amount":   gorm.Expr("product_price_amount"),
+			"list_price_currency": gorm.Expr("product_price_currency"),
+		}).Error
 }
 
 // 根据ID查找Order信息
@@ -38,9 +77,18 @@ func (u *OrderRepository) FindOrderByID(orderID int64) (order *model.Order, err
 	return order, u.mysqlDb.Preload("OrderDetail").First(order, orderID).Error
 }
 
-// 创建Order信息
-func (u *OrderRepository) CreateOrder(order *model.Order) (int64, error) {
-	return order.ID, u.mysqlDb.Create(order).Error
+// 创建Order信息，afterCreate 不为空时在同一事务内执行（如核销优惠码），失败则整单回滚
+func (u *OrderRepository) CreateOrder(order *model.Order, afterCreate func(tx *gorm.DB) error) (int64, error) {
+	err := u.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+		if afterCreate != nil {
+			return afterCreate(tx)
+		}
+		return nil
+	})
+	return order.ID, err
 }
 
 // 根据ID删除Order信息
@@ -74,7 +122,7 @@ func (u *OrderRepository) DeleteOrderByID(orderID int64) error {
 
 // 更新Order信息
 func (u *OrderRepository) UpdateOrder(order *model.Order) error {
-	return u.mysqlDb.Model(order).Update(order).Error
+	return u.mysqlDb.Model(order).Updates(order).Error
 }
 
 // 获取结果集
@@ -82,6 +130,38 @@ func (u *OrderRepository) FindAll() (orderAll []model.Order, err error) {
 	return orderAll, u.mysqlDb.Preload("OrderDetail").Find(&orderAll).Error
 }
 
+// 按支付状态获取订单，不加载订单明细
+func (u *OrderRepository) FindAllByPayStatus(query PayStatusQuery) (orderAll []model.Order, err error) {
+	db := u.mysqlDb.Where("pay_status IN ?", query.PayStatus)
+	if !query.PaidFrom.IsZero() {
+		db = db.Where("paid_at >= ?", query.PaidFrom)
+	}
+	if !query.PaidTo.IsZero() {
+		db = db.Where("paid_at < ?", query.PaidTo)
+	}
+	if len(query.OrderIDs) > 0 {
+		db = db.Where("id IN ?", query.OrderIDs)
+	}
+	return orderAll, db.Order("id").Find(&orderAll).Error
+}
+
+// 获取用户的全部订单
+func (u *OrderRepository) FindAllByUser(userID int64) (orderAll []model.Order, err error) {
+	return orderAll, u.mysqlDb.Preload("OrderDetail").Where("user_id = ?", userID).Order("id").Find(&orderAll).Error
+}
+
+// 清除用户订单收货地址中的收件人、电话、详细地址与邮编，保留国家与省市用于税务核算，返回更新的订单数
+func (u *OrderRepository) AnonymizeShipping(userID int64) (int64, error) {
+	db := u.mysqlDb.Model(&model.Order{}).Where("user_id = ?", userID).UpdateColumns(map[string]interface{}{
+		"ship_address_id":  0,
+		"ship_recipient":   "",
+		"ship_phone":       "",
+		"ship_detail":      "",
+		"ship_postal_code": "",
+	})
+	return db.RowsAffected, db.Error
+}
+
 // 更新订单的发货状态
 func (u *OrderRepository) UpdateShipStatus(orderID int64, shipStatus int32) error {
 	db := u.mysqlDb.Model(&model.Order{}).Where("id = ?", orderID).UpdateColumn("ship_status", shipStatus)
@@ -94,9 +174,13 @@ func (u *OrderRepository) UpdateShipStatus(orderID int64, shipStatus int32) erro
 	return nil
 }
 
-// 更新订单的支付状态
+// 更新订单的支付状态，首次置为已支付时记录支付时间
 func (u *OrderRepository) UpdatePayStatus(orderID int64, payStatus int32) error {
-	db := u.mysqlDb.Model(&model.Order{}).Where("id = ?", orderID).UpdateColumn("pay_status", payStatus)
+	values := map[string]interface{}{"pay_status": payStatus}
+	if payStatus == model.PayStatusPaid {
+		values["paid_at"] = gorm.Expr("COALESCE(paid_at, ?)", time.Now())
+	}
+	db := u.mysqlDb.Model(&model.Order{}).Where("id = ?", orderID).UpdateColumns(values)
 	if db.Error != nil {
 		return db.Error
 	}
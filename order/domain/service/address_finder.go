@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"order/domain/model"
+	pb "order/proto/user"
+)
+
+// IAddressFinder 查询用户地址簿中的收货地址，addressID 为 0 时返回默认地址
+type IAddressFinder interface {
+	FindAddress(ctx context.Context, userID, addressID int64) (model.ShippingAddress, error)
+}
+
+// 创建基于用户服务 RPC 的查询器，调用时转发下单用户的访问令牌
+func NewAddressFinder(userService pb.UserService) IAddressFinder {
+	return &AddressFinder{userService: userService}
+}
+
+type AddressFinder struct {
+	userService pb.UserService
+}
+
+func (a *AddressFinder) FindAddress(ctx context.Context, userID, addressID int64) (model.ShippingAddress, error) {
+	var (
+		address *pb.AddressInfo
+		err     error
+	)
+	if addressID == 0 {
+		address, err = a.userService.FindDefaultAddress(ctx, &pb.UserID{UserId: userID})
+	} else {
+		address, err = a.userService.FindAddressByID(ctx, &pb.AddressRequest{UserId: userID, AddressId: addressID})
+	}
+	if err != nil {
+		return model.ShippingAddress{}, fmt.Errorf("查询收货地址失败: %w", err)
+	}
+	return model.ShippingAddress{
+		AddressID:  address.Id,
+		Recipient:  address.Recipient,
+		Phone:      address.Phone,
+		Country:    address.Country,
+		Region:     address.Region,
+		City:       address.City,
+		Detail:     address.Detail,
+		PostalCode: address.PostalCode,
+	}, nil
+}
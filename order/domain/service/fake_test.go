@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"order/domain/model"
+	"order/domain/repository"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/promotion"
+	"gorm.io/gorm"
+)
+
+// 以下为服务测试使用的内存实现，只模拟服务依赖的仓储语义
+
+type fakeOrders struct {
+	repository.IOrderRepository
+	orders map[int64]*model.Order
+}
+
+// CreateOrder 与真实仓储一样在写入后执行 afterCreate，失败时不保留订单
+func (f *fakeOrders) CreateOrder(order *model.Order, afterCreate func(tx *gorm.DB) error) (int64, error) {
+	order.ID = int64(len(f.orders) + 1)
+	if afterCreate != nil {
+		if err := afterCreate(nil); err != nil {
+			return order.ID, err
+		}
+	}
+	copied := *order
+	f.orders[order.ID] = &copied
+	return order.ID, nil
+}
+
+// fakeAddresses 返回用户的固定默认地址
+type fakeAddresses struct{}
+
+func (fakeAddresses) FindAddress(ctx context.Context, userID, addressID int64) (model.ShippingAddress, error) {
+	return model.ShippingAddress{AddressID: 1, Recipient: "张三", Phone: "13800000000"}, nil
+}
+
+// fakeProducts 商品服务的当前价格与分类，未登记的商品视为已下架
+type fakeProducts map[int64]*ProductPrice
+
+func (f fakeProducts) FindProduct(ctx context.Context, productID int64) (*ProductPrice, error) {
+	product, ok := f[productID]
+	if !ok {
+		return nil, ErrProductUnavailable
+	}
+	copied := *product
+	return &copied, nil
+}
+
+// fakePromotions 在固定时刻按给定规则计算，核销时记录已应用的规则
+type fakePromotions struct {
+	rules    []promotion.Rule
+	redeemed []int64
+	err      error // Redeem 返回的错误
+}
+
+var testNow = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func (f *fakePromotions) Price(userID int64, couponCode string, lines []promotion.Line) (*promotion.Result, error) {
+	var rules []promotion.Rule
+	for _, rule := range f.rules {
+		if rule.CouponCode == "" || rule.CouponCode == couponCode {
+			rules = append(rules, rule)
+		}
+	}
+	return promotion.Apply(lines, rules, testNow)
+}
+
+func (f *fakePromotions) Redeem(tx *gorm.DB, userID int64, orderID int64, result *promotion.Result) error {
+	if f.err != nil {
+		return f.err
+	}
+	for _, applied := range result.Applied {
+		f.redeemed = append(f.redeemed, applied.RuleID)
+	}
+	return nil
+}
@@ -1,32 +1,142 @@
 package service
 
 import (
+	"context"
 	"order/domain/model"
 	"order/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/exchange"
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/Ben1524/GoMall/common/promotion"
+	"gorm.io/gorm"
 )
 
 type IOrderDataService interface {
-	AddOrder(*model.Order) (int64, error)
+	AddOrder(context.Context, *model.Order) (int64, error)
 	DeleteOrder(int64) error
 	UpdateOrder(*model.Order) error
 	FindOrderByID(int64) (*model.Order, error)
 	FindAllOrder() ([]model.Order, error)
+	FindAllByUser(int64) ([]model.Order, error)
+	FindAllByPayStatus(repository.PayStatusQuery) ([]model.Order, error)
 	UpdateShipStatus(int64, int32) error
 	UpdatePayStatus(int64, int32) error
 }
 
+// IPromotionService 下单时计算优惠并在订单事务内核销，由 promotion.Service 实现
+type IPromotionService interface {
+	Price(userID int64, couponCode string, lines []promotion.Line) (*promotion.Result, error)
+	Redeem(tx *gorm.DB, userID int64, orderID int64, result *promotion.Result) error
+}
+
 // 创建
-func NewOrderDataService(orderRepository repository.IOrderRepository) IOrderDataService {
-	return &OrderDataService{orderRepository}
+func NewOrderDataService(orderRepository repository.IOrderRepository, promotionService IPromotionService,
+	rates exchange.Provider, addresses IAddressFinder, products IProductFinder) IOrderDataService {
+	return &OrderDataService{OrderRepository: orderRepository, PromotionService: promotionService, Rates: rates,
+		Addresses: addresses, Products: products}
 }
 
 type OrderDataService struct {
-	OrderRepository repository.IOrderRepository
+	OrderRepository  repository.IOrderRepository
+	PromotionService IPromotionService
+	Rates            exchange.Provider
+	Addresses        IAddressFinder
+	Products         IProductFinder
 }
 
-// 插入
-func (u *OrderDataService) AddOrder(order *model.Order) (int64, error) {
-	return u.OrderRepository.CreateOrder(order)
+// 插入，按商品服务的当前价格与分类计价（忽略调用方传入的单价与分类），按促销规则重新计算订单金额，
+// 并在创建订单的事务内核销优惠。
+// 订单以 Currency 结算，未指定时使用第一条明细的币种；币种不同的明细按当前汇率换算为结算币种，
+// 所用汇率记录在订单上，没有对应汇率时返回 exchange.ErrRateNotFound。
+// 收货地址按 ShippingAddress.AddressID 从地址簿快照到订单上，为 0 时使用默认地址。
+func (u *OrderDataService) AddOrder(ctx context.Context, order *model.Order) (int64, error) {
+	address, err := u.Addresses.FindAddress(ctx, order.UserID, order.ShippingAddress.AddressID)
+	if err != nil {
+		return 0, err
+	}
+	order.ShippingAddress = address
+	if err := u.price(ctx, order); err != nil {
+		return 0, err
+	}
+	if err := u.settle(ctx, order); err != nil {
+		return 0, err
+	}
+	lines := make([]promotion.Line, 0, len(order.OrderDetail))
+	for _, detail := range order.OrderDetail {
+		lines = append(lines, promotion.Line{
+			ProductID:  detail.ProductID,
+			CategoryID: detail.ProductCategoryID,
+			Price:      detail.ProductPrice,
+			Num:        detail.ProductNum,
+		})
+	}
+	result, err := u.PromotionService.Price(order.UserID, order.CouponCode, lines)
+	if err != nil {
+		return 0, err
+	}
+	order.OriginalPrice = result.Subtotal
+	order.Discount = result.Discount
+	order.Price = result.Total
+	order.FreeShipping = result.FreeShipping
+
+	return u.OrderRepository.CreateOrder(order, func(tx *gorm.DB) error {
+		return u.PromotionService.Redeem(tx, order.UserID, order.ID, result)
+	})
+}
+
+// price 以商品服务返回的标价与分类覆盖明细中的对应字段
+func (u *OrderDataService) price(ctx context.Context, order *model.Order) error {
+	for i := range order.OrderDetail {
+		detail := &order.OrderDetail[i]
+		product, err := u.Products.FindProduct(ctx, detail.ProductID)
+		if err != nil {
+			return err
+		}
+		detail.ProductCategoryID = product.CategoryID
+		detail.ProductPrice = product.Price
+	}
+	return nil
+}
+
+// settle 确定结算币种，并将明细标价换算为结算币种的单价
+func (u *OrderDataService) settle(ctx context.Context, order *model.Order) error {
+	currency := order.Currency
+	if currency == "" && len(order.OrderDetail) > 0 {
+		currency = order.OrderDetail[0].ProductPrice.Currency
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	order.Currency = money.Zero(currency).Currency
+	if !money.ValidCurrency(order.Currency) {
+		return money.ErrInvalidCurrency
+	}
+
+	order.ExchangeRates = nil
+	used := map[string]bool{}
+	for i := range order.OrderDetail {
+		detail := &order.OrderDetail[i]
+		detail.ListPrice = detail.ProductPrice
+		if detail.ListPrice.Currency == order.Currency {
+			continue
+		}
+		converted, rate, err := exchange.ConvertTo(ctx, u.Rates, detail.ListPrice, order.Currency)
+		if err != nil {
+			return err
+		}
+		detail.ProductPrice = converted
+		if !used[rate.From] {
+			used[rate.From] = true
+			order.ExchangeRates = append(order.ExchangeRates, model.ExchangeRate{
+				From:   rate.From,
+				To:     rate.To,
+				Rate:   rate.String(),
+				Source: rate.Source,
+				AsOf:   rate.AsOf,
+			})
+		}
+	}
+	return nil
 }
 
 // 删除
@@ -49,6 +159,19 @@ func (u *OrderDataService) FindAllOrder() ([]model.Order, error) {
 	return u.OrderRepository.FindAll()
 }
 
+// 查找用户的所有订单
+func (u *OrderDataService) FindAllByUser(userID int64) ([]model.Order, error) {
+	return u.OrderRepository.FindAllByUser(userID)
+}
+
+// 按支付状态查找，未指定状态时返回空
+func (u *OrderDataService) FindAllByPayStatus(query repository.PayStatusQuery) ([]model.Order, error) {
+	if len(query.PayStatus) == 0 {
+		return nil, nil
+	}
+	return u.OrderRepository.FindAllByPayStatus(query)
+}
+
 func (u *OrderDataService) UpdateShipStatus(orderID int64, shipStatus int32) error {
 	return u.OrderRepository.UpdateShipStatus(orderID, shipStatus)
 }
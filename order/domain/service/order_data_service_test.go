@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"order/domain/model"
+	"slices"
+	"testing"
+
+	"github.com/Ben1524/GoMall/common/exchange"
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/Ben1524/GoMall/common/promotion"
+)
+
+type orderFixture struct {
+	orders     *fakeOrders
+	promotions *fakePromotions
+	service    IOrderDataService
+}
+
+// newOrderFixture 商品 1 标价 20.00 USD、分类 100，商品 2 标价 10.00 EUR、分类 200；1 USD = 0.8 EUR
+func newOrderFixture(t *testing.T, rules ...promotion.Rule) *orderFixture {
+	t.Helper()
+	rates, err := exchange.NewTable(exchange.Document{Base: "USD", AsOf: testNow, Rates: map[string]string{"EUR": "0.8"}}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &orderFixture{orders: &fakeOrders{orders: map[int64]*model.Order{}}, promotions: &fakePromotions{rules: rules}}
+	f.service = NewOrderDataService(f.orders, f.promotions, rates, fakeAddresses{}, fakeProducts{
+		1: {ProductID: 1, CategoryID: 100, Price: money.New(2000, "USD")},
+		2: {ProductID: 2, CategoryID: 200, Price: money.New(1000, "EUR")},
+	})
+	return f
+}
+
+// TestAddOrderRepricesFromProductService 忽略调用方传入的单价与分类，按商品服务的当前价格计价并核销促销
+func TestAddOrderRepricesFromProductService(t *testing.T) {
+	f := newOrderFixture(t,
+		promotion.Rule{ID: 1, Type: promotion.RulePercentOff, Percent: 10, CategoryIDs: []int64{100}},
+		promotion.Rule{ID: 2, Type: promotion.RuleFixedAmount, Amount: money.New(500, "USD"), CouponCode: "SAVE5"},
+	)
+	order := &model.Order{UserID: 7, OrderDetail: []model.OrderDetail{
+		{ProductID: 1, ProductNum: 3, ProductPrice: money.New(1, "USD"), ProductCategoryID: 999},
+	}}
+
+	if _, err := f.service.AddOrder(context.Background(), order); err != nil {
+		t.Fatalf("AddOrder: %v", err)
+	}
+	if detail := order.OrderDetail[0]; detail.ProductPrice != money.New(2000, "USD") || detail.ProductCategoryID != 100 {
+		t.Fatalf("detail price = %v category = %d, want 20.00 USD and 100", detail.ProductPrice, detail.ProductCategoryID)
+	}
+	if order.OriginalPrice != money.New(6000, "USD") || order.Discount != money.New(600, "USD") || order.Price != money.New(5400, "USD") {
+		t.Fatalf("order = %v / %v / %v, want 60.00 / 6.00 / 54.00 USD", order.OriginalPrice, order.Discount, order.Price)
+	}
+	// 未输入优惠码时只核销自动生效的促销
+	if !slices.Equal(f.promotions.redeemed, []int64{1}) {
+		t.Fatalf("redeemed = %v, want [1]", f.promotions.redeemed)
+	}
+	if order.ShippingAddress.AddressID != 1 {
+		t.Fatalf("shipping address = %+v, want default address snapshot", order.ShippingAddress)
+	}
+}
+
+// TestAddOrderRedeemsCoupon 输入优惠码时与自动促销一起在创建订单时核销
+func TestAddOrderRedeemsCoupon(t *testing.T) {
+	f := newOrderFixture(t,
+		promotion.Rule{ID: 1, Type: promotion.RulePercentOff, Percent: 10, CategoryIDs: []int64{100}},
+		promotion.Rule{ID: 2, Type: promotion.RuleFixedAmount, Amount: money.New(500, "USD"), CouponCode: "SAVE5"},
+	)
+	order := &model.Order{UserID: 7, CouponCode: "SAVE5", OrderDetail: []model.OrderDetail{{ProductID: 1, ProductNum: 3}}}
+
+	if _, err := f.service.AddOrder(context.Background(), order); err != nil {
+		t.Fatalf("AddOrder: %v", err)
+	}
+	if order.Discount != money.New(1100, "USD") || order.Price != money.New(4900, "USD") {
+		t.Fatalf("discount = %v price = %v, want 11.00 and 49.00 USD", order.Discount, order.Price)
+	}
+	if !slices.Equal(f.promotions.redeemed, []int64{1, 2}) {
+		t.Fatalf("redeemed = %v, want [1 2]", f.promotions.redeemed)
+	}
+}
+
+// TestAddOrderRedeemFailureDiscardsOrder 核销失败（如优惠码已用完）时整单不创建
+func TestAddOrderRedeemFailureDiscardsOrder(t *testing.T) {
+	f := newOrderFixture(t, promotion.Rule{ID: 2, Type: promotion.RuleFixedAmount, Amount: money.New(500, "USD"), CouponCode: "SAVE5"})
+	f.promotions.err = promotion.ErrCouponExhausted
+	order := &model.Order{UserID: 7, CouponCode: "SAVE5", OrderDetail: []model.OrderDetail{{ProductID: 1, ProductNum: 1}}}
+
+	if _, err := f.service.AddOrder(context.Background(), order); !errors.Is(err, promotion.ErrCouponExhausted) {
+		t.Fatalf("err = %v, want ErrCouponExhausted", err)
+	}
+	if len(f.orders.orders) != 0 {
+		t.Fatalf("orders = %d, want none", len(f.orders.orders))
+	}
+}
+
+// TestAddOrderSettlesInOrderCurrency 不同币种的明细按汇率换算为结算币种，并记录所用汇率
+func TestAddOrderSettlesInOrderCurrency(t *testing.T) {
+	f := newOrderFixture(t)
+	order := &model.Order{UserID: 7, Currency: "usd", OrderDetail: []model.OrderDetail{
+		{ProductID: 1, ProductNum: 1},
+		{ProductID: 2, ProductNum: 2},
+	}}
+
+	if _, err := f.service.AddOrder(context.Background(), order); err != nil {
+		t.Fatalf("AddOrder: %v", err)
+	}
+	detail := order.OrderDetail[1]
+	if detail.ListPrice != money.New(1000, "EUR") || detail.ProductPrice != money.New(1250, "USD") {
+		t.Fatalf("list price = %v unit price = %v, want 10.00 EUR and 12.50 USD", detail.ListPrice, detail.ProductPrice)
+	}
+	if order.Currency != "USD" || order.Price != money.New(4500, "USD") {
+		t.Fatalf("currency = %s price = %v, want USD and 45.00 USD", order.Currency, order.Price)
+	}
+	if len(order.ExchangeRates) != 1 || order.ExchangeRates[0].From != "EUR" || order.ExchangeRates[0].To != "USD" {
+		t.Fatalf("exchange rates = %+v, want one EUR->USD rate", order.ExchangeRates)
+	}
+}
+
+// TestAddOrderRejectsUnavailableProduct 下架商品不能下单
+func TestAddOrderRejectsUnavailableProduct(t *testing.T) {
+	f := newOrderFixture(t)
+	order := &model.Order{UserID: 7, OrderDetail: []model.OrderDetail{{ProductID: 3, ProductNum: 1}}}
+
+	if _, err := f.service.AddOrder(context.Background(), order); !errors.Is(err, ErrProductUnavailable) {
+		t.Fatalf("err = %v, want ErrProductUnavailable", err)
+	}
+	if len(f.orders.orders) != 0 || len(f.promotions.redeemed) != 0 {
+		t.Fatalf("orders = %d redeemed = %v, want none", len(f.orders.orders), f.promotions.redeemed)
+	}
+}
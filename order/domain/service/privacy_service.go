@@ -0,0 +1,47 @@
+package service
+
+import (
+	"order/domain/model"
+	"order/domain/repository"
+)
+
+// IPrivacyService 用户数据导出与删除，由用户服务的隐私请求流程调用，可重复执行。
+// 订单须依法保留，删除时只匿名化收货地址中的个人信息，金额、明细与状态不变。
+type IPrivacyService interface {
+	ExportUserData(int64) ([]model.Order, error)
+	// EraseUserData 返回匿名化的订单数与用户的全部订单ID
+	EraseUserData(int64) (int64, []int64, error)
+}
+
+// 创建
+func NewPrivacyService(orderRepository repository.IOrderRepository) IPrivacyService {
+	return &PrivacyService{OrderRepository: orderRepository}
+}
+
+type PrivacyService struct {
+	OrderRepository repository.IOrderRepository
+}
+
+// 导出
+func (u *PrivacyService) ExportUserData(userID int64) ([]model.Order, error) {
+	return u.OrderRepository.FindAllByUser(userID)
+}
+
+// 匿名化
+func (u *PrivacyService) EraseUserData(userID int64) (int64, []int64, error) {
+	orders, err := u.OrderRepository.FindAllByUser(userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	affected, err := u.OrderRepository.AnonymizeShipping(userID)
+	return affected, OrderIDs(orders), err
+}
+
+// OrderIDs 取出订单ID
+func OrderIDs(orders []model.Order) []int64 {
+	ids := make([]int64, 0, len(orders))
+	for _, order := range orders {
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
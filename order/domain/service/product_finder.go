@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pb "order/proto/product"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// ErrProductUnavailable 商品已下架
+var ErrProductUnavailable = errors.New("商品已下架，无法下单")
+
+// ProductPrice 下单计价所需的商品信息，以商品服务为准
+type ProductPrice struct {
+	ProductID  int64
+	CategoryID int64
+	Price      money.Money // 商品当前标价
+}
+
+// IProductFinder 向商品服务查询在售商品的当前价格与分类
+type IProductFinder interface {
+	FindProduct(ctx context.Context, productID int64) (*ProductPrice, error)
+}
+
+// 商品上架状态，与商品服务 model.ProductStatusOnSale 保持一致
+const productStatusOnSale int32 = 1
+
+// 创建基于商品服务 RPC 的查询器
+func NewProductFinder(productService pb.ProductService) IProductFinder {
+	return &ProductFinder{productService: productService}
+}
+
+type ProductFinder struct {
+	productService pb.ProductService
+}
+
+// 查询商品，下架的商品返回 ErrProductUnavailable；商品服务的 go-micro 错误（如 404）原样保留
+func (p *ProductFinder) FindProduct(ctx context.Context, productID int64) (*ProductPrice, error) {
+	productInfo, err := p.productService.FindProductByID(ctx, &pb.RequestID{ProductId: productID})
+	if err != nil {
+		return nil, fmt.Errorf("查询商品信息失败: %w", err)
+	}
+	if productInfo.GetProductStatus() != productStatusOnSale {
+		return nil, ErrProductUnavailable
+	}
+	price := money.FromProto(productInfo.GetPrice())
+	if productInfo.GetPrice() == nil {
+		// 未升级的商品服务只返回 product_price，按默认币种换算
+		price = money.FromFloat(productInfo.GetProductPrice(), money.DefaultCurrency)
+	}
+	return &ProductPrice{ProductID: productID, CategoryID: productInfo.GetProductCategoryId(), Price: price}, nil
+}
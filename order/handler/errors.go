@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"order/domain/service"
+
+	"github.com/Ben1524/GoMall/common/exchange"
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/Ben1524/GoMall/common/promotion"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// 返回给调用方的 go-micro 错误 ID
+const serviceID = "go.micro.service.order"
+
+// toMicroError 将优惠码、商品、金额与汇率校验错误转换为带状态码的 go-micro 错误，
+// 下游服务（如查询收货地址、商品）返回的 go-micro 错误保留原状态码，其余错误原样返回
+func toMicroError(err error) error {
+	var microErr *microerrors.Error
+	switch {
+	case errors.As(err, &microErr):
+		return microErr
+	case errors.Is(err, promotion.ErrCouponNotFound):
+		return microerrors.NotFound(serviceID, "%s", err.Error())
+	case errors.Is(err, promotion.ErrCouponNotApplicable):
+		return microerrors.BadRequest(serviceID, "%s", err.Error())
+	case errors.Is(err, promotion.ErrCouponExhausted):
+		return microerrors.Conflict(serviceID, "%s", err.Error())
+	case errors.Is(err, money.ErrCurrencyMismatch), errors.Is(err, money.ErrInvalidCurrency), errors.Is(err, money.ErrOverflow),
+		errors.Is(err, exchange.ErrRateNotFound), errors.Is(err, service.ErrProductUnavailable):
+		return microerrors.BadRequest(serviceID, "%s", err.Error())
+	}
+	return err
+}
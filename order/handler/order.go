@@ -3,9 +3,13 @@ package handler
 import (
 	"context"
 	"order/domain/model"
+	"order/domain/repository"
 	"order/domain/service"
 	. "order/proto/order"
+	"time"
 
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/money"
 	common "github.com/Ben1524/GoMall/common/utils"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -23,28 +27,61 @@ func NewOrderHandler(service service.IOrderDataService) *Order {
 	}
 }
 
-// 根据订单ID查询订单
+// 根据订单ID查询订单，只有订单本人、订单管理员与内部服务可以查看
 func (o *Order) GetOrderByID(ctx context.Context, request *OrderID, response *OrderInfo) error {
 	order, err := o.OrderDataService.FindOrderByID(request.OrderId)
 	if err != nil {
 		return err
 	}
-	if err := common.SwapTo(order, response); err != nil {
+	if err := authorizeOrder(ctx, order); err != nil {
 		return err
 	}
-	return nil
+	return fillOrderInfo(order, response)
 }
 
-// 查找所有订单
+// 查找订单，拥有订单管理权限时返回全部订单，否则只返回本人的订单
 func (o *Order) GetAllOrder(ctx context.Context, request *AllOrderRequest, response *AllOrder) error {
-	orderAll, err := o.OrderDataService.FindAllOrder()
+	userID, claims, err := callerUserID(ctx)
+	if err != nil {
+		return err
+	}
+	var orderAll []model.Order
+	if claims.Can(auth.PermOrderManage) {
+		orderAll, err = o.OrderDataService.FindAllOrder()
+	} else {
+		orderAll, err = o.OrderDataService.FindAllByUser(userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range orderAll {
+		order := &OrderInfo{}
+		if err := fillOrderInfo(&orderAll[i], order); err != nil {
+			return err
+		}
+		response.OrderInfo = append(response.OrderInfo, order)
+	}
+	return nil
+}
+
+// 按支付状态查询订单，供支付对账使用
+func (o *Order) GetOrdersByPayStatus(ctx context.Context, request *PayStatusFilter, response *AllOrder) error {
+	query := repository.PayStatusQuery{PayStatus: request.PayStatus, OrderIDs: request.OrderId}
+	if request.PaidFrom > 0 {
+		query.PaidFrom = time.Unix(request.PaidFrom, 0)
+	}
+	if request.PaidTo > 0 {
+		query.PaidTo = time.Unix(request.PaidTo, 0)
+	}
+	orderAll, err := o.OrderDataService.FindAllByPayStatus(query)
 	if err != nil {
 		return err
 	}
 
-	for _, v := range orderAll {
+	for i := range orderAll {
 		order := &OrderInfo{}
-		if err := common.SwapTo(v, order); err != nil {
+		if err := fillOrderInfo(&orderAll[i], order); err != nil {
 			return err
 		}
 		response.OrderInfo = append(response.OrderInfo, order)
@@ -52,15 +89,23 @@ func (o *Order) GetAllOrder(ctx context.Context, request *AllOrderRequest, respo
 	return nil
 }
 
-// 创建订单
+// 创建订单，下单用户取自令牌，忽略请求中的 user_id
 func (o *Order) CreateOrder(ctx context.Context, request *OrderInfo, response *OrderID) error {
+	userID, _, err := callerUserID(ctx)
+	if err != nil {
+		return err
+	}
 	orderAdd := &model.Order{}
 	if err := common.SwapTo(request, orderAdd); err != nil {
 		return err
 	}
-	orderID, err := o.OrderDataService.AddOrder(orderAdd)
+	orderAdd.UserID = userID
+	fillPrices(request, orderAdd)
+	// 收货地址只能从地址簿选择，忽略请求中的快照
+	orderAdd.ShippingAddress = model.ShippingAddress{AddressID: request.AddressId}
+	orderID, err := o.OrderDataService.AddOrder(ctx, orderAdd)
 	if err != nil {
-		return err
+		return toMicroError(err)
 	}
 	response.OrderId = orderID
 	return nil
@@ -99,9 +144,57 @@ func (o *Order) UpdateOrder(ctx context.Context, request *OrderInfo, response *R
 	if err := common.SwapTo(request, order); err != nil {
 		return err
 	}
+	fillPrices(request, order)
 	if err := o.OrderDataService.UpdateOrder(order); err != nil {
 		return err
 	}
 	response.Msg = "订单更新成功"
 	return nil
 }
+
+// fillPrices 未传 Money 字段的旧调用方按废弃的浮点字段以默认币种换算
+func fillPrices(request *OrderInfo, order *model.Order) {
+	if request.Amount == nil {
+		order.Price = money.FromFloat(request.Price, money.DefaultCurrency)
+	}
+	if request.OriginalAmount == nil {
+		order.OriginalPrice = money.FromFloat(request.OriginalPrice, money.DefaultCurrency)
+	}
+	if request.DiscountAmount == nil {
+		order.Discount = money.FromFloat(request.Discount, money.DefaultCurrency)
+	}
+	for i, detail := range request.OrderDetail {
+		if i < len(order.OrderDetail) && detail.UnitPrice == nil {
+			order.OrderDetail[i].ProductPrice = money.FromFloat(detail.ProductPrice, money.DefaultCurrency)
+		}
+	}
+}
+
+// fillOrderInfo 将订单转换为 OrderInfo，并填充汇率与废弃的浮点字段
+func fillOrderInfo(order *model.Order, info *OrderInfo) error {
+	if err := common.SwapTo(order, info); err != nil {
+		return err
+	}
+	for _, rate := range order.ExchangeRates {
+		info.ExchangeRates = append(info.ExchangeRates, &ExchangeRate{
+			From:   rate.From,
+			To:     rate.To,
+			Rate:   rate.Rate,
+			Source: rate.Source,
+			AsOf:   rate.AsOf.Unix(),
+		})
+	}
+	info.AddressId = order.ShippingAddress.AddressID
+	fillLegacyPrices(info)
+	return nil
+}
+
+// fillLegacyPrices 同时填充废弃的浮点字段，兼容未升级的调用方
+func fillLegacyPrices(info *OrderInfo) {
+	info.Price = money.FromProto(info.Amount).Float64()
+	info.OriginalPrice = money.FromProto(info.OriginalAmount).Float64()
+	info.Discount = money.FromProto(info.DiscountAmount).Float64()
+	for _, detail := range info.OrderDetail {
+		detail.ProductPrice = money.FromProto(detail.UnitPrice).Float64()
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"order/domain/model"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// Policy 订单服务的访问策略，未列入的端点一律拒绝。UpdateOrderPayStatus 与 GetOrdersByPayStatus
+// 由支付服务在回调与对账任务中以服务令牌调用，用户令牌（包括管理员）不能调用。
+// 只要求登录的查询与下单端点在处理器中再按令牌中的用户限定范围：查询用 authorizeOrder 校验是否为订单本人，
+// GetAllOrder 只返回本人订单，CreateOrder 的下单用户取自令牌
+var Policy = auth.Policy{
+	"Order.GetOrderByID": auth.PermAuthenticated,
+	"Order.GetAllOrder":  auth.PermAuthenticated,
+	"Order.CreateOrder":  auth.PermAuthenticated,
+
+	"Order.DeleteOrderByID":       auth.PermOrderManage,
+	"Order.UpdateOrder":           auth.PermOrderManage,
+	"Order.UpdateOrderShipStatus": auth.PermOrderManage,
+
+	"Order.UpdateOrderPayStatus": auth.PermServiceCall,
+	"Order.GetOrdersByPayStatus": auth.PermServiceCall,
+
+	// 隐私请求流程由用户服务以服务令牌调用
+	"Privacy.ExportUserData": auth.PermPrivacyProcess,
+	"Privacy.EraseUserData":  auth.PermPrivacyProcess,
+}
+
+// authorizeOrder 只允许订单本人、拥有订单管理权限的员工或内部服务（如支付服务查询应付金额）访问订单
+func authorizeOrder(ctx context.Context, order *model.Order) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return microerrors.Unauthorized(serviceID, "%s", auth.ErrUnauthenticated.Error())
+	}
+	if claims.IsService() || claims.Can(auth.PermOrderManage) {
+		return nil
+	}
+	if order.UserID > 0 && order.UserID == claims.UserID() {
+		return nil
+	}
+	slog.WarnContext(ctx, "拒绝访问", "audit", "access_denied", "target", "order", "order_id", order.ID,
+		"owner_id", order.UserID, "user_id", claims.UserID(), "session_id", claims.SessionID, "roles", claims.Roles)
+	return microerrors.Forbidden(serviceID, "%s", auth.ErrForbidden.Error())
+}
+
+// callerUserID 令牌中的用户ID，服务令牌或未登录时返回 401
+func callerUserID(ctx context.Context) (int64, *auth.Claims, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok || claims.UserID() <= 0 {
+		return 0, nil, microerrors.Unauthorized(serviceID, "%s", auth.ErrUnauthenticated.Error())
+	}
+	return claims.UserID(), claims, nil
+}
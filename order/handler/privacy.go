@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"order/domain/service"
+
+	"github.com/Ben1524/GoMall/common/proto/privacy"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// Privacy 隐私请求流程的订单步骤，只接受带 privacy:process 权限的服务令牌（见 Policy）；返回的订单ID供支付服务的步骤使用
+type Privacy struct {
+	PrivacyService service.IPrivacyService
+}
+
+// 导出用户的全部订单
+func (h *Privacy) ExportUserData(ctx context.Context, request *privacy.UserDataRequest, response *privacy.UserDataResponse) error {
+	if request.UserId <= 0 {
+		return microerrors.BadRequest(serviceID, "用户ID不合法")
+	}
+	orders, err := h.PrivacyService.ExportUserData(request.UserId)
+	if err != nil {
+		return err
+	}
+	response.OrderIds = service.OrderIDs(orders)
+	response.Data, err = json.Marshal(map[string]interface{}{"orders": orders})
+	return err
+}
+
+// 匿名化用户订单的收货地址
+func (h *Privacy) EraseUserData(ctx context.Context, request *privacy.UserDataRequest, response *privacy.UserDataResponse) (err error) {
+	if request.UserId <= 0 {
+		return microerrors.BadRequest(serviceID, "用户ID不合法")
+	}
+	response.Affected, response.OrderIds, err = h.PrivacyService.EraseUserData(request.UserId)
+	return err
+}
@@ -13,9 +13,14 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/microauth"
 	config "github.com/Ben1524/GoMall/common/config"
 	"github.com/Ben1524/GoMall/common/db"
+	"github.com/Ben1524/GoMall/common/exchange"
 	"github.com/Ben1524/GoMall/common/otel"
+	"github.com/Ben1524/GoMall/common/promotion"
+	"github.com/Ben1524/GoMall/common/proto/privacy"
 	"go-micro.dev/v5"
 	"go-micro.dev/v5/client"
 	"go-micro.dev/v5/registry"
@@ -26,6 +31,8 @@ import (
 	"golang.org/x/time/rate"
 
 	pb "order/proto/order"
+	productpb "order/proto/product"
+	userpb "order/proto/user"
 
 	// 限流器（Uber 令牌桶）
 	ratelimit "github.com/micro/plugins/v5/wrapper/ratelimiter/uber"
@@ -89,7 +96,11 @@ func main() {
 		os.Exit(1)
 	}
 	defer func() {
-		if err := mysqlDB.Close(); err != nil {
+		sqlDB, err := mysqlDB.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
 			slog.Warn("关闭MySQL连接失败", "error", err)
 		} else {
 			slog.Info("MySQL连接已关闭")
@@ -103,7 +114,30 @@ func main() {
 		panic(err)
 	}
 
-	orderService := srv.NewOrderDataService(orderRepository)
+	// 促销规则与购物车共用，订单创建时核销
+	promotionService := promotion.NewService(mysqlDB)
+	if err := promotionService.InitTable(); err != nil {
+		slog.Error("init promotion table error")
+		panic(err)
+	}
+
+	// 明细币种与订单结算币种不同时按汇率换算
+	rates, err := exchange.New(cfg.ExchangeRate)
+	if err != nil {
+		slog.Error("初始化汇率来源失败", "error", err)
+		panic(err)
+	}
+	// 校验网关转发的访问令牌，与用户服务共享会话存储
+	sessionStore, err := auth.NewStore(cfg)
+	if err != nil {
+		slog.Error("初始化会话存储失败", "error", err)
+		os.Exit(1)
+	}
+	tokenManager, err := auth.NewManager(cfg.JWT, sessionStore)
+	if err != nil {
+		slog.Error("初始化令牌校验失败", "error", err)
+		os.Exit(1)
+	}
 
 	consulRegistry := consul.NewConsulRegistry(registry.Addrs("127.0.0.1:8500"))
 
@@ -124,6 +158,7 @@ func main() {
 	handlerWrappers := []server.HandlerWrapper{
 		ratelimit.NewHandlerWrapper(qps, ratelimit3.WithSlack(3*qps)),
 		opentelemetry.NewHandlerWrapper(),
+		microauth.NewHandlerWrapper(tokenManager, cfg.Server.ServiceName, handler.Policy),
 	}
 	if cfg.Metrics.Enabled {
 		handlerWrappers = append([]server.HandlerWrapper{promMetrics.ServerWrapper()}, handlerWrappers...)
@@ -160,11 +195,23 @@ func main() {
 
 	service := micro.NewService(serviceOptions...)
 	service.Init()
+
+	// 下单时从用户服务的地址簿快照收货地址
+	userService := userpb.NewUserService("go.micro.service.user", service.Client())
+	productService := productpb.NewProductService("go.micro.service.product", service.Client())
+	orderService := srv.NewOrderDataService(orderRepository, promotionService, rates, srv.NewAddressFinder(userService),
+		srv.NewProductFinder(productService))
 	if err := pb.RegisterOrderHandler(service.Server(), handler.NewOrderHandler(orderService)); err != nil {
 		slog.Error("注册Cart处理器失败", "error", err)
 		os.Exit(1)
 	}
 
+	// 隐私请求流程的订单步骤，只供用户服务以服务令牌调用
+	if err := privacy.RegisterPrivacyHandler(service.Server(), &handler.Privacy{PrivacyService: srv.NewPrivacyService(orderRepository)}); err != nil {
+		slog.Error("注册Privacy处理器失败", "error", err)
+		os.Exit(1)
+	}
+
 	if err := service.Run(); err != nil {
 		slog.Error("服务运行失败", "error", err)
 	}
@@ -6,4 +6,5 @@ type Payment struct {
 	PaymentSid    string `json:"payment_sid"`    //支付 SID
 	PaymentStatus bool   `json:"payment_status"` //支付通道状态 true 为生产
 	PaymentImage  string `json:"payment_image"`  //支付图片或logo
+	Provider      string `json:"provider"`       //支付渠道，如 paypal、mock，为空视为 paypal
 }
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 对账运行状态
+const (
+	ReconciliationRunning   = "running"
+	ReconciliationCompleted = "completed"
+	ReconciliationFailed    = "failed"
+)
+
+// 差异类型
+const (
+	DiscrepancyMissingInSettlement = "missing_in_settlement" // 本地已扣款，渠道对账单中没有
+	DiscrepancyMissingInLedger     = "missing_in_ledger"     // 渠道对账单中有扣款，本地没有对应交易
+	DiscrepancyAmountMismatch      = "amount_mismatch"       // 本地与渠道扣款金额不一致
+	DiscrepancyStatusMismatch      = "status_mismatch"       // 渠道已结算，本地交易未处于已扣款状态
+	DiscrepancyPaidWithoutCapture  = "paid_without_capture"  // 订单已支付，但没有已扣款的交易
+	DiscrepancyCaptureWithoutOrder = "capture_without_order" // 已扣款的交易，订单不存在或未支付
+)
+
+// ReconciliationRun 一次对账，Day 为对账日期（YYYY-MM-DD）
+type ReconciliationRun struct {
+	ID            int64      `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	Day           string     `gorm:"not_null;size:10;index" json:"day"`
+	Status        string     `gorm:"not_null;index" json:"status"`
+	Channels      int64      `json:"channels"`      // 已对账的支付通道数
+	Skipped       int64      `json:"skipped"`       // 不支持对账单而跳过的支付通道数
+	Matched       int64      `json:"matched"`       // 与对账单一致的扣款数
+	Discrepancies int64      `json:"discrepancies"` // 差异数
+	Error         string     `json:"error"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+}
+
+// Discrepancy 对账差异明细
+type Discrepancy struct {
+	ID             int64       `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	RunID          int64       `gorm:"not_null;index" json:"run_id"`
+	Type           string      `gorm:"not_null;index" json:"type"`
+	PaymentID      int64       `json:"payment_id"`
+	OrderID        int64       `gorm:"index" json:"order_id"`
+	TransactionID  int64       `json:"transaction_id"`
+	CaptureRef     string      `json:"capture_ref"`
+	LedgerAmount   money.Money `gorm:"embedded;embeddedPrefix:ledger_" json:"ledger_amount"`
+	ProviderAmount money.Money `gorm:"embedded;embeddedPrefix:provider_" json:"provider_amount"`
+	Detail         string      `json:"detail"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 退款状态
+const (
+	RefundPending   = "pending"   // 已受理，等待支付渠道处理
+	RefundSucceeded = "succeeded" // 退款成功
+	RefundFailed    = "failed"    // 退款失败，预占的可退金额已释放
+)
+
+// Refund 针对某笔已扣款交易的退款，RefundID 由调用方生成，用于幂等
+type Refund struct {
+	ID            int64       `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	RefundID      string      `gorm:"not_null;size:64;uniqueIndex" json:"refund_id"`
+	OrderID       int64       `gorm:"not_null;index" json:"order_id"`
+	TransactionID int64       `gorm:"not_null;index" json:"transaction_id"`
+	Amount        money.Money `gorm:"embedded;embeddedPrefix:refund_" json:"amount"`
+	Reason        string      `json:"reason"`
+	Status        string      `gorm:"not_null;index" json:"status"`
+	ProviderRef   string      `gorm:"size:64;index" json:"provider_ref"` // 支付渠道侧的退款号
+	FailureReason string      `json:"failure_reason"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// RoutingRule 支付通道路由规则。未指定支付通道的交易按 Priority 从小到大匹配第一条启用的规则，
+// 条件为空表示不限；金额范围以 Currency 的最小单位表示，因此设置金额范围时必须指定币种。
+// 命中后依次尝试 PaymentID 与 FallbackPaymentIDs，不健康的通道排在最后。
+type RoutingRule struct {
+	ID                 int64     `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	Name               string    `gorm:"size:64" json:"name"`
+	Priority           int64     `gorm:"not_null;index" json:"priority"`
+	Enabled            bool      `gorm:"not_null" json:"enabled"`
+	Currency           string    `gorm:"size:3" json:"currency"`                      // 交易币种，为空不限
+	MinAmount          int64     `json:"min_amount"`                                  // 最小金额（含），0 不限
+	MaxAmount          int64     `json:"max_amount"`                                  // 最大金额（含），0 不限
+	Countries          []string  `gorm:"serializer:json" json:"countries"`            // 用户所在国家（ISO 3166-1 alpha-2），为空不限
+	PaymentID          int64     `gorm:"not_null" json:"payment_id"`                  // 首选支付通道
+	FallbackPaymentIDs []int64   `gorm:"serializer:json" json:"fallback_payment_ids"` // 首选通道失败时依次尝试的备用通道
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
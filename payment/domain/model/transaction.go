@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 交易状态
+const (
+	TransactionPending    = "pending"    // 已创建，等待支付
+	TransactionAuthorized = "authorized" // 已授权，等待扣款
+	TransactionCaptured   = "captured"   // 已扣款
+	TransactionFailed     = "failed"     // 支付失败
+	TransactionRefunded   = "refunded"   // 已全额退款
+)
+
+// Transaction 订单的一笔支付交易，Payment 为所使用的支付通道
+type Transaction struct {
+	ID             int64       `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	OrderID        int64       `gorm:"not_null;index" json:"order_id"`
+	UserID         int64       `gorm:"index" json:"user_id"`       // 下单用户，创建时取自订单
+	PaymentID      int64       `gorm:"not_null" json:"payment_id"` // 未指定时由路由规则选择，失败切换备用通道后为最终使用的通道
+	Country        string      `gorm:"size:2" json:"country"`      // 用户所在国家，参与通道路由
+	Amount         money.Money `gorm:"embedded;embeddedPrefix:charge_" json:"amount"`
+	RefundedAmount money.Money `gorm:"embedded;embeddedPrefix:refunded_total_" json:"refunded_amount"` // 累计退款金额，含处理中的退款
+	ProviderRef    string      `gorm:"index" json:"provider_ref"`
+	ApprovalURL    string      `json:"approval_url"` // 用户跳转付款的地址
+	CaptureRef     string      `gorm:"index" json:"capture_ref"`
+	Status         string      `gorm:"not_null;index" json:"status"`
+	FailureReason  string      `json:"failure_reason"`
+	CapturedAt     *time.Time  `json:"captured_at"`
+	DisputedAt     *time.Time  `json:"disputed_at"` // 买家发起争议的时间，由渠道回调写入
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// 回调事件处理结果
+const (
+	WebhookProcessed = "processed" // 已据此更新交易
+	WebhookIgnored   = "ignored"   // 校验通过但无需处理，如找不到关联交易
+)
+
+// WebhookEvent 已处理的支付渠道回调，Provider + EventID 唯一，用于拒绝重放
+type WebhookEvent struct {
+	ID            int64     `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	Provider      string    `gorm:"not_null;size:32;uniqueIndex:idx_webhook_provider_event" json:"provider"`
+	EventID       string    `gorm:"not_null;size:128;uniqueIndex:idx_webhook_provider_event" json:"event_id"`
+	EventType     string    `json:"event_type"`
+	ProviderRef   string    `json:"provider_ref"`
+	CaptureRef    string    `json:"capture_ref"`
+	RefundRef     string    `json:"refund_ref"` // 退款事件关联的渠道退款号
+	Status        string    `json:"status"`
+	TransactionID int64     `gorm:"index" json:"transaction_id"`
+	Result        string    `json:"result"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -12,6 +12,7 @@ type IPaymentRepository interface {
 	DeletePaymentByID(int64) error
 	UpdatePayment(*model.Payment) error
 	FindAll() ([]model.Payment, error)
+	UpdatePaymentSid(int64, string) error
 }
 
 // 创建paymentRepository
@@ -25,7 +26,7 @@ type PaymentRepository struct {
 
 // 初始化表
 func (u *PaymentRepository) InitTable() error {
-	return u.mysqlDb.AutoMigrate(&model.Payment{}).Error
+	return u.mysqlDb.AutoMigrate(&model.Payment{})
 }
 
 // 根据ID查找Payment信息
@@ -36,7 +37,10 @@ func (u *PaymentRepository) FindPaymentByID(paymentID int64) (payment *model.Pay
 
 // 创建Payment信息
 func (u *PaymentRepository) CreatePayment(payment *model.Payment) (int64, error) {
-	return payment.ID, u.mysqlDb.Create(payment).Error
+	if err := u.mysqlDb.Create(payment).Error; err != nil {
+		return 0, err
+	}
+	return payment.ID, nil
 }
 
 // 根据ID删除Payment信息
@@ -44,12 +48,21 @@ func (u *PaymentRepository) DeletePaymentByID(paymentID int64) error {
 	return u.mysqlDb.Where("id = ?", paymentID).Delete(&model.Payment{}).Error
 }
 
-// 更新Payment信息
+// 更新Payment信息，零值字段同样写入（如切换回沙箱环境）；PaymentSid 为空时保留原密钥
 func (u *PaymentRepository) UpdatePayment(payment *model.Payment) error {
-	return u.mysqlDb.Model(payment).Update(payment).Error
+	omit := []string{"id"}
+	if payment.PaymentSid == "" {
+		omit = append(omit, "payment_sid")
+	}
+	return u.mysqlDb.Model(payment).Select("*").Omit(omit...).Updates(payment).Error
 }
 
 // 获取结果集
 func (u *PaymentRepository) FindAll() (paymentAll []model.Payment, err error) {
 	return paymentAll, u.mysqlDb.Find(&paymentAll).Error
 }
+
+// 只更新渠道密钥密文
+func (u *PaymentRepository) UpdatePaymentSid(paymentID int64, paymentSid string) error {
+	return u.mysqlDb.Model(&model.Payment{ID: paymentID}).UpdateColumn("payment_sid", paymentSid).Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"payment/domain/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IReconciliationRepository interface {
+	InitTable() error
+	CreateRun(*model.ReconciliationRun) (int64, error)
+	FinishRun(*model.ReconciliationRun, []model.Discrepancy) error
+	FindRunByID(int64) (*model.ReconciliationRun, error)
+	FindRuns(int) ([]model.ReconciliationRun, error)
+	FindDiscrepancies(int64) ([]model.Discrepancy, error)
+}
+
+// 创建reconciliationRepository
+func NewReconciliationRepository(db *gorm.DB) IReconciliationRepository {
+	return &ReconciliationRepository{mysqlDb: db}
+}
+
+type ReconciliationRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (u *ReconciliationRepository) InitTable() error {
+	return u.mysqlDb.AutoMigrate(&model.ReconciliationRun{}, &model.Discrepancy{})
+}
+
+// 创建对账记录
+func (u *ReconciliationRepository) CreateRun(run *model.ReconciliationRun) (int64, error) {
+	return run.ID, u.mysqlDb.Create(run).Error
+}
+
+// FinishRun 在同一事务内保存差异明细并更新对账结果
+func (u *ReconciliationRepository) FinishRun(run *model.ReconciliationRun, discrepancies []model.Discrepancy) error {
+	now := time.Now()
+	run.FinishedAt = &now
+	return u.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		for i := range discrepancies {
+			discrepancies[i].RunID = run.ID
+		}
+		if len(discrepancies) > 0 {
+			if err := tx.CreateInBatches(discrepancies, 500).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(run).Select("status", "channels", "skipped", "matched", "discrepancies", "error", "finished_at").
+			Updates(run).Error
+	})
+}
+
+// 根据ID查找对账记录
+func (u *ReconciliationRepository) FindRunByID(runID int64) (run *model.ReconciliationRun, err error) {
+	run = &model.ReconciliationRun{}
+	return run, u.mysqlDb.First(run, runID).Error
+}
+
+// 查询最近 limit 次对账，按时间倒序
+func (u *ReconciliationRepository) FindRuns(limit int) (runAll []model.ReconciliationRun, err error) {
+	return runAll, u.mysqlDb.Order("id DESC").Limit(limit).Find(&runAll).Error
+}
+
+// 查询一次对账的差异明细
+func (u *ReconciliationRepository) FindDiscrepancies(runID int64) (discrepancyAll []model.Discrepancy, err error) {
+	return discrepancyAll, u.mysqlDb.Where("run_id = ?", runID).Order("id").Find(&discrepancyAll).Error
+}
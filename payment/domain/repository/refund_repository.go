@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"errors"
+	"payment/domain/model"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrRefundExceeded 累计退款金额将超过交易扣款金额
+var ErrRefundExceeded = errors.New("退款金额超过可退金额")
+
+type IRefundRepository interface {
+	InitTable() error
+	FindRefundByRefundID(string) (*model.Refund, error)
+	FindRefundByProviderRef(string) (*model.Refund, error)
+	FindAllByOrder(int64) ([]model.Refund, error)
+	FindAllByOrders([]int64) ([]model.Refund, error)
+	ClearReasons([]int64) (int64, error)
+	ReserveRefund(*model.Refund) error
+	SetProviderRef(int64, string) error
+	CompleteRefund(int64, string) (*model.Transaction, error)
+	FailRefund(int64, string) (*model.Transaction, error)
+}
+
+// 创建refundRepository
+func NewRefundRepository(db *gorm.DB) IRefundRepository {
+	return &RefundRepository{mysqlDb: db}
+}
+
+type RefundRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (u *RefundRepository) InitTable() error {
+	if err := u.mysqlDb.AutoMigrate(&model.Refund{}); err != nil {
+		return err
+	}
+	if err := money.MigrateFloatColumnByCurrency(u.mysqlDb, &model.Refund{}, "amount", "currency", "refund_"); err != nil {
+		return err
+	}
+	return dropLegacyColumns(u.mysqlDb, &model.Refund{}, "currency")
+}
+
+// 根据调用方提供的退款号查找
+func (u *RefundRepository) FindRefundByRefundID(refundID string) (refund *model.Refund, err error) {
+	refund = &model.Refund{}
+	return refund, u.mysqlDb.Where("refund_id = ?", refundID).First(refund).Error
+}
+
+// 根据支付渠道侧的退款号查找
+func (u *RefundRepository) FindRefundByProviderRef(providerRef string) (refund *model.Refund, err error) {
+	refund = &model.Refund{}
+	return refund, u.mysqlDb.Where("provider_ref = ?", providerRef).First(refund).Error
+}
+
+// 查询订单的所有退款，按创建顺序
+func (u *RefundRepository) FindAllByOrder(orderID int64) (refundAll []model.Refund, err error) {
+	return refundAll, u.mysqlDb.Where("order_id = ?", orderID).Order("id").Find(&refundAll).Error
+}
+
+// 查询多个订单的所有退款
+func (u *RefundRepository) FindAllByOrders(orderIDs []int64) (refundAll []model.Refund, err error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+	return refundAll, u.mysqlDb.Where("order_id IN ?", orderIDs).Order("id").Find(&refundAll).Error
+}
+
+// 清除多个订单退款中用户填写的退款原因，返回更新的退款数
+func (u *RefundRepository) ClearReasons(orderIDs []int64) (int64, error) {
+	if len(orderIDs) == 0 {
+		return 0, nil
+	}
+	db := u.mysqlDb.Model(&model.Refund{}).Where("order_id IN ? AND reason <> ?", orderIDs, "").UpdateColumn("reason", "")
+	return db.RowsAffected, db.Error
+}
+
+// ReserveRefund 锁定交易，校验可退金额后创建 pending 退款并预占退款金额，
+// 保证并发退款的累计金额不超过扣款金额
+func (u *RefundRepository) ReserveRefund(refund *model.Refund) error {
+	return u.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		transaction := &model.Transaction{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(transaction, refund.TransactionID).Error; err != nil {
+			return err
+		}
+		if transaction.Status != model.TransactionCaptured {
+			return ErrStatusConflict
+		}
+		refunded, err := transaction.RefundedAmount.Add(refund.Amount)
+		if err != nil {
+			return err
+		}
+		if cmp, err := refunded.Cmp(transaction.Amount); err != nil {
+			return err
+		} else if cmp > 0 {
+			return ErrRefundExceeded
+		}
+		if err := tx.Create(refund).Error; err != nil {
+			return err
+		}
+		return tx.Model(transaction).UpdateColumns(map[string]interface{}{
+			"refunded_total_amount":   gorm.Expr("refunded_total_amount + ?", refund.Amount.Amount),
+			"refunded_total_currency": refunded.Currency,
+		}).Error
+	})
+}
+
+// SetProviderRef 记录渠道已受理、异步处理中的退款的渠道退款号，退款已处于终态时返回 ErrStatusConflict
+func (u *RefundRepository) SetProviderRef(refundID int64, providerRef string) error {
+	db := u.mysqlDb.Model(&model.Refund{ID: refundID}).Where("status = ?", model.RefundPending).Update("provider_ref", providerRef)
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return ErrStatusConflict
+	}
+	return nil
+}
+
+// CompleteRefund 退款成功，已成功的退款累计达到扣款金额时交易置为 refunded，返回更新后的交易。
+// 交易的累计退款含处理中的退款，不能据此判断，否则处理中的退款失败后交易仍为 refunded
+func (u *RefundRepository) CompleteRefund(refundID int64, providerRef string) (*model.Transaction, error) {
+	transaction := &model.Transaction{}
+	err := u.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		refund, err := finishRefund(tx, refundID, map[string]interface{}{
+			"status":       model.RefundSucceeded,
+			"provider_ref": providerRef,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(transaction, refund.TransactionID).Error; err != nil {
+			return err
+		}
+		var succeeded int64
+		if err := tx.Model(&model.Refund{}).Where("transaction_id = ? AND status = ?", refund.TransactionID, model.RefundSucceeded).
+			Select("COALESCE(SUM(refund_amount), 0)").Scan(&succeeded).Error; err != nil {
+			return err
+		}
+		if succeeded >= transaction.Amount.Amount {
+			transaction.Status = model.TransactionRefunded
+			return tx.Model(transaction).Update("status", model.TransactionRefunded).Error
+		}
+		return nil
+	})
+	return transaction, err
+}
+
+// FailRefund 退款失败，释放预占的退款金额，返回更新后的交易
+func (u *RefundRepository) FailRefund(refundID int64, reason string) (*model.Transaction, error) {
+	transaction := &model.Transaction{}
+	err := u.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		refund, err := finishRefund(tx, refundID, map[string]interface{}{
+			"status":         model.RefundFailed,
+			"failure_reason": reason,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Transaction{ID: refund.TransactionID}).
+			UpdateColumn("refunded_total_amount", gorm.Expr("refunded_total_amount - ?", refund.Amount.Amount)).Error; err != nil {
+			return err
+		}
+		return tx.First(transaction, refund.TransactionID).Error
+	})
+	return transaction, err
+}
+
+// finishRefund 将 pending 退款更新为终态，已处于终态时返回 ErrStatusConflict
+func finishRefund(tx *gorm.DB, refundID int64, values map[string]interface{}) (*model.Refund, error) {
+	db := tx.Model(&model.Refund{ID: refundID}).Where("status = ?", model.RefundPending).Updates(values)
+	if db.Error != nil {
+		return nil, db.Error
+	}
+	if db.RowsAffected == 0 {
+		return nil, ErrStatusConflict
+	}
+	refund := &model.Refund{}
+	return refund, tx.First(refund, refundID).Error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"payment/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type IRoutingRuleRepository interface {
+	InitTable() error
+	CreateRule(*model.RoutingRule) (int64, error)
+	UpdateRule(*model.RoutingRule) error
+	DeleteRuleByID(int64) error
+	FindRuleByID(int64) (*model.RoutingRule, error)
+	FindAll() ([]model.RoutingRule, error)
+}
+
+// 创建routingRuleRepository
+func NewRoutingRuleRepository(db *gorm.DB) IRoutingRuleRepository {
+	return &RoutingRuleRepository{mysqlDb: db}
+}
+
+type RoutingRuleRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (u *RoutingRuleRepository) InitTable() error {
+	return u.mysqlDb.AutoMigrate(&model.RoutingRule{})
+}
+
+// 创建路由规则
+func (u *RoutingRuleRepository) CreateRule(rule *model.RoutingRule) (int64, error) {
+	return rule.ID, u.mysqlDb.Create(rule).Error
+}
+
+// 更新路由规则，零值字段（如停用、清空条件）同样写入
+func (u *RoutingRuleRepository) UpdateRule(rule *model.RoutingRule) error {
+	return u.mysqlDb.Model(rule).Select("*").Omit("created_at").Updates(rule).Error
+}
+
+// 根据ID删除路由规则
+func (u *RoutingRuleRepository) DeleteRuleByID(ruleID int64) error {
+	return u.mysqlDb.Where("id = ?", ruleID).Delete(&model.RoutingRule{}).Error
+}
+
+// 根据ID查找路由规则
+func (u *RoutingRuleRepository) FindRuleByID(ruleID int64) (rule *model.RoutingRule, err error) {
+	rule = &model.RoutingRule{}
+	return rule, u.mysqlDb.First(rule, ruleID).Error
+}
+
+// 获取全部路由规则，按匹配顺序排列
+func (u *RoutingRuleRepository) FindAll() (ruleAll []model.RoutingRule, err error) {
+	return ruleAll, u.mysqlDb.Order("priority, id").Find(&ruleAll).Error
+}
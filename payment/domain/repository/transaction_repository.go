@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"payment/domain/model"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+)
+
+// ErrStatusConflict 条件更新未命中，即交易当前状态不允许本次流转
+var ErrStatusConflict = errors.New("交易状态不允许该操作")
+
+type ITransactionRepository interface {
+	InitTable() error
+	FindTransactionByID(int64) (*model.Transaction, error)
+	CreateTransaction(*model.Transaction) (int64, error)
+	FindAllByOrder(int64) ([]model.Transaction, error)
+	FindTransactionByRef(string, string) (*model.Transaction, error)
+	UpdateStatus(int64, []string, map[string]interface{}) error
+
+	FindCapturedBetween(time.Time, time.Time) ([]model.Transaction, error)
+	FindAllByOrders([]int64) ([]model.Transaction, error)
+	ClearApprovalURLs([]int64) (int64, error)
+}
+
+// 创建transactionRepository
+func NewTransactionRepository(db *gorm.DB) ITransactionRepository {
+	return &TransactionRepository{mysqlDb: db}
+}
+
+type TransactionRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (u *TransactionRepository) InitTable() error {
+	if err := u.mysqlDb.AutoMigrate(&model.Transaction{}); err != nil {
+		return err
+	}
+	if err := money.MigrateFloatColumnByCurrency(u.mysqlDb, &model.Transaction{}, "amount", "currency", "charge_"); err != nil {
+		return err
+	}
+	if err := money.MigrateFloatColumnByCurrency(u.mysqlDb, &model.Transaction{}, "refunded_amount", "currency", "refunded_total_"); err != nil {
+		return err
+	}
+	return dropLegacyColumns(u.mysqlDb, &model.Transaction{}, "currency")
+}
+
+// dropLegacyColumns 删除已迁移完成的旧列，如多个旧金额列共用的币种列；旧列为 NOT NULL 且不再写入，保留会导致插入失败
+func dropLegacyColumns(db *gorm.DB, model interface{}, columns ...string) error {
+	for _, column := range columns {
+		if !db.Migrator().HasColumn(model, column) {
+			continue
+		}
+		if err := db.Migrator().DropColumn(model, column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 根据ID查找交易
+func (u *TransactionRepository) FindTransactionByID(transactionID int64) (transaction *model.Transaction, err error) {
+	transaction = &model.Transaction{}
+	return transaction, u.mysqlDb.First(transaction, transactionID).Error
+}
+
+// 创建交易
+func (u *TransactionRepository) CreateTransaction(transaction *model.Transaction) (int64, error) {
+	err := u.mysqlDb.Create(transaction).Error
+	return transaction.ID, err
+}
+
+// 查询订单的所有交易，按创建顺序
+func (u *TransactionRepository) FindAllByOrder(orderID int64) (transactionAll []model.Transaction, err error) {
+	return transactionAll, u.mysqlDb.Where("order_id = ?", orderID).Order("id").Find(&transactionAll).Error
+}
+
+// 根据渠道支付单号查找交易，providerRef 为空时按扣款号查找
+func (u *TransactionRepository) FindTransactionByRef(providerRef, captureRef string) (transaction *model.Transaction, err error) {
+	transaction = &model.Transaction{}
+	if providerRef != "" {
+		return transaction, u.mysqlDb.Where("provider_ref = ?", providerRef).First(transaction).Error
+	}
+	return transaction, u.mysqlDb.Where("capture_ref = ?", captureRef).First(transaction).Error
+}
+
+// 仅当交易处于 from 中的状态时更新，避免并发回调重复流转
+func (u *TransactionRepository) UpdateStatus(transactionID int64, from []string, values map[string]interface{}) error {
+	db := u.mysqlDb.Model(&model.Transaction{ID: transactionID}).Where("status IN ?", from).Updates(values)
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return ErrStatusConflict
+	}
+	return nil
+}
+
+// 查找扣款时间在 [from, to) 内的交易，含之后已退款的交易
+func (u *TransactionRepository) FindCapturedBetween(from, to time.Time) (transactionAll []model.Transaction, err error) {
+	return transactionAll, u.mysqlDb.Where("captured_at >= ? AND captured_at < ?", from, to).
+		Order("id").Find(&transactionAll).Error
+}
+
+// 查询多个订单的所有交易
+func (u *TransactionRepository) FindAllByOrders(orderIDs []int64) (transactionAll []model.Transaction, err error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+	return transactionAll, u.mysqlDb.Where("order_id IN ?", orderIDs).Order("id").Find(&transactionAll).Error
+}
+
+// 清除多个订单交易的付款跳转地址，返回更新的交易数
+func (u *TransactionRepository) ClearApprovalURLs(orderIDs []int64) (int64, error) {
+	if len(orderIDs) == 0 {
+		return 0, nil
+	}
+	db := u.mysqlDb.Model(&model.Transaction{}).Where("order_id IN ? AND approval_url <> ?", orderIDs, "").
+		UpdateColumn("approval_url", "")
+	return db.RowsAffected, db.Error
+}
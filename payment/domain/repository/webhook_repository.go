@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"errors"
+	"payment/domain/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrDuplicateEvent 同一渠道的事件ID已处理过
+var ErrDuplicateEvent = errors.New("回调事件已处理")
+
+type IWebhookRepository interface {
+	InitTable() error
+	ExistsEvent(string, string) (bool, error)
+	CreateEvent(*model.WebhookEvent) error
+}
+
+// 创建webhookRepository
+func NewWebhookRepository(db *gorm.DB) IWebhookRepository {
+	return &WebhookRepository{mysqlDb: db}
+}
+
+type WebhookRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (u *WebhookRepository) InitTable() error {
+	return u.mysqlDb.AutoMigrate(&model.WebhookEvent{})
+}
+
+// 事件是否已处理
+func (u *WebhookRepository) ExistsEvent(provider, eventID string) (bool, error) {
+	var count int64
+	err := u.mysqlDb.Model(&model.WebhookEvent{}).
+		Where("provider = ? AND event_id = ?", provider, eventID).Count(&count).Error
+	return count > 0, err
+}
+
+// 记录已处理的事件，并发重复投递时唯一索引冲突，返回 ErrDuplicateEvent
+func (u *WebhookRepository) CreateEvent(event *model.WebhookEvent) error {
+	err := u.mysqlDb.Create(event).Error
+	if err == nil {
+		return nil
+	}
+	if exists, existsErr := u.ExistsEvent(event.Provider, event.EventID); existsErr == nil && exists {
+		return ErrDuplicateEvent
+	}
+	return err
+}
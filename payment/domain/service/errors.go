@@ -0,0 +1,45 @@
+package service
+
+import "net/http"
+
+// PaymentError 支付业务错误，Code 与 HTTP 状态码保持一致，
+// handler 据此转换为 go-micro 错误，网关再映射为对应的 4xx 响应。
+type PaymentError struct {
+	Code int32
+	Msg  string
+}
+
+func (e *PaymentError) Error() string {
+	return e.Msg
+}
+
+var (
+	ErrInvalidOrder           = &PaymentError{Code: http.StatusBadRequest, Msg: "订单ID不合法"}
+	ErrInvalidAmount          = &PaymentError{Code: http.StatusBadRequest, Msg: "交易金额必须大于0"}
+	ErrInvalidCurrency        = &PaymentError{Code: http.StatusBadRequest, Msg: "币种必须为3位ISO 4217代码"}
+	ErrPaymentNotFound        = &PaymentError{Code: http.StatusNotFound, Msg: "支付通道不存在"}
+	ErrTransactionNotFound    = &PaymentError{Code: http.StatusNotFound, Msg: "交易不存在"}
+	ErrTransactionTransition  = &PaymentError{Code: http.StatusConflict, Msg: "交易当前状态不允许该操作"}
+	ErrUnknownProvider        = &PaymentError{Code: http.StatusBadRequest, Msg: "支付通道配置的渠道不受支持"}
+	ErrProviderRefMissing     = &PaymentError{Code: http.StatusConflict, Msg: "交易尚未在支付渠道创建"}
+	ErrRefundIDRequired       = &PaymentError{Code: http.StatusBadRequest, Msg: "退款号不能为空"}
+	ErrRefundIDConflict       = &PaymentError{Code: http.StatusConflict, Msg: "退款号已用于其他退款请求"}
+	ErrRefundOrderMismatch    = &PaymentError{Code: http.StatusBadRequest, Msg: "交易不属于该订单"}
+	ErrRefundExceeded         = &PaymentError{Code: http.StatusConflict, Msg: "退款金额超过可退金额"}
+	ErrSettlementCurrency     = &PaymentError{Code: http.StatusBadRequest, Msg: "支付币种与订单结算币种不一致"}
+	ErrAmountMismatch         = &PaymentError{Code: http.StatusBadRequest, Msg: "支付金额与订单应付金额不一致"}
+	ErrRefundCurrency         = &PaymentError{Code: http.StatusBadRequest, Msg: "退款币种与交易币种不一致"}
+	ErrWebhookSignature       = &PaymentError{Code: http.StatusUnauthorized, Msg: "回调签名校验失败"}
+	ErrWebhookInvalid         = &PaymentError{Code: http.StatusBadRequest, Msg: "回调缺少事件ID"}
+	ErrWebhookReplayed        = &PaymentError{Code: http.StatusConflict, Msg: "回调事件已处理"}
+	ErrReconciliationNotFound = &PaymentError{Code: http.StatusNotFound, Msg: "对账记录不存在"}
+	ErrReconciliationDay      = &PaymentError{Code: http.StatusBadRequest, Msg: "对账日期格式应为 YYYY-MM-DD"}
+	ErrInvalidCountry         = &PaymentError{Code: http.StatusBadRequest, Msg: "国家必须为2位ISO 3166-1代码"}
+	ErrRoutingRuleNotFound    = &PaymentError{Code: http.StatusNotFound, Msg: "路由规则不存在"}
+	ErrNoRoute                = &PaymentError{Code: http.StatusUnprocessableEntity, Msg: "没有匹配的支付通道路由规则，请指定支付通道"}
+)
+
+// providerError 支付渠道调用失败，对外返回 502
+func providerError(err error) error {
+	return &PaymentError{Code: http.StatusBadGateway, Msg: "支付渠道调用失败: " + err.Error()}
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"payment/domain/model"
+	"payment/domain/repository"
+	"payment/provider"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 以下为服务测试使用的内存实现，只模拟服务依赖的仓储语义
+
+type fakePayments struct {
+	repository.IPaymentRepository
+	payments map[int64]*model.Payment
+}
+
+func (f *fakePayments) FindPaymentByID(paymentID int64) (*model.Payment, error) {
+	if payment, ok := f.payments[paymentID]; ok {
+		return payment, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakePayments) FindAll() ([]model.Payment, error) {
+	var paymentAll []model.Payment
+	for _, payment := range f.payments {
+		paymentAll = append(paymentAll, *payment)
+	}
+	slices.SortFunc(paymentAll, func(a, b model.Payment) int { return cmp.Compare(a.ID, b.ID) })
+	return paymentAll, nil
+}
+
+type fakeTransactions struct {
+	repository.ITransactionRepository
+	transactions map[int64]*model.Transaction
+}
+
+func newFakeTransactions(transactions ...model.Transaction) *fakeTransactions {
+	f := &fakeTransactions{transactions: map[int64]*model.Transaction{}}
+	for i := range transactions {
+		f.transactions[transactions[i].ID] = &transactions[i]
+	}
+	return f
+}
+
+func (f *fakeTransactions) FindTransactionByID(transactionID int64) (*model.Transaction, error) {
+	transaction, ok := f.transactions[transactionID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *transaction
+	return &copied, nil
+}
+
+func (f *fakeTransactions) CreateTransaction(transaction *model.Transaction) (int64, error) {
+	transaction.ID = int64(len(f.transactions) + 1)
+	copied := *transaction
+	f.transactions[transaction.ID] = &copied
+	return transaction.ID, nil
+}
+
+func (f *fakeTransactions) FindAllByOrder(orderID int64) ([]model.Transaction, error) {
+	var transactionAll []model.Transaction
+	for _, transaction := range f.transactions {
+		if transaction.OrderID == orderID {
+			transactionAll = append(transactionAll, *transaction)
+		}
+	}
+	slices.SortFunc(transactionAll, func(a, b model.Transaction) int { return cmp.Compare(a.ID, b.ID) })
+	return transactionAll, nil
+}
+
+func (f *fakeTransactions) UpdateStatus(transactionID int64, from []string, values map[string]interface{}) error {
+	transaction, ok := f.transactions[transactionID]
+	if !ok || !slices.Contains(from, transaction.Status) {
+		return repository.ErrStatusConflict
+	}
+	for column, value := range values {
+		switch column {
+		case "status":
+			transaction.Status = value.(string)
+		case "payment_id":
+			transaction.PaymentID = value.(int64)
+		case "provider_ref":
+			transaction.ProviderRef = value.(string)
+		case "approval_url":
+			transaction.ApprovalURL = value.(string)
+		case "capture_ref":
+			transaction.CaptureRef = value.(string)
+		case "failure_reason":
+			transaction.FailureReason = value.(string)
+		case "captured_at":
+			capturedAt := value.(time.Time)
+			transaction.CapturedAt = &capturedAt
+		}
+	}
+	return nil
+}
+
+// fakeProviders 按渠道名返回固定的渠道实现，未登记的渠道视为不受支持
+type fakeProviders map[string]provider.Provider
+
+func (f fakeProviders) Resolve(name, secret string, live bool) (provider.Provider, error) {
+	if channelProvider, ok := f[name]; ok {
+		return channelProvider, nil
+	}
+	return nil, provider.ErrUnknownProvider
+}
+
+// fakeOrders 订单服务，记录最近一次更新的支付状态
+type fakeOrders struct {
+	orders    map[int64]*OrderSummary
+	payStatus map[int64]int32
+}
+
+func (f *fakeOrders) FindOrder(ctx context.Context, orderID int64) (*OrderSummary, error) {
+	if order, ok := f.orders[orderID]; ok {
+		return order, nil
+	}
+	return nil, ErrInvalidOrder
+}
+
+func (f *fakeOrders) UpdatePayStatus(ctx context.Context, orderID int64, payStatus int32) error {
+	if f.payStatus == nil {
+		f.payStatus = map[int64]int32{}
+	}
+	f.payStatus[orderID] = payStatus
+	return nil
+}
+
+// fakeRouter 总是返回固定的路由结果
+type fakeRouter struct {
+	IRoutingService
+	route *Route
+}
+
+func (f *fakeRouter) Route(RouteRequest) (*Route, error) {
+	return f.route, nil
+}
+
+func (f *fakeRouter) ReportResult(int64, error) {}
+
+// fakeRefunds 退款流水，预占与释放直接修改 transactions 中交易的累计退款
+type fakeRefunds struct {
+	repository.IRefundRepository
+	transactions *fakeTransactions
+	refunds      map[int64]*model.Refund
+}
+
+func newFakeRefunds(transactions *fakeTransactions) *fakeRefunds {
+	return &fakeRefunds{transactions: transactions, refunds: map[int64]*model.Refund{}}
+}
+
+func (f *fakeRefunds) FindRefundByRefundID(refundID string) (*model.Refund, error) {
+	return f.find(func(refund *model.Refund) bool { return refund.RefundID == refundID })
+}
+
+func (f *fakeRefunds) FindRefundByProviderRef(providerRef string) (*model.Refund, error) {
+	return f.find(func(refund *model.Refund) bool { return refund.ProviderRef == providerRef })
+}
+
+func (f *fakeRefunds) find(match func(*model.Refund) bool) (*model.Refund, error) {
+	for _, refund := range f.refunds {
+		if match(refund) {
+			copied := *refund
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRefunds) ReserveRefund(refund *model.Refund) error {
+	transaction, ok := f.transactions.transactions[refund.TransactionID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if transaction.Status != model.TransactionCaptured {
+		return repository.ErrStatusConflict
+	}
+	refunded, err := transaction.RefundedAmount.Add(refund.Amount)
+	if err != nil {
+		return err
+	}
+	if refunded.Amount > transaction.Amount.Amount {
+		return repository.ErrRefundExceeded
+	}
+	if _, err := f.FindRefundByRefundID(refund.RefundID); err == nil {
+		return errors.New("duplicate refund_id")
+	}
+	refund.ID = int64(len(f.refunds) + 1)
+	copied := *refund
+	f.refunds[refund.ID] = &copied
+	transaction.RefundedAmount = refunded
+	return nil
+}
+
+func (f *fakeRefunds) SetProviderRef(refundID int64, providerRef string) error {
+	refund, ok := f.refunds[refundID]
+	if !ok || refund.Status != model.RefundPending {
+		return repository.ErrStatusConflict
+	}
+	refund.ProviderRef = providerRef
+	return nil
+}
+
+func (f *fakeRefunds) CompleteRefund(refundID int64, providerRef string) (*model.Transaction, error) {
+	refund, ok := f.refunds[refundID]
+	if !ok || refund.Status != model.RefundPending {
+		return nil, repository.ErrStatusConflict
+	}
+	refund.Status = model.RefundSucceeded
+	refund.ProviderRef = providerRef
+	var succeeded int64
+	for _, other := range f.refunds {
+		if other.TransactionID == refund.TransactionID && other.Status == model.RefundSucceeded {
+			succeeded += other.Amount.Amount
+		}
+	}
+	transaction := f.transactions.transactions[refund.TransactionID]
+	if succeeded >= transaction.Amount.Amount {
+		transaction.Status = model.TransactionRefunded
+	}
+	copied := *transaction
+	return &copied, nil
+}
+
+func (f *fakeRefunds) FailRefund(refundID int64, reason string) (*model.Transaction, error) {
+	refund, ok := f.refunds[refundID]
+	if !ok || refund.Status != model.RefundPending {
+		return nil, repository.ErrStatusConflict
+	}
+	refund.Status = model.RefundFailed
+	refund.FailureReason = reason
+	transaction := f.transactions.transactions[refund.TransactionID]
+	transaction.RefundedAmount.Amount -= refund.Amount.Amount
+	copied := *transaction
+	return &copied, nil
+}
+
+// fakeWebhooks 已处理的回调事件
+type fakeWebhooks struct {
+	events []model.WebhookEvent
+}
+
+func (f *fakeWebhooks) InitTable() error { return nil }
+
+func (f *fakeWebhooks) ExistsEvent(providerName, eventID string) (bool, error) {
+	return slices.ContainsFunc(f.events, func(event model.WebhookEvent) bool {
+		return event.Provider == providerName && event.EventID == eventID
+	}), nil
+}
+
+func (f *fakeWebhooks) CreateEvent(event *model.WebhookEvent) error {
+	f.events = append(f.events, *event)
+	return nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "payment/proto/order"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 订单支付状态，与订单服务 model.PayStatus* 保持一致
+const (
+	orderPayStatusPaid              int32 = 1
+	orderPayStatusRefunding         int32 = 2
+	orderPayStatusRefunded          int32 = 3
+	orderPayStatusPartiallyRefunded int32 = 4
+)
+
+// IOrderUpdater 通知订单服务更新订单支付状态
+type IOrderUpdater interface {
+	UpdatePayStatus(ctx context.Context, orderID int64, payStatus int32) error
+}
+
+// 创建基于订单服务 RPC 的更新器
+func NewOrderUpdater(orderService pb.OrderService) IOrderUpdater {
+	return &OrderUpdater{orderService: orderService}
+}
+
+type OrderUpdater struct {
+	orderService pb.OrderService
+}
+
+func (o *OrderUpdater) UpdatePayStatus(ctx context.Context, orderID int64, payStatus int32) error {
+	if _, err := o.orderService.UpdateOrderPayStatus(ctx, &pb.PayStatus{OrderId: orderID, PayStatus: payStatus}); err != nil {
+		return fmt.Errorf("更新订单支付状态失败: %w", err)
+	}
+	return nil
+}
+
+// PaidOrder 订单服务中已支付的订单
+type PaidOrder struct {
+	OrderID   int64
+	PayStatus int32
+}
+
+// IPaidOrderFinder 查询已支付（含退款中、已退款、部分退款）的订单，供对账使用
+type IPaidOrderFinder interface {
+	// FindPaidOrders 查询首次支付时间在 [from, to) 内的订单
+	FindPaidOrders(ctx context.Context, from, to time.Time) ([]PaidOrder, error)
+	// FindPaidOrdersByID 查询 orderIDs 中已支付的订单
+	FindPaidOrdersByID(ctx context.Context, orderIDs []int64) ([]PaidOrder, error)
+}
+
+// 创建基于订单服务 RPC 的查询器
+func NewPaidOrderFinder(orderService pb.OrderService) IPaidOrderFinder {
+	return &PaidOrderFinder{orderService: orderService}
+}
+
+type PaidOrderFinder struct {
+	orderService pb.OrderService
+}
+
+func (o *PaidOrderFinder) FindPaidOrders(ctx context.Context, from, to time.Time) ([]PaidOrder, error) {
+	return o.find(ctx, &pb.PayStatusFilter{PaidFrom: from.Unix(), PaidTo: to.Unix()})
+}
+
+func (o *PaidOrderFinder) FindPaidOrdersByID(ctx context.Context, orderIDs []int64) ([]PaidOrder, error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+	return o.find(ctx, &pb.PayStatusFilter{OrderId: orderIDs})
+}
+
+func (o *PaidOrderFinder) find(ctx context.Context, filter *pb.PayStatusFilter) ([]PaidOrder, error) {
+	filter.PayStatus = []int32{
+		orderPayStatusPaid, orderPayStatusRefunding, orderPayStatusRefunded, orderPayStatusPartiallyRefunded,
+	}
+	rsp, err := o.orderService.GetOrdersByPayStatus(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("查询已支付订单失败: %w", err)
+	}
+	orders := make([]PaidOrder, 0, len(rsp.OrderInfo))
+	for _, order := range rsp.OrderInfo {
+		orders = append(orders, PaidOrder{OrderID: order.Id, PayStatus: order.PayStatus})
+	}
+	return orders, nil
+}
+
+// OrderSummary 支付所需的订单信息
+type OrderSummary struct {
+	UserID    int64       // 下单用户，交易与退款只允许本人或支付运营人员访问
+	AmountDue money.Money // 应付金额，交易须以订单的结算币种按该金额支付
+}
+
+// IOrderFinder 查询订单的所属用户与应付金额
+type IOrderFinder interface {
+	FindOrder(ctx context.Context, orderID int64) (*OrderSummary, error)
+}
+
+// 创建基于订单服务 RPC 的查询器
+func NewOrderFinder(orderService pb.OrderService) IOrderFinder {
+	return &OrderFinder{orderService: orderService}
+}
+
+type OrderFinder struct {
+	orderService pb.OrderService
+}
+
+func (o *OrderFinder) FindOrder(ctx context.Context, orderID int64) (*OrderSummary, error) {
+	order, err := o.orderService.GetOrderByID(ctx, &pb.OrderID{OrderId: orderID})
+	if err != nil {
+		return nil, fmt.Errorf("查询订单失败: %w", err)
+	}
+	summary := &OrderSummary{UserID: order.UserId, AmountDue: money.Zero(order.Currency)}
+	if order.Amount != nil {
+		summary.AmountDue = money.FromProto(order.Amount)
+	}
+	return summary, nil
+}
@@ -1,29 +1,47 @@
 package service
 
 import (
+	"errors"
 	"payment/domain/model"
 	"payment/domain/repository"
 )
 
+// RedactedSecret 读接口中代替渠道密钥返回的占位值，更新时传回该值表示不修改密钥
+const RedactedSecret = "******"
+
+// ISecretKeyring 渠道密钥的字段级加解密，由 common/secret.Keyring 实现
+type ISecretKeyring interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+	NeedsRotation(value string) bool
+	Rotate(value string) (string, error)
+}
+
 type IPaymentDataService interface {
 	AddPayment(*model.Payment) (int64, error)
 	DeletePayment(int64) error
 	UpdatePayment(*model.Payment) error
 	FindPaymentByID(int64) (*model.Payment, error)
 	FindAllPayment() ([]model.Payment, error)
+
+	RotateSecrets() (int, error)
 }
 
 // 创建
-func NewPaymentDataService(paymentRepository repository.IPaymentRepository) IPaymentDataService {
-	return &PaymentDataService{paymentRepository}
+func NewPaymentDataService(paymentRepository repository.IPaymentRepository, keyring ISecretKeyring) IPaymentDataService {
+	return &PaymentDataService{PaymentRepository: paymentRepository, Keyring: keyring}
 }
 
 type PaymentDataService struct {
 	PaymentRepository repository.IPaymentRepository
+	Keyring           ISecretKeyring
 }
 
-// 插入
+// 插入，渠道密钥加密后保存
 func (u *PaymentDataService) AddPayment(payment *model.Payment) (int64, error) {
+	if err := u.encryptSecret(payment); err != nil {
+		return 0, err
+	}
 	return u.PaymentRepository.CreatePayment(payment)
 }
 
@@ -32,17 +50,58 @@ func (u *PaymentDataService) DeletePayment(paymentID int64) error {
 	return u.PaymentRepository.DeletePaymentByID(paymentID)
 }
 
-// 更新
+// 更新，未传密钥或传回占位值时保留原密钥
 func (u *PaymentDataService) UpdatePayment(payment *model.Payment) error {
+	if err := u.encryptSecret(payment); err != nil {
+		return err
+	}
 	return u.PaymentRepository.UpdatePayment(payment)
 }
 
-// 查找
+// 查找，PaymentSid 为密文
 func (u *PaymentDataService) FindPaymentByID(paymentID int64) (*model.Payment, error) {
 	return u.PaymentRepository.FindPaymentByID(paymentID)
 }
 
-// 查找
+// 查找，PaymentSid 为密文
 func (u *PaymentDataService) FindAllPayment() ([]model.Payment, error) {
 	return u.PaymentRepository.FindAll()
 }
+
+// RotateSecrets 将明文或非主密钥加密的渠道密钥用主密钥重新加密，返回处理的通道数。
+// 启动时执行，轮换密钥后重启即可完成存量数据的重新加密。
+func (u *PaymentDataService) RotateSecrets() (int, error) {
+	payments, err := u.PaymentRepository.FindAll()
+	if err != nil {
+		return 0, err
+	}
+	rotated := 0
+	for _, payment := range payments {
+		if payment.PaymentSid == "" || !u.Keyring.NeedsRotation(payment.PaymentSid) {
+			continue
+		}
+		ciphertext, err := u.Keyring.Rotate(payment.PaymentSid)
+		if err != nil {
+			return rotated, errors.Join(errors.New("重新加密支付通道密钥失败"), err)
+		}
+		if err := u.PaymentRepository.UpdatePaymentSid(payment.ID, ciphertext); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
+// encryptSecret 加密新传入的渠道密钥，空值与占位值置空，由仓储的更新逻辑忽略
+func (u *PaymentDataService) encryptSecret(payment *model.Payment) error {
+	if payment.PaymentSid == "" || payment.PaymentSid == RedactedSecret {
+		payment.PaymentSid = ""
+		return nil
+	}
+	ciphertext, err := u.Keyring.Encrypt(payment.PaymentSid)
+	if err != nil {
+		return err
+	}
+	payment.PaymentSid = ciphertext
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"payment/domain/model"
+	"payment/domain/repository"
+)
+
+// PaymentUserData 用户订单的支付交易与退款，供隐私导出
+type PaymentUserData struct {
+	Transactions []model.Transaction `json:"transactions"`
+	Refunds      []model.Refund      `json:"refunds"`
+}
+
+// IPrivacyService 用户数据导出与删除，由用户服务的隐私请求流程调用，可重复执行。
+// 支付服务不记录用户ID，按订单服务返回的订单ID查找；交易与退款须依法保留，
+// 删除时只清除付款跳转地址与用户填写的退款原因，金额、渠道单号与状态不变。
+type IPrivacyService interface {
+	ExportUserData([]int64) (*PaymentUserData, error)
+	EraseUserData([]int64) (int64, error)
+}
+
+// 创建
+func NewPrivacyService(transactionRepository repository.ITransactionRepository, refundRepository repository.IRefundRepository) IPrivacyService {
+	return &PrivacyService{TransactionRepository: transactionRepository, RefundRepository: refundRepository}
+}
+
+type PrivacyService struct {
+	TransactionRepository repository.ITransactionRepository
+	RefundRepository      repository.IRefundRepository
+}
+
+// 导出
+func (u *PrivacyService) ExportUserData(orderIDs []int64) (*PaymentUserData, error) {
+	data := &PaymentUserData{}
+	var err error
+	if data.Transactions, err = u.TransactionRepository.FindAllByOrders(orderIDs); err != nil {
+		return nil, err
+	}
+	if data.Refunds, err = u.RefundRepository.FindAllByOrders(orderIDs); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// 匿名化
+func (u *PrivacyService) EraseUserData(orderIDs []int64) (int64, error) {
+	affected, err := u.TransactionRepository.ClearApprovalURLs(orderIDs)
+	if err != nil {
+		return affected, err
+	}
+	n, err := u.RefundRepository.ClearReasons(orderIDs)
+	return affected + n, err
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"payment/domain/model"
+	"payment/domain/repository"
+	"payment/provider"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IReconciliationMetrics 对账结果的指标上报，由 metrics.Prometheus 实现
+type IReconciliationMetrics interface {
+	ObserveReconciliation(run *model.ReconciliationRun, discrepancies []model.Discrepancy)
+}
+
+type IReconciliationService interface {
+	Reconcile(ctx context.Context, day time.Time) (*model.ReconciliationRun, error)
+	Run(ctx context.Context, runAt string) error
+	FindRuns(limit int) ([]model.ReconciliationRun, error)
+	FindRun(runID int64) (*model.ReconciliationRun, []model.Discrepancy, error)
+}
+
+// 创建
+func NewReconciliationService(reconciliationRepository repository.IReconciliationRepository,
+	transactionRepository repository.ITransactionRepository, paymentRepository repository.IPaymentRepository,
+	providers IProviderResolver, orders IPaidOrderFinder, metrics IReconciliationMetrics) IReconciliationService {
+	return &ReconciliationService{
+		ReconciliationRepository: reconciliationRepository,
+		TransactionRepository:    transactionRepository,
+		PaymentRepository:        paymentRepository,
+		Providers:                providers,
+		Orders:                   orders,
+		Metrics:                  metrics,
+	}
+}
+
+type ReconciliationService struct {
+	ReconciliationRepository repository.IReconciliationRepository
+	TransactionRepository    repository.ITransactionRepository
+	PaymentRepository        repository.IPaymentRepository
+	Providers                IProviderResolver
+	Orders                   IPaidOrderFinder
+	Metrics                  IReconciliationMetrics
+}
+
+// Reconcile 对账 day 当天（本地时区）的扣款：逐个支付通道比对本地交易与渠道对账单，
+// 再比对订单支付状态。对账过程出错时记录为 failed 并返回错误。
+func (u *ReconciliationService) Reconcile(ctx context.Context, day time.Time) (*model.ReconciliationRun, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	run := &model.ReconciliationRun{
+		Day:       from.Format(time.DateOnly),
+		Status:    model.ReconciliationRunning,
+		StartedAt: time.Now(),
+	}
+	if _, err := u.ReconciliationRepository.CreateRun(run); err != nil {
+		return nil, err
+	}
+
+	discrepancies, err := u.reconcile(ctx, run, from, from.AddDate(0, 0, 1))
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = model.ReconciliationCompleted
+	if err != nil {
+		run.Status = model.ReconciliationFailed
+		run.Error = err.Error()
+		discrepancies = nil
+	}
+	run.Discrepancies = int64(len(discrepancies))
+	for i := range discrepancies {
+		discrepancies[i].RunID = run.ID
+	}
+	if finishErr := u.ReconciliationRepository.FinishRun(run, discrepancies); finishErr != nil {
+		return run, finishErr
+	}
+	u.Metrics.ObserveReconciliation(run, discrepancies)
+	return run, err
+}
+
+func (u *ReconciliationService) reconcile(ctx context.Context, run *model.ReconciliationRun, from, to time.Time) ([]model.Discrepancy, error) {
+	ledger, err := u.TransactionRepository.FindCapturedBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := u.PaymentRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var discrepancies []model.Discrepancy
+	for i := range channels {
+		channel := &channels[i]
+		channelProvider, err := resolveProvider(u.Providers, channel)
+		if errors.Is(err, ErrUnknownProvider) {
+			run.Skipped++
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("支付通道 %d: %w", channel.ID, err)
+		}
+		reporter, ok := channelProvider.(provider.SettlementReporter)
+		if !ok {
+			run.Skipped++
+			continue
+		}
+		entries, err := reporter.SettlementReport(ctx, from)
+		if errors.Is(err, provider.ErrSettlementUnsupported) {
+			run.Skipped++
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("获取支付通道 %d 对账单失败: %w", channel.ID, err)
+		}
+		run.Channels++
+
+		matched, found, unmatched := compareSettlement(channelTransactions(ledger, channel.ID), entries)
+		discrepancies = append(discrepancies, found...)
+		for _, entry := range unmatched {
+			discrepancy, err := u.checkUnmatched(channel.ID, entry)
+			if err != nil {
+				return nil, err
+			}
+			if discrepancy == nil {
+				matched++
+				continue
+			}
+			discrepancies = append(discrepancies, *discrepancy)
+		}
+		run.Matched += matched
+	}
+
+	found, err := u.compareOrders(ctx, from, to, ledger)
+	if err != nil {
+		return nil, err
+	}
+	return append(discrepancies, found...), nil
+}
+
+// checkUnmatched 对账单中有、当天账本中没有的扣款：本地交易扣款时间落在其他日期时视为一致，
+// 交易存在但未扣款为状态不一致，不存在则为本地缺失
+func (u *ReconciliationService) checkUnmatched(paymentID int64, entry provider.SettlementEntry) (*model.Discrepancy, error) {
+	transaction, err := u.TransactionRepository.FindTransactionByRef(entry.ProviderRef, entry.CaptureRef)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		discrepancy := settlementDiscrepancy(model.DiscrepancyMissingInLedger, paymentID, entry, "本地没有对应的交易")
+		return &discrepancy, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if settled(transaction) {
+		return nil, nil
+	}
+	discrepancy := settlementDiscrepancy(model.DiscrepancyStatusMismatch, paymentID, entry,
+		"渠道已结算，本地交易状态为 "+transaction.Status)
+	discrepancy.OrderID = transaction.OrderID
+	discrepancy.TransactionID = transaction.ID
+	discrepancy.LedgerAmount = transaction.Amount
+	return &discrepancy, nil
+}
+
+// compareOrders 比对订单支付状态与交易：当天首次支付的订单须有已扣款的交易，当天已扣款的交易须对应已支付订单。
+// 只比对 [from, to) 内的订单与交易，历史订单的差异已在其支付当天上报，不会每天重复上报
+func (u *ReconciliationService) compareOrders(ctx context.Context, from, to time.Time, ledger []model.Transaction) ([]model.Discrepancy, error) {
+	orders, err := u.Orders.FindPaidOrders(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	orderIDs := make([]int64, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.OrderID)
+	}
+	var transactions []model.Transaction
+	if len(orderIDs) > 0 {
+		if transactions, err = u.TransactionRepository.FindAllByOrders(orderIDs); err != nil {
+			return nil, err
+		}
+	}
+	ledgerOrderIDs := make([]int64, 0, len(ledger))
+	for _, transaction := range ledger {
+		ledgerOrderIDs = append(ledgerOrderIDs, transaction.OrderID)
+	}
+	ledgerOrders, err := u.Orders.FindPaidOrdersByID(ctx, ledgerOrderIDs)
+	if err != nil {
+		return nil, err
+	}
+	return compareOrders(orders, transactions, ledgerOrders, ledger), nil
+}
+
+// Run 每天 runAt（HH:MM，本地时区）对账前一天，直到 ctx 结束
+func (u *ReconciliationService) Run(ctx context.Context, runAt string) error {
+	at, err := time.Parse("15:04", runAt)
+	if err != nil {
+		return fmt.Errorf("对账时间格式错误: %w", err)
+	}
+	for {
+		next := nextRunTime(time.Now(), at.Hour(), at.Minute())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		run, err := u.Reconcile(ctx, next.AddDate(0, 0, -1))
+		if err != nil {
+			slog.Error("对账失败", "day", next.AddDate(0, 0, -1).Format(time.DateOnly), "error", err)
+			continue
+		}
+		slog.Info("对账完成", "day", run.Day, "channels", run.Channels,
+			"matched", run.Matched, "discrepancies", run.Discrepancies)
+	}
+}
+
+// 查找最近的对账记录，limit 不大于 0 时取 30 条
+func (u *ReconciliationService) FindRuns(limit int) ([]model.ReconciliationRun, error) {
+	if limit <= 0 {
+		limit = 30
+	}
+	return u.ReconciliationRepository.FindRuns(limit)
+}
+
+// 查找对账记录及其差异明细
+func (u *ReconciliationService) FindRun(runID int64) (*model.ReconciliationRun, []model.Discrepancy, error) {
+	run, err := u.ReconciliationRepository.FindRunByID(runID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrReconciliationNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	discrepancies, err := u.ReconciliationRepository.FindDiscrepancies(runID)
+	return run, discrepancies, err
+}
+
+// nextRunTime 返回 now 之后最近的 hour:minute
+func nextRunTime(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// compareSettlement 按扣款单号比对通道当天的本地交易与渠道对账单，
+// 返回一致的笔数、差异，以及本地当天账本中找不到的对账单条目
+func compareSettlement(ledger []model.Transaction, entries []provider.SettlementEntry) (int64, []model.Discrepancy, []provider.SettlementEntry) {
+	settlement := make(map[string]provider.SettlementEntry, len(entries))
+	for _, entry := range entries {
+		settlement[entry.CaptureRef] = entry
+	}
+
+	var matched int64
+	var discrepancies []model.Discrepancy
+	seen := make(map[string]bool, len(ledger))
+	for _, transaction := range ledger {
+		seen[transaction.CaptureRef] = true
+		entry, ok := settlement[transaction.CaptureRef]
+		if !ok {
+			discrepancies = append(discrepancies, transactionDiscrepancy(model.DiscrepancyMissingInSettlement,
+				transaction, "渠道对账单中没有该扣款"))
+			continue
+		}
+		if cmp, err := transaction.Amount.Cmp(entry.Amount); err != nil || cmp != 0 {
+			discrepancy := transactionDiscrepancy(model.DiscrepancyAmountMismatch, transaction, "本地与渠道扣款金额不一致")
+			discrepancy.ProviderAmount = entry.Amount
+			discrepancies = append(discrepancies, discrepancy)
+			continue
+		}
+		matched++
+	}
+
+	var unmatched []provider.SettlementEntry
+	for _, entry := range entries {
+		if !seen[entry.CaptureRef] {
+			unmatched = append(unmatched, entry)
+		}
+	}
+	return matched, discrepancies, unmatched
+}
+
+// compareOrders orders 为当天首次支付的订单，transactions 为这些订单的全部交易，
+// ledger 为当天已扣款的交易，ledgerOrders 为其对应订单中已支付的订单
+func compareOrders(orders []PaidOrder, transactions []model.Transaction, ledgerOrders []PaidOrder, ledger []model.Transaction) []model.Discrepancy {
+	paid := make(map[int64]bool, len(ledgerOrders))
+	for _, order := range ledgerOrders {
+		paid[order.OrderID] = true
+	}
+	captured := make(map[int64]bool, len(transactions))
+	for i := range transactions {
+		if settled(&transactions[i]) {
+			captured[transactions[i].OrderID] = true
+		}
+	}
+
+	var discrepancies []model.Discrepancy
+	for _, order := range orders {
+		if !captured[order.OrderID] {
+			discrepancies = append(discrepancies, model.Discrepancy{
+				Type:    model.DiscrepancyPaidWithoutCapture,
+				OrderID: order.OrderID,
+				Detail:  fmt.Sprintf("订单支付状态为 %d，但没有已扣款的交易", order.PayStatus),
+			})
+		}
+	}
+	for _, transaction := range ledger {
+		if !paid[transaction.OrderID] {
+			discrepancies = append(discrepancies, transactionDiscrepancy(model.DiscrepancyCaptureWithoutOrder,
+				transaction, "交易已扣款，但订单不存在或未支付"))
+		}
+	}
+	return discrepancies
+}
+
+// settled 交易是否已扣款，全额退款的交易也曾扣款
+func settled(transaction *model.Transaction) bool {
+	return transaction.Status == model.TransactionCaptured || transaction.Status == model.TransactionRefunded
+}
+
+func channelTransactions(ledger []model.Transaction, paymentID int64) []model.Transaction {
+	var transactions []model.Transaction
+	for _, transaction := range ledger {
+		if transaction.PaymentID == paymentID {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions
+}
+
+func transactionDiscrepancy(kind string, transaction model.Transaction, detail string) model.Discrepancy {
+	return model.Discrepancy{
+		Type:          kind,
+		PaymentID:     transaction.PaymentID,
+		OrderID:       transaction.OrderID,
+		TransactionID: transaction.ID,
+		CaptureRef:    transaction.CaptureRef,
+		LedgerAmount:  transaction.Amount,
+		Detail:        detail,
+	}
+}
+
+func settlementDiscrepancy(kind string, paymentID int64, entry provider.SettlementEntry, detail string) model.Discrepancy {
+	return model.Discrepancy{
+		Type:           kind,
+		PaymentID:      paymentID,
+		CaptureRef:     entry.CaptureRef,
+		ProviderAmount: entry.Amount,
+		Detail:         detail,
+	}
+}
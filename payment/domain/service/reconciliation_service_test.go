@@ -0,0 +1,70 @@
+package service
+
+import (
+	"payment/domain/model"
+	"payment/provider"
+	"testing"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+func TestCompareSettlement(t *testing.T) {
+	ledger := []model.Transaction{
+		{ID: 1, OrderID: 11, PaymentID: 1, CaptureRef: "c1", Amount: money.New(1000, "USD"), Status: model.TransactionCaptured},
+		{ID: 2, OrderID: 12, PaymentID: 1, CaptureRef: "c2", Amount: money.New(2000, "USD"), Status: model.TransactionCaptured},
+		{ID: 3, OrderID: 13, PaymentID: 1, CaptureRef: "c3", Amount: money.New(3000, "USD"), Status: model.TransactionRefunded},
+	}
+	entries := []provider.SettlementEntry{
+		{CaptureRef: "c1", Amount: money.New(1000, "USD")},
+		{CaptureRef: "c2", Amount: money.New(1999, "USD")},
+		{CaptureRef: "c4", Amount: money.New(500, "USD")},
+	}
+	matched, discrepancies, unmatched := compareSettlement(ledger, entries)
+	if matched != 1 {
+		t.Fatalf("matched = %d, want 1", matched)
+	}
+	types := map[string]int64{}
+	for _, d := range discrepancies {
+		types[d.Type] = d.TransactionID
+	}
+	if len(discrepancies) != 2 || types[model.DiscrepancyAmountMismatch] != 2 || types[model.DiscrepancyMissingInSettlement] != 3 {
+		t.Fatalf("discrepancies = %+v", discrepancies)
+	}
+	if len(unmatched) != 1 || unmatched[0].CaptureRef != "c4" {
+		t.Fatalf("unmatched = %+v", unmatched)
+	}
+}
+
+func TestCompareOrders(t *testing.T) {
+	orders := []PaidOrder{{OrderID: 11, PayStatus: orderPayStatusPaid}, {OrderID: 12, PayStatus: orderPayStatusRefunded}}
+	transactions := []model.Transaction{
+		{ID: 1, OrderID: 11, Status: model.TransactionCaptured},
+		{ID: 2, OrderID: 12, Status: model.TransactionFailed},
+	}
+	ledger := []model.Transaction{
+		{ID: 1, OrderID: 11, Status: model.TransactionCaptured},
+		{ID: 5, OrderID: 15, Status: model.TransactionCaptured},
+	}
+	ledgerOrders := []PaidOrder{{OrderID: 11, PayStatus: orderPayStatusPaid}}
+	discrepancies := compareOrders(orders, transactions, ledgerOrders, ledger)
+	if len(discrepancies) != 2 {
+		t.Fatalf("discrepancies = %+v", discrepancies)
+	}
+	if d := discrepancies[0]; d.Type != model.DiscrepancyPaidWithoutCapture || d.OrderID != 12 {
+		t.Fatalf("discrepancies[0] = %+v", d)
+	}
+	if d := discrepancies[1]; d.Type != model.DiscrepancyCaptureWithoutOrder || d.TransactionID != 5 {
+		t.Fatalf("discrepancies[1] = %+v", d)
+	}
+}
+
+func TestNextRunTime(t *testing.T) {
+	now := time.Date(2024, 3, 1, 1, 30, 0, 0, time.UTC)
+	if got := nextRunTime(now, 2, 0); !got.Equal(time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)) {
+		t.Fatalf("next = %v", got)
+	}
+	if got := nextRunTime(now, 1, 30); !got.Equal(time.Date(2024, 3, 2, 1, 30, 0, 0, time.UTC)) {
+		t.Fatalf("next = %v", got)
+	}
+}
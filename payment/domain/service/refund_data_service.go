@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"payment/domain/model"
+	"payment/domain/repository"
+	"payment/provider"
+	"strings"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+)
+
+// RefundRequest 退款请求，Amount 为 0 表示退还交易剩余的全部可退金额，币种为空时使用交易币种
+type RefundRequest struct {
+	RefundID      string
+	OrderID       int64
+	TransactionID int64
+	Amount        money.Money
+	Reason        string
+}
+
+type IRefundDataService interface {
+	Refund(context.Context, *RefundRequest) (*model.Refund, error)
+	FindAllByOrder(int64) ([]model.Refund, error)
+}
+
+// 创建
+func NewRefundDataService(refundRepository repository.IRefundRepository, transactionRepository repository.ITransactionRepository,
+	paymentRepository repository.IPaymentRepository, providers IProviderResolver, orderUpdater IOrderUpdater) IRefundDataService {
+	return &RefundDataService{
+		RefundRepository:      refundRepository,
+		TransactionRepository: transactionRepository,
+		PaymentRepository:     paymentRepository,
+		Providers:             providers,
+		OrderUpdater:          orderUpdater,
+	}
+}
+
+type RefundDataService struct {
+	RefundRepository      repository.IRefundRepository
+	TransactionRepository repository.ITransactionRepository
+	PaymentRepository     repository.IPaymentRepository
+	Providers             IProviderResolver
+	OrderUpdater          IOrderUpdater
+}
+
+// Refund 对已扣款交易发起全额或部分退款。
+// 同一 RefundID 重复请求返回首次结果，失败的退款需换新的 RefundID 重新发起；
+// 订单在渠道处理期间为退款中，完成后为已退款或部分退款。
+// 渠道异步处理的退款保持 pending，由退款回调确认成功或失败。
+func (u *RefundDataService) Refund(ctx context.Context, req *RefundRequest) (*model.Refund, error) {
+	req.RefundID = strings.TrimSpace(req.RefundID)
+	if req.RefundID == "" {
+		return nil, ErrRefundIDRequired
+	}
+	if req.OrderID <= 0 {
+		return nil, ErrInvalidOrder
+	}
+	if req.Amount.IsNegative() {
+		return nil, ErrInvalidAmount
+	}
+	if existing, err := u.findExisting(req); existing != nil || err != nil {
+		return existing, err
+	}
+
+	transaction, err := u.TransactionRepository.FindTransactionByID(req.TransactionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if transaction.OrderID != req.OrderID {
+		return nil, ErrRefundOrderMismatch
+	}
+	if transaction.Status != model.TransactionCaptured || transaction.CaptureRef == "" {
+		return nil, ErrTransactionTransition
+	}
+	amount := money.New(req.Amount.Amount, transaction.Amount.Currency)
+	if currency := strings.ToUpper(strings.TrimSpace(req.Amount.Currency)); currency != "" && currency != amount.Currency {
+		return nil, ErrRefundCurrency
+	}
+	if amount.IsZero() {
+		if amount, err = transaction.Amount.Sub(transaction.RefundedAmount); err != nil {
+			return nil, err
+		}
+	}
+	if !amount.IsPositive() {
+		return nil, ErrRefundExceeded
+	}
+	channel, err := findPayment(u.PaymentRepository, transaction.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	channelProvider, err := resolveProvider(u.Providers, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := &model.Refund{
+		RefundID:      req.RefundID,
+		OrderID:       req.OrderID,
+		TransactionID: req.TransactionID,
+		Amount:        amount,
+		Reason:        req.Reason,
+		Status:        model.RefundPending,
+	}
+	if err := u.RefundRepository.ReserveRefund(refund); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRefundExceeded):
+			return nil, ErrRefundExceeded
+		case errors.Is(err, repository.ErrStatusConflict):
+			return nil, ErrTransactionTransition
+		}
+		// 并发请求使用同一 RefundID 时唯一索引冲突，以先写入的记录为准
+		if existing, findErr := u.findExisting(req); existing != nil || findErr != nil {
+			return existing, findErr
+		}
+		return nil, err
+	}
+
+	if err := u.OrderUpdater.UpdatePayStatus(ctx, req.OrderID, orderPayStatusRefunding); err != nil {
+		return u.fail(ctx, refund, err.Error())
+	}
+	result, err := channelProvider.Refund(ctx, &provider.RefundRequest{
+		CaptureRef: transaction.CaptureRef,
+		RefundID:   refund.RefundID,
+		Amount:     refund.Amount,
+	})
+	if err != nil {
+		if _, failErr := u.fail(ctx, refund, err.Error()); failErr != nil {
+			return nil, failErr
+		}
+		return nil, providerError(err)
+	}
+	switch result.Status {
+	case provider.StatusFailed:
+		return u.fail(ctx, refund, "支付渠道拒绝退款")
+	case provider.StatusRefunded:
+	default:
+		// 渠道尚未完成，保持 pending 与订单退款中；回调可能先于此处到达，此时以回调结果为准
+		if err := u.RefundRepository.SetProviderRef(refund.ID, result.RefundRef); err != nil {
+			if errors.Is(err, repository.ErrStatusConflict) {
+				return u.RefundRepository.FindRefundByRefundID(refund.RefundID)
+			}
+			return nil, err
+		}
+		refund.ProviderRef = result.RefundRef
+		return refund, nil
+	}
+
+	if _, err := u.RefundRepository.CompleteRefund(refund.ID, result.RefundRef); err != nil {
+		return nil, err
+	}
+	refund.Status = model.RefundSucceeded
+	refund.ProviderRef = result.RefundRef
+	u.updateOrder(ctx, req.OrderID)
+	return refund, nil
+}
+
+// 查找订单的所有退款
+func (u *RefundDataService) FindAllByOrder(orderID int64) ([]model.Refund, error) {
+	if orderID <= 0 {
+		return nil, ErrInvalidOrder
+	}
+	return u.RefundRepository.FindAllByOrder(orderID)
+}
+
+// findExisting 查找同一 RefundID 的已有退款，请求参数不一致时视为冲突
+func (u *RefundDataService) findExisting(req *RefundRequest) (*model.Refund, error) {
+	refund, err := u.RefundRepository.FindRefundByRefundID(req.RefundID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if refund.OrderID != req.OrderID || refund.TransactionID != req.TransactionID ||
+		(!req.Amount.IsZero() && refund.Amount.Amount != req.Amount.Amount) {
+		return nil, ErrRefundIDConflict
+	}
+	return refund, nil
+}
+
+// fail 退款失败，释放预占金额并恢复订单支付状态
+func (u *RefundDataService) fail(ctx context.Context, refund *model.Refund, reason string) (*model.Refund, error) {
+	if _, err := u.RefundRepository.FailRefund(refund.ID, reason); err != nil {
+		return nil, err
+	}
+	refund.Status = model.RefundFailed
+	refund.FailureReason = reason
+	u.updateOrder(ctx, refund.OrderID)
+	return refund, nil
+}
+
+// updateOrder 按订单所有交易的累计退款重新计算订单支付状态。
+// 此时渠道侧已有结果，订单服务更新失败只记录日志，不影响退款结果。
+func (u *RefundDataService) updateOrder(ctx context.Context, orderID int64) {
+	updateRefundPayStatus(ctx, u.TransactionRepository, u.OrderUpdater, orderID)
+}
+
+// updateRefundPayStatus 退款完成或失败后重新计算订单支付状态，同步退款与退款回调共用
+func updateRefundPayStatus(ctx context.Context, transactionRepository repository.ITransactionRepository, orderUpdater IOrderUpdater, orderID int64) {
+	transactions, err := transactionRepository.FindAllByOrder(orderID)
+	if err != nil {
+		slog.Warn("查询订单交易失败", "order_id", orderID, "error", err)
+		return
+	}
+	var paid, refunded int64
+	for _, transaction := range transactions {
+		if transaction.Status == model.TransactionCaptured || transaction.Status == model.TransactionRefunded {
+			paid += transaction.Amount.Amount
+			refunded += transaction.RefundedAmount.Amount
+		}
+	}
+	payStatus := orderPayStatusPaid
+	switch {
+	case paid > 0 && refunded >= paid:
+		payStatus = orderPayStatusRefunded
+	case refunded > 0:
+		payStatus = orderPayStatusPartiallyRefunded
+	}
+	if err := orderUpdater.UpdatePayStatus(ctx, orderID, payStatus); err != nil {
+		slog.Warn("更新订单退款状态失败", "order_id", orderID, "pay_status", payStatus, "error", err)
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"payment/domain/model"
+	"payment/provider"
+	"testing"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+type refundFixture struct {
+	transactions *fakeTransactions
+	refunds      *fakeRefunds
+	orders       *fakeOrders
+	service      IRefundDataService
+}
+
+// newRefundFixture 订单 7 的交易 1 在模拟渠道扣款 1000 USD；captureRef 为空时使用渠道中不存在的扣款号
+func newRefundFixture(t *testing.T, channelProvider provider.Provider, captureRef string) *refundFixture {
+	t.Helper()
+	f := &refundFixture{orders: &fakeOrders{}}
+	f.transactions = newFakeTransactions(model.Transaction{ID: 1, OrderID: 7, PaymentID: 1, Amount: money.New(1000, "USD"),
+		RefundedAmount: money.Zero("USD"), CaptureRef: captureRef, Status: model.TransactionCaptured})
+	f.refunds = newFakeRefunds(f.transactions)
+	f.service = NewRefundDataService(f.refunds, f.transactions,
+		&fakePayments{payments: map[int64]*model.Payment{1: {ID: 1, Provider: provider.MockName}}},
+		fakeProviders{provider.MockName: channelProvider}, f.orders)
+	return f
+}
+
+// capturedMock 在模拟渠道中为订单 7 的交易 1 扣款 1000 USD，返回扣款号
+func capturedMock(t *testing.T) (*provider.Mock, string) {
+	t.Helper()
+	mock := provider.NewMock("secret", "")
+	ctx := context.Background()
+	payment, err := mock.CreatePayment(ctx, &provider.CreatePaymentRequest{OrderID: 7, TransactionID: 1, Amount: money.New(1000, "USD")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	capture, err := mock.Capture(ctx, payment.ProviderRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mock, capture.CaptureRef
+}
+
+func (f *refundFixture) refund(refundID string, amount int64) (*model.Refund, error) {
+	return f.service.Refund(context.Background(), &RefundRequest{RefundID: refundID, OrderID: 7, TransactionID: 1,
+		Amount: money.New(amount, "")})
+}
+
+// TestRefundReservesCapturedTotal 累计退款不超过扣款金额，金额为 0 时退还剩余金额
+func TestRefundReservesCapturedTotal(t *testing.T) {
+	mock, captureRef := capturedMock(t)
+	f := newRefundFixture(t, mock, captureRef)
+
+	refund, err := f.refund("R1", 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.Status != model.RefundSucceeded || refund.ProviderRef != "MOCK-REF-R1" {
+		t.Fatalf("R1 = %+v, want succeeded", refund)
+	}
+	if f.orders.payStatus[7] != orderPayStatusPartiallyRefunded {
+		t.Fatalf("pay status = %d, want partially refunded", f.orders.payStatus[7])
+	}
+
+	if _, err := f.refund("R2", 500); err != ErrRefundExceeded {
+		t.Fatalf("R2 err = %v, want ErrRefundExceeded", err)
+	}
+	if len(f.refunds.refunds) != 1 || f.transactions.transactions[1].RefundedAmount.Amount != 600 {
+		t.Fatalf("refunds = %d, refunded = %v, want only R1 reserved", len(f.refunds.refunds), f.transactions.transactions[1].RefundedAmount)
+	}
+
+	refund, err = f.refund("R3", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transaction := f.transactions.transactions[1]
+	if refund.Amount.Amount != 400 || transaction.RefundedAmount.Amount != 1000 || transaction.Status != model.TransactionRefunded {
+		t.Fatalf("R3 = %+v, transaction = %+v, want remaining 400 refunded", refund, transaction)
+	}
+	if f.orders.payStatus[7] != orderPayStatusRefunded {
+		t.Fatalf("pay status = %d, want refunded", f.orders.payStatus[7])
+	}
+}
+
+// TestRefundIdempotentOnRefundID 同一 RefundID 重复请求返回首次结果，参数不一致时冲突
+func TestRefundIdempotentOnRefundID(t *testing.T) {
+	mock, captureRef := capturedMock(t)
+	f := newRefundFixture(t, mock, captureRef)
+
+	first, err := f.refund("R1", 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := f.refund(" R1 ", 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != first.ID || again.Status != model.RefundSucceeded {
+		t.Fatalf("重复请求 = %+v, want %+v", again, first)
+	}
+	if len(f.refunds.refunds) != 1 || f.transactions.transactions[1].RefundedAmount.Amount != 600 {
+		t.Fatalf("refunds = %d, refunded = %v, want a single reservation", len(f.refunds.refunds), f.transactions.transactions[1].RefundedAmount)
+	}
+	if _, err := f.refund("R1", 500); err != ErrRefundIDConflict {
+		t.Fatalf("金额不一致 err = %v, want ErrRefundIDConflict", err)
+	}
+}
+
+// TestRefundReleasesReservationOnProviderFailure 渠道调用失败时退款置为失败，释放预占金额并恢复订单支付状态
+func TestRefundReleasesReservationOnProviderFailure(t *testing.T) {
+	f := newRefundFixture(t, provider.NewMock("secret", ""), "MOCK-CAP-UNKNOWN")
+
+	_, err := f.refund("R1", 600)
+	var paymentErr *PaymentError
+	if !errors.As(err, &paymentErr) || paymentErr.Code != http.StatusBadGateway {
+		t.Fatalf("err = %v, want provider error", err)
+	}
+	refund, _ := f.refunds.FindRefundByRefundID("R1")
+	if refund == nil || refund.Status != model.RefundFailed || refund.FailureReason == "" {
+		t.Fatalf("R1 = %+v, want failed", refund)
+	}
+	if transaction := f.transactions.transactions[1]; transaction.RefundedAmount.Amount != 0 || transaction.Status != model.TransactionCaptured {
+		t.Fatalf("transaction = %+v, want reservation released", transaction)
+	}
+	if f.orders.payStatus[7] != orderPayStatusPaid {
+		t.Fatalf("pay status = %d, want paid", f.orders.payStatus[7])
+	}
+
+	// 失败的 RefundID 重复请求返回失败结果，不再调用渠道
+	if again, err := f.refund("R1", 600); err != nil || again.Status != model.RefundFailed {
+		t.Fatalf("重复请求 = %+v, %v, want failed refund", again, err)
+	}
+}
+
+// pendingRefundProvider 渠道受理退款后异步处理
+type pendingRefundProvider struct {
+	provider.Provider
+}
+
+func (pendingRefundProvider) Refund(ctx context.Context, req *provider.RefundRequest) (*provider.RefundResult, error) {
+	return &provider.RefundResult{RefundRef: "REF-" + req.RefundID, Status: provider.StatusPending}, nil
+}
+
+// TestRefundPendingAtProvider 渠道异步处理的退款保持 pending 并记录渠道退款号，订单保持退款中
+func TestRefundPendingAtProvider(t *testing.T) {
+	f := newRefundFixture(t, pendingRefundProvider{}, "CAP-1")
+
+	refund, err := f.refund("R1", 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := f.refunds.FindRefundByRefundID("R1")
+	if refund.Status != model.RefundPending || stored.Status != model.RefundPending || stored.ProviderRef != "REF-R1" {
+		t.Fatalf("R1 = %+v, stored = %+v, want pending with REF-R1", refund, stored)
+	}
+	if f.transactions.transactions[1].RefundedAmount.Amount != 600 || f.orders.payStatus[7] != orderPayStatusRefunding {
+		t.Fatalf("refunded = %v, pay status = %d, want 600 reserved and refunding",
+			f.transactions.transactions[1].RefundedAmount, f.orders.payStatus[7])
+	}
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"payment/domain/model"
+	"payment/domain/repository"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+)
+
+// RouteRequest 路由条件，Country 为用户所在国家（ISO 3166-1 alpha-2），可为空
+type RouteRequest struct {
+	Amount  money.Money
+	Country string
+}
+
+// RouteCandidate 路由结果中的一个支付通道
+type RouteCandidate struct {
+	PaymentID int64
+	Healthy   bool
+}
+
+// Route 路由结果，Candidates 为依次尝试的支付通道
+type Route struct {
+	RuleID     int64
+	Candidates []RouteCandidate
+}
+
+// PaymentIDs 按尝试顺序返回支付通道ID
+func (r *Route) PaymentIDs() []int64 {
+	ids := make([]int64, 0, len(r.Candidates))
+	for _, candidate := range r.Candidates {
+		ids = append(ids, candidate.PaymentID)
+	}
+	return ids
+}
+
+type IRoutingService interface {
+	AddRule(*model.RoutingRule) (int64, error)
+	UpdateRule(*model.RoutingRule) error
+	DeleteRule(int64) error
+	FindRuleByID(int64) (*model.RoutingRule, error)
+	FindAllRules() ([]model.RoutingRule, error)
+	// Route 按当前规则与通道健康状态为交易选择支付通道，每次调用都读取最新规则
+	Route(RouteRequest) (*Route, error)
+	// ReportResult 上报一次渠道调用结果，err 为空表示成功
+	ReportResult(paymentID int64, err error)
+}
+
+// 创建
+func NewRoutingService(ruleRepository repository.IRoutingRuleRepository, paymentRepository repository.IPaymentRepository,
+	health *ChannelHealth) IRoutingService {
+	return &RoutingService{RuleRepository: ruleRepository, PaymentRepository: paymentRepository, Health: health}
+}
+
+type RoutingService struct {
+	RuleRepository    repository.IRoutingRuleRepository
+	PaymentRepository repository.IPaymentRepository
+	Health            *ChannelHealth
+}
+
+// 新增规则
+func (u *RoutingService) AddRule(rule *model.RoutingRule) (int64, error) {
+	if err := u.validateRule(rule); err != nil {
+		return 0, err
+	}
+	return u.RuleRepository.CreateRule(rule)
+}
+
+// 更新规则，立即对后续交易生效
+func (u *RoutingService) UpdateRule(rule *model.RoutingRule) error {
+	existing, err := u.FindRuleByID(rule.ID)
+	if err != nil {
+		return err
+	}
+	if err := u.validateRule(rule); err != nil {
+		return err
+	}
+	rule.CreatedAt = existing.CreatedAt
+	return u.RuleRepository.UpdateRule(rule)
+}
+
+// 删除规则
+func (u *RoutingService) DeleteRule(ruleID int64) error {
+	if _, err := u.FindRuleByID(ruleID); err != nil {
+		return err
+	}
+	return u.RuleRepository.DeleteRuleByID(ruleID)
+}
+
+// 查找规则
+func (u *RoutingService) FindRuleByID(ruleID int64) (*model.RoutingRule, error) {
+	rule, err := u.RuleRepository.FindRuleByID(ruleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRoutingRuleNotFound
+	}
+	return rule, err
+}
+
+// 查找全部规则，按匹配顺序排列
+func (u *RoutingService) FindAllRules() ([]model.RoutingRule, error) {
+	return u.RuleRepository.FindAll()
+}
+
+func (u *RoutingService) Route(request RouteRequest) (*Route, error) {
+	rules, err := u.RuleRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	rule := matchRule(rules, request)
+	if rule == nil {
+		return nil, ErrNoRoute
+	}
+	return &Route{RuleID: rule.ID, Candidates: orderCandidates(rule, u.Health.Healthy)}, nil
+}
+
+func (u *RoutingService) ReportResult(paymentID int64, err error) {
+	u.Health.Report(paymentID, err)
+}
+
+// validateRule 规范化并校验规则，引用的支付通道必须存在
+func (u *RoutingService) validateRule(rule *model.RoutingRule) error {
+	rule.Currency = strings.ToUpper(strings.TrimSpace(rule.Currency))
+	if rule.Currency != "" && !money.ValidCurrency(rule.Currency) {
+		return ErrInvalidCurrency
+	}
+	if rule.MinAmount < 0 || rule.MaxAmount < 0 || (rule.MaxAmount > 0 && rule.MaxAmount < rule.MinAmount) {
+		return invalidRoutingRule("金额范围不合法")
+	}
+	if (rule.MinAmount > 0 || rule.MaxAmount > 0) && rule.Currency == "" {
+		return invalidRoutingRule("设置金额范围时必须指定币种")
+	}
+	for i, country := range rule.Countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if !validCountry(country) {
+			return ErrInvalidCountry
+		}
+		rule.Countries[i] = country
+	}
+	if rule.PaymentID <= 0 {
+		return invalidRoutingRule("必须指定首选支付通道")
+	}
+	seen := map[int64]bool{}
+	for _, paymentID := range append([]int64{rule.PaymentID}, rule.FallbackPaymentIDs...) {
+		if seen[paymentID] {
+			return invalidRoutingRule("支付通道重复")
+		}
+		seen[paymentID] = true
+		if _, err := findPayment(u.PaymentRepository, paymentID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func invalidRoutingRule(reason string) error {
+	return &PaymentError{Code: http.StatusBadRequest, Msg: "路由规则不合法: " + reason}
+}
+
+// validCountry 国家代码为两位大写字母
+func validCountry(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, c := range country {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRule 返回第一条匹配的启用规则，rules 须已按匹配顺序排列
+func matchRule(rules []model.RoutingRule, request RouteRequest) *model.RoutingRule {
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Enabled {
+			continue
+		}
+		if rule.Currency != "" && rule.Currency != request.Amount.Currency {
+			continue
+		}
+		if rule.MinAmount > 0 && request.Amount.Amount < rule.MinAmount {
+			continue
+		}
+		if rule.MaxAmount > 0 && request.Amount.Amount > rule.MaxAmount {
+			continue
+		}
+		if len(rule.Countries) > 0 && !containsCountry(rule.Countries, request.Country) {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+func containsCountry(countries []string, country string) bool {
+	for _, c := range countries {
+		if c == country {
+			return true
+		}
+	}
+	return false
+}
+
+// orderCandidates 按首选、备用的顺序排列通道，不健康的通道保持相对顺序排在最后，
+// 全部不健康时仍依次尝试
+func orderCandidates(rule *model.RoutingRule, healthy func(int64) bool) []RouteCandidate {
+	var up, down []RouteCandidate
+	for _, paymentID := range append([]int64{rule.PaymentID}, rule.FallbackPaymentIDs...) {
+		if healthy(paymentID) {
+			up = append(up, RouteCandidate{PaymentID: paymentID, Healthy: true})
+		} else {
+			down = append(down, RouteCandidate{PaymentID: paymentID})
+		}
+	}
+	return append(up, down...)
+}
+
+// ChannelHealth 按连续失败次数判定支付通道健康状态，状态只保存在本实例内存中。
+// 连续失败达到阈值后在冷却期内视为不健康；冷却期过后放行请求试探，再次失败则重新进入冷却期。
+type ChannelHealth struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	channels map[int64]*channelState
+}
+
+type channelState struct {
+	failures  int
+	downUntil time.Time
+}
+
+// NewChannelHealth 创建通道健康状态，threshold 不大于 0 时所有通道始终视为健康
+func NewChannelHealth(threshold int, cooldown time.Duration) *ChannelHealth {
+	return &ChannelHealth{threshold: threshold, cooldown: cooldown, now: time.Now, channels: map[int64]*channelState{}}
+}
+
+// Healthy 通道是否健康
+func (h *ChannelHealth) Healthy(paymentID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	state, ok := h.channels[paymentID]
+	return !ok || h.threshold <= 0 || state.failures < h.threshold || !h.now().Before(state.downUntil)
+}
+
+// Report 记录一次调用结果，成功时清除失败计数
+func (h *ChannelHealth) Report(paymentID int64, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err == nil {
+		delete(h.channels, paymentID)
+		return
+	}
+	state, ok := h.channels[paymentID]
+	if !ok {
+		state = &channelState{}
+		h.channels[paymentID] = state
+	}
+	state.failures++
+	if h.threshold > 0 && state.failures >= h.threshold {
+		state.downUntil = h.now().Add(h.cooldown)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"payment/domain/model"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+func TestMatchRule(t *testing.T) {
+	rules := []model.RoutingRule{
+		{ID: 1, Enabled: false, PaymentID: 9},
+		{ID: 2, Enabled: true, Currency: "EUR", Countries: []string{"DE", "FR"}, PaymentID: 2},
+		{ID: 3, Enabled: true, Currency: "USD", MinAmount: 100000, PaymentID: 3},
+		{ID: 4, Enabled: true, Currency: "USD", MaxAmount: 99999, PaymentID: 4},
+	}
+	cases := []struct {
+		request RouteRequest
+		want    int64
+	}{
+		{RouteRequest{Amount: money.New(500, "EUR"), Country: "DE"}, 2},
+		{RouteRequest{Amount: money.New(500, "EUR"), Country: "US"}, 0},
+		{RouteRequest{Amount: money.New(100000, "USD")}, 3},
+		{RouteRequest{Amount: money.New(99999, "USD")}, 4},
+		{RouteRequest{Amount: money.New(500, "JPY")}, 0},
+	}
+	for _, c := range cases {
+		var got int64
+		if rule := matchRule(rules, c.request); rule != nil {
+			got = rule.ID
+		}
+		if got != c.want {
+			t.Errorf("matchRule(%+v) = %d, want %d", c.request, got, c.want)
+		}
+	}
+}
+
+func TestOrderCandidates(t *testing.T) {
+	rule := &model.RoutingRule{PaymentID: 1, FallbackPaymentIDs: []int64{2, 3}}
+	down := map[int64]bool{1: true, 2: true}
+	candidates := orderCandidates(rule, func(id int64) bool { return !down[id] })
+	want := []RouteCandidate{{PaymentID: 3, Healthy: true}, {PaymentID: 1}, {PaymentID: 2}}
+	if !reflect.DeepEqual(candidates, want) {
+		t.Fatalf("candidates = %+v, want %+v", candidates, want)
+	}
+}
+
+// TestChannelHealth 连续失败达到阈值后冷却，冷却期过后放行试探，成功后恢复
+func TestChannelHealth(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	health := NewChannelHealth(2, time.Minute)
+	health.now = func() time.Time { return now }
+	failure := errors.New("timeout")
+
+	health.Report(1, failure)
+	if !health.Healthy(1) {
+		t.Fatal("未达到阈值时应视为健康")
+	}
+	health.Report(1, failure)
+	if health.Healthy(1) {
+		t.Fatal("达到阈值后应视为不健康")
+	}
+	now = now.Add(time.Minute)
+	if !health.Healthy(1) {
+		t.Fatal("冷却期过后应放行试探")
+	}
+	health.Report(1, failure)
+	if health.Healthy(1) {
+		t.Fatal("试探失败后应重新进入冷却期")
+	}
+	health.Report(1, nil)
+	if !health.Healthy(1) {
+		t.Fatal("成功后应恢复健康")
+	}
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"payment/domain/model"
+	"payment/domain/repository"
+	"payment/provider"
+	"strings"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"gorm.io/gorm"
+)
+
+// 交易状态流转：key 为目标状态，value 为允许的来源状态。
+// refunded 由退款流水按累计退款金额流转，不在此处直接设置。
+var transactionTransitions = map[string][]string{
+	model.TransactionAuthorized: {model.TransactionPending},
+	model.TransactionCaptured:   {model.TransactionPending, model.TransactionAuthorized},
+	model.TransactionFailed:     {model.TransactionPending, model.TransactionAuthorized},
+}
+
+// IProviderResolver 按支付通道的渠道名与凭证解析支付渠道
+type IProviderResolver interface {
+	Resolve(name, secret string, live bool) (provider.Provider, error)
+}
+
+type ITransactionDataService interface {
+	CreateTransaction(context.Context, *model.Transaction) (int64, error)
+	CaptureTransaction(context.Context, int64, string) (*model.Transaction, error)
+	FindTransactionByID(int64) (*model.Transaction, error)
+	FindAllByOrder(int64) ([]model.Transaction, error)
+	TransitionStatus(int64, string, map[string]interface{}) error
+}
+
+// 创建，router 为空时交易必须指定支付通道
+func NewTransactionDataService(transactionRepository repository.ITransactionRepository,
+	paymentRepository repository.IPaymentRepository, providers IProviderResolver, orders IOrderFinder,
+	router IRoutingService) ITransactionDataService {
+	return &TransactionDataService{
+		TransactionRepository: transactionRepository,
+		PaymentRepository:     paymentRepository,
+		Providers:             providers,
+		Orders:                orders,
+		Router:                router,
+	}
+}
+
+type TransactionDataService struct {
+	TransactionRepository repository.ITransactionRepository
+	PaymentRepository     repository.IPaymentRepository
+	Providers             IProviderResolver
+	Orders                IOrderFinder
+	Router                IRoutingService
+}
+
+// 创建交易，初始状态为 pending，并在支付渠道创建支付单。
+// 交易以订单的结算币种支付，金额须等于订单应付金额，未指定金额时按订单应付金额支付。
+// 未指定支付通道时按路由规则选择，跳过无法解析的通道，渠道下单失败时依次切换到备用通道，全部失败才置为 failed。
+// 调用方已自行在渠道下单（传入 ProviderRef）时不再重复创建。
+func (u *TransactionDataService) CreateTransaction(ctx context.Context, transaction *model.Transaction) (int64, error) {
+	if transaction.OrderID <= 0 {
+		return 0, ErrInvalidOrder
+	}
+	transaction.Amount = money.New(transaction.Amount.Amount, transaction.Amount.Currency)
+	if u.Orders != nil {
+		order, err := u.Orders.FindOrder(ctx, transaction.OrderID)
+		if err != nil {
+			return 0, err
+		}
+		transaction.UserID = order.UserID
+		due := order.AmountDue
+		if transaction.Amount.Currency == "" && transaction.Amount.IsZero() {
+			transaction.Amount = due
+		}
+		if transaction.Amount.Currency != due.Currency {
+			return 0, ErrSettlementCurrency
+		}
+		if transaction.Amount.Amount != due.Amount {
+			return 0, ErrAmountMismatch
+		}
+	}
+	if !transaction.Amount.IsPositive() {
+		return 0, ErrInvalidAmount
+	}
+	if !money.ValidCurrency(transaction.Amount.Currency) {
+		return 0, ErrInvalidCurrency
+	}
+	transaction.RefundedAmount = money.Zero(transaction.Amount.Currency)
+	transaction.Country = strings.ToUpper(strings.TrimSpace(transaction.Country))
+	if transaction.Country != "" && !validCountry(transaction.Country) {
+		return 0, ErrInvalidCountry
+	}
+	candidates := []int64{transaction.PaymentID}
+	if transaction.PaymentID == 0 {
+		if u.Router == nil {
+			return 0, ErrPaymentNotFound
+		}
+		route, err := u.Router.Route(RouteRequest{Amount: transaction.Amount, Country: transaction.Country})
+		if err != nil {
+			return 0, err
+		}
+		candidates = route.PaymentIDs()
+	}
+	// 依次解析候选通道，跳过已删除或渠道不受支持的通道，全部无法解析时返回首个通道的错误
+	var channelProvider provider.Provider
+	resolveErr := error(ErrPaymentNotFound)
+	for i, paymentID := range candidates {
+		resolved, err := u.channelProvider(paymentID)
+		if err == nil {
+			channelProvider, candidates = resolved, candidates[i:]
+			break
+		}
+		slog.Warn("支付通道不可用，跳过", "payment_id", paymentID, "error", err)
+		if i == 0 {
+			resolveErr = err
+		}
+	}
+	if channelProvider == nil {
+		return 0, resolveErr
+	}
+	transaction.PaymentID = candidates[0]
+	transaction.Status = model.TransactionPending
+	transaction.FailureReason = ""
+	transaction.CaptureRef = ""
+	transaction.CapturedAt = nil
+	transactionID, err := u.TransactionRepository.CreateTransaction(transaction)
+	if err != nil || transaction.ProviderRef != "" {
+		return transactionID, err
+	}
+
+	var created *provider.CreatePaymentResult
+	for i, paymentID := range candidates {
+		if i > 0 {
+			slog.Warn("支付通道下单失败，切换备用通道", "transaction_id", transactionID,
+				"from", transaction.PaymentID, "to", paymentID, "error", err)
+			if channelProvider, err = u.channelProvider(paymentID); err != nil {
+				continue
+			}
+			if err = u.TransactionRepository.UpdateStatus(transactionID, []string{model.TransactionPending},
+				map[string]interface{}{"payment_id": paymentID}); err != nil {
+				return transactionID, err
+			}
+			transaction.PaymentID = paymentID
+		}
+		created, err = channelProvider.CreatePayment(ctx, &provider.CreatePaymentRequest{
+			OrderID:       transaction.OrderID,
+			TransactionID: transactionID,
+			Amount:        transaction.Amount,
+		})
+		u.reportResult(paymentID, err)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		if failErr := u.TransitionStatus(transactionID, model.TransactionFailed,
+			map[string]interface{}{"failure_reason": err.Error()}); failErr != nil {
+			return transactionID, failErr
+		}
+		var paymentErr *PaymentError
+		if errors.As(err, &paymentErr) {
+			return transactionID, err
+		}
+		return transactionID, providerError(err)
+	}
+	transaction.ProviderRef = created.ProviderRef
+	transaction.ApprovalURL = created.ApprovalURL
+	return transactionID, u.TransactionRepository.UpdateStatus(transactionID, []string{model.TransactionPending},
+		map[string]interface{}{"provider_ref": created.ProviderRef, "approval_url": created.ApprovalURL})
+}
+
+// 在支付渠道扣款，渠道拒绝时交易置为 failed 并记录原因。
+// providerRef 仅在交易尚无渠道单号时使用，兼容由客户端直接在渠道下单的场景。
+func (u *TransactionDataService) CaptureTransaction(ctx context.Context, transactionID int64, providerRef string) (*model.Transaction, error) {
+	transaction, err := u.FindTransactionByID(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.Status != model.TransactionPending && transaction.Status != model.TransactionAuthorized {
+		return nil, ErrTransactionTransition
+	}
+	if transaction.ProviderRef == "" {
+		transaction.ProviderRef = providerRef
+	}
+	if transaction.ProviderRef == "" {
+		return nil, ErrProviderRefMissing
+	}
+	channelProvider, err := u.channelProvider(transaction.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	captured, err := channelProvider.Capture(ctx, transaction.ProviderRef)
+	u.reportResult(transaction.PaymentID, err)
+	if err != nil {
+		return nil, providerError(err)
+	}
+	if captured.Status == provider.StatusFailed {
+		err = u.TransitionStatus(transactionID, model.TransactionFailed, map[string]interface{}{
+			"provider_ref":   transaction.ProviderRef,
+			"failure_reason": "支付渠道拒绝扣款",
+		})
+	} else {
+		err = u.TransitionStatus(transactionID, model.TransactionCaptured, map[string]interface{}{
+			"provider_ref": transaction.ProviderRef,
+			"capture_ref":  captured.CaptureRef,
+			"captured_at":  time.Now(),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u.FindTransactionByID(transactionID)
+}
+
+// 查找
+func (u *TransactionDataService) FindTransactionByID(transactionID int64) (*model.Transaction, error) {
+	transaction, err := u.TransactionRepository.FindTransactionByID(transactionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTransactionNotFound
+	}
+	return transaction, err
+}
+
+// 查找订单的所有交易
+func (u *TransactionDataService) FindAllByOrder(orderID int64) ([]model.Transaction, error) {
+	if orderID <= 0 {
+		return nil, ErrInvalidOrder
+	}
+	return u.TransactionRepository.FindAllByOrder(orderID)
+}
+
+// channelProvider 查找支付通道并解析其渠道实现
+func (u *TransactionDataService) channelProvider(paymentID int64) (provider.Provider, error) {
+	channel, err := findPayment(u.PaymentRepository, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveProvider(u.Providers, channel)
+}
+
+// reportResult 向路由上报渠道调用结果，用于判定通道健康状态
+func (u *TransactionDataService) reportResult(paymentID int64, err error) {
+	if u.Router != nil {
+		u.Router.ReportResult(paymentID, err)
+	}
+}
+
+// TransitionStatus 按状态机将交易流转到 to，values 为同时更新的其他字段
+func (u *TransactionDataService) TransitionStatus(transactionID int64, to string, values map[string]interface{}) error {
+	from, ok := transactionTransitions[to]
+	if !ok {
+		return ErrTransactionTransition
+	}
+	if _, err := u.FindTransactionByID(transactionID); err != nil {
+		return err
+	}
+	updates := map[string]interface{}{"status": to}
+	for k, v := range values {
+		updates[k] = v
+	}
+	if err := u.TransactionRepository.UpdateStatus(transactionID, from, updates); err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return ErrTransactionTransition
+		}
+		return err
+	}
+	return nil
+}
+
+// 查找支付通道
+func findPayment(paymentRepository repository.IPaymentRepository, paymentID int64) (*model.Payment, error) {
+	channel, err := paymentRepository.FindPaymentByID(paymentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPaymentNotFound
+	}
+	return channel, err
+}
+
+// 解析支付通道对应的渠道实现，PaymentSid 为渠道密钥，PaymentStatus 为 true 时使用生产环境
+func resolveProvider(providers IProviderResolver, channel *model.Payment) (provider.Provider, error) {
+	channelProvider, err := providers.Resolve(channel.Provider, channel.PaymentSid, channel.PaymentStatus)
+	if errors.Is(err, provider.ErrUnknownProvider) {
+		return nil, ErrUnknownProvider
+	}
+	return channelProvider, err
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"payment/domain/model"
+	"payment/provider"
+	"testing"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// TestCreateTransactionSkipsUnresolvableCandidates 路由的首选通道已删除或渠道不受支持时使用后续通道
+func TestCreateTransactionSkipsUnresolvableCandidates(t *testing.T) {
+	transactions := newFakeTransactions()
+	service := NewTransactionDataService(transactions,
+		&fakePayments{payments: map[int64]*model.Payment{
+			2: {ID: 2, Provider: "retired"},
+			3: {ID: 3, Provider: provider.MockName},
+		}},
+		fakeProviders{provider.MockName: provider.NewMock("secret", "")},
+		&fakeOrders{orders: map[int64]*OrderSummary{7: {UserID: 1, AmountDue: money.New(1000, "USD")}}},
+		&fakeRouter{route: &Route{Candidates: []RouteCandidate{{PaymentID: 1}, {PaymentID: 2}, {PaymentID: 3}}}})
+
+	transactionID, err := service.CreateTransaction(context.Background(), &model.Transaction{OrderID: 7})
+	if err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+	transaction := transactions.transactions[transactionID]
+	if transaction.PaymentID != 3 || transaction.Status != model.TransactionPending || transaction.ProviderRef == "" {
+		t.Fatalf("transaction = %+v, want pending on payment 3", transaction)
+	}
+}
+
+// TestCreateTransactionNoResolvableCandidate 所有候选通道都无法解析时返回首个通道的错误且不创建交易
+func TestCreateTransactionNoResolvableCandidate(t *testing.T) {
+	transactions := newFakeTransactions()
+	service := NewTransactionDataService(transactions,
+		&fakePayments{payments: map[int64]*model.Payment{2: {ID: 2, Provider: "retired"}}},
+		fakeProviders{}, nil,
+		&fakeRouter{route: &Route{Candidates: []RouteCandidate{{PaymentID: 1}, {PaymentID: 2}}}})
+
+	_, err := service.CreateTransaction(context.Background(), &model.Transaction{OrderID: 7, Amount: money.New(1000, "USD")})
+	if err != ErrPaymentNotFound {
+		t.Fatalf("err = %v, want ErrPaymentNotFound", err)
+	}
+	if len(transactions.transactions) != 0 {
+		t.Fatalf("transactions = %+v, want none", transactions.transactions)
+	}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"payment/domain/model"
+	"payment/domain/repository"
+	"payment/provider"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IWebhookService interface {
+	HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) (*model.WebhookEvent, error)
+}
+
+// 创建
+func NewWebhookService(webhookRepository repository.IWebhookRepository, transactionRepository repository.ITransactionRepository,
+	refundRepository repository.IRefundRepository, paymentRepository repository.IPaymentRepository, providers IProviderResolver,
+	orderUpdater IOrderUpdater) IWebhookService {
+	return &WebhookService{
+		WebhookRepository:     webhookRepository,
+		TransactionRepository: transactionRepository,
+		RefundRepository:      refundRepository,
+		PaymentRepository:     paymentRepository,
+		Providers:             providers,
+		OrderUpdater:          orderUpdater,
+	}
+}
+
+type WebhookService struct {
+	WebhookRepository     repository.IWebhookRepository
+	TransactionRepository repository.ITransactionRepository
+	RefundRepository      repository.IRefundRepository
+	PaymentRepository     repository.IPaymentRepository
+	Providers             IProviderResolver
+	OrderUpdater          IOrderUpdater
+}
+
+// HandleWebhook 校验渠道签名并按事件ID去重，然后将事件转换为交易状态变更：
+// 扣款完成时交易置为 captured 并将订单标记为已支付；退款事件更新对应的退款流水与交易累计退款。
+// 签名无效或重放的回调被拒绝并记录日志。
+func (u *WebhookService) HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) (*model.WebhookEvent, error) {
+	verified, err := u.verify(ctx, providerName, header, body)
+	if err != nil {
+		slog.Warn("拒绝支付回调：签名校验失败", "provider", providerName, "error", err)
+		return nil, err
+	}
+	if verified.EventID == "" {
+		slog.Warn("拒绝支付回调：缺少事件ID", "provider", providerName, "event_type", verified.EventType)
+		return nil, ErrWebhookInvalid
+	}
+	if exists, err := u.WebhookRepository.ExistsEvent(providerName, verified.EventID); err != nil {
+		return nil, err
+	} else if exists {
+		slog.Warn("拒绝支付回调：事件重放", "provider", providerName, "event_id", verified.EventID)
+		return nil, ErrWebhookReplayed
+	}
+
+	event := &model.WebhookEvent{
+		Provider:    providerName,
+		EventID:     verified.EventID,
+		EventType:   verified.EventType,
+		ProviderRef: verified.ProviderRef,
+		CaptureRef:  verified.CaptureRef,
+		RefundRef:   verified.RefundRef,
+		Status:      string(verified.Status),
+		Result:      model.WebhookIgnored,
+	}
+	if verified.IsRefund() {
+		refund, processed, err := u.applyRefund(ctx, verified)
+		if err != nil {
+			return nil, err
+		}
+		if refund != nil {
+			event.TransactionID = refund.TransactionID
+		}
+		if processed {
+			event.Result = model.WebhookProcessed
+		}
+		return u.createEvent(event)
+	}
+
+	transaction, err := u.findTransaction(verified)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		slog.Warn("支付回调找不到关联交易", "provider", providerName, "event_id", verified.EventID,
+			"provider_ref", verified.ProviderRef, "capture_ref", verified.CaptureRef)
+	} else {
+		event.TransactionID = transaction.ID
+		processed, err := u.apply(ctx, transaction, verified)
+		if err != nil {
+			return nil, err
+		}
+		if processed {
+			event.Result = model.WebhookProcessed
+		}
+	}
+	return u.createEvent(event)
+}
+
+// createEvent 记录已处理的回调，并发重复投递时唯一索引冲突，视为重放
+func (u *WebhookService) createEvent(event *model.WebhookEvent) (*model.WebhookEvent, error) {
+	if err := u.WebhookRepository.CreateEvent(event); err != nil {
+		if errors.Is(err, repository.ErrDuplicateEvent) {
+			slog.Warn("拒绝支付回调：事件重放", "provider", event.Provider, "event_id", event.EventID)
+			return nil, ErrWebhookReplayed
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+// verify 使用该渠道下各支付通道的凭证依次校验签名，任一通过即可
+func (u *WebhookService) verify(ctx context.Context, providerName string, header http.Header, body []byte) (*provider.WebhookEvent, error) {
+	channels, err := u.PaymentRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []model.Payment
+	for _, channel := range channels {
+		if channel.Provider == providerName || (channel.Provider == "" && providerName == provider.PayPalName) {
+			candidates = append(candidates, channel)
+		}
+	}
+	if len(candidates) == 0 {
+		// 没有配置通道时仍可校验不依赖通道凭证的渠道，如模拟渠道
+		candidates = append(candidates, model.Payment{Provider: providerName})
+	}
+
+	lastErr := error(ErrWebhookSignature)
+	for i := range candidates {
+		channelProvider, err := resolveProvider(u.Providers, &candidates[i])
+		if err != nil {
+			return nil, err
+		}
+		event, err := channelProvider.VerifyWebhook(ctx, header, body)
+		if err == nil {
+			return event, nil
+		}
+		lastErr = err
+	}
+	slog.Debug("支付回调签名校验失败", "provider", providerName, "error", lastErr)
+	return nil, ErrWebhookSignature
+}
+
+// findTransaction 查找事件关联的交易，不存在时返回 nil
+func (u *WebhookService) findTransaction(event *provider.WebhookEvent) (*model.Transaction, error) {
+	if event.ProviderRef == "" && event.CaptureRef == "" {
+		return nil, nil
+	}
+	transaction, err := u.TransactionRepository.FindTransactionByRef(event.ProviderRef, event.CaptureRef)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return transaction, err
+}
+
+// apply 按事件状态更新交易，交易已处于目标状态时视为未处理
+func (u *WebhookService) apply(ctx context.Context, transaction *model.Transaction, event *provider.WebhookEvent) (bool, error) {
+	var err error
+	switch event.Status {
+	case provider.StatusApproved:
+		err = u.TransactionRepository.UpdateStatus(transaction.ID, []string{model.TransactionPending},
+			map[string]interface{}{"status": model.TransactionAuthorized})
+	case provider.StatusCaptured:
+		err = u.TransactionRepository.UpdateStatus(transaction.ID,
+			[]string{model.TransactionPending, model.TransactionAuthorized}, map[string]interface{}{
+				"status":      model.TransactionCaptured,
+				"capture_ref": event.CaptureRef,
+				"captured_at": time.Now(),
+			})
+		if err == nil || (errors.Is(err, repository.ErrStatusConflict) && transaction.Status == model.TransactionCaptured) {
+			// 订单更新失败时返回错误，渠道重试回调时再次标记
+			if err := u.OrderUpdater.UpdatePayStatus(ctx, transaction.OrderID, orderPayStatusPaid); err != nil {
+				return false, err
+			}
+		}
+	case provider.StatusFailed:
+		err = u.TransactionRepository.UpdateStatus(transaction.ID,
+			[]string{model.TransactionPending, model.TransactionAuthorized}, map[string]interface{}{
+				"status":         model.TransactionFailed,
+				"failure_reason": "支付渠道回调: " + event.EventType,
+			})
+	case provider.StatusDisputed:
+		slog.Warn("支付交易发生争议", "transaction_id", transaction.ID, "order_id", transaction.OrderID, "event_type", event.EventType)
+		err = u.TransactionRepository.UpdateStatus(transaction.ID,
+			[]string{model.TransactionCaptured, model.TransactionRefunded}, map[string]interface{}{"disputed_at": time.Now()})
+	default:
+		// 其余事件仅记录
+		return false, nil
+	}
+	if errors.Is(err, repository.ErrStatusConflict) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// applyRefund 按退款事件更新退款流水：完成时确认退款，失败时释放预占的可退金额，随后重新计算订单支付状态。
+// 退款仍在处理中或已处于终态时视为未处理；不是经由本服务发起的退款找不到流水，仅记录日志。
+func (u *WebhookService) applyRefund(ctx context.Context, event *provider.WebhookEvent) (*model.Refund, bool, error) {
+	refund, err := u.findRefund(event)
+	if err != nil {
+		return nil, false, err
+	}
+	if refund == nil {
+		slog.Warn("退款回调找不到关联退款", "event_id", event.EventID, "refund_id", event.RefundID,
+			"refund_ref", event.RefundRef, "capture_ref", event.CaptureRef)
+		return nil, false, nil
+	}
+	switch event.Status {
+	case provider.StatusRefunded:
+		providerRef := event.RefundRef
+		if providerRef == "" {
+			providerRef = refund.ProviderRef
+		}
+		_, err = u.RefundRepository.CompleteRefund(refund.ID, providerRef)
+	case provider.StatusFailed:
+		_, err = u.RefundRepository.FailRefund(refund.ID, "支付渠道回调: "+event.EventType)
+	default:
+		return refund, false, nil
+	}
+	if errors.Is(err, repository.ErrStatusConflict) {
+		return refund, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	updateRefundPayStatus(ctx, u.TransactionRepository, u.OrderUpdater, refund.OrderID)
+	return refund, true, nil
+}
+
+// findRefund 先按回传的 RefundID 查找，回调可能先于同步退款记录渠道退款号到达；再按渠道退款号查找。不存在时返回 nil
+func (u *WebhookService) findRefund(event *provider.WebhookEvent) (*model.Refund, error) {
+	if event.RefundID != "" {
+		refund, err := u.RefundRepository.FindRefundByRefundID(event.RefundID)
+		if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+			return refund, err
+		}
+	}
+	if event.RefundRef == "" {
+		return nil, nil
+	}
+	refund, err := u.RefundRepository.FindRefundByProviderRef(event.RefundRef)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return refund, err
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"payment/domain/model"
+	"payment/provider"
+	"testing"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+type webhookFixture struct {
+	transactions *fakeTransactions
+	refunds      *fakeRefunds
+	webhooks     *fakeWebhooks
+	orders       *fakeOrders
+	mock         *provider.Mock
+	service      IWebhookService
+}
+
+// newWebhookFixture 订单 7 有一笔已扣款 1000 USD 的交易，并预占了 R1（400）与 R2（600）两笔处理中的退款
+func newWebhookFixture(t *testing.T) *webhookFixture {
+	t.Helper()
+	f := &webhookFixture{
+		transactions: newFakeTransactions(model.Transaction{ID: 1, OrderID: 7, PaymentID: 1, Amount: money.New(1000, "USD"),
+			RefundedAmount: money.Zero("USD"), CaptureRef: "CAP-1", Status: model.TransactionCaptured}),
+		webhooks: &fakeWebhooks{},
+		orders:   &fakeOrders{},
+		mock:     provider.NewMock("secret", ""),
+	}
+	f.refunds = newFakeRefunds(f.transactions)
+	for refundID, amount := range map[string]int64{"R1": 400, "R2": 600} {
+		refund := &model.Refund{RefundID: refundID, OrderID: 7, TransactionID: 1, Amount: money.New(amount, "USD"), Status: model.RefundPending}
+		if err := f.refunds.ReserveRefund(refund); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.service = NewWebhookService(f.webhooks, f.transactions, f.refunds,
+		&fakePayments{payments: map[int64]*model.Payment{1: {ID: 1, Provider: provider.MockName}}},
+		fakeProviders{provider.MockName: f.mock}, f.orders)
+	return f
+}
+
+func (f *webhookFixture) send(t *testing.T, body map[string]string) *model.WebhookEvent {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := http.Header{}
+	header.Set(provider.MockSignatureHeader, f.mock.SignWebhook(raw))
+	event, err := f.service.HandleWebhook(context.Background(), provider.MockName, header, raw)
+	if err != nil {
+		t.Fatalf("HandleWebhook(%s): %v", raw, err)
+	}
+	return event
+}
+
+// TestWebhookRefundEvents 退款回调确认或失败对应的退款流水，失败时释放预占的可退金额
+func TestWebhookRefundEvents(t *testing.T) {
+	f := newWebhookFixture(t)
+
+	event := f.send(t, map[string]string{"id": "EV-1", "event_type": "refund.completed", "refund_id": "R1",
+		"refund_ref": "REF-1", "capture_ref": "CAP-1", "status": string(provider.StatusRefunded)})
+	if event.Result != model.WebhookProcessed || event.TransactionID != 1 {
+		t.Fatalf("event = %+v, want processed for transaction 1", event)
+	}
+	if refund, _ := f.refunds.FindRefundByRefundID("R1"); refund.Status != model.RefundSucceeded || refund.ProviderRef != "REF-1" {
+		t.Fatalf("R1 = %+v, want succeeded with REF-1", refund)
+	}
+
+	// 按渠道退款号关联，失败后释放 R2 预占的 600
+	f.refunds.refunds[2].ProviderRef = "REF-2"
+	f.send(t, map[string]string{"id": "EV-2", "event_type": "refund.failed", "refund_ref": "REF-2",
+		"status": string(provider.StatusFailed)})
+	if refund, _ := f.refunds.FindRefundByRefundID("R2"); refund.Status != model.RefundFailed {
+		t.Fatalf("R2 = %+v, want failed", refund)
+	}
+	transaction := f.transactions.transactions[1]
+	if transaction.RefundedAmount.Amount != 400 || transaction.Status != model.TransactionCaptured {
+		t.Fatalf("transaction = %+v, want 400 refunded and still captured", transaction)
+	}
+	if f.orders.payStatus[7] != orderPayStatusPartiallyRefunded {
+		t.Fatalf("pay status = %d, want partially refunded", f.orders.payStatus[7])
+	}
+
+	// 已处于终态的退款再收到回调不再处理
+	event = f.send(t, map[string]string{"id": "EV-3", "event_type": "refund.completed", "refund_id": "R2",
+		"status": string(provider.StatusRefunded)})
+	if event.Result != model.WebhookIgnored {
+		t.Fatalf("event = %+v, want ignored", event)
+	}
+	if refund, _ := f.refunds.FindRefundByRefundID("R2"); refund.Status != model.RefundFailed {
+		t.Fatalf("R2 = %+v, want still failed", refund)
+	}
+}
+
+// TestWebhookRefundPending 退款处理中的回调与找不到退款流水的回调仅记录
+func TestWebhookRefundPending(t *testing.T) {
+	f := newWebhookFixture(t)
+	for _, body := range []map[string]string{
+		{"id": "EV-1", "event_type": "refund.pending", "refund_id": "R1", "status": string(provider.StatusPending)},
+		{"id": "EV-2", "event_type": "refund.completed", "refund_id": "R9", "status": string(provider.StatusRefunded)},
+	} {
+		if event := f.send(t, body); event.Result != model.WebhookIgnored {
+			t.Fatalf("event = %+v, want ignored", event)
+		}
+	}
+	if refund, _ := f.refunds.FindRefundByRefundID("R1"); refund.Status != model.RefundPending {
+		t.Fatalf("R1 = %+v, want pending", refund)
+	}
+	if len(f.orders.payStatus) != 0 {
+		t.Fatalf("pay status = %v, want untouched", f.orders.payStatus)
+	}
+}
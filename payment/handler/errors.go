@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"errors"
+	"payment/domain/service"
+
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// 返回给调用方的 go-micro 错误 ID
+const serviceID = "go.micro.service.payment"
+
+// toMicroError 将支付业务错误转换为带状态码的 go-micro 错误，其余错误记录后原样返回
+func toMicroError(err error) error {
+	var paymentErr *service.PaymentError
+	if errors.As(err, &paymentErr) {
+		return microerrors.New(serviceID, paymentErr.Msg, paymentErr.Code)
+	}
+	ErrorHandle(err)
+	return err
+}
@@ -11,13 +11,28 @@ import (
 )
 
 type Payment struct {
-	PaymentDataService service.IPaymentDataService
-	tracer             trace.Tracer // 新增：用于创建span的trace
+	PaymentDataService     service.IPaymentDataService
+	TransactionDataService service.ITransactionDataService
+	RefundDataService      service.IRefundDataService
+	WebhookService         service.IWebhookService
+	ReconciliationService  service.IReconciliationService
+	RoutingService         service.IRoutingService
+	Orders                 service.IOrderFinder // 校验交易与退款的调用方是否为订单本人
+	tracer                 trace.Tracer         // 新增：用于创建span的trace
 }
 
-func NewPaymentHandler(service service.IPaymentDataService) *Payment {
+func NewPaymentHandler(paymentService service.IPaymentDataService, transactionService service.ITransactionDataService,
+	refundService service.IRefundDataService, webhookService service.IWebhookService,
+	reconciliationService service.IReconciliationService, routingService service.IRoutingService,
+	orders service.IOrderFinder) *Payment {
 	return &Payment{
-		PaymentDataService: service,
+		PaymentDataService:     paymentService,
+		TransactionDataService: transactionService,
+		RefundDataService:      refundService,
+		WebhookService:         webhookService,
+		ReconciliationService:  reconciliationService,
+		RoutingService:         routingService,
+		Orders:                 orders,
 		// 定义tracer名称（建议包含服务名和组件名，确保唯一）
 		tracer: trace.NewNoopTracerProvider().Tracer("payment/handler", trace.WithInstrumentationVersion("v1.0.0")),
 	}
@@ -53,7 +68,11 @@ func (e *Payment) FindPaymentByID(ctx context.Context, request *payment.PaymentI
 	if err != nil {
 		ErrorHandle(err)
 	}
-	return common.SwapTo(payment, response)
+	if err := common.SwapTo(payment, response); err != nil {
+		return err
+	}
+	redactSecret(response)
+	return nil
 }
 
 func (e *Payment) FindAllPayment(ctx context.Context, request *payment.All, response *payment.PaymentAll) error {
@@ -67,6 +86,7 @@ func (e *Payment) FindAllPayment(ctx context.Context, request *payment.All, resp
 		if err := common.SwapTo(v, paymentInfo); err != nil {
 			ErrorHandle(err)
 		}
+		redactSecret(paymentInfo)
 		response.PaymentInfo = append(response.PaymentInfo, paymentInfo)
 	}
 	return nil
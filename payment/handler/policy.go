@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// Policy 支付服务的访问策略，未列入的端点一律拒绝。HandleWebhook 由渠道签名校验，不要求令牌；
+// 只要求登录的交易与退款端点在处理器中再用 authorizeOrder 校验是否为订单本人
+var Policy = auth.Policy{
+	"Payment.FindPaymentByID": auth.PermAuthenticated,
+	"Payment.FindAllPayment":  auth.PermAuthenticated,
+
+	"Payment.AddPayment":        auth.PermPaymentManage,
+	"Payment.UpdatePayment":     auth.PermPaymentManage,
+	"Payment.DeletePaymentByID": auth.PermPaymentManage,
+
+	"Payment.CreateTransaction":       auth.PermAuthenticated,
+	"Payment.CaptureTransaction":      auth.PermAuthenticated,
+	"Payment.FindTransactionByID":     auth.PermAuthenticated,
+	"Payment.FindTransactionsByOrder": auth.PermAuthenticated,
+	"Payment.FindRefundsByOrder":      auth.PermAuthenticated,
+
+	"Payment.HandleWebhook": auth.PermPublic,
+
+	"Payment.Refund":                 auth.PermPaymentRefund,
+	"Payment.RunReconciliation":      auth.PermPaymentManage,
+	"Payment.FindReconciliationRuns": auth.PermPaymentManage,
+	"Payment.FindReconciliationRun":  auth.PermPaymentManage,
+	"Payment.AddRoutingRule":         auth.PermPaymentManage,
+	"Payment.UpdateRoutingRule":      auth.PermPaymentManage,
+	"Payment.DeleteRoutingRule":      auth.PermPaymentManage,
+	"Payment.FindRoutingRules":       auth.PermPaymentManage,
+	"Payment.RouteTransaction":       auth.PermPaymentManage,
+
+	// 隐私请求流程由用户服务以服务令牌调用
+	"Privacy.ExportUserData": auth.PermPrivacyProcess,
+	"Privacy.EraseUserData":  auth.PermPrivacyProcess,
+}
+
+// authorizeOrder 只允许订单本人或拥有 staff 权限的调用方访问订单的交易与退款
+func (e *Payment) authorizeOrder(ctx context.Context, orderID int64, staff auth.Permission) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return microerrors.Unauthorized(serviceID, "%s", auth.ErrUnauthenticated.Error())
+	}
+	if claims.Can(staff) {
+		return nil
+	}
+	order, err := e.Orders.FindOrder(ctx, orderID)
+	if err != nil {
+		return toMicroError(err)
+	}
+	if order.UserID > 0 && order.UserID == claims.UserID() {
+		return nil
+	}
+	slog.WarnContext(ctx, "拒绝访问", "audit", "access_denied", "target", "order_payment", "order_id", orderID,
+		"owner_id", order.UserID, "user_id", claims.UserID(), "session_id", claims.SessionID, "roles", claims.Roles)
+	return microerrors.Forbidden(serviceID, "%s", auth.ErrForbidden.Error())
+}
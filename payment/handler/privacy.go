@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"payment/domain/service"
+
+	"github.com/Ben1524/GoMall/common/proto/privacy"
+)
+
+// Privacy 隐私请求流程的支付步骤，只接受带 privacy:process 权限的服务令牌（见 Policy）；按请求中的订单ID查找交易与退款
+type Privacy struct {
+	PrivacyService service.IPrivacyService
+}
+
+// 导出用户订单的交易与退款
+func (h *Privacy) ExportUserData(ctx context.Context, request *privacy.UserDataRequest, response *privacy.UserDataResponse) error {
+	data, err := h.PrivacyService.ExportUserData(request.OrderIds)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.Data, err = json.Marshal(data)
+	return err
+}
+
+// 清除用户订单交易与退款中的个人信息
+func (h *Privacy) EraseUserData(ctx context.Context, request *privacy.UserDataRequest, response *privacy.UserDataResponse) (err error) {
+	response.Affected, err = h.PrivacyService.EraseUserData(request.OrderIds)
+	return toMicroError(err)
+}
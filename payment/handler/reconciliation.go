@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"payment/domain/model"
+	"payment/domain/service"
+	payment "payment/proto/payment"
+	"time"
+)
+
+// 立即对账指定日期，未指定时对账前一天
+func (e *Payment) RunReconciliation(ctx context.Context, request *payment.ReconciliationRequest, response *payment.ReconciliationRunInfo) error {
+	day := time.Now().AddDate(0, 0, -1)
+	if request.Day != "" {
+		var err error
+		if day, err = time.ParseInLocation(time.DateOnly, request.Day, time.Local); err != nil {
+			return toMicroError(service.ErrReconciliationDay)
+		}
+	}
+	run, err := e.ReconciliationService.Reconcile(ctx, day)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillReconciliationRunInfo(run, response)
+	return nil
+}
+
+// 查询最近的对账记录，不含差异明细
+func (e *Payment) FindReconciliationRuns(ctx context.Context, request *payment.ReconciliationQuery, response *payment.ReconciliationRunAll) error {
+	runs, err := e.ReconciliationService.FindRuns(int(request.Limit))
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range runs {
+		info := &payment.ReconciliationRunInfo{}
+		fillReconciliationRunInfo(&runs[i], info)
+		response.RunInfo = append(response.RunInfo, info)
+	}
+	return nil
+}
+
+// 查询对账记录及差异明细
+func (e *Payment) FindReconciliationRun(ctx context.Context, request *payment.ReconciliationRunID, response *payment.ReconciliationRunInfo) error {
+	run, discrepancies, err := e.ReconciliationService.FindRun(request.RunId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillReconciliationRunInfo(run, response)
+	for i := range discrepancies {
+		response.Discrepancies = append(response.Discrepancies, toDiscrepancyInfo(&discrepancies[i]))
+	}
+	return nil
+}
+
+func fillReconciliationRunInfo(run *model.ReconciliationRun, info *payment.ReconciliationRunInfo) {
+	info.Id = run.ID
+	info.Day = run.Day
+	info.Status = run.Status
+	info.Channels = run.Channels
+	info.Skipped = run.Skipped
+	info.Matched = run.Matched
+	info.DiscrepancyCount = run.Discrepancies
+	info.Error = run.Error
+	info.StartedAt = run.StartedAt.Unix()
+	if run.FinishedAt != nil {
+		info.FinishedAt = run.FinishedAt.Unix()
+	}
+}
+
+func toDiscrepancyInfo(discrepancy *model.Discrepancy) *payment.DiscrepancyInfo {
+	info := &payment.DiscrepancyInfo{
+		Id:            discrepancy.ID,
+		Type:          discrepancy.Type,
+		PaymentId:     discrepancy.PaymentID,
+		OrderId:       discrepancy.OrderID,
+		TransactionId: discrepancy.TransactionID,
+		CaptureRef:    discrepancy.CaptureRef,
+		Detail:        discrepancy.Detail,
+	}
+	if discrepancy.LedgerAmount.Currency != "" {
+		info.LedgerAmount = toMoney(discrepancy.LedgerAmount)
+	}
+	if discrepancy.ProviderAmount.Currency != "" {
+		info.ProviderAmount = toMoney(discrepancy.ProviderAmount)
+	}
+	return info
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"payment/domain/model"
+	"payment/domain/service"
+	payment "payment/proto/payment"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 发起退款
+func (e *Payment) Refund(ctx context.Context, request *payment.RefundRequest, response *payment.RefundInfo) error {
+	amount := money.FromProto(request.RefundAmount)
+	// 未传 Money 字段的旧调用方按废弃的浮点金额换算，小数位取交易币种（如 JPY 无小数、KWD 三位小数）
+	if request.RefundAmount == nil && request.Amount != 0 {
+		transaction, err := e.TransactionDataService.FindTransactionByID(request.TransactionId)
+		if err != nil {
+			return toMicroError(err)
+		}
+		amount = money.FromFloat(request.Amount, transaction.Amount.Currency)
+	}
+	refund, err := e.RefundDataService.Refund(ctx, &service.RefundRequest{
+		RefundID:      request.RefundId,
+		OrderID:       request.OrderId,
+		TransactionID: request.TransactionId,
+		Amount:        amount,
+		Reason:        request.Reason,
+	})
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillRefundInfo(refund, response)
+	return nil
+}
+
+// 查询订单的所有退款，只允许订单本人或可处理退款的人员
+func (e *Payment) FindRefundsByOrder(ctx context.Context, request *payment.OrderID, response *payment.RefundAll) error {
+	if err := e.authorizeOrder(ctx, request.OrderId, auth.PermPaymentRefund); err != nil {
+		return err
+	}
+	refunds, err := e.RefundDataService.FindAllByOrder(request.OrderId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range refunds {
+		info := &payment.RefundInfo{}
+		fillRefundInfo(&refunds[i], info)
+		response.RefundInfo = append(response.RefundInfo, info)
+	}
+	return nil
+}
+
+func fillRefundInfo(refund *model.Refund, info *payment.RefundInfo) {
+	info.Id = refund.ID
+	info.RefundId = refund.RefundID
+	info.OrderId = refund.OrderID
+	info.TransactionId = refund.TransactionID
+	info.RefundAmount = toMoney(refund.Amount)
+	info.Amount = refund.Amount.Float64()
+	info.Currency = refund.Amount.Currency
+	info.Reason = refund.Reason
+	info.Status = refund.Status
+	info.ProviderRef = refund.ProviderRef
+	info.FailureReason = refund.FailureReason
+	info.CreatedAt = refund.CreatedAt.Unix()
+	info.UpdatedAt = refund.UpdatedAt.Unix()
+}
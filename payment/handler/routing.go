@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"payment/domain/model"
+	"payment/domain/service"
+	payment "payment/proto/payment"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 新增路由规则
+func (e *Payment) AddRoutingRule(ctx context.Context, request *payment.RoutingRule, response *payment.RoutingRuleID) (err error) {
+	response.RuleId, err = e.RoutingService.AddRule(toRoutingRule(request))
+	return toMicroError(err)
+}
+
+// 更新路由规则
+func (e *Payment) UpdateRoutingRule(ctx context.Context, request *payment.RoutingRule, response *payment.Response) error {
+	return toMicroError(e.RoutingService.UpdateRule(toRoutingRule(request)))
+}
+
+// 删除路由规则
+func (e *Payment) DeleteRoutingRule(ctx context.Context, request *payment.RoutingRuleID, response *payment.Response) error {
+	return toMicroError(e.RoutingService.DeleteRule(request.RuleId))
+}
+
+// 查询全部路由规则，按匹配顺序排列
+func (e *Payment) FindRoutingRules(ctx context.Context, request *payment.All, response *payment.RoutingRuleAll) error {
+	rules, err := e.RoutingService.FindAllRules()
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range rules {
+		response.Rules = append(response.Rules, toRoutingRuleInfo(&rules[i]))
+	}
+	return nil
+}
+
+// 预览交易的路由结果
+func (e *Payment) RouteTransaction(ctx context.Context, request *payment.RouteRequest, response *payment.RouteResult) error {
+	route, err := e.RoutingService.Route(service.RouteRequest{
+		Amount:  money.FromProto(request.Amount),
+		Country: request.Country,
+	})
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.RuleId = route.RuleID
+	for _, candidate := range route.Candidates {
+		response.Candidates = append(response.Candidates, &payment.RouteCandidate{
+			PaymentId: candidate.PaymentID,
+			Healthy:   candidate.Healthy,
+		})
+	}
+	return nil
+}
+
+func toRoutingRule(info *payment.RoutingRule) *model.RoutingRule {
+	return &model.RoutingRule{
+		ID:                 info.Id,
+		Name:               info.Name,
+		Priority:           info.Priority,
+		Enabled:            info.Enabled,
+		Currency:           info.Currency,
+		MinAmount:          info.MinAmount,
+		MaxAmount:          info.MaxAmount,
+		Countries:          info.Countries,
+		PaymentID:          info.PaymentId,
+		FallbackPaymentIDs: info.FallbackPaymentIds,
+	}
+}
+
+func toRoutingRuleInfo(rule *model.RoutingRule) *payment.RoutingRule {
+	return &payment.RoutingRule{
+		Id:                 rule.ID,
+		Name:               rule.Name,
+		Priority:           rule.Priority,
+		Enabled:            rule.Enabled,
+		Currency:           rule.Currency,
+		MinAmount:          rule.MinAmount,
+		MaxAmount:          rule.MaxAmount,
+		Countries:          rule.Countries,
+		PaymentId:          rule.PaymentID,
+		FallbackPaymentIds: rule.FallbackPaymentIDs,
+		CreatedAt:          rule.CreatedAt.Unix(),
+		UpdatedAt:          rule.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"payment/domain/service"
+	payment "payment/proto/payment"
+)
+
+// redactSecret 读接口不返回渠道密钥，已配置密钥时返回占位值；明文密钥只在支付服务进程内由渠道实现解密使用
+func redactSecret(info *payment.PaymentInfo) {
+	if info.PaymentSid != "" {
+		info.PaymentSid = service.RedactedSecret
+	}
+}
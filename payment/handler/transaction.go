@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"payment/domain/model"
+	payment "payment/proto/payment"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// 创建支付交易，只允许订单本人或支付运营人员
+func (e *Payment) CreateTransaction(ctx context.Context, request *payment.TransactionInfo, response *payment.TransactionID) (err error) {
+	if err := e.authorizeOrder(ctx, request.OrderId, auth.PermPaymentManage); err != nil {
+		return err
+	}
+	transaction := &model.Transaction{
+		OrderID:     request.OrderId,
+		PaymentID:   request.PaymentId,
+		Amount:      money.FromProto(request.ChargeAmount),
+		ProviderRef: request.ProviderRef,
+		Country:     request.Country,
+	}
+	// 未传 Money 字段的旧调用方按废弃的浮点金额与币种换算
+	if request.ChargeAmount == nil {
+		transaction.Amount = money.FromFloat(request.Amount, request.Currency)
+	}
+	response.TransactionId, err = e.TransactionDataService.CreateTransaction(ctx, transaction)
+	return toMicroError(err)
+}
+
+// 确认扣款，只允许订单本人或支付运营人员
+func (e *Payment) CaptureTransaction(ctx context.Context, request *payment.CaptureRequest, response *payment.TransactionInfo) error {
+	if _, err := e.findAuthorizedTransaction(ctx, request.TransactionId, auth.PermPaymentManage); err != nil {
+		return err
+	}
+	transaction, err := e.TransactionDataService.CaptureTransaction(ctx, request.TransactionId, request.ProviderRef)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillTransactionInfo(transaction, response)
+	return nil
+}
+
+// 根据ID查询交易，只允许订单本人或可处理退款的人员
+func (e *Payment) FindTransactionByID(ctx context.Context, request *payment.TransactionID, response *payment.TransactionInfo) error {
+	transaction, err := e.findAuthorizedTransaction(ctx, request.TransactionId, auth.PermPaymentRefund)
+	if err != nil {
+		return err
+	}
+	fillTransactionInfo(transaction, response)
+	return nil
+}
+
+// 查询订单的所有交易，只允许订单本人或可处理退款的人员
+func (e *Payment) FindTransactionsByOrder(ctx context.Context, request *payment.OrderID, response *payment.TransactionAll) error {
+	if err := e.authorizeOrder(ctx, request.OrderId, auth.PermPaymentRefund); err != nil {
+		return err
+	}
+	transactions, err := e.TransactionDataService.FindAllByOrder(request.OrderId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range transactions {
+		info := &payment.TransactionInfo{}
+		fillTransactionInfo(&transactions[i], info)
+		response.TransactionInfo = append(response.TransactionInfo, info)
+	}
+	return nil
+}
+
+// findAuthorizedTransaction 查找交易并校验调用方是否可访问其订单
+func (e *Payment) findAuthorizedTransaction(ctx context.Context, transactionID int64, staff auth.Permission) (*model.Transaction, error) {
+	transaction, err := e.TransactionDataService.FindTransactionByID(transactionID)
+	if err != nil {
+		return nil, toMicroError(err)
+	}
+	if err := e.authorizeOrder(ctx, transaction.OrderID, staff); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func fillTransactionInfo(transaction *model.Transaction, info *payment.TransactionInfo) {
+	info.Id = transaction.ID
+	info.OrderId = transaction.OrderID
+	info.UserId = transaction.UserID
+	info.PaymentId = transaction.PaymentID
+	info.Country = transaction.Country
+	info.ChargeAmount = toMoney(transaction.Amount)
+	info.RefundedTotal = toMoney(transaction.RefundedAmount)
+	// 同时填充废弃的浮点字段，兼容未升级的调用方
+	info.Amount = transaction.Amount.Float64()
+	info.Currency = transaction.Amount.Currency
+	info.RefundedAmount = transaction.RefundedAmount.Float64()
+	info.ProviderRef = transaction.ProviderRef
+	info.ApprovalUrl = transaction.ApprovalURL
+	info.CaptureRef = transaction.CaptureRef
+	info.Status = transaction.Status
+	info.FailureReason = transaction.FailureReason
+	info.CreatedAt = transaction.CreatedAt.Unix()
+	info.UpdatedAt = transaction.UpdatedAt.Unix()
+}
+
+func toMoney(m money.Money) *payment.Money {
+	return &payment.Money{Amount: m.Amount, Currency: m.Currency}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	payment "payment/proto/payment"
+)
+
+// 处理支付渠道回调
+func (e *Payment) HandleWebhook(ctx context.Context, request *payment.WebhookRequest, response *payment.WebhookResponse) error {
+	header := http.Header{}
+	for key, values := range request.Header {
+		for _, value := range values.GetValues() {
+			header.Add(key, value)
+		}
+	}
+	event, err := e.WebhookService.HandleWebhook(ctx, request.Provider, header, request.Body)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.EventId = event.EventID
+	response.EventType = event.EventType
+	response.Status = event.Status
+	response.TransactionId = event.TransactionID
+	response.Result = event.Result
+	return nil
+}
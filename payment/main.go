@@ -11,11 +11,16 @@ import (
 	srv "payment/domain/service"
 	"payment/handler"
 	"payment/metrics"
+	"payment/provider"
 	"syscall"
 
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/microauth"
 	config "github.com/Ben1524/GoMall/common/config"
 	"github.com/Ben1524/GoMall/common/db"
 	"github.com/Ben1524/GoMall/common/otel"
+	"github.com/Ben1524/GoMall/common/proto/privacy"
+	"github.com/Ben1524/GoMall/common/secret"
 	"go-micro.dev/v5"
 	"go-micro.dev/v5/client"
 	"go-micro.dev/v5/registry"
@@ -25,6 +30,7 @@ import (
 	ratelimit3 "go.uber.org/ratelimit"
 	"golang.org/x/time/rate"
 
+	orderpb "payment/proto/order"
 	pb "payment/proto/payment"
 
 	// 限流器（Uber 令牌桶）
@@ -89,7 +95,11 @@ func main() {
 		os.Exit(1)
 	}
 	defer func() {
-		if err := mysqlDB.Close(); err != nil {
+		sqlDB, err := mysqlDB.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
 			slog.Warn("关闭MySQL连接失败", "error", err)
 		} else {
 			slog.Info("MySQL连接已关闭")
@@ -103,7 +113,48 @@ func main() {
 		panic(err)
 	}
 
-	paymentService := srv.NewPaymentDataService(paymentRepository)
+	// 渠道密钥加密保存，启动时将明文与旧密钥加密的密钥用主密钥重新加密
+	keyring, err := secret.LoadKeyring(cfg.SecretKeys)
+	if err != nil {
+		slog.Error("加载加密密钥失败", "error", err)
+		panic(err)
+	}
+	paymentService := srv.NewPaymentDataService(paymentRepository, keyring)
+	if rotated, err := paymentService.RotateSecrets(); err != nil {
+		slog.Error("重新加密支付通道密钥失败", "error", err)
+		panic(err)
+	} else if rotated > 0 {
+		slog.Info("已重新加密支付通道密钥", "count", rotated, "key_id", keyring.PrimaryKeyID())
+	}
+
+	transactionRepository := repository.NewTransactionRepository(mysqlDB)
+	if err := transactionRepository.InitTable(); err != nil {
+		slog.Error("init transaction table error")
+		panic(err)
+	}
+	// 未指定支付通道的交易按路由规则选择通道，规则保存在数据库中可随时修改
+	routingRuleRepository := repository.NewRoutingRuleRepository(mysqlDB)
+	if err := routingRuleRepository.InitTable(); err != nil {
+		slog.Error("init routing rule table error")
+		panic(err)
+	}
+	routingService := srv.NewRoutingService(routingRuleRepository, paymentRepository,
+		srv.NewChannelHealth(cfg.PaymentRouting.FailureThreshold, cfg.PaymentRouting.Cooldown))
+
+	// 支付渠道：PayPal 公共参数来自配置，密钥来自各支付通道
+	providers := provider.NewRegistry(cfg.PaymentProvider, keyring)
+
+	// 校验网关转发的访问令牌，与用户服务共享会话存储
+	sessionStore, err := auth.NewStore(cfg)
+	if err != nil {
+		slog.Error("初始化会话存储失败", "error", err)
+		os.Exit(1)
+	}
+	tokenManager, err := auth.NewManager(cfg.JWT, sessionStore)
+	if err != nil {
+		slog.Error("初始化令牌校验失败", "error", err)
+		os.Exit(1)
+	}
 
 	consulRegistry := consul.NewConsulRegistry(registry.Addrs("127.0.0.1:8500"))
 
@@ -124,6 +175,7 @@ func main() {
 	handlerWrappers := []server.HandlerWrapper{
 		ratelimit.NewHandlerWrapper(qps, ratelimit3.WithSlack(3*qps)),
 		opentelemetry.NewHandlerWrapper(),
+		microauth.NewHandlerWrapper(tokenManager, cfg.Server.ServiceName, handler.Policy),
 	}
 	if cfg.Metrics.Enabled {
 		handlerWrappers = append([]server.HandlerWrapper{promMetrics.ServerWrapper()}, handlerWrappers...)
@@ -160,11 +212,62 @@ func main() {
 
 	service := micro.NewService(serviceOptions...)
 	service.Init()
-	if err := pb.RegisterPaymentHandler(service.Server(), handler.NewPaymentHandler(paymentService)); err != nil {
+
+	// 交易按订单结算币种支付，应付金额与币种来自订单服务；回调与对账任务不代表用户，
+	// 订单服务的调用一律以支付服务的服务令牌发出
+	orderClient := microauth.NewServiceClient(service.Client(), tokenManager, cfg.Server.ServiceName)
+	orderService := orderpb.NewOrderService("go.micro.service.order", orderClient)
+	orderFinder := srv.NewOrderFinder(orderService)
+	transactionService := srv.NewTransactionDataService(transactionRepository, paymentRepository, providers,
+		orderFinder, routingService)
+
+	// 退款与渠道回调完成后通知订单服务更新支付状态
+	refundRepository := repository.NewRefundRepository(mysqlDB)
+	if err := refundRepository.InitTable(); err != nil {
+		slog.Error("init refund table error")
+		panic(err)
+	}
+	orderUpdater := srv.NewOrderUpdater(orderService)
+	refundService := srv.NewRefundDataService(refundRepository, transactionRepository, paymentRepository, providers, orderUpdater)
+
+	webhookRepository := repository.NewWebhookRepository(mysqlDB)
+	if err := webhookRepository.InitTable(); err != nil {
+		slog.Error("init webhook table error")
+		panic(err)
+	}
+	webhookService := srv.NewWebhookService(webhookRepository, transactionRepository, refundRepository, paymentRepository, providers, orderUpdater)
+
+	// 每日对账：比对本地交易、渠道对账单与订单支付状态
+	reconciliationRepository := repository.NewReconciliationRepository(mysqlDB)
+	if err := reconciliationRepository.InitTable(); err != nil {
+		slog.Error("init reconciliation table error")
+		panic(err)
+	}
+	paidOrderFinder := srv.NewPaidOrderFinder(orderService)
+	reconciliationService := srv.NewReconciliationService(reconciliationRepository, transactionRepository,
+		paymentRepository, providers, paidOrderFinder, promMetrics)
+	if cfg.Reconciliation.Enabled {
+		go func() {
+			if err := reconciliationService.Run(ctx, cfg.Reconciliation.RunAt); err != nil && ctx.Err() == nil {
+				slog.Error("对账任务退出", "error", err)
+			}
+		}()
+	}
+
+	paymentHandler := handler.NewPaymentHandler(paymentService, transactionService, refundService, webhookService,
+		reconciliationService, routingService, orderFinder)
+	if err := pb.RegisterPaymentHandler(service.Server(), paymentHandler); err != nil {
 		slog.Error("注册Cart处理器失败", "error", err)
 		os.Exit(1)
 	}
 
+	// 隐私请求流程的支付步骤，只供用户服务以服务令牌调用
+	privacyService := srv.NewPrivacyService(transactionRepository, refundRepository)
+	if err := privacy.RegisterPrivacyHandler(service.Server(), &handler.Privacy{PrivacyService: privacyService}); err != nil {
+		slog.Error("注册Privacy处理器失败", "error", err)
+		os.Exit(1)
+	}
+
 	if err := service.Run(); err != nil {
 		slog.Error("服务运行失败", "error", err)
 	}
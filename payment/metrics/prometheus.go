@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"payment/domain/model"
 	"strings"
 	"sync"
 	"time"
@@ -23,6 +24,10 @@ var (
 
 	clientRequestTotal    *prometheus.CounterVec
 	clientRequestDuration *prometheus.HistogramVec
+
+	reconciliationRuns          *prometheus.CounterVec // 按结果统计的对账次数
+	reconciliationDiscrepancies *prometheus.GaugeVec   // 最近一次对账按类型统计的差异数
+	reconciliationLastSuccess   prometheus.Gauge       // 最近一次对账成功的时间戳
 )
 
 // Prometheus 负责暴露 Prometheus 相关能力（HTTP 服务 + 指标包装器）。
@@ -84,15 +89,63 @@ func initCollectors() {
 			[]string{"caller", "target", "endpoint"},
 		)
 
+		reconciliationRuns = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "gomall",
+				Subsystem: "payment",
+				Name:      "reconciliation_runs_total",
+				Help:      "Total number of reconciliation runs by result status.",
+			},
+			[]string{"status"},
+		)
+
+		reconciliationDiscrepancies = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "gomall",
+				Subsystem: "payment",
+				Name:      "reconciliation_discrepancies",
+				Help:      "Discrepancies found by the latest completed reconciliation run, by type.",
+			},
+			[]string{"type"},
+		)
+
+		reconciliationLastSuccess = prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "gomall",
+				Subsystem: "payment",
+				Name:      "reconciliation_last_success_timestamp_seconds",
+				Help:      "Unix timestamp of the latest completed reconciliation run.",
+			},
+		)
+
 		prometheus.MustRegister(
 			serverRequestTotal,
 			serverRequestDuration,
 			clientRequestTotal,
 			clientRequestDuration,
+			reconciliationRuns,
+			reconciliationDiscrepancies,
+			reconciliationLastSuccess,
 		)
 	})
 }
 
+// ObserveReconciliation 记录一次对账的结果，完成时按类型刷新差异数
+func (p *Prometheus) ObserveReconciliation(run *model.ReconciliationRun, discrepancies []model.Discrepancy) {
+	if !p.enabled {
+		return
+	}
+	reconciliationRuns.WithLabelValues(run.Status).Inc()
+	if run.Status != model.ReconciliationCompleted {
+		return
+	}
+	reconciliationDiscrepancies.Reset()
+	for _, discrepancy := range discrepancies {
+		reconciliationDiscrepancies.WithLabelValues(discrepancy.Type).Inc()
+	}
+	reconciliationLastSuccess.SetToCurrentTime()
+}
+
 // StartHTTPServer 启动 Prometheus metrics HTTP 服务。
 func (p *Prometheus) StartHTTPServer(host, port, path string) {
 	if !p.enabled {
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// Mock 渠道名
+const MockName = "mock"
+
+// MockSignatureHeader 模拟渠道回调的签名头，值为请求体的 HMAC-SHA256 十六进制
+const MockSignatureHeader = "X-Mock-Signature"
+
+// MockDeclineCents 金额最小单位的末两位为该值时（如 10.13 USD）模拟扣款被拒，便于测试失败流程
+const MockDeclineCents = 13
+
+var ErrMockRefundExceeded = errors.New("退款金额超过可退金额")
+
+// Mock 本地确定性支付渠道，不访问网络，用于测试与开发环境。
+// 渠道单号由订单与交易ID生成，同样的输入总是得到同样的结果。
+// 配置了 settlementDir 时，扣款成功会追加到当天的对账单文件，对账时从该目录读取。
+type Mock struct {
+	webhookSecret []byte
+	settlementDir string
+	now           func() time.Time
+
+	mu       sync.Mutex
+	payments map[string]*mockPayment // key 为 ProviderRef
+	captures map[string]*mockPayment // key 为 CaptureRef
+	refunds  map[string]*RefundResult
+}
+
+type mockPayment struct {
+	ref      string
+	amount   money.Money
+	status   Status
+	refunded money.Money
+}
+
+// 创建模拟渠道，webhookSecret 用于回调签名，settlementDir 为空时不生成对账单
+func NewMock(webhookSecret, settlementDir string) *Mock {
+	return &Mock{
+		webhookSecret: []byte(webhookSecret),
+		settlementDir: settlementDir,
+		now:           time.Now,
+		payments:      map[string]*mockPayment{},
+		captures:      map[string]*mockPayment{},
+		refunds:       map[string]*RefundResult{},
+	}
+}
+
+func (m *Mock) Name() string {
+	return MockName
+}
+
+func (m *Mock) CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*CreatePaymentResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ref := fmt.Sprintf("MOCK-PAY-%d-%d", req.OrderID, req.TransactionID)
+	if _, ok := m.payments[ref]; !ok {
+		m.payments[ref] = &mockPayment{ref: ref, amount: req.Amount, refunded: money.Zero(req.Amount.Currency), status: StatusCreated}
+	}
+	return &CreatePaymentResult{
+		ProviderRef: ref,
+		ApprovalURL: "mock://approve/" + ref,
+		Status:      m.payments[ref].status,
+	}, nil
+}
+
+// Capture 重复扣款返回同一结果
+func (m *Mock) Capture(ctx context.Context, providerRef string) (*CaptureResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	payment, ok := m.payments[providerRef]
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+	captureRef := "MOCK-CAP-" + providerRef[len("MOCK-PAY-"):]
+	switch payment.status {
+	case StatusCreated, StatusApproved:
+		if payment.amount.Amount%100 == MockDeclineCents {
+			payment.status = StatusFailed
+			return &CaptureResult{Status: StatusFailed}, nil
+		}
+		if err := m.settle(captureRef, payment); err != nil {
+			return nil, err
+		}
+		payment.status = StatusCaptured
+		m.captures[captureRef] = payment
+	case StatusFailed:
+		return &CaptureResult{Status: StatusFailed}, nil
+	}
+	return &CaptureResult{CaptureRef: captureRef, Status: payment.status}, nil
+}
+
+// Refund 同一 RefundID 重复请求返回首次结果，累计退款不超过扣款金额
+func (m *Mock) Refund(ctx context.Context, req *RefundRequest) (*RefundResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if result, ok := m.refunds[req.RefundID]; ok {
+		return result, nil
+	}
+	payment, ok := m.captures[req.CaptureRef]
+	if !ok {
+		return nil, ErrPaymentNotFound
+	}
+	refunded, err := payment.refunded.Add(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if refunded.Amount > payment.amount.Amount {
+		return nil, ErrMockRefundExceeded
+	}
+	payment.refunded = refunded
+	if refunded.Amount == payment.amount.Amount {
+		payment.status = StatusRefunded
+	}
+	result := &RefundResult{RefundRef: "MOCK-REF-" + req.RefundID, Status: StatusRefunded}
+	m.refunds[req.RefundID] = result
+	return result, nil
+}
+
+// SettlementReport 读取 day 当天的对账单文件
+func (m *Mock) SettlementReport(ctx context.Context, day time.Time) ([]SettlementEntry, error) {
+	if m.settlementDir == "" {
+		return nil, ErrSettlementUnsupported
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return readSettlementFile(SettlementFile(m.settlementDir, day))
+}
+
+// settle 将扣款写入当天对账单
+func (m *Mock) settle(captureRef string, payment *mockPayment) error {
+	if m.settlementDir == "" {
+		return nil
+	}
+	now := m.now()
+	return appendSettlementFile(SettlementFile(m.settlementDir, now), SettlementEntry{
+		CaptureRef:  captureRef,
+		ProviderRef: payment.ref,
+		Amount:      payment.amount,
+		SettledAt:   now,
+	})
+}
+
+func (m *Mock) QueryStatus(ctx context.Context, providerRef string) (Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	payment, ok := m.payments[providerRef]
+	if !ok {
+		return "", ErrPaymentNotFound
+	}
+	return payment.status, nil
+}
+
+// mockWebhookBody 模拟渠道回调的请求体
+type mockWebhookBody struct {
+	ID          string `json:"id"`
+	EventType   string `json:"event_type"`
+	ProviderRef string `json:"provider_ref"`
+	CaptureRef  string `json:"capture_ref"`
+	RefundRef   string `json:"refund_ref"`
+	RefundID    string `json:"refund_id"`
+	Status      Status `json:"status"`
+}
+
+// VerifyWebhook 校验 MockSignatureHeader 中的 HMAC 签名
+func (m *Mock) VerifyWebhook(ctx context.Context, header http.Header, body []byte) (*WebhookEvent, error) {
+	signature, err := hex.DecodeString(header.Get(MockSignatureHeader))
+	if err != nil || !hmac.Equal(signature, m.sign(body)) {
+		return nil, ErrInvalidSignature
+	}
+	var event mockWebhookBody
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("解析模拟 webhook 失败: %w", err)
+	}
+	return &WebhookEvent{
+		EventID:     event.ID,
+		EventType:   event.EventType,
+		ProviderRef: event.ProviderRef,
+		CaptureRef:  event.CaptureRef,
+		RefundRef:   event.RefundRef,
+		RefundID:    event.RefundID,
+		Status:      event.Status,
+	}, nil
+}
+
+// SignWebhook 计算回调签名，供测试与本地联调构造回调请求
+func (m *Mock) SignWebhook(body []byte) string {
+	return hex.EncodeToString(m.sign(body))
+}
+
+func (m *Mock) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, m.webhookSecret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// TestMockPaymentFlow 创建、扣款、部分退款与全额退款
+func TestMockPaymentFlow(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMock("secret", "")
+
+	created, err := mock.CreatePayment(ctx, &CreatePaymentRequest{OrderID: 1, TransactionID: 2, Amount: money.New(10000, "USD")})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	if created.ProviderRef != "MOCK-PAY-1-2" || created.Status != StatusCreated {
+		t.Fatalf("unexpected payment %+v", created)
+	}
+
+	captured, err := mock.Capture(ctx, created.ProviderRef)
+	if err != nil || captured.Status != StatusCaptured {
+		t.Fatalf("Capture: %+v %v", captured, err)
+	}
+	again, err := mock.Capture(ctx, created.ProviderRef)
+	if err != nil || again.CaptureRef != captured.CaptureRef {
+		t.Fatalf("repeated Capture should be idempotent: %+v %v", again, err)
+	}
+
+	if _, err := mock.Refund(ctx, &RefundRequest{CaptureRef: captured.CaptureRef, RefundID: "r1", Amount: money.New(4000, "USD")}); err != nil {
+		t.Fatalf("partial Refund: %v", err)
+	}
+	if _, err := mock.Refund(ctx, &RefundRequest{CaptureRef: captured.CaptureRef, RefundID: "r1", Amount: money.New(4000, "USD")}); err != nil {
+		t.Fatalf("repeated Refund should be idempotent: %v", err)
+	}
+	if _, err := mock.Refund(ctx, &RefundRequest{CaptureRef: captured.CaptureRef, RefundID: "r2", Amount: money.New(7000, "USD")}); err != ErrMockRefundExceeded {
+		t.Fatalf("over refund error = %v, want ErrMockRefundExceeded", err)
+	}
+	if _, err := mock.Refund(ctx, &RefundRequest{CaptureRef: captured.CaptureRef, RefundID: "r3", Amount: money.New(6000, "USD")}); err != nil {
+		t.Fatalf("final Refund: %v", err)
+	}
+	if status, _ := mock.QueryStatus(ctx, created.ProviderRef); status != StatusRefunded {
+		t.Fatalf("status = %v, want refunded", status)
+	}
+}
+
+// TestMockDecline 分位为 MockDeclineCents 的金额扣款失败
+func TestMockDecline(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMock("secret", "")
+
+	created, _ := mock.CreatePayment(ctx, &CreatePaymentRequest{OrderID: 1, TransactionID: 3, Amount: money.New(1013, "USD")})
+	captured, err := mock.Capture(ctx, created.ProviderRef)
+	if err != nil || captured.Status != StatusFailed {
+		t.Fatalf("Capture: %+v %v, want failed", captured, err)
+	}
+}
+
+// TestMockWebhookSignature 回调签名校验
+func TestMockWebhookSignature(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMock("secret", "")
+	body := []byte(`{"id":"evt-1","event_type":"capture.completed","provider_ref":"MOCK-PAY-1-2","status":"captured"}`)
+
+	header := http.Header{}
+	header.Set(MockSignatureHeader, mock.SignWebhook(body))
+	event, err := mock.VerifyWebhook(ctx, header, body)
+	if err != nil {
+		t.Fatalf("VerifyWebhook: %v", err)
+	}
+	if event.EventID != "evt-1" || event.Status != StatusCaptured {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	header.Set(MockSignatureHeader, NewMock("other", "").SignWebhook(body))
+	if _, err := mock.VerifyWebhook(ctx, header, body); err != ErrInvalidSignature {
+		t.Fatalf("err = %v, want ErrInvalidSignature", err)
+	}
+}
+
+// TestMockSettlement 扣款写入当天对账单，对账时读回
+func TestMockSettlement(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMock("secret", t.TempDir())
+	day := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	mock.now = func() time.Time { return day }
+
+	created, _ := mock.CreatePayment(ctx, &CreatePaymentRequest{OrderID: 1, TransactionID: 4, Amount: money.New(2550, "USD")})
+	captured, err := mock.Capture(ctx, created.ProviderRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 重复扣款不会重复写入
+	if _, err := mock.Capture(ctx, created.ProviderRef); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := mock.SettlementReport(ctx, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].CaptureRef != captured.CaptureRef ||
+		entries[0].ProviderRef != created.ProviderRef || entries[0].Amount != money.New(2550, "USD") {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if entries, err := mock.SettlementReport(ctx, day.AddDate(0, 0, 1)); err != nil || len(entries) != 0 {
+		t.Fatalf("next day entries = %+v, %v", entries, err)
+	}
+	if _, err := NewMock("secret", "").SettlementReport(ctx, day); err != ErrSettlementUnsupported {
+		t.Fatalf("err = %v, want ErrSettlementUnsupported", err)
+	}
+}
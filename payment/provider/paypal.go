@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/plutov/paypal/v3"
+)
+
+// PayPal 渠道名
+const PayPalName = "paypal"
+
+// PayPalConfig PayPal 凭证，ClientID 来自配置，Secret 来自支付通道的加密存储
+type PayPalConfig struct {
+	ClientID  string
+	Secret    string
+	Live      bool
+	WebhookID string
+	ReturnURL string
+	CancelURL string
+}
+
+// PayPal 基于 PayPal Orders v2 API 的支付渠道
+type PayPal struct {
+	client *paypal.Client
+	config PayPalConfig
+
+	mu         sync.Mutex
+	tokenReady bool
+}
+
+// 创建 PayPal 渠道
+func NewPayPal(config PayPalConfig) (*PayPal, error) {
+	apiBase := paypal.APIBaseSandBox
+	if config.Live {
+		apiBase = paypal.APIBaseLive
+	}
+	client, err := paypal.NewClient(config.ClientID, config.Secret, apiBase)
+	if err != nil {
+		return nil, err
+	}
+	return &PayPal{client: client, config: config}, nil
+}
+
+func (p *PayPal) Name() string {
+	return PayPalName
+}
+
+// ensureToken 首次调用前获取 access token，之后由 SDK 在过期前自动刷新
+func (p *PayPal) ensureToken() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tokenReady {
+		return nil
+	}
+	if _, err := p.client.GetAccessToken(); err != nil {
+		return err
+	}
+	p.tokenReady = true
+	return nil
+}
+
+func (p *PayPal) CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*CreatePaymentResult, error) {
+	if err := p.ensureToken(); err != nil {
+		return nil, err
+	}
+	order, err := p.client.CreateOrder(paypal.OrderIntentCapture, []paypal.PurchaseUnitRequest{{
+		ReferenceID: strconv.FormatInt(req.OrderID, 10),
+		CustomID:    strconv.FormatInt(req.TransactionID, 10),
+		Amount:      &paypal.PurchaseUnitAmount{Currency: req.Amount.Currency, Value: req.Amount.Decimal()},
+	}}, nil, &paypal.ApplicationContext{ReturnURL: p.config.ReturnURL, CancelURL: p.config.CancelURL})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CreatePaymentResult{ProviderRef: order.ID, Status: payPalStatus(order.Status)}
+	for _, link := range order.Links {
+		if link.Rel == "approve" {
+			result.ApprovalURL = link.Href
+		}
+	}
+	return result, nil
+}
+
+func (p *PayPal) Capture(ctx context.Context, providerRef string) (*CaptureResult, error) {
+	if err := p.ensureToken(); err != nil {
+		return nil, err
+	}
+	// 以渠道单号作为幂等键，重复扣款请求不会重复扣款
+	resp, err := p.client.CaptureOrderWithPaypalRequestId(providerRef, paypal.CaptureOrderRequest{}, "capture-"+providerRef)
+	if err != nil {
+		return nil, err
+	}
+	result := &CaptureResult{Status: payPalStatus(resp.Status)}
+	for _, unit := range resp.PurchaseUnits {
+		if unit.Payments != nil && len(unit.Payments.Captures) > 0 {
+			result.CaptureRef = unit.Payments.Captures[0].ID
+		}
+	}
+	return result, nil
+}
+
+func (p *PayPal) Refund(ctx context.Context, req *RefundRequest) (*RefundResult, error) {
+	if err := p.ensureToken(); err != nil {
+		return nil, err
+	}
+	resp, err := p.client.RefundCaptureWithPaypalRequestId(req.CaptureRef, paypal.RefundCaptureRequest{
+		Amount:    &paypal.Money{Currency: req.Amount.Currency, Value: req.Amount.Decimal()},
+		InvoiceID: req.RefundID,
+	}, req.RefundID)
+	if err != nil {
+		return nil, err
+	}
+	return &RefundResult{RefundRef: resp.ID, Status: payPalRefundStatus(resp.Status)}, nil
+}
+
+func (p *PayPal) QueryStatus(ctx context.Context, providerRef string) (Status, error) {
+	if err := p.ensureToken(); err != nil {
+		return "", err
+	}
+	order, err := p.client.GetOrder(providerRef)
+	if err != nil {
+		return "", err
+	}
+	return payPalStatus(order.Status), nil
+}
+
+// VerifyWebhook 调用 PayPal 签名校验接口，通过后解析事件
+func (p *PayPal) VerifyWebhook(ctx context.Context, header http.Header, body []byte) (*WebhookEvent, error) {
+	if err := p.ensureToken(); err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header = header
+	resp, err := p.client.VerifyWebhookSignature(httpReq, p.config.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.VerificationStatus != "SUCCESS" {
+		return nil, ErrInvalidSignature
+	}
+
+	var event struct {
+		ID        string `json:"id"`
+		EventType string `json:"event_type"`
+		Resource  struct {
+			ID                   string       `json:"id"`
+			Status               string       `json:"status"`
+			InvoiceID            string       `json:"invoice_id"`
+			Links                []payPalLink `json:"links"`
+			DisputedTransactions []struct {
+				SellerTransactionID string `json:"seller_transaction_id"`
+			} `json:"disputed_transactions"`
+			SupplementaryData struct {
+				RelatedIDs struct {
+					OrderID string `json:"order_id"`
+				} `json:"related_ids"`
+			} `json:"supplementary_data"`
+		} `json:"resource"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("解析 PayPal webhook 失败: %w", err)
+	}
+
+	result := &WebhookEvent{EventID: event.ID, EventType: event.EventType, ProviderRef: event.Resource.ID}
+	switch event.EventType {
+	case "PAYMENT.CAPTURE.COMPLETED":
+		result.ProviderRef = event.Resource.SupplementaryData.RelatedIDs.OrderID
+		result.CaptureRef = event.Resource.ID
+		result.Status = StatusCaptured
+	case "PAYMENT.CAPTURE.DENIED", "PAYMENT.CAPTURE.DECLINED":
+		result.ProviderRef = event.Resource.SupplementaryData.RelatedIDs.OrderID
+		result.CaptureRef = event.Resource.ID
+		result.Status = StatusFailed
+	case "PAYMENT.CAPTURE.REFUNDED":
+		// 资源为退款单，InvoiceID 为发起退款时的 RefundID，up 链接指向被退款的扣款
+		result.ProviderRef = ""
+		result.RefundRef = event.Resource.ID
+		result.RefundID = event.Resource.InvoiceID
+		result.CaptureRef = payPalLinkID(event.Resource.Links, "up")
+		result.Status = StatusRefunded
+	case "CHECKOUT.ORDER.APPROVED":
+		result.Status = StatusApproved
+	case "CUSTOMER.DISPUTE.CREATED":
+		// 争议的资源ID为争议单号，关联交易为卖家侧的扣款号
+		result.ProviderRef = ""
+		if len(event.Resource.DisputedTransactions) > 0 {
+			result.CaptureRef = event.Resource.DisputedTransactions[0].SellerTransactionID
+		}
+		result.Status = StatusDisputed
+	default:
+		if strings.HasPrefix(event.EventType, "PAYMENT.REFUND.") {
+			result.ProviderRef = ""
+			result.RefundRef = event.Resource.ID
+			result.RefundID = event.Resource.InvoiceID
+			result.CaptureRef = payPalLinkID(event.Resource.Links, "up")
+			result.Status = payPalRefundStatus(event.Resource.Status)
+			break
+		}
+		result.Status = payPalStatus(event.Resource.Status)
+	}
+	return result, nil
+}
+
+// payPalLink PayPal 资源中的关联链接
+type payPalLink struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+// payPalLinkID 取资源链接中 rel 对应地址的最后一段，即关联资源的ID
+func payPalLinkID(links []payPalLink, rel string) string {
+	for _, link := range links {
+		if link.Rel == rel {
+			return path.Base(strings.TrimRight(link.Href, "/"))
+		}
+	}
+	return ""
+}
+
+// PayPal 退款状态映射，未完成的退款为处理中
+func payPalRefundStatus(status string) Status {
+	switch status {
+	case "COMPLETED":
+		return StatusRefunded
+	case "CANCELLED", "FAILED":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+// PayPal 订单/扣款状态映射
+func payPalStatus(status string) Status {
+	switch status {
+	case "APPROVED":
+		return StatusApproved
+	case "COMPLETED":
+		return StatusCaptured
+	case "VOIDED", "DECLINED", "DENIED", "FAILED":
+		return StatusFailed
+	case "REFUNDED":
+		return StatusRefunded
+	default:
+		return StatusCreated
+	}
+}
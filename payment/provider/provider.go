@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// Status 支付渠道侧的交易状态
+type Status string
+
+const (
+	StatusCreated  Status = "created"  // 已创建，等待用户付款
+	StatusApproved Status = "approved" // 用户已付款授权，等待扣款
+	StatusCaptured Status = "captured" // 已扣款
+	StatusFailed   Status = "failed"   // 支付失败或被拒绝
+	StatusRefunded Status = "refunded" // 已全额退款；退款结果与退款回调中表示该笔退款已完成
+	StatusPending  Status = "pending"  // 退款已受理，渠道异步处理中
+	StatusDisputed Status = "disputed" // 买家发起争议
+)
+
+var (
+	ErrUnknownProvider  = errors.New("未知的支付渠道")
+	ErrInvalidSignature = errors.New("webhook 签名校验失败")
+	ErrPaymentNotFound  = errors.New("支付渠道中不存在该交易")
+)
+
+// CreatePaymentRequest 创建渠道支付单
+type CreatePaymentRequest struct {
+	OrderID       int64
+	TransactionID int64
+	Amount        money.Money
+}
+
+// CreatePaymentResult 渠道支付单，ApprovalURL 为用户跳转付款的地址
+type CreatePaymentResult struct {
+	ProviderRef string
+	ApprovalURL string
+	Status      Status
+}
+
+// CaptureResult 扣款结果，CaptureRef 用于后续退款
+type CaptureResult struct {
+	CaptureRef string
+	Status     Status
+}
+
+// RefundRequest 退款请求，RefundID 由调用方生成，渠道据此保证幂等
+type RefundRequest struct {
+	CaptureRef string
+	RefundID   string
+	Amount     money.Money
+}
+
+// RefundResult 退款结果，Status 为 StatusRefunded、StatusPending 或 StatusFailed
+type RefundResult struct {
+	RefundRef string
+	Status    Status
+}
+
+// WebhookEvent 校验通过的渠道回调事件
+type WebhookEvent struct {
+	EventID     string // 渠道事件ID，用于去重
+	EventType   string
+	ProviderRef string // 事件关联的渠道支付单号
+	CaptureRef  string
+	RefundRef   string // 退款事件关联的渠道退款号
+	RefundID    string // 退款事件回传的调用方退款号，即 RefundRequest.RefundID
+	Status      Status
+}
+
+// IsRefund 是否为退款事件，退款事件的 Status 表示该笔退款的状态
+func (e *WebhookEvent) IsRefund() bool {
+	return e.RefundRef != "" || e.RefundID != ""
+}
+
+// Provider 支付渠道，覆盖创建支付、扣款、退款、查询与回调校验
+type Provider interface {
+	Name() string
+	CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*CreatePaymentResult, error)
+	Capture(ctx context.Context, providerRef string) (*CaptureResult, error)
+	Refund(ctx context.Context, req *RefundRequest) (*RefundResult, error)
+	QueryStatus(ctx context.Context, providerRef string) (Status, error)
+	VerifyWebhook(ctx context.Context, header http.Header, body []byte) (*WebhookEvent, error)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+// SecretDecrypter 解密支付通道中加密保存的渠道密钥
+type SecretDecrypter interface {
+	Decrypt(ciphertext string) (string, error)
+}
+
+// Registry 根据支付通道配置的渠道名解析 Provider。
+// PayPal 的 ClientID 等公共参数来自配置，Secret 来自支付通道的密文，解密后按凭证缓存客户端。
+type Registry struct {
+	payPal  config.PayPalConfig
+	mock    *Mock
+	secrets SecretDecrypter
+
+	mu      sync.Mutex
+	payPals map[string]*PayPal
+}
+
+// 创建渠道注册表，未启用模拟渠道时解析 mock 返回 ErrUnknownProvider
+func NewRegistry(cfg config.PaymentProviderConfig, secrets SecretDecrypter) *Registry {
+	registry := &Registry{payPal: cfg.PayPal, secrets: secrets, payPals: map[string]*PayPal{}}
+	if cfg.Mock.Enabled {
+		registry.mock = NewMock(cfg.Mock.WebhookSecret, cfg.Mock.SettlementDir)
+	}
+	return registry
+}
+
+// Resolve 返回渠道实现，name 为空时默认 PayPal；secret 为加密后的渠道密钥，live 为 true 时使用生产环境
+func (r *Registry) Resolve(name, secret string, live bool) (Provider, error) {
+	switch name {
+	case PayPalName, "":
+		return r.resolvePayPal(secret, live)
+	case MockName:
+		if r.mock == nil {
+			return nil, ErrUnknownProvider
+		}
+		return r.mock, nil
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
+
+func (r *Registry) resolvePayPal(secret string, live bool) (*PayPal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strconv.FormatBool(live) + ":" + secret
+	if payPal, ok := r.payPals[key]; ok {
+		return payPal, nil
+	}
+	plaintext, err := r.secrets.Decrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("解密渠道密钥失败: %w", err)
+	}
+	payPal, err := NewPayPal(PayPalConfig{
+		ClientID:  r.payPal.ClientID,
+		Secret:    plaintext,
+		Live:      live,
+		WebhookID: r.payPal.WebhookID,
+		ReturnURL: r.payPal.ReturnURL,
+		CancelURL: r.payPal.CancelURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.payPals[key] = payPal
+	return payPal, nil
+}
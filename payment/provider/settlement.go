@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/money"
+)
+
+// ErrSettlementUnsupported 渠道不提供结算对账单
+var ErrSettlementUnsupported = errors.New("支付渠道不支持结算对账单")
+
+// SettlementEntry 渠道结算对账单中的一笔扣款
+type SettlementEntry struct {
+	CaptureRef  string
+	ProviderRef string
+	Amount      money.Money
+	SettledAt   time.Time
+}
+
+// SettlementReporter 提供按日结算对账单的渠道实现该接口
+type SettlementReporter interface {
+	// SettlementReport 返回 day 当天（按 day 的时区）结算的扣款，当天无对账单时返回空列表
+	SettlementReport(ctx context.Context, day time.Time) ([]SettlementEntry, error)
+}
+
+// 对账单文件的列
+var settlementHeader = []string{"capture_ref", "provider_ref", "amount", "currency", "settled_at"}
+
+// SettlementFile 对账单文件路径：<dir>/settlement-YYYY-MM-DD.csv
+func SettlementFile(dir string, day time.Time) string {
+	return filepath.Join(dir, "settlement-"+day.Format(time.DateOnly)+".csv")
+}
+
+// ReadSettlement 解析 CSV 对账单，首行为表头，金额为十进制字符串，时间为 RFC 3339
+func ReadSettlement(r io.Reader) ([]SettlementEntry, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析对账单失败: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	var entries []SettlementEntry
+	for i, record := range records[1:] {
+		if len(record) != len(settlementHeader) {
+			return nil, fmt.Errorf("对账单第 %d 行列数错误", i+2)
+		}
+		amount, err := money.Parse(record[2], record[3])
+		if err != nil {
+			return nil, fmt.Errorf("对账单第 %d 行金额错误: %w", i+2, err)
+		}
+		settledAt, err := time.Parse(time.RFC3339, record[4])
+		if err != nil {
+			return nil, fmt.Errorf("对账单第 %d 行时间错误: %w", i+2, err)
+		}
+		entries = append(entries, SettlementEntry{
+			CaptureRef:  record[0],
+			ProviderRef: record[1],
+			Amount:      amount,
+			SettledAt:   settledAt,
+		})
+	}
+	return entries, nil
+}
+
+// readSettlementFile 读取对账单文件，文件不存在视为当天无结算
+func readSettlementFile(path string) ([]SettlementEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadSettlement(file)
+}
+
+// appendSettlementFile 向对账单文件追加一行，文件不存在时先写表头
+func appendSettlementFile(path string, entry SettlementEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	if info.Size() == 0 {
+		if err := writer.Write(settlementHeader); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write([]string{
+		entry.CaptureRef,
+		entry.ProviderRef,
+		entry.Amount.Decimal(),
+		entry.Amount.Currency,
+		entry.SettledAt.Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -3,10 +3,9 @@ module paymentApi
 go 1.25.1
 
 require (
-	github.com/Ben1524/GoMall/common v0.0.0-20251010113224-0b29ecb3327e
+	github.com/Ben1524/GoMall/common v0.0.0-00010101000000-000000000000
 	github.com/gin-gonic/gin v1.10.0
 	github.com/micro/plugins/v5/wrapper/breaker/gobreaker v1.0.2
-	github.com/plutov/paypal/v3 v3.1.0
 	github.com/prometheus/client_golang v1.11.1
 	github.com/sony/gobreaker v1.0.0
 	go-micro.dev/v5 v5.9.0
@@ -14,6 +13,8 @@ require (
 	google.golang.org/protobuf v1.36.10
 )
 
+replace github.com/Ben1524/GoMall/common => ../common
+
 require (
 	github.com/armon/go-metrics v0.4.1 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"paymentApi/proto/payment"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// transactionCaptured 支付服务中已扣款、可退款的交易状态
+const transactionCaptured = "captured"
+
+// legacyRefundKeys 旧接口接收的参数：payment_id 为支付通道，refund_id 为退款号，money 为退款金额；
+// order_id 为新增的必填参数，transaction_id、currency 与 reason 可选
+var legacyRefundKeys = []string{"payment_id", "refund_id", "money", "order_id", "transaction_id", "currency", "reason"}
+
+// HandleLegacyRefund 兼容历史路径 /paymentApi/payPalRefund，参数依次取自 query、表单与 JSON 请求体，
+// 成功时与旧接口一样返回纯文本 "<refund_id>支付成功！"，失败时返回统一的 JSON 错误体。
+// 旧接口向固定的 PayPal 账户打款，不关联订单；现改为对订单已扣款的交易退款，因此须额外传入 order_id，
+// 交易按 transaction_id 或 payment_id 在订单的已扣款交易中确定，money 缺省币种取交易币种。
+// 已废弃，响应带 Deprecation 头，请改用 POST /api/v1/payments/:id/refunds。
+func (e *PaymentApi) HandleLegacyRefund(ctx *gin.Context) {
+	ctx.Header("Deprecation", "true")
+
+	params, err := legacyParams(ctx.Request)
+	if err != nil {
+		respondBadRequest(ctx, "invalid request payload", err)
+		return
+	}
+	refundID := params.Get("refund_id")
+	if refundID == "" || len(refundID) > 64 {
+		respondBadRequest(ctx, "refund_id is required and at most 64 characters", nil)
+		return
+	}
+	if params.Get("money") == "" {
+		respondBadRequest(ctx, "money is required", nil)
+		return
+	}
+	orderID, err := strconv.ParseInt(params.Get("order_id"), 10, 64)
+	if err != nil || orderID <= 0 {
+		respondBadRequest(ctx, "invalid order_id", err)
+		return
+	}
+	var paymentID, transactionID int64
+	if value := params.Get("payment_id"); value != "" {
+		if paymentID, err = strconv.ParseInt(value, 10, 64); err != nil || paymentID <= 0 {
+			respondBadRequest(ctx, "invalid payment_id", err)
+			return
+		}
+	}
+	if value := params.Get("transaction_id"); value != "" {
+		if transactionID, err = strconv.ParseInt(value, 10, 64); err != nil || transactionID <= 0 {
+			respondBadRequest(ctx, "invalid transaction_id", err)
+			return
+		}
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	transaction, err := e.legacyRefundTransaction(requestCtx, orderID, paymentID, transactionID)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Header("Link", fmt.Sprintf("</api/v1/payments/%d/refunds>; rel=\"successor-version\"", transaction.GetId()))
+
+	if _, err := e.refund(requestCtx, transaction, orderID, refundRequest{
+		RefundID: refundID,
+		Amount:   params.Get("money"),
+		Currency: params.Get("currency"),
+		Reason:   params.Get("reason"),
+	}); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.String(http.StatusOK, refundID+"支付成功！")
+}
+
+// legacyRefundTransaction 确定旧接口要退款的交易：指定 transaction_id 时直接查询，
+// 否则取订单中唯一的已扣款交易，同时给出 payment_id 时只在该通道的交易中查找
+func (e *PaymentApi) legacyRefundTransaction(ctx context.Context, orderID, paymentID, transactionID int64) (*payment.TransactionInfo, error) {
+	if transactionID > 0 {
+		transaction, err := e.PaymentService.FindTransactionByID(ctx, &payment.TransactionID{TransactionId: transactionID})
+		if err != nil {
+			return nil, err
+		}
+		if paymentID > 0 && transaction.GetPaymentId() != paymentID {
+			return nil, &badRequestError{message: "transaction_id does not belong to payment_id"}
+		}
+		return transaction, nil
+	}
+
+	resp, err := e.PaymentService.FindTransactionsByOrder(ctx, &payment.OrderID{OrderId: orderID})
+	if err != nil {
+		return nil, err
+	}
+	var found *payment.TransactionInfo
+	for _, transaction := range resp.GetTransactionInfo() {
+		if transaction.GetStatus() != transactionCaptured || paymentID > 0 && transaction.GetPaymentId() != paymentID {
+			continue
+		}
+		if found != nil {
+			return nil, &badRequestError{message: "order has several captured transactions, transaction_id is required"}
+		}
+		found = transaction
+	}
+	if found == nil {
+		return nil, &badRequestError{message: "order has no captured transaction to refund"}
+	}
+	return found, nil
+}
+
+// legacyParams 合并 query、表单与 JSON 请求体中的参数，先出现的优先
+func legacyParams(r *http.Request) (url.Values, error) {
+	params := url.Values{}
+	merge := func(key, value string) {
+		if value != "" && params.Get(key) == "" {
+			params.Set(key, value)
+		}
+	}
+	query := r.URL.Query()
+	for _, key := range legacyRefundKeys {
+		merge(key, query.Get(key))
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		for _, key := range legacyRefundKeys {
+			merge(key, r.PostForm.Get(key))
+		}
+		return params, nil
+	}
+
+	var body map[string]interface{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+	for _, key := range legacyRefundKeys {
+		switch value := body[key].(type) {
+		case string:
+			merge(key, value)
+		case json.Number:
+			merge(key, value.String())
+		}
+	}
+	return params, nil
+}
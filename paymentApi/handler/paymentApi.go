@@ -2,15 +2,18 @@ package handler
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"paymentApi/proto/payment"
-	"paymentApi/proto/paymentApi"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/Ben1524/GoMall/common/elk_log"
-	"github.com/plutov/paypal/v3"
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/ginauth"
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/gin-gonic/gin"
+	microerrors "go-micro.dev/v5/errors"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -19,9 +22,8 @@ type PaymentApi struct {
 	tracer         trace.Tracer
 }
 
-const (
-	ClientID string = "Ab4q3_yda8OnhXn13HvQGfCJV9tcMBABkmhMc5PAisRps4fOXlkf_f9NqT24He67A6Vrf4A4xLau0ld4"
-)
+// 下单与退款会同步调用支付渠道，超时比普通查询宽松
+const defaultRequestTimeout = 10 * time.Second
 
 func NewPaymentApiHandler(paymentService payment.PaymentService) *PaymentApi {
 	return &PaymentApi{PaymentService: paymentService,
@@ -29,90 +31,308 @@ func NewPaymentApiHandler(paymentService payment.PaymentService) *PaymentApi {
 	}
 }
 
-// PaymentApi.PayPalRefund 通过API向外暴露为/paymentApi/payPalRefund，接收http请求
-// 即：/paymentApi/payPalRefund 请求会调用 go.micro.api.paymentApi 服务的PaymentApi.PayPalRefund
-func (e *PaymentApi) PayPalRefund(ctx context.Context, req *paymentApi.Request, rsp *paymentApi.Response) error {
-	//验证payment 支付通道是否赋值
-	if err := isOK("payment_id", req); err != nil {
-		rsp.StatusCode = 500
-		return err
+// RegisterRoutes 将支付相关路由注册到给定路由组。
+func (e *PaymentApi) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/payments", e.handleCreatePayment)
+	group.GET("/payments/:id", e.handleGetPayment)
+	group.POST("/payments/:id/refunds", e.handleRefund)
+	group.GET("/orders/:orderID/payments", e.handleGetOrderPayments)
+	group.GET("/payment-channels", e.handleGetChannels)
+	group.GET("/payment-routes", e.handlePreviewRoute)
+	group.GET("/payment-routing-rules", e.handleGetRoutingRules)
+	group.POST("/payment-routing-rules", e.handleCreateRoutingRule)
+	group.PUT("/payment-routing-rules/:id", e.handleUpdateRoutingRule)
+	group.DELETE("/payment-routing-rules/:id", e.handleDeleteRoutingRule)
+}
+
+// createPaymentRequest 为订单创建支付，amount 为十进制金额字符串（如 "19.99"），按币种小数位精确解析；
+// amount 缺省时按订单应付金额以订单结算币种支付，指定时须与应付金额一致；payment_id 缺省时按路由规则选择支付通道，country 参与路由
+type createPaymentRequest struct {
+	OrderID     int64  `json:"order_id" binding:"required,gt=0"`
+	PaymentID   int64  `json:"payment_id" binding:"omitempty,gt=0"`
+	Country     string `json:"country" binding:"omitempty,len=2"`
+	Amount      string `json:"amount"`
+	Currency    string `json:"currency" binding:"required_with=Amount,omitempty,len=3"`
+	ProviderRef string `json:"provider_ref"` // 客户端已在渠道下单时传入渠道单号
+}
+
+// refundRequest 退款，amount 缺省时退还全部可退金额，currency 缺省时使用交易币种
+type refundRequest struct {
+	RefundID string `json:"refund_id" binding:"required,max=64"`
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+	Reason   string `json:"reason" binding:"max=255"`
+}
+
+func (e *PaymentApi) handleCreatePayment(ctx *gin.Context) {
+	var body createPaymentRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		respondBadRequest(ctx, "invalid request payload", err)
+		return
 	}
-	//验证 退款号
-	if err := isOK("refund_id", req); err != nil {
-		rsp.StatusCode = http.StatusBadRequest
-		return err
+	var amount *payment.Money
+	if body.Amount != "" {
+		parsed, err := money.Parse(body.Amount, body.Currency)
+		if err != nil || !parsed.IsPositive() {
+			respondBadRequest(ctx, "amount must be a positive decimal in the given currency", err)
+			return
+		}
+		amount = &payment.Money{Amount: parsed.Amount, Currency: parsed.Currency}
 	}
-	//验证 退款金额
-	if err := isOK("money", req); err != nil {
-		rsp.StatusCode = http.StatusBadRequest
-		return err
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	created, err := e.PaymentService.CreateTransaction(requestCtx, &payment.TransactionInfo{
+		OrderId:      body.OrderID,
+		PaymentId:    body.PaymentID,
+		ChargeAmount: amount,
+		ProviderRef:  body.ProviderRef,
+		Country:      body.Country,
+	})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
 	}
+	transaction, err := e.PaymentService.FindTransactionByID(requestCtx, created)
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	if !authorizeTransaction(ctx, transaction) {
+		return
+	}
+	ctx.JSON(http.StatusCreated, transactionJSON(transaction))
+}
 
-	//获取paymentID
-	payID, err := strconv.ParseInt(req.Get["payment_id"].Values[0], 10, 64)
+func (e *PaymentApi) handleGetPayment(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	transaction, err := e.PaymentService.FindTransactionByID(requestCtx, &payment.TransactionID{TransactionId: id})
 	if err != nil {
-		ErrorHandle(err)
-		return err
+		respondServiceError(ctx, err)
+		return
+	}
+	if !authorizeTransaction(ctx, transaction) {
+		return
 	}
-	//获取支付通道信息
-	paymentInfo, err := e.PaymentService.FindPaymentByID(ctx, &payment.PaymentID{PaymentId: payID})
+	ctx.JSON(http.StatusOK, transactionJSON(transaction))
+}
+
+func (e *PaymentApi) handleGetOrderPayments(ctx *gin.Context) {
+	orderID, ok := parseIDParam(ctx, "orderID")
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.PaymentService.FindTransactionsByOrder(requestCtx, &payment.OrderID{OrderId: orderID})
 	if err != nil {
-		ErrorHandle(err)
-		return err
-	}
-	//SID 获取 paymentInfo.PaymentSid
-	//支付模式
-	status := paypal.APIBaseSandBox
-	if paymentInfo.PaymentStatus == "paypal_live" {
-		status = paypal.APIBaseLive
-	}
-	//退款例子
-	payout := paypal.Payout{
-		SenderBatchHeader: &paypal.SenderBatchHeader{
-			EmailSubject: req.Get["refund_id"].Values[0] + " 提醒你收款！",
-			EmailMessage: req.Get["refund_id"].Values[0] + " 您有一个收款信息！",
-			//每笔转账都要唯一
-			SenderBatchID: req.Get["refund_id"].Values[0],
-		},
-		Items: []paypal.PayoutItem{
-			{
-				RecipientType: "EMAIL",
-				//RecipientWallet: "",
-				Receiver: "sb-vvhq82259765@personal.example.com",
-				Amount: &paypal.AmountPayout{
-					//币种
-					Currency: "USD",
-					Value:    req.Get["money"].Values[0],
-				},
-				Note:         req.Get["refund_id"].Values[0],
-				SenderItemID: req.Get["refund_id"].Values[0],
-			},
-		},
-	}
-	//创建支付客户端
-	payPalClient, err := paypal.NewClient(ClientID, paymentInfo.PaymentSid, status)
+		respondServiceError(ctx, err)
+		return
+	}
+	items := make([]gin.H, 0, len(resp.GetTransactionInfo()))
+	for _, transaction := range resp.GetTransactionInfo() {
+		if !authorizeTransaction(ctx, transaction) {
+			return
+		}
+		items = append(items, transactionJSON(transaction))
+	}
+	ctx.JSON(http.StatusOK, gin.H{"payments": items})
+}
+
+// handleRefund 对一笔已扣款的交易退款，订单ID与缺省币种取自交易
+func (e *PaymentApi) handleRefund(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	var body refundRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		respondBadRequest(ctx, "invalid request payload", err)
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	transaction, err := e.PaymentService.FindTransactionByID(requestCtx, &payment.TransactionID{TransactionId: id})
 	if err != nil {
-		ErrorHandle(err)
+		respondServiceError(ctx, err)
+		return
 	}
-	// 获取 token
-	_, err = payPalClient.GetAccessToken()
+	refund, err := e.refund(requestCtx, transaction, transaction.GetOrderId(), body)
 	if err != nil {
-		ErrorHandle(err)
+		respondError(ctx, err)
+		return
 	}
-	paymentResult, err := payPalClient.CreateSinglePayout(payout)
+	ctx.JSON(http.StatusCreated, refundJSON(refund))
+}
+
+func (e *PaymentApi) handleGetChannels(ctx *gin.Context) {
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.PaymentService.FindAllPayment(requestCtx, &payment.All{})
 	if err != nil {
-		ErrorHandle(err)
+		respondServiceError(ctx, err)
+		return
+	}
+	items := make([]gin.H, 0, len(resp.GetPaymentInfo()))
+	for _, channel := range resp.GetPaymentInfo() {
+		items = append(items, gin.H{
+			"id":       channel.GetId(),
+			"name":     channel.GetPaymentName(),
+			"provider": channel.GetProvider(),
+			"status":   channel.GetPaymentStatus(),
+			"image":    channel.GetPaymentImage(),
+		})
+	}
+	ctx.JSON(http.StatusOK, gin.H{"channels": items})
+}
+
+// badRequestError 请求参数错误，由 respondError 转换为 400
+type badRequestError struct {
+	message string
+	err     error
+}
+
+func (e *badRequestError) Error() string {
+	return e.message
+}
+
+// refund 发起退款，orderID 由调用方给出，与交易不符时由支付服务拒绝
+func (e *PaymentApi) refund(ctx context.Context, transaction *payment.TransactionInfo, orderID int64, body refundRequest) (*payment.RefundInfo, error) {
+	var amount *payment.Money
+	if body.Amount != "" {
+		currency := body.Currency
+		if currency == "" {
+			currency = transaction.GetChargeAmount().GetCurrency()
+		}
+		parsed, err := money.Parse(body.Amount, currency)
+		if err != nil || parsed.IsNegative() {
+			return nil, &badRequestError{message: "amount must be a non-negative decimal in the given currency", err: err}
+		}
+		amount = &payment.Money{Amount: parsed.Amount, Currency: parsed.Currency}
+	}
+	return e.PaymentService.Refund(ctx, &payment.RefundRequest{
+		RefundId:      body.RefundID,
+		OrderId:       orderID,
+		TransactionId: transaction.GetId(),
+		RefundAmount:  amount,
+		Reason:        body.Reason,
+	})
+}
+
+// authorizeTransaction 支付服务已按订单归属校验调用方，网关再比对交易记录的下单用户，
+// 不是本人且不能处理退款时返回 403；早期交易未记录下单用户，以支付服务的校验为准
+func authorizeTransaction(ctx *gin.Context, transaction *payment.TransactionInfo) bool {
+	claims, ok := ginauth.Claims(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid user identity"})
+		return false
+	}
+	owner := transaction.GetUserId()
+	if owner == 0 || owner == claims.UserID() || claims.Can(auth.PermPaymentRefund) {
+		return true
+	}
+	ctx.JSON(http.StatusForbidden, gin.H{"error": "cannot access another user's payment"})
+	return false
+}
+
+func transactionJSON(transaction *payment.TransactionInfo) gin.H {
+	return gin.H{
+		"id":              transaction.GetId(),
+		"order_id":        transaction.GetOrderId(),
+		"user_id":         transaction.GetUserId(),
+		"payment_id":      transaction.GetPaymentId(),
+		"country":         transaction.GetCountry(),
+		"amount":          moneyJSON(transaction.GetChargeAmount()),
+		"refunded_amount": moneyJSON(transaction.GetRefundedTotal()),
+		"status":          transaction.GetStatus(),
+		"provider_ref":    transaction.GetProviderRef(),
+		"capture_ref":     transaction.GetCaptureRef(),
+		"approval_url":    transaction.GetApprovalUrl(),
+		"failure_reason":  transaction.GetFailureReason(),
+		"created_at":      transaction.GetCreatedAt(),
+		"updated_at":      transaction.GetUpdatedAt(),
+	}
+}
+
+func refundJSON(refund *payment.RefundInfo) gin.H {
+	return gin.H{
+		"id":             refund.GetId(),
+		"refund_id":      refund.GetRefundId(),
+		"order_id":       refund.GetOrderId(),
+		"transaction_id": refund.GetTransactionId(),
+		"amount":         moneyJSON(refund.GetRefundAmount()),
+		"reason":         refund.GetReason(),
+		"status":         refund.GetStatus(),
+		"provider_ref":   refund.GetProviderRef(),
+		"failure_reason": refund.GetFailureReason(),
+		"created_at":     refund.GetCreatedAt(),
+		"updated_at":     refund.GetUpdatedAt(),
+	}
+}
+
+// moneyJSON 金额同时给出最小单位与十进制字符串，避免客户端按浮点换算
+func moneyJSON(m *payment.Money) gin.H {
+	if m == nil {
+		return nil
+	}
+	return gin.H{
+		"amount":   m.GetAmount(),
+		"currency": m.GetCurrency(),
+		"decimal":  money.New(m.GetAmount(), m.GetCurrency()).Decimal(),
+	}
+}
+
+func parseIDParam(ctx *gin.Context, key string) (int64, bool) {
+	value, err := strconv.ParseInt(ctx.Param(key), 10, 64)
+	if err != nil || value <= 0 {
+		respondBadRequest(ctx, fmt.Sprintf("invalid %s", key), err)
+		return 0, false
 	}
-	elk_log.Info(fmt.Sprintf("Payment result: %v", paymentResult))
-	rsp.Body = req.Get["refund_id"].Values[0] + "支付成功！"
-	return err
+	return value, true
 }
 
-func isOK(key string, req *paymentApi.Request) error {
-	if _, ok := req.Get[key]; !ok {
-		err := errors.New(key + " 参数异常")
+func respondBadRequest(ctx *gin.Context, message string, err error) {
+	payload := gin.H{"error": message}
+	if err != nil {
+		payload["details"] = err.Error()
+	}
+	ctx.JSON(http.StatusBadRequest, payload)
+}
+
+func respondError(ctx *gin.Context, err error) {
+	if badRequest, ok := err.(*badRequestError); ok {
+		respondBadRequest(ctx, badRequest.message, badRequest.err)
+		return
+	}
+	respondServiceError(ctx, err)
+}
+
+// respondServiceError 支付服务返回的业务错误（4xx）与渠道失败（502）原样透出状态码，其余按 500 处理
+func respondServiceError(ctx *gin.Context, err error) {
+	merr := microerrors.FromError(err)
+	code := int(merr.Code)
+	if code < http.StatusBadRequest || code > 599 {
+		code = http.StatusInternalServerError
+	}
+	if code >= http.StatusInternalServerError {
 		ErrorHandle(err)
-		return err
 	}
-	return nil
+	message := merr.Detail
+	if message == "" || code == http.StatusInternalServerError {
+		message = strings.ToLower(http.StatusText(code))
+	}
+	ctx.JSON(code, gin.H{"error": message})
 }
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"paymentApi/proto/payment"
+
+	"github.com/Ben1524/GoMall/common/money"
+	"github.com/gin-gonic/gin"
+)
+
+// routingRuleRequest 支付通道路由规则，金额为 currency 下的十进制字符串，条件为空表示不限；enabled 缺省为 true
+type routingRuleRequest struct {
+	Name               string   `json:"name" binding:"max=64"`
+	Priority           int64    `json:"priority"`
+	Enabled            *bool    `json:"enabled"`
+	Currency           string   `json:"currency" binding:"required_with=MinAmount MaxAmount,omitempty,len=3"`
+	MinAmount          string   `json:"min_amount"`
+	MaxAmount          string   `json:"max_amount"`
+	Countries          []string `json:"countries" binding:"dive,len=2"`
+	PaymentID          int64    `json:"payment_id" binding:"required,gt=0"`
+	FallbackPaymentIDs []int64  `json:"fallback_payment_ids" binding:"dive,gt=0"`
+}
+
+func (e *PaymentApi) handleGetRoutingRules(ctx *gin.Context) {
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.PaymentService.FindRoutingRules(requestCtx, &payment.All{})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	items := make([]gin.H, 0, len(resp.GetRules()))
+	for _, rule := range resp.GetRules() {
+		items = append(items, routingRuleJSON(rule))
+	}
+	ctx.JSON(http.StatusOK, gin.H{"rules": items})
+}
+
+func (e *PaymentApi) handleCreateRoutingRule(ctx *gin.Context) {
+	rule, ok := bindRoutingRule(ctx)
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	created, err := e.PaymentService.AddRoutingRule(requestCtx, rule)
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"id": created.GetRuleId()})
+}
+
+// handleUpdateRoutingRule 整体替换规则
+func (e *PaymentApi) handleUpdateRoutingRule(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	rule, ok := bindRoutingRule(ctx)
+	if !ok {
+		return
+	}
+	rule.Id = id
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	if _, err := e.PaymentService.UpdateRoutingRule(requestCtx, rule); err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (e *PaymentApi) handleDeleteRoutingRule(ctx *gin.Context) {
+	id, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	if _, err := e.PaymentService.DeleteRoutingRule(requestCtx, &payment.RoutingRuleID{RuleId: id}); err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// handlePreviewRoute 按 amount、currency、country 查询参数预览路由结果
+func (e *PaymentApi) handlePreviewRoute(ctx *gin.Context) {
+	amount, err := money.Parse(ctx.Query("amount"), ctx.Query("currency"))
+	if err != nil || !amount.IsPositive() {
+		respondBadRequest(ctx, "amount must be a positive decimal in the given currency", err)
+		return
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	route, err := e.PaymentService.RouteTransaction(requestCtx, &payment.RouteRequest{
+		Amount:  &payment.Money{Amount: amount.Amount, Currency: amount.Currency},
+		Country: ctx.Query("country"),
+	})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	candidates := make([]gin.H, 0, len(route.GetCandidates()))
+	for _, candidate := range route.GetCandidates() {
+		candidates = append(candidates, gin.H{"payment_id": candidate.GetPaymentId(), "healthy": candidate.GetHealthy()})
+	}
+	ctx.JSON(http.StatusOK, gin.H{"rule_id": route.GetRuleId(), "candidates": candidates})
+}
+
+func bindRoutingRule(ctx *gin.Context) (*payment.RoutingRule, bool) {
+	var body routingRuleRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		respondBadRequest(ctx, "invalid request payload", err)
+		return nil, false
+	}
+	rule := &payment.RoutingRule{
+		Name:               body.Name,
+		Priority:           body.Priority,
+		Enabled:            body.Enabled == nil || *body.Enabled,
+		Currency:           body.Currency,
+		Countries:          body.Countries,
+		PaymentId:          body.PaymentID,
+		FallbackPaymentIds: body.FallbackPaymentIDs,
+	}
+	for _, bound := range []struct {
+		value  string
+		target *int64
+	}{{body.MinAmount, &rule.MinAmount}, {body.MaxAmount, &rule.MaxAmount}} {
+		if bound.value == "" {
+			continue
+		}
+		parsed, err := money.Parse(bound.value, body.Currency)
+		if err != nil || parsed.IsNegative() {
+			respondBadRequest(ctx, "min_amount and max_amount must be non-negative decimals in the given currency", err)
+			return nil, false
+		}
+		*bound.target = parsed.Amount
+	}
+	return rule, true
+}
+
+func routingRuleJSON(rule *payment.RoutingRule) gin.H {
+	item := gin.H{
+		"id":                   rule.GetId(),
+		"name":                 rule.GetName(),
+		"priority":             rule.GetPriority(),
+		"enabled":              rule.GetEnabled(),
+		"currency":             rule.GetCurrency(),
+		"min_amount":           nil,
+		"max_amount":           nil,
+		"countries":            rule.GetCountries(),
+		"payment_id":           rule.GetPaymentId(),
+		"fallback_payment_ids": rule.GetFallbackPaymentIds(),
+		"created_at":           rule.GetCreatedAt(),
+		"updated_at":           rule.GetUpdatedAt(),
+	}
+	if rule.GetMinAmount() > 0 {
+		item["min_amount"] = moneyJSON(&payment.Money{Amount: rule.GetMinAmount(), Currency: rule.GetCurrency()})
+	}
+	if rule.GetMaxAmount() > 0 {
+		item["max_amount"] = moneyJSON(&payment.Money{Amount: rule.GetMaxAmount(), Currency: rule.GetCurrency()})
+	}
+	return item
+}
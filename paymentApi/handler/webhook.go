@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"paymentApi/proto/payment"
+
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// Webhook 将渠道回调的原始请求头与请求体转发给支付服务校验并处理，
+// 返回响应状态码；签名无效或重放的回调由支付服务拒绝，这里记录来源后返回对应的 4xx
+func (e *PaymentApi) Webhook(ctx context.Context, provider, remoteAddr string, header http.Header, body []byte) (int, *payment.WebhookResponse) {
+	request := &payment.WebhookRequest{
+		Provider: provider,
+		Header:   make(map[string]*payment.HeaderValues, len(header)),
+		Body:     body,
+	}
+	for key, values := range header {
+		request.Header[key] = &payment.HeaderValues{Values: values}
+	}
+
+	response, err := e.PaymentService.HandleWebhook(ctx, request)
+	if err != nil {
+		code := int(microerrors.FromError(err).Code)
+		if code < http.StatusBadRequest || code > 599 {
+			code = http.StatusInternalServerError
+		}
+		if code < http.StatusInternalServerError {
+			slog.Warn("拒绝支付回调", "provider", provider, "remote_addr", remoteAddr, "status", code, "error", err)
+		} else {
+			ErrorHandle(err)
+		}
+		return code, nil
+	}
+	return http.StatusOK, response
+}
@@ -13,6 +13,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Ben1524/GoMall/common/auth"
 	"github.com/Ben1524/GoMall/common/config"
 	"github.com/Ben1524/GoMall/common/otel"
 	gobreaker2 "github.com/micro/plugins/v5/wrapper/breaker/gobreaker"
@@ -188,8 +189,20 @@ func main() {
 
 	cartSrv := payment.NewPaymentService("go.micro.service.payment", service.Client())
 
+	// 访问令牌校验，与用户服务共享会话存储，吊销立即生效
+	sessionStore, err := auth.NewStore(cfg)
+	if err != nil {
+		slog.Error("初始化会话存储失败", "error", err)
+		os.Exit(1)
+	}
+	tokenManager, err := auth.NewManager(cfg.JWT, sessionStore)
+	if err != nil {
+		slog.Error("初始化令牌校验失败", "error", err)
+		os.Exit(1)
+	}
+
 	h := handler.NewPaymentApiHandler(cartSrv)
-	engine := router.New(cfg, h)
+	engine := router.New(cfg, tokenManager, h)
 
 	if engine == nil {
 		slog.Error("router初始化失败", "error", err)
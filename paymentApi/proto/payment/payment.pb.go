@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.36.9
 // 	protoc        v5.29.3
-// source: proto/payment.proto
+// source: proto/payment/payment.proto
 
 package payment
 
@@ -29,13 +29,14 @@ type PaymentInfo struct {
 	PaymentSid    string                 `protobuf:"bytes,3,opt,name=payment_sid,json=paymentSid,proto3" json:"payment_sid,omitempty"`
 	PaymentStatus string                 `protobuf:"bytes,4,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
 	PaymentImage  string                 `protobuf:"bytes,5,opt,name=Payment_image,json=PaymentImage,proto3" json:"Payment_image,omitempty"`
+	Provider      string                 `protobuf:"bytes,6,opt,name=provider,proto3" json:"provider,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PaymentInfo) Reset() {
 	*x = PaymentInfo{}
-	mi := &file_proto_payment_proto_msgTypes[0]
+	mi := &file_proto_payment_payment_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -47,7 +48,7 @@ func (x *PaymentInfo) String() string {
 func (*PaymentInfo) ProtoMessage() {}
 
 func (x *PaymentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_proto_msgTypes[0]
+	mi := &file_proto_payment_payment_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,7 +61,7 @@ func (x *PaymentInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaymentInfo.ProtoReflect.Descriptor instead.
 func (*PaymentInfo) Descriptor() ([]byte, []int) {
-	return file_proto_payment_proto_rawDescGZIP(), []int{0}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *PaymentInfo) GetId() int64 {
@@ -98,6 +99,13 @@ func (x *PaymentInfo) GetPaymentImage() string {
 	return ""
 }
 
+func (x *PaymentInfo) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
 type PaymentID struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PaymentId     int64                  `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
@@ -107,7 +115,7 @@ type PaymentID struct {
 
 func (x *PaymentID) Reset() {
 	*x = PaymentID{}
-	mi := &file_proto_payment_proto_msgTypes[1]
+	mi := &file_proto_payment_payment_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -119,7 +127,7 @@ func (x *PaymentID) String() string {
 func (*PaymentID) ProtoMessage() {}
 
 func (x *PaymentID) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_proto_msgTypes[1]
+	mi := &file_proto_payment_payment_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -132,7 +140,7 @@ func (x *PaymentID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaymentID.ProtoReflect.Descriptor instead.
 func (*PaymentID) Descriptor() ([]byte, []int) {
-	return file_proto_payment_proto_rawDescGZIP(), []int{1}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *PaymentID) GetPaymentId() int64 {
@@ -151,7 +159,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_proto_payment_proto_msgTypes[2]
+	mi := &file_proto_payment_payment_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -163,7 +171,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_proto_msgTypes[2]
+	mi := &file_proto_payment_payment_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -176,7 +184,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_proto_payment_proto_rawDescGZIP(), []int{2}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Response) GetMsg() string {
@@ -194,7 +202,7 @@ type All struct {
 
 func (x *All) Reset() {
 	*x = All{}
-	mi := &file_proto_payment_proto_msgTypes[3]
+	mi := &file_proto_payment_payment_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -206,7 +214,7 @@ func (x *All) String() string {
 func (*All) ProtoMessage() {}
 
 func (x *All) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_proto_msgTypes[3]
+	mi := &file_proto_payment_payment_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,7 +227,7 @@ func (x *All) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use All.ProtoReflect.Descriptor instead.
 func (*All) Descriptor() ([]byte, []int) {
-	return file_proto_payment_proto_rawDescGZIP(), []int{3}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{3}
 }
 
 type PaymentAll struct {
@@ -231,7 +239,7 @@ type PaymentAll struct {
 
 func (x *PaymentAll) Reset() {
 	*x = PaymentAll{}
-	mi := &file_proto_payment_proto_msgTypes[4]
+	mi := &file_proto_payment_payment_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -243,7 +251,7 @@ func (x *PaymentAll) String() string {
 func (*PaymentAll) ProtoMessage() {}
 
 func (x *PaymentAll) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_proto_msgTypes[4]
+	mi := &file_proto_payment_payment_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -256,7 +264,7 @@ func (x *PaymentAll) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaymentAll.ProtoReflect.Descriptor instead.
 func (*PaymentAll) Descriptor() ([]byte, []int) {
-	return file_proto_payment_proto_rawDescGZIP(), []int{4}
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PaymentAll) GetPaymentInfo() []*PaymentInfo {
@@ -266,94 +274,1970 @@ func (x *PaymentAll) GetPaymentInfo() []*PaymentInfo {
 	return nil
 }
 
-var File_proto_payment_proto protoreflect.FileDescriptor
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_payment_proto_rawDesc = "" +
-	"\n" +
-	"\x13proto/payment.proto\x12\apayment\"\xad\x01\n" +
-	"\vPaymentInfo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
-	"\fpayment_name\x18\x02 \x01(\tR\vpaymentName\x12\x1f\n" +
-	"\vpayment_sid\x18\x03 \x01(\tR\n" +
-	"paymentSid\x12%\n" +
-	"\x0epayment_status\x18\x04 \x01(\tR\rpaymentStatus\x12#\n" +
-	"\rPayment_image\x18\x05 \x01(\tR\fPaymentImage\"*\n" +
-	"\tPaymentID\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x01 \x01(\x03R\tpaymentId\"\x1c\n" +
-	"\bResponse\x12\x10\n" +
-	"\x03msg\x18\x01 \x01(\tR\x03msg\"\x05\n" +
-	"\x03All\"E\n" +
-	"\n" +
-	"PaymentAll\x127\n" +
-	"\fpayment_info\x18\x01 \x03(\v2\x14.payment.PaymentInfoR\vpaymentInfo2\xb3\x02\n" +
-	"\aPayment\x128\n" +
-	"\n" +
-	"AddPayment\x12\x14.payment.PaymentInfo\x1a\x12.payment.PaymentID\"\x00\x12:\n" +
-	"\rUpdatePayment\x12\x14.payment.PaymentInfo\x1a\x11.payment.Response\"\x00\x12<\n" +
-	"\x11DeletePaymentByID\x12\x12.payment.PaymentID\x1a\x11.payment.Response\"\x00\x12=\n" +
-	"\x0fFindPaymentByID\x12\x12.payment.PaymentID\x1a\x14.payment.PaymentInfo\"\x00\x125\n" +
-	"\x0eFindAllPayment\x12\f.payment.All\x1a\x13.payment.PaymentAll\"\x00B\x11Z\x0f./proto;paymentb\x06proto3"
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_proto_payment_proto_rawDescOnce sync.Once
-	file_proto_payment_proto_rawDescData []byte
-)
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_proto_payment_proto_rawDescGZIP() []byte {
-	file_proto_payment_proto_rawDescOnce.Do(func() {
-		file_proto_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_payment_proto_rawDesc), len(file_proto_payment_proto_rawDesc)))
-	})
-	return file_proto_payment_proto_rawDescData
-}
-
-var file_proto_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_proto_payment_proto_goTypes = []any{
-	(*PaymentInfo)(nil), // 0: payment.PaymentInfo
-	(*PaymentID)(nil),   // 1: payment.PaymentID
-	(*Response)(nil),    // 2: payment.Response
-	(*All)(nil),         // 3: payment.All
-	(*PaymentAll)(nil),  // 4: payment.PaymentAll
-}
-var file_proto_payment_proto_depIdxs = []int32{
-	0, // 0: payment.PaymentAll.payment_info:type_name -> payment.PaymentInfo
-	0, // 1: payment.Payment.AddPayment:input_type -> payment.PaymentInfo
-	0, // 2: payment.Payment.UpdatePayment:input_type -> payment.PaymentInfo
-	1, // 3: payment.Payment.DeletePaymentByID:input_type -> payment.PaymentID
-	1, // 4: payment.Payment.FindPaymentByID:input_type -> payment.PaymentID
-	3, // 5: payment.Payment.FindAllPayment:input_type -> payment.All
-	1, // 6: payment.Payment.AddPayment:output_type -> payment.PaymentID
-	2, // 7: payment.Payment.UpdatePayment:output_type -> payment.Response
-	2, // 8: payment.Payment.DeletePaymentByID:output_type -> payment.Response
-	0, // 9: payment.Payment.FindPaymentByID:output_type -> payment.PaymentInfo
-	4, // 10: payment.Payment.FindAllPayment:output_type -> payment.PaymentAll
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
-}
-
-func init() { file_proto_payment_proto_init() }
-func file_proto_payment_proto_init() {
-	if File_proto_payment_proto != nil {
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type TransactionInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PaymentId      int64                  `protobuf:"varint,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency       string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	ProviderRef    string                 `protobuf:"bytes,6,opt,name=provider_ref,json=providerRef,proto3" json:"provider_ref,omitempty"`
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	FailureReason  string                 `protobuf:"bytes,8,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ApprovalUrl    string                 `protobuf:"bytes,11,opt,name=approval_url,json=approvalUrl,proto3" json:"approval_url,omitempty"`
+	CaptureRef     string                 `protobuf:"bytes,12,opt,name=capture_ref,json=captureRef,proto3" json:"capture_ref,omitempty"`
+	RefundedAmount float64                `protobuf:"fixed64,13,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	Country        string                 `protobuf:"bytes,14,opt,name=country,proto3" json:"country,omitempty"`
+	UserId         int64                  `protobuf:"varint,15,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChargeAmount   *Money                 `protobuf:"bytes,16,opt,name=charge_amount,json=chargeAmount,proto3" json:"charge_amount,omitempty"`
+	RefundedTotal  *Money                 `protobuf:"bytes,17,opt,name=refunded_total,json=refundedTotal,proto3" json:"refunded_total,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransactionInfo) Reset() {
+	*x = TransactionInfo{}
+	mi := &file_proto_payment_payment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionInfo) ProtoMessage() {}
+
+func (x *TransactionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionInfo.ProtoReflect.Descriptor instead.
+func (*TransactionInfo) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TransactionInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TransactionInfo) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+func (x *TransactionInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransactionInfo) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *TransactionInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetApprovalUrl() string {
+	if x != nil {
+		return x.ApprovalUrl
+	}
+	return ""
+}
+
+func (x *TransactionInfo) GetCaptureRef() string {
+	if x != nil {
+		return x.CaptureRef
+	}
+	return ""
+}
+
+func (x *TransactionInfo) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *TransactionInfo) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TransactionInfo) GetChargeAmount() *Money {
+	if x != nil {
+		return x.ChargeAmount
+	}
+	return nil
+}
+
+func (x *TransactionInfo) GetRefundedTotal() *Money {
+	if x != nil {
+		return x.RefundedTotal
+	}
+	return nil
+}
+
+type TransactionID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId int64                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionID) Reset() {
+	*x = TransactionID{}
+	mi := &file_proto_payment_payment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionID) ProtoMessage() {}
+
+func (x *TransactionID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionID.ProtoReflect.Descriptor instead.
+func (*TransactionID) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TransactionID) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+type CaptureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId int64                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	ProviderRef   string                 `protobuf:"bytes,2,opt,name=provider_ref,json=providerRef,proto3" json:"provider_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureRequest) Reset() {
+	*x = CaptureRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureRequest) ProtoMessage() {}
+
+func (x *CaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureRequest.ProtoReflect.Descriptor instead.
+func (*CaptureRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CaptureRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *CaptureRequest) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+type OrderID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderID) Reset() {
+	*x = OrderID{}
+	mi := &file_proto_payment_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderID) ProtoMessage() {}
+
+func (x *OrderID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderID.ProtoReflect.Descriptor instead.
+func (*OrderID) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OrderID) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type TransactionAll struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionInfo []*TransactionInfo     `protobuf:"bytes,1,rep,name=transaction_info,json=transactionInfo,proto3" json:"transaction_info,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransactionAll) Reset() {
+	*x = TransactionAll{}
+	mi := &file_proto_payment_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionAll) ProtoMessage() {}
+
+func (x *TransactionAll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionAll.ProtoReflect.Descriptor instead.
+func (*TransactionAll) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TransactionAll) GetTransactionInfo() []*TransactionInfo {
+	if x != nil {
+		return x.TransactionInfo
+	}
+	return nil
+}
+
+type RefundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundId      string                 `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TransactionId int64                  `protobuf:"varint,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	RefundAmount  *Money                 `protobuf:"bytes,6,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundRequest) Reset() {
+	*x = RefundRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundRequest) ProtoMessage() {}
+
+func (x *RefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundRequest.ProtoReflect.Descriptor instead.
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefundRequest) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *RefundRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *RefundRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetRefundAmount() *Money {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return nil
+}
+
+type RefundInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RefundId      string                 `protobuf:"bytes,2,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TransactionId int64                  `protobuf:"varint,4,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	ProviderRef   string                 `protobuf:"bytes,9,opt,name=provider_ref,json=providerRef,proto3" json:"provider_ref,omitempty"`
+	FailureReason string                 `protobuf:"bytes,10,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RefundAmount  *Money                 `protobuf:"bytes,13,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundInfo) Reset() {
+	*x = RefundInfo{}
+	mi := &file_proto_payment_payment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundInfo) ProtoMessage() {}
+
+func (x *RefundInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundInfo.ProtoReflect.Descriptor instead.
+func (*RefundInfo) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RefundInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RefundInfo) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *RefundInfo) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *RefundInfo) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundInfo) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *RefundInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *RefundInfo) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *RefundInfo) GetRefundAmount() *Money {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return nil
+}
+
+type RefundAll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundInfo    []*RefundInfo          `protobuf:"bytes,1,rep,name=refund_info,json=refundInfo,proto3" json:"refund_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundAll) Reset() {
+	*x = RefundAll{}
+	mi := &file_proto_payment_payment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundAll) ProtoMessage() {}
+
+func (x *RefundAll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundAll.ProtoReflect.Descriptor instead.
+func (*RefundAll) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RefundAll) GetRefundInfo() []*RefundInfo {
+	if x != nil {
+		return x.RefundInfo
+	}
+	return nil
+}
+
+type HeaderValues struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeaderValues) Reset() {
+	*x = HeaderValues{}
+	mi := &file_proto_payment_payment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeaderValues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderValues) ProtoMessage() {}
+
+func (x *HeaderValues) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderValues.ProtoReflect.Descriptor instead.
+func (*HeaderValues) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HeaderValues) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type WebhookRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Provider      string                   `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Header        map[string]*HeaderValues `protobuf:"bytes,2,rep,name=header,proto3" json:"header,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Body          []byte                   `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookRequest) Reset() {
+	*x = WebhookRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookRequest) ProtoMessage() {}
+
+func (x *WebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookRequest.ProtoReflect.Descriptor instead.
+func (*WebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WebhookRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *WebhookRequest) GetHeader() map[string]*HeaderValues {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *WebhookRequest) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type WebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TransactionId int64                  `protobuf:"varint,4,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Result        string                 `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookResponse) Reset() {
+	*x = WebhookResponse{}
+	mi := &file_proto_payment_payment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookResponse) ProtoMessage() {}
+
+func (x *WebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookResponse.ProtoReflect.Descriptor instead.
+func (*WebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WebhookResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookResponse) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookResponse) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *WebhookResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type ReconciliationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconciliationRequest) Reset() {
+	*x = ReconciliationRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationRequest) ProtoMessage() {}
+
+func (x *ReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*ReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReconciliationRequest) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+type ReconciliationQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconciliationQuery) Reset() {
+	*x = ReconciliationQuery{}
+	mi := &file_proto_payment_payment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationQuery) ProtoMessage() {}
+
+func (x *ReconciliationQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationQuery.ProtoReflect.Descriptor instead.
+func (*ReconciliationQuery) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReconciliationQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ReconciliationRunID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         int64                  `protobuf:"varint,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconciliationRunID) Reset() {
+	*x = ReconciliationRunID{}
+	mi := &file_proto_payment_payment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationRunID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationRunID) ProtoMessage() {}
+
+func (x *ReconciliationRunID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationRunID.ProtoReflect.Descriptor instead.
+func (*ReconciliationRunID) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReconciliationRunID) GetRunId() int64 {
+	if x != nil {
+		return x.RunId
+	}
+	return 0
+}
+
+type ReconciliationRunInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Day              string                 `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"`
+	Status           string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Channels         int64                  `protobuf:"varint,4,opt,name=channels,proto3" json:"channels,omitempty"`
+	Skipped          int64                  `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Matched          int64                  `protobuf:"varint,6,opt,name=matched,proto3" json:"matched,omitempty"`
+	DiscrepancyCount int64                  `protobuf:"varint,7,opt,name=discrepancy_count,json=discrepancyCount,proto3" json:"discrepancy_count,omitempty"`
+	Error            string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt        int64                  `protobuf:"varint,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt       int64                  `protobuf:"varint,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Discrepancies    []*DiscrepancyInfo     `protobuf:"bytes,11,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReconciliationRunInfo) Reset() {
+	*x = ReconciliationRunInfo{}
+	mi := &file_proto_payment_payment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationRunInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationRunInfo) ProtoMessage() {}
+
+func (x *ReconciliationRunInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationRunInfo.ProtoReflect.Descriptor instead.
+func (*ReconciliationRunInfo) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReconciliationRunInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReconciliationRunInfo) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *ReconciliationRunInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReconciliationRunInfo) GetChannels() int64 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *ReconciliationRunInfo) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ReconciliationRunInfo) GetMatched() int64 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *ReconciliationRunInfo) GetDiscrepancyCount() int64 {
+	if x != nil {
+		return x.DiscrepancyCount
+	}
+	return 0
+}
+
+func (x *ReconciliationRunInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReconciliationRunInfo) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *ReconciliationRunInfo) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *ReconciliationRunInfo) GetDiscrepancies() []*DiscrepancyInfo {
+	if x != nil {
+		return x.Discrepancies
+	}
+	return nil
+}
+
+type DiscrepancyInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	PaymentId      int64                  `protobuf:"varint,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId        int64                  `protobuf:"varint,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TransactionId  int64                  `protobuf:"varint,5,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	CaptureRef     string                 `protobuf:"bytes,6,opt,name=capture_ref,json=captureRef,proto3" json:"capture_ref,omitempty"`
+	LedgerAmount   *Money                 `protobuf:"bytes,7,opt,name=ledger_amount,json=ledgerAmount,proto3" json:"ledger_amount,omitempty"`
+	ProviderAmount *Money                 `protobuf:"bytes,8,opt,name=provider_amount,json=providerAmount,proto3" json:"provider_amount,omitempty"`
+	Detail         string                 `protobuf:"bytes,9,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiscrepancyInfo) Reset() {
+	*x = DiscrepancyInfo{}
+	mi := &file_proto_payment_payment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscrepancyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscrepancyInfo) ProtoMessage() {}
+
+func (x *DiscrepancyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscrepancyInfo.ProtoReflect.Descriptor instead.
+func (*DiscrepancyInfo) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DiscrepancyInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DiscrepancyInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DiscrepancyInfo) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *DiscrepancyInfo) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *DiscrepancyInfo) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *DiscrepancyInfo) GetCaptureRef() string {
+	if x != nil {
+		return x.CaptureRef
+	}
+	return ""
+}
+
+func (x *DiscrepancyInfo) GetLedgerAmount() *Money {
+	if x != nil {
+		return x.LedgerAmount
+	}
+	return nil
+}
+
+func (x *DiscrepancyInfo) GetProviderAmount() *Money {
+	if x != nil {
+		return x.ProviderAmount
+	}
+	return nil
+}
+
+func (x *DiscrepancyInfo) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type ReconciliationRunAll struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	RunInfo       []*ReconciliationRunInfo `protobuf:"bytes,1,rep,name=run_info,json=runInfo,proto3" json:"run_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconciliationRunAll) Reset() {
+	*x = ReconciliationRunAll{}
+	mi := &file_proto_payment_payment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconciliationRunAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconciliationRunAll) ProtoMessage() {}
+
+func (x *ReconciliationRunAll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconciliationRunAll.ProtoReflect.Descriptor instead.
+func (*ReconciliationRunAll) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReconciliationRunAll) GetRunInfo() []*ReconciliationRunInfo {
+	if x != nil {
+		return x.RunInfo
+	}
+	return nil
+}
+
+type RoutingRule struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Priority           int64                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Enabled            bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Currency           string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	MinAmount          int64                  `protobuf:"varint,6,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount          int64                  `protobuf:"varint,7,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	Countries          []string               `protobuf:"bytes,8,rep,name=countries,proto3" json:"countries,omitempty"`
+	PaymentId          int64                  `protobuf:"varint,9,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	FallbackPaymentIds []int64                `protobuf:"varint,10,rep,packed,name=fallback_payment_ids,json=fallbackPaymentIds,proto3" json:"fallback_payment_ids,omitempty"`
+	CreatedAt          int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          int64                  `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RoutingRule) Reset() {
+	*x = RoutingRule{}
+	mi := &file_proto_payment_payment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingRule) ProtoMessage() {}
+
+func (x *RoutingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingRule.ProtoReflect.Descriptor instead.
+func (*RoutingRule) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RoutingRule) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RoutingRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoutingRule) GetPriority() int64 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *RoutingRule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RoutingRule) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RoutingRule) GetMinAmount() int64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *RoutingRule) GetMaxAmount() int64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *RoutingRule) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *RoutingRule) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *RoutingRule) GetFallbackPaymentIds() []int64 {
+	if x != nil {
+		return x.FallbackPaymentIds
+	}
+	return nil
+}
+
+func (x *RoutingRule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *RoutingRule) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type RoutingRuleID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        int64                  `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoutingRuleID) Reset() {
+	*x = RoutingRuleID{}
+	mi := &file_proto_payment_payment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingRuleID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingRuleID) ProtoMessage() {}
+
+func (x *RoutingRuleID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingRuleID.ProtoReflect.Descriptor instead.
+func (*RoutingRuleID) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RoutingRuleID) GetRuleId() int64 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+type RoutingRuleAll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*RoutingRule         `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoutingRuleAll) Reset() {
+	*x = RoutingRuleAll{}
+	mi := &file_proto_payment_payment_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingRuleAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingRuleAll) ProtoMessage() {}
+
+func (x *RoutingRuleAll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingRuleAll.ProtoReflect.Descriptor instead.
+func (*RoutingRuleAll) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RoutingRuleAll) GetRules() []*RoutingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type RouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        *Money                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteRequest) Reset() {
+	*x = RouteRequest{}
+	mi := &file_proto_payment_payment_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteRequest) ProtoMessage() {}
+
+func (x *RouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteRequest.ProtoReflect.Descriptor instead.
+func (*RouteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RouteRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *RouteRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type RouteCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     int64                  `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Healthy       bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteCandidate) Reset() {
+	*x = RouteCandidate{}
+	mi := &file_proto_payment_payment_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteCandidate) ProtoMessage() {}
+
+func (x *RouteCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteCandidate.ProtoReflect.Descriptor instead.
+func (*RouteCandidate) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RouteCandidate) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *RouteCandidate) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+type RouteResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        int64                  `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Candidates    []*RouteCandidate      `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteResult) Reset() {
+	*x = RouteResult{}
+	mi := &file_proto_payment_payment_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteResult) ProtoMessage() {}
+
+func (x *RouteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_payment_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteResult.ProtoReflect.Descriptor instead.
+func (*RouteResult) Descriptor() ([]byte, []int) {
+	return file_proto_payment_payment_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RouteResult) GetRuleId() int64 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *RouteResult) GetCandidates() []*RouteCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+var File_proto_payment_payment_proto protoreflect.FileDescriptor
+
+const file_proto_payment_payment_proto_rawDesc = "" +
+	"\n" +
+	"\x1bproto/payment/payment.proto\x12\apayment\"\xc9\x01\n" +
+	"\vPaymentInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\fpayment_name\x18\x02 \x01(\tR\vpaymentName\x12\x1f\n" +
+	"\vpayment_sid\x18\x03 \x01(\tR\n" +
+	"paymentSid\x12%\n" +
+	"\x0epayment_status\x18\x04 \x01(\tR\rpaymentStatus\x12#\n" +
+	"\rPayment_image\x18\x05 \x01(\tR\fPaymentImage\x12\x1a\n" +
+	"\bprovider\x18\x06 \x01(\tR\bprovider\"*\n" +
+	"\tPaymentID\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\x03R\tpaymentId\"\x1c\n" +
+	"\bResponse\x12\x10\n" +
+	"\x03msg\x18\x01 \x01(\tR\x03msg\"\x05\n" +
+	"\x03All\"E\n" +
+	"\n" +
+	"PaymentAll\x127\n" +
+	"\fpayment_info\x18\x01 \x03(\v2\x14.payment.PaymentInfoR\vpaymentInfo\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xbb\x04\n" +
+	"\x0fTransactionInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x03 \x01(\x03R\tpaymentId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12!\n" +
+	"\fprovider_ref\x18\x06 \x01(\tR\vproviderRef\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\b \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\x03R\tupdatedAt\x12!\n" +
+	"\fapproval_url\x18\v \x01(\tR\vapprovalUrl\x12\x1f\n" +
+	"\vcapture_ref\x18\f \x01(\tR\n" +
+	"captureRef\x12'\n" +
+	"\x0frefunded_amount\x18\r \x01(\x01R\x0erefundedAmount\x12\x18\n" +
+	"\acountry\x18\x0e \x01(\tR\acountry\x12\x17\n" +
+	"\auser_id\x18\x0f \x01(\x03R\x06userId\x123\n" +
+	"\rcharge_amount\x18\x10 \x01(\v2\x0e.payment.MoneyR\fchargeAmount\x125\n" +
+	"\x0erefunded_total\x18\x11 \x01(\v2\x0e.payment.MoneyR\rrefundedTotal\"6\n" +
+	"\rTransactionID\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\x03R\rtransactionId\"Z\n" +
+	"\x0eCaptureRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\x03R\rtransactionId\x12!\n" +
+	"\fprovider_ref\x18\x02 \x01(\tR\vproviderRef\"$\n" +
+	"\aOrderID\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"U\n" +
+	"\x0eTransactionAll\x12C\n" +
+	"\x10transaction_info\x18\x01 \x03(\v2\x18.payment.TransactionInfoR\x0ftransactionInfo\"\xd3\x01\n" +
+	"\rRefundRequest\x12\x1b\n" +
+	"\trefund_id\x18\x01 \x01(\tR\brefundId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x03R\aorderId\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\x03R\rtransactionId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x123\n" +
+	"\rrefund_amount\x18\x06 \x01(\v2\x0e.payment.MoneyR\frefundAmount\"\x9c\x03\n" +
+	"\n" +
+	"RefundInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\trefund_id\x18\x02 \x01(\tR\brefundId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x03R\aorderId\x12%\n" +
+	"\x0etransaction_id\x18\x04 \x01(\x03R\rtransactionId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12!\n" +
+	"\fprovider_ref\x18\t \x01(\tR\vproviderRef\x12%\n" +
+	"\x0efailure_reason\x18\n" +
+	" \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\x03R\tupdatedAt\x123\n" +
+	"\rrefund_amount\x18\r \x01(\v2\x0e.payment.MoneyR\frefundAmount\"A\n" +
+	"\tRefundAll\x124\n" +
+	"\vrefund_info\x18\x01 \x03(\v2\x13.payment.RefundInfoR\n" +
+	"refundInfo\"&\n" +
+	"\fHeaderValues\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xcf\x01\n" +
+	"\x0eWebhookRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12;\n" +
+	"\x06header\x18\x02 \x03(\v2#.payment.WebhookRequest.HeaderEntryR\x06header\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\fR\x04body\x1aP\n" +
+	"\vHeaderEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.payment.HeaderValuesR\x05value:\x028\x01\"\xa2\x01\n" +
+	"\x0fWebhookResponse\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12%\n" +
+	"\x0etransaction_id\x18\x04 \x01(\x03R\rtransactionId\x12\x16\n" +
+	"\x06result\x18\x05 \x01(\tR\x06result\")\n" +
+	"\x15ReconciliationRequest\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\"+\n" +
+	"\x13ReconciliationQuery\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\",\n" +
+	"\x13ReconciliationRunID\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\x03R\x05runId\"\xe4\x02\n" +
+	"\x15ReconciliationRunInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03day\x18\x02 \x01(\tR\x03day\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\bchannels\x18\x04 \x01(\x03R\bchannels\x12\x18\n" +
+	"\askipped\x18\x05 \x01(\x03R\askipped\x12\x18\n" +
+	"\amatched\x18\x06 \x01(\x03R\amatched\x12+\n" +
+	"\x11discrepancy_count\x18\a \x01(\x03R\x10discrepancyCount\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\t \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\x03R\n" +
+	"finishedAt\x12>\n" +
+	"\rdiscrepancies\x18\v \x03(\v2\x18.payment.DiscrepancyInfoR\rdiscrepancies\"\xbd\x02\n" +
+	"\x0fDiscrepancyInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x03 \x01(\x03R\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\x03R\aorderId\x12%\n" +
+	"\x0etransaction_id\x18\x05 \x01(\x03R\rtransactionId\x12\x1f\n" +
+	"\vcapture_ref\x18\x06 \x01(\tR\n" +
+	"captureRef\x123\n" +
+	"\rledger_amount\x18\a \x01(\v2\x0e.payment.MoneyR\fledgerAmount\x127\n" +
+	"\x0fprovider_amount\x18\b \x01(\v2\x0e.payment.MoneyR\x0eproviderAmount\x12\x16\n" +
+	"\x06detail\x18\t \x01(\tR\x06detail\"Q\n" +
+	"\x14ReconciliationRunAll\x129\n" +
+	"\brun_info\x18\x01 \x03(\v2\x1e.payment.ReconciliationRunInfoR\arunInfo\"\xee\x02\n" +
+	"\vRoutingRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x03R\bpriority\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x06 \x01(\x03R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\a \x01(\x03R\tmaxAmount\x12\x1c\n" +
+	"\tcountries\x18\b \x03(\tR\tcountries\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\t \x01(\x03R\tpaymentId\x120\n" +
+	"\x14fallback_payment_ids\x18\n" +
+	" \x03(\x03R\x12fallbackPaymentIds\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\x03R\tupdatedAt\"(\n" +
+	"\rRoutingRuleID\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\x03R\x06ruleId\"<\n" +
+	"\x0eRoutingRuleAll\x12*\n" +
+	"\x05rules\x18\x01 \x03(\v2\x14.payment.RoutingRuleR\x05rules\"P\n" +
+	"\fRouteRequest\x12&\n" +
+	"\x06amount\x18\x01 \x01(\v2\x0e.payment.MoneyR\x06amount\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\"I\n" +
+	"\x0eRouteCandidate\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\x03R\tpaymentId\x12\x18\n" +
+	"\ahealthy\x18\x02 \x01(\bR\ahealthy\"_\n" +
+	"\vRouteResult\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\x03R\x06ruleId\x127\n" +
+	"\n" +
+	"candidates\x18\x02 \x03(\v2\x17.payment.RouteCandidateR\n" +
+	"candidates2\xe4\n" +
+	"\n" +
+	"\aPayment\x128\n" +
+	"\n" +
+	"AddPayment\x12\x14.payment.PaymentInfo\x1a\x12.payment.PaymentID\"\x00\x12:\n" +
+	"\rUpdatePayment\x12\x14.payment.PaymentInfo\x1a\x11.payment.Response\"\x00\x12<\n" +
+	"\x11DeletePaymentByID\x12\x12.payment.PaymentID\x1a\x11.payment.Response\"\x00\x12=\n" +
+	"\x0fFindPaymentByID\x12\x12.payment.PaymentID\x1a\x14.payment.PaymentInfo\"\x00\x125\n" +
+	"\x0eFindAllPayment\x12\f.payment.All\x1a\x13.payment.PaymentAll\"\x00\x12G\n" +
+	"\x11CreateTransaction\x12\x18.payment.TransactionInfo\x1a\x16.payment.TransactionID\"\x00\x12I\n" +
+	"\x12CaptureTransaction\x12\x17.payment.CaptureRequest\x1a\x18.payment.TransactionInfo\"\x00\x12I\n" +
+	"\x13FindTransactionByID\x12\x16.payment.TransactionID\x1a\x18.payment.TransactionInfo\"\x00\x12F\n" +
+	"\x17FindTransactionsByOrder\x12\x10.payment.OrderID\x1a\x17.payment.TransactionAll\"\x00\x127\n" +
+	"\x06Refund\x12\x16.payment.RefundRequest\x1a\x13.payment.RefundInfo\"\x00\x12<\n" +
+	"\x12FindRefundsByOrder\x12\x10.payment.OrderID\x1a\x12.payment.RefundAll\"\x00\x12D\n" +
+	"\rHandleWebhook\x12\x17.payment.WebhookRequest\x1a\x18.payment.WebhookResponse\"\x00\x12U\n" +
+	"\x11RunReconciliation\x12\x1e.payment.ReconciliationRequest\x1a\x1e.payment.ReconciliationRunInfo\"\x00\x12W\n" +
+	"\x16FindReconciliationRuns\x12\x1c.payment.ReconciliationQuery\x1a\x1d.payment.ReconciliationRunAll\"\x00\x12W\n" +
+	"\x15FindReconciliationRun\x12\x1c.payment.ReconciliationRunID\x1a\x1e.payment.ReconciliationRunInfo\"\x00\x12@\n" +
+	"\x0eAddRoutingRule\x12\x14.payment.RoutingRule\x1a\x16.payment.RoutingRuleID\"\x00\x12>\n" +
+	"\x11UpdateRoutingRule\x12\x14.payment.RoutingRule\x1a\x11.payment.Response\"\x00\x12@\n" +
+	"\x11DeleteRoutingRule\x12\x16.payment.RoutingRuleID\x1a\x11.payment.Response\"\x00\x12;\n" +
+	"\x10FindRoutingRules\x12\f.payment.All\x1a\x17.payment.RoutingRuleAll\"\x00\x12A\n" +
+	"\x10RouteTransaction\x12\x15.payment.RouteRequest\x1a\x14.payment.RouteResult\"\x00B\x11Z\x0f./proto;paymentb\x06proto3"
+
+var (
+	file_proto_payment_payment_proto_rawDescOnce sync.Once
+	file_proto_payment_payment_proto_rawDescData []byte
+)
+
+func file_proto_payment_payment_proto_rawDescGZIP() []byte {
+	file_proto_payment_payment_proto_rawDescOnce.Do(func() {
+		file_proto_payment_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_payment_payment_proto_rawDesc), len(file_proto_payment_payment_proto_rawDesc)))
+	})
+	return file_proto_payment_payment_proto_rawDescData
+}
+
+var file_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_proto_payment_payment_proto_goTypes = []any{
+	(*PaymentInfo)(nil),           // 0: payment.PaymentInfo
+	(*PaymentID)(nil),             // 1: payment.PaymentID
+	(*Response)(nil),              // 2: payment.Response
+	(*All)(nil),                   // 3: payment.All
+	(*PaymentAll)(nil),            // 4: payment.PaymentAll
+	(*Money)(nil),                 // 5: payment.Money
+	(*TransactionInfo)(nil),       // 6: payment.TransactionInfo
+	(*TransactionID)(nil),         // 7: payment.TransactionID
+	(*CaptureRequest)(nil),        // 8: payment.CaptureRequest
+	(*OrderID)(nil),               // 9: payment.OrderID
+	(*TransactionAll)(nil),        // 10: payment.TransactionAll
+	(*RefundRequest)(nil),         // 11: payment.RefundRequest
+	(*RefundInfo)(nil),            // 12: payment.RefundInfo
+	(*RefundAll)(nil),             // 13: payment.RefundAll
+	(*HeaderValues)(nil),          // 14: payment.HeaderValues
+	(*WebhookRequest)(nil),        // 15: payment.WebhookRequest
+	(*WebhookResponse)(nil),       // 16: payment.WebhookResponse
+	(*ReconciliationRequest)(nil), // 17: payment.ReconciliationRequest
+	(*ReconciliationQuery)(nil),   // 18: payment.ReconciliationQuery
+	(*ReconciliationRunID)(nil),   // 19: payment.ReconciliationRunID
+	(*ReconciliationRunInfo)(nil), // 20: payment.ReconciliationRunInfo
+	(*DiscrepancyInfo)(nil),       // 21: payment.DiscrepancyInfo
+	(*ReconciliationRunAll)(nil),  // 22: payment.ReconciliationRunAll
+	(*RoutingRule)(nil),           // 23: payment.RoutingRule
+	(*RoutingRuleID)(nil),         // 24: payment.RoutingRuleID
+	(*RoutingRuleAll)(nil),        // 25: payment.RoutingRuleAll
+	(*RouteRequest)(nil),          // 26: payment.RouteRequest
+	(*RouteCandidate)(nil),        // 27: payment.RouteCandidate
+	(*RouteResult)(nil),           // 28: payment.RouteResult
+	nil,                           // 29: payment.WebhookRequest.HeaderEntry
+}
+var file_proto_payment_payment_proto_depIdxs = []int32{
+	0,  // 0: payment.PaymentAll.payment_info:type_name -> payment.PaymentInfo
+	5,  // 1: payment.TransactionInfo.charge_amount:type_name -> payment.Money
+	5,  // 2: payment.TransactionInfo.refunded_total:type_name -> payment.Money
+	6,  // 3: payment.TransactionAll.transaction_info:type_name -> payment.TransactionInfo
+	5,  // 4: payment.RefundRequest.refund_amount:type_name -> payment.Money
+	5,  // 5: payment.RefundInfo.refund_amount:type_name -> payment.Money
+	12, // 6: payment.RefundAll.refund_info:type_name -> payment.RefundInfo
+	29, // 7: payment.WebhookRequest.header:type_name -> payment.WebhookRequest.HeaderEntry
+	21, // 8: payment.ReconciliationRunInfo.discrepancies:type_name -> payment.DiscrepancyInfo
+	5,  // 9: payment.DiscrepancyInfo.ledger_amount:type_name -> payment.Money
+	5,  // 10: payment.DiscrepancyInfo.provider_amount:type_name -> payment.Money
+	20, // 11: payment.ReconciliationRunAll.run_info:type_name -> payment.ReconciliationRunInfo
+	23, // 12: payment.RoutingRuleAll.rules:type_name -> payment.RoutingRule
+	5,  // 13: payment.RouteRequest.amount:type_name -> payment.Money
+	27, // 14: payment.RouteResult.candidates:type_name -> payment.RouteCandidate
+	14, // 15: payment.WebhookRequest.HeaderEntry.value:type_name -> payment.HeaderValues
+	0,  // 16: payment.Payment.AddPayment:input_type -> payment.PaymentInfo
+	0,  // 17: payment.Payment.UpdatePayment:input_type -> payment.PaymentInfo
+	1,  // 18: payment.Payment.DeletePaymentByID:input_type -> payment.PaymentID
+	1,  // 19: payment.Payment.FindPaymentByID:input_type -> payment.PaymentID
+	3,  // 20: payment.Payment.FindAllPayment:input_type -> payment.All
+	6,  // 21: payment.Payment.CreateTransaction:input_type -> payment.TransactionInfo
+	8,  // 22: payment.Payment.CaptureTransaction:input_type -> payment.CaptureRequest
+	7,  // 23: payment.Payment.FindTransactionByID:input_type -> payment.TransactionID
+	9,  // 24: payment.Payment.FindTransactionsByOrder:input_type -> payment.OrderID
+	11, // 25: payment.Payment.Refund:input_type -> payment.RefundRequest
+	9,  // 26: payment.Payment.FindRefundsByOrder:input_type -> payment.OrderID
+	15, // 27: payment.Payment.HandleWebhook:input_type -> payment.WebhookRequest
+	17, // 28: payment.Payment.RunReconciliation:input_type -> payment.ReconciliationRequest
+	18, // 29: payment.Payment.FindReconciliationRuns:input_type -> payment.ReconciliationQuery
+	19, // 30: payment.Payment.FindReconciliationRun:input_type -> payment.ReconciliationRunID
+	23, // 31: payment.Payment.AddRoutingRule:input_type -> payment.RoutingRule
+	23, // 32: payment.Payment.UpdateRoutingRule:input_type -> payment.RoutingRule
+	24, // 33: payment.Payment.DeleteRoutingRule:input_type -> payment.RoutingRuleID
+	3,  // 34: payment.Payment.FindRoutingRules:input_type -> payment.All
+	26, // 35: payment.Payment.RouteTransaction:input_type -> payment.RouteRequest
+	1,  // 36: payment.Payment.AddPayment:output_type -> payment.PaymentID
+	2,  // 37: payment.Payment.UpdatePayment:output_type -> payment.Response
+	2,  // 38: payment.Payment.DeletePaymentByID:output_type -> payment.Response
+	0,  // 39: payment.Payment.FindPaymentByID:output_type -> payment.PaymentInfo
+	4,  // 40: payment.Payment.FindAllPayment:output_type -> payment.PaymentAll
+	7,  // 41: payment.Payment.CreateTransaction:output_type -> payment.TransactionID
+	6,  // 42: payment.Payment.CaptureTransaction:output_type -> payment.TransactionInfo
+	6,  // 43: payment.Payment.FindTransactionByID:output_type -> payment.TransactionInfo
+	10, // 44: payment.Payment.FindTransactionsByOrder:output_type -> payment.TransactionAll
+	12, // 45: payment.Payment.Refund:output_type -> payment.RefundInfo
+	13, // 46: payment.Payment.FindRefundsByOrder:output_type -> payment.RefundAll
+	16, // 47: payment.Payment.HandleWebhook:output_type -> payment.WebhookResponse
+	20, // 48: payment.Payment.RunReconciliation:output_type -> payment.ReconciliationRunInfo
+	22, // 49: payment.Payment.FindReconciliationRuns:output_type -> payment.ReconciliationRunAll
+	20, // 50: payment.Payment.FindReconciliationRun:output_type -> payment.ReconciliationRunInfo
+	24, // 51: payment.Payment.AddRoutingRule:output_type -> payment.RoutingRuleID
+	2,  // 52: payment.Payment.UpdateRoutingRule:output_type -> payment.Response
+	2,  // 53: payment.Payment.DeleteRoutingRule:output_type -> payment.Response
+	25, // 54: payment.Payment.FindRoutingRules:output_type -> payment.RoutingRuleAll
+	28, // 55: payment.Payment.RouteTransaction:output_type -> payment.RouteResult
+	36, // [36:56] is the sub-list for method output_type
+	16, // [16:36] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_proto_payment_payment_proto_init() }
+func file_proto_payment_payment_proto_init() {
+	if File_proto_payment_payment_proto != nil {
 		return
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payment_proto_rawDesc), len(file_proto_payment_proto_rawDesc)),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payment_payment_proto_rawDesc), len(file_proto_payment_payment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_proto_payment_proto_goTypes,
-		DependencyIndexes: file_proto_payment_proto_depIdxs,
-		MessageInfos:      file_proto_payment_proto_msgTypes,
+		GoTypes:           file_proto_payment_payment_proto_goTypes,
+		DependencyIndexes: file_proto_payment_payment_proto_depIdxs,
+		MessageInfos:      file_proto_payment_payment_proto_msgTypes,
 	}.Build()
-	File_proto_payment_proto = out.File
-	file_proto_payment_proto_goTypes = nil
-	file_proto_payment_proto_depIdxs = nil
+	File_proto_payment_payment_proto = out.File
+	file_proto_payment_payment_proto_goTypes = nil
+	file_proto_payment_payment_proto_depIdxs = nil
 }
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-micro. DO NOT EDIT.
-// source: proto/payment.proto
+// source: proto/payment/payment.proto
 
 package payment
 
@@ -35,6 +35,21 @@ type PaymentService interface {
 	DeletePaymentByID(ctx context.Context, in *PaymentID, opts ...client.CallOption) (*Response, error)
 	FindPaymentByID(ctx context.Context, in *PaymentID, opts ...client.CallOption) (*PaymentInfo, error)
 	FindAllPayment(ctx context.Context, in *All, opts ...client.CallOption) (*PaymentAll, error)
+	CreateTransaction(ctx context.Context, in *TransactionInfo, opts ...client.CallOption) (*TransactionID, error)
+	CaptureTransaction(ctx context.Context, in *CaptureRequest, opts ...client.CallOption) (*TransactionInfo, error)
+	FindTransactionByID(ctx context.Context, in *TransactionID, opts ...client.CallOption) (*TransactionInfo, error)
+	FindTransactionsByOrder(ctx context.Context, in *OrderID, opts ...client.CallOption) (*TransactionAll, error)
+	Refund(ctx context.Context, in *RefundRequest, opts ...client.CallOption) (*RefundInfo, error)
+	FindRefundsByOrder(ctx context.Context, in *OrderID, opts ...client.CallOption) (*RefundAll, error)
+	HandleWebhook(ctx context.Context, in *WebhookRequest, opts ...client.CallOption) (*WebhookResponse, error)
+	RunReconciliation(ctx context.Context, in *ReconciliationRequest, opts ...client.CallOption) (*ReconciliationRunInfo, error)
+	FindReconciliationRuns(ctx context.Context, in *ReconciliationQuery, opts ...client.CallOption) (*ReconciliationRunAll, error)
+	FindReconciliationRun(ctx context.Context, in *ReconciliationRunID, opts ...client.CallOption) (*ReconciliationRunInfo, error)
+	AddRoutingRule(ctx context.Context, in *RoutingRule, opts ...client.CallOption) (*RoutingRuleID, error)
+	UpdateRoutingRule(ctx context.Context, in *RoutingRule, opts ...client.CallOption) (*Response, error)
+	DeleteRoutingRule(ctx context.Context, in *RoutingRuleID, opts ...client.CallOption) (*Response, error)
+	FindRoutingRules(ctx context.Context, in *All, opts ...client.CallOption) (*RoutingRuleAll, error)
+	RouteTransaction(ctx context.Context, in *RouteRequest, opts ...client.CallOption) (*RouteResult, error)
 }
 
 type paymentService struct {
@@ -99,6 +114,156 @@ func (c *paymentService) FindAllPayment(ctx context.Context, in *All, opts ...cl
 	return out, nil
 }
 
+func (c *paymentService) CreateTransaction(ctx context.Context, in *TransactionInfo, opts ...client.CallOption) (*TransactionID, error) {
+	req := c.c.NewRequest(c.name, "Payment.CreateTransaction", in)
+	out := new(TransactionID)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) CaptureTransaction(ctx context.Context, in *CaptureRequest, opts ...client.CallOption) (*TransactionInfo, error) {
+	req := c.c.NewRequest(c.name, "Payment.CaptureTransaction", in)
+	out := new(TransactionInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) FindTransactionByID(ctx context.Context, in *TransactionID, opts ...client.CallOption) (*TransactionInfo, error) {
+	req := c.c.NewRequest(c.name, "Payment.FindTransactionByID", in)
+	out := new(TransactionInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) FindTransactionsByOrder(ctx context.Context, in *OrderID, opts ...client.CallOption) (*TransactionAll, error) {
+	req := c.c.NewRequest(c.name, "Payment.FindTransactionsByOrder", in)
+	out := new(TransactionAll)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) Refund(ctx context.Context, in *RefundRequest, opts ...client.CallOption) (*RefundInfo, error) {
+	req := c.c.NewRequest(c.name, "Payment.Refund", in)
+	out := new(RefundInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) FindRefundsByOrder(ctx context.Context, in *OrderID, opts ...client.CallOption) (*RefundAll, error) {
+	req := c.c.NewRequest(c.name, "Payment.FindRefundsByOrder", in)
+	out := new(RefundAll)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) HandleWebhook(ctx context.Context, in *WebhookRequest, opts ...client.CallOption) (*WebhookResponse, error) {
+	req := c.c.NewRequest(c.name, "Payment.HandleWebhook", in)
+	out := new(WebhookResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) RunReconciliation(ctx context.Context, in *ReconciliationRequest, opts ...client.CallOption) (*ReconciliationRunInfo, error) {
+	req := c.c.NewRequest(c.name, "Payment.RunReconciliation", in)
+	out := new(ReconciliationRunInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) FindReconciliationRuns(ctx context.Context, in *ReconciliationQuery, opts ...client.CallOption) (*ReconciliationRunAll, error) {
+	req := c.c.NewRequest(c.name, "Payment.FindReconciliationRuns", in)
+	out := new(ReconciliationRunAll)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) FindReconciliationRun(ctx context.Context, in *ReconciliationRunID, opts ...client.CallOption) (*ReconciliationRunInfo, error) {
+	req := c.c.NewRequest(c.name, "Payment.FindReconciliationRun", in)
+	out := new(ReconciliationRunInfo)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) AddRoutingRule(ctx context.Context, in *RoutingRule, opts ...client.CallOption) (*RoutingRuleID, error) {
+	req := c.c.NewRequest(c.name, "Payment.AddRoutingRule", in)
+	out := new(RoutingRuleID)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) UpdateRoutingRule(ctx context.Context, in *RoutingRule, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.name, "Payment.UpdateRoutingRule", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) DeleteRoutingRule(ctx context.Context, in *RoutingRuleID, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.name, "Payment.DeleteRoutingRule", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) FindRoutingRules(ctx context.Context, in *All, opts ...client.CallOption) (*RoutingRuleAll, error) {
+	req := c.c.NewRequest(c.name, "Payment.FindRoutingRules", in)
+	out := new(RoutingRuleAll)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentService) RouteTransaction(ctx context.Context, in *RouteRequest, opts ...client.CallOption) (*RouteResult, error) {
+	req := c.c.NewRequest(c.name, "Payment.RouteTransaction", in)
+	out := new(RouteResult)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Payment service
 
 type PaymentHandler interface {
@@ -107,6 +272,21 @@ type PaymentHandler interface {
 	DeletePaymentByID(context.Context, *PaymentID, *Response) error
 	FindPaymentByID(context.Context, *PaymentID, *PaymentInfo) error
 	FindAllPayment(context.Context, *All, *PaymentAll) error
+	CreateTransaction(context.Context, *TransactionInfo, *TransactionID) error
+	CaptureTransaction(context.Context, *CaptureRequest, *TransactionInfo) error
+	FindTransactionByID(context.Context, *TransactionID, *TransactionInfo) error
+	FindTransactionsByOrder(context.Context, *OrderID, *TransactionAll) error
+	Refund(context.Context, *RefundRequest, *RefundInfo) error
+	FindRefundsByOrder(context.Context, *OrderID, *RefundAll) error
+	HandleWebhook(context.Context, *WebhookRequest, *WebhookResponse) error
+	RunReconciliation(context.Context, *ReconciliationRequest, *ReconciliationRunInfo) error
+	FindReconciliationRuns(context.Context, *ReconciliationQuery, *ReconciliationRunAll) error
+	FindReconciliationRun(context.Context, *ReconciliationRunID, *ReconciliationRunInfo) error
+	AddRoutingRule(context.Context, *RoutingRule, *RoutingRuleID) error
+	UpdateRoutingRule(context.Context, *RoutingRule, *Response) error
+	DeleteRoutingRule(context.Context, *RoutingRuleID, *Response) error
+	FindRoutingRules(context.Context, *All, *RoutingRuleAll) error
+	RouteTransaction(context.Context, *RouteRequest, *RouteResult) error
 }
 
 func RegisterPaymentHandler(s server.Server, hdlr PaymentHandler, opts ...server.HandlerOption) error {
@@ -116,6 +296,21 @@ func RegisterPaymentHandler(s server.Server, hdlr PaymentHandler, opts ...server
 		DeletePaymentByID(ctx context.Context, in *PaymentID, out *Response) error
 		FindPaymentByID(ctx context.Context, in *PaymentID, out *PaymentInfo) error
 		FindAllPayment(ctx context.Context, in *All, out *PaymentAll) error
+		CreateTransaction(ctx context.Context, in *TransactionInfo, out *TransactionID) error
+		CaptureTransaction(ctx context.Context, in *CaptureRequest, out *TransactionInfo) error
+		FindTransactionByID(ctx context.Context, in *TransactionID, out *TransactionInfo) error
+		FindTransactionsByOrder(ctx context.Context, in *OrderID, out *TransactionAll) error
+		Refund(ctx context.Context, in *RefundRequest, out *RefundInfo) error
+		FindRefundsByOrder(ctx context.Context, in *OrderID, out *RefundAll) error
+		HandleWebhook(ctx context.Context, in *WebhookRequest, out *WebhookResponse) error
+		RunReconciliation(ctx context.Context, in *ReconciliationRequest, out *ReconciliationRunInfo) error
+		FindReconciliationRuns(ctx context.Context, in *ReconciliationQuery, out *ReconciliationRunAll) error
+		FindReconciliationRun(ctx context.Context, in *ReconciliationRunID, out *ReconciliationRunInfo) error
+		AddRoutingRule(ctx context.Context, in *RoutingRule, out *RoutingRuleID) error
+		UpdateRoutingRule(ctx context.Context, in *RoutingRule, out *Response) error
+		DeleteRoutingRule(ctx context.Context, in *RoutingRuleID, out *Response) error
+		FindRoutingRules(ctx context.Context, in *All, out *RoutingRuleAll) error
+		RouteTransaction(ctx context.Context, in *RouteRequest, out *RouteResult) error
 	}
 	type Payment struct {
 		payment
@@ -147,3 +342,63 @@ func (h *paymentHandler) FindPaymentByID(ctx context.Context, in *PaymentID, out
 func (h *paymentHandler) FindAllPayment(ctx context.Context, in *All, out *PaymentAll) error {
 	return h.PaymentHandler.FindAllPayment(ctx, in, out)
 }
+
+func (h *paymentHandler) CreateTransaction(ctx context.Context, in *TransactionInfo, out *TransactionID) error {
+	return h.PaymentHandler.CreateTransaction(ctx, in, out)
+}
+
+func (h *paymentHandler) CaptureTransaction(ctx context.Context, in *CaptureRequest, out *TransactionInfo) error {
+	return h.PaymentHandler.CaptureTransaction(ctx, in, out)
+}
+
+func (h *paymentHandler) FindTransactionByID(ctx context.Context, in *TransactionID, out *TransactionInfo) error {
+	return h.PaymentHandler.FindTransactionByID(ctx, in, out)
+}
+
+func (h *paymentHandler) FindTransactionsByOrder(ctx context.Context, in *OrderID, out *TransactionAll) error {
+	return h.PaymentHandler.FindTransactionsByOrder(ctx, in, out)
+}
+
+func (h *paymentHandler) Refund(ctx context.Context, in *RefundRequest, out *RefundInfo) error {
+	return h.PaymentHandler.Refund(ctx, in, out)
+}
+
+func (h *paymentHandler) FindRefundsByOrder(ctx context.Context, in *OrderID, out *RefundAll) error {
+	return h.PaymentHandler.FindRefundsByOrder(ctx, in, out)
+}
+
+func (h *paymentHandler) HandleWebhook(ctx context.Context, in *WebhookRequest, out *WebhookResponse) error {
+	return h.PaymentHandler.HandleWebhook(ctx, in, out)
+}
+
+func (h *paymentHandler) RunReconciliation(ctx context.Context, in *ReconciliationRequest, out *ReconciliationRunInfo) error {
+	return h.PaymentHandler.RunReconciliation(ctx, in, out)
+}
+
+func (h *paymentHandler) FindReconciliationRuns(ctx context.Context, in *ReconciliationQuery, out *ReconciliationRunAll) error {
+	return h.PaymentHandler.FindReconciliationRuns(ctx, in, out)
+}
+
+func (h *paymentHandler) FindReconciliationRun(ctx context.Context, in *ReconciliationRunID, out *ReconciliationRunInfo) error {
+	return h.PaymentHandler.FindReconciliationRun(ctx, in, out)
+}
+
+func (h *paymentHandler) AddRoutingRule(ctx context.Context, in *RoutingRule, out *RoutingRuleID) error {
+	return h.PaymentHandler.AddRoutingRule(ctx, in, out)
+}
+
+func (h *paymentHandler) UpdateRoutingRule(ctx context.Context, in *RoutingRule, out *Response) error {
+	return h.PaymentHandler.UpdateRoutingRule(ctx, in, out)
+}
+
+func (h *paymentHandler) DeleteRoutingRule(ctx context.Context, in *RoutingRuleID, out *Response) error {
+	return h.PaymentHandler.DeleteRoutingRule(ctx, in, out)
+}
+
+func (h *paymentHandler) FindRoutingRules(ctx context.Context, in *All, out *RoutingRuleAll) error {
+	return h.PaymentHandler.FindRoutingRules(ctx, in, out)
+}
+
+func (h *paymentHandler) RouteTransaction(ctx context.Context, in *RouteRequest, out *RouteResult) error {
+	return h.PaymentHandler.RouteTransaction(ctx, in, out)
+}
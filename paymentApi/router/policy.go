@@ -0,0 +1,18 @@
+package router
+
+import "github.com/Ben1524/GoMall/common/auth"
+
+// routePolicy REST 接口的访问策略，键为 "方法 路由模板"；未列入的接口一律拒绝。
+// 只要求登录的支付接口另由支付服务与处理器校验调用方是否为订单本人
+var routePolicy = auth.Policy{
+	"POST /api/v1/payments":                    auth.PermAuthenticated,
+	"GET /api/v1/payments/:id":                 auth.PermAuthenticated,
+	"GET /api/v1/orders/:orderID/payments":     auth.PermAuthenticated,
+	"GET /api/v1/payment-channels":             auth.PermAuthenticated,
+	"POST /api/v1/payments/:id/refunds":        auth.PermPaymentRefund,
+	"GET /api/v1/payment-routes":               auth.PermPaymentManage,
+	"GET /api/v1/payment-routing-rules":        auth.PermPaymentManage,
+	"POST /api/v1/payment-routing-rules":       auth.PermPaymentManage,
+	"PUT /api/v1/payment-routing-rules/:id":    auth.PermPaymentManage,
+	"DELETE /api/v1/payment-routing-rules/:id": auth.PermPaymentManage,
+}
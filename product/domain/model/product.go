@@ -1,12 +1,22 @@
 package model
 
+import "github.com/Ben1524/GoMall/common/money"
+
+// 商品状态
+const (
+	ProductStatusOnSale  int32 = 1 // 在售，可加入购物车
+	ProductStatusOffSale int32 = 2 // 已下架
+)
+
 type Product struct {
-	ID                 int64          `gorm:"primary_key;not_null;auto_increment" json:"id"`
-	ProductName        string         `json:"product_name"`
-	ProductSku         string         `gorm:"unique_index:not_null" json:"product_sku"`
-	ProductPrice       float64        `json:"product_price"`
-	ProductDescription string         `json:"product_description"`
-	ProductImage       []ProductImage `gorm:"ForeignKey:ImageProductID" json:"product_image"`
-	ProductSize        []ProductSize  `gorm:"ForeignKey:SizeProductID" json:"product_size"`
-	ProductSeo         ProductSeo     `gorm:"ForeignKey:SeoProductID" json:"product_seo"` // 一个产品对应一套 SEO 配置（如标题、关键词、描述，用于搜索引擎优化）
+	ID                   int64          `gorm:"primary_key;not_null;auto_increment" json:"id"`
+	ProductName          string         `json:"product_name"`
+	ProductSku           string         `gorm:"unique_index:not_null" json:"product_sku"`
+	ProductPrice         money.Money    `gorm:"embedded;embeddedPrefix:product_price_" json:"price"`
+	ProductDescription   string         `json:"product_description"`
+	ProductStatus        int32          `gorm:"default:1" json:"product_status"` // 商品状态，见 ProductStatus* 常量
+	ProductPurchaseLimit int64          `json:"product_purchase_limit"`          // 单个用户限购数量，0 表示不限购
+	ProductImage         []ProductImage `gorm:"ForeignKey:ImageProductID" json:"product_image"`
+	ProductSize          []ProductSize  `gorm:"ForeignKey:SizeProductID" json:"product_size"`
+	ProductSeo           ProductSeo     `gorm:"ForeignKey:SeoProductID" json:"product_seo"` // 一个产品对应一套 SEO 配置（如标题、关键词、描述，用于搜索引擎优化）
 }
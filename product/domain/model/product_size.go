@@ -5,4 +5,5 @@ type ProductSize struct {
 	SizeName string `json:"size_name"`
 	SizeCode string `gorm:"unique_index;not_null" json:"size_code"`
 	SizeProductID int64 `json:"size_product_id"`
+	SizeStock int64 `json:"size_stock"` // 该规格的可售库存
 }
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"product/domain/model"
 
+	"github.com/Ben1524/GoMall/common/money"
 	"gorm.io/gorm"
 )
 
@@ -30,7 +31,11 @@ type ProductRepository struct {
 // 初始化表
 func (u *ProductRepository) InitTable() error {
 	// AutoMigrate会自动创建表、缺失的外键、约束、列和索引，CreateTable只会创建表
-	return u.mysqlDb.AutoMigrate(&model.Product{}, &model.ProductSeo{}, &model.ProductImage{}, &model.ProductSize{}).Error
+	if err := u.mysqlDb.AutoMigrate(&model.Product{}, &model.ProductSeo{}, &model.ProductImage{}, &model.ProductSize{}); err != nil {
+		return err
+	}
+	// 历史的浮点价格列迁移为最小单位
+	return money.MigrateFloatColumn(u.mysqlDb, &model.Product{}, "product_price", "product_price_", money.DefaultCurrency)
 }
 
 // 根据ID查找Product信息
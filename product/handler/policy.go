@@ -0,0 +1,13 @@
+package handler
+
+import "github.com/Ben1524/GoMall/common/auth"
+
+// Policy 商品服务的访问策略，查询不要求令牌，维护商品需要 product:write 权限；未列入的端点一律拒绝
+var Policy = auth.Policy{
+	"Product.FindProductByID": auth.PermPublic,
+	"Product.FindAllProduct":  auth.PermPublic,
+
+	"Product.AddProduct":        auth.PermProductWrite,
+	"Product.UpdateProduct":     auth.PermProductWrite,
+	"Product.DeleteProductByID": auth.PermProductWrite,
+}
@@ -2,26 +2,43 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"product/domain/model"
 	"product/domain/service"
 	. "product/proto/product"
 
+	"github.com/Ben1524/GoMall/common/exchange"
+	"github.com/Ben1524/GoMall/common/money"
 	common "github.com/Ben1524/GoMall/common/utils"
+	"go-micro.dev/v5"
+	microerrors "go-micro.dev/v5/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
 )
 
+// 返回给调用方的 go-micro 错误 ID
+const serviceID = "go.micro.service.product"
+
+// 商品变更（价格、库存、上下架）发布的主题，购物车据此触发心愿单提醒
+const ProductChangedTopic = "go.micro.topic.product.changed"
+
 type Product struct {
 	ProductDataService service.IProductDataService
-	tracer             trace.Tracer // 新增：用于创建span的tracer
+	ProductChanged     micro.Event
+	Rates              exchange.Provider // 展示币种换算
+	tracer             trace.Tracer      // 新增：用于创建span的tracer
 }
 
 // 初始化handler时，创建唯一的tracer
-func NewProductHandler(service service.IProductDataService) *Product {
+func NewProductHandler(service service.IProductDataService, productChanged micro.Event, rates exchange.Provider) *Product {
 	return &Product{
 		ProductDataService: service,
+		ProductChanged:     productChanged,
+		Rates:              rates,
 		// 定义tracer名称（建议包含服务名和组件名，确保唯一）
 		tracer: otel.Tracer("product/handler", trace.WithInstrumentationVersion("v1.0.0")),
 	}
@@ -39,13 +56,18 @@ func (h *Product) FindProductByID(ctx context.Context, request *RequestID, respo
 	productData, err := h.ProductDataService.FindProductByID(request.ProductId)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 以 404 返回，调用方（如购物车校验）据此区分“商品不存在”与服务故障
+			return microerrors.NotFound(serviceID, "商品不存在: %d", request.ProductId)
+		}
 		return err
 	}
 	if err := common.SwapTo(productData, response); err != nil {
 		span.RecordError(err)
 		return err
 	}
-	return nil
+	response.ProductPrice = productData.ProductPrice.Float64()
+	return h.fillDisplayPrice(ctx, productData.ProductPrice, request.DisplayCurrency, response)
 }
 
 // 添加商品
@@ -54,6 +76,9 @@ func (h *Product) AddProduct(ctx context.Context, request *ProductInfo, response
 	if err := common.SwapTo(request, productAdd); err != nil {
 		return err
 	}
+	if err := fillPrice(request, productAdd); err != nil {
+		return err
+	}
 	fmt.Println(productAdd)
 	productID, err := h.ProductDataService.AddProduct(productAdd)
 	if err != nil {
@@ -69,10 +94,17 @@ func (h *Product) UpdateProduct(ctx context.Context, request *ProductInfo, respo
 	if err := common.SwapTo(request, productAdd); err != nil {
 		return err
 	}
+	if err := fillPrice(request, productAdd); err != nil {
+		return err
+	}
 	err := h.ProductDataService.UpdateProduct(productAdd)
 	if err != nil {
 		return err
 	}
+	// 更新已落库，事件发布失败只记录日志，不影响本次更新结果
+	if err := h.ProductChanged.Publish(ctx, &ProductChanged{ProductId: productAdd.ID}); err != nil {
+		slog.Warn("发布商品变更事件失败", "product_id", productAdd.ID, "error", err)
+	}
 	response.Msg = "更新成功"
 	return nil
 }
@@ -99,7 +131,45 @@ func (h *Product) FindAllProduct(ctx context.Context, request *RequestAll, respo
 		if err != nil {
 			return err
 		}
+		productInfo.ProductPrice = v.ProductPrice.Float64()
+		if err := h.fillDisplayPrice(ctx, v.ProductPrice, request.DisplayCurrency, productInfo); err != nil {
+			return err
+		}
 		response.ProductInfo = append(response.ProductInfo, productInfo)
 	}
 	return nil
 }
+
+// fillDisplayPrice 按当前汇率将价格换算为展示币种，currency 为空时不换算
+func (h *Product) fillDisplayPrice(ctx context.Context, price money.Money, currency string, info *ProductInfo) error {
+	if currency == "" {
+		return nil
+	}
+	if !money.ValidCurrency(money.Zero(currency).Currency) {
+		return microerrors.BadRequest(serviceID, "展示币种不合法: %q", currency)
+	}
+	display, _, err := exchange.ConvertTo(ctx, h.Rates, price, money.Zero(currency).Currency)
+	if errors.Is(err, exchange.ErrRateNotFound) {
+		return microerrors.BadRequest(serviceID, "%s", err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	info.DisplayPrice = &Money{Amount: display.Amount, Currency: display.Currency}
+	return nil
+}
+
+// fillPrice 未传 price 的旧调用方按 product_price 以默认币种换算
+func fillPrice(request *ProductInfo, product *model.Product) error {
+	if request.Price == nil {
+		product.ProductPrice = money.FromFloat(request.ProductPrice, money.DefaultCurrency)
+	}
+	product.ProductPrice = money.New(product.ProductPrice.Amount, product.ProductPrice.Currency)
+	if !money.ValidCurrency(product.ProductPrice.Currency) {
+		return microerrors.BadRequest(serviceID, "商品价格币种不合法: %q", product.ProductPrice.Currency)
+	}
+	if product.ProductPrice.IsNegative() {
+		return microerrors.BadRequest(serviceID, "商品价格不能为负数")
+	}
+	return nil
+}
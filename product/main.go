@@ -7,8 +7,11 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/microauth"
 	common "github.com/Ben1524/GoMall/common/config"
 	db "github.com/Ben1524/GoMall/common/db"
+	"github.com/Ben1524/GoMall/common/exchange"
 	"github.com/Ben1524/GoMall/common/otel"
 	"go-micro.dev/v5"
 	"go-micro.dev/v5/registry"
@@ -51,6 +54,18 @@ func main() {
 		}
 	}()
 
+	// 校验网关转发的访问令牌，维护商品需要 product:write 权限
+	sessionStore, err := auth.NewStore(config)
+	if err != nil {
+		slog.Error("初始化会话存储失败", "error", err)
+		os.Exit(1)
+	}
+	tokenManager, err := auth.NewManager(config.JWT, sessionStore)
+	if err != nil {
+		slog.Error("初始化令牌校验失败", "error", err)
+		os.Exit(1)
+	}
+
 	// 初始化Consul注册中心
 	consulRegistry := consul.NewConsulRegistry(
 		registry.Addrs("127.0.0.1:8500"), // 简化注册中心地址配置
@@ -61,8 +76,11 @@ func main() {
 		micro.Name(config.Server.ServiceName),
 		micro.Version("latest"),
 		micro.Registry(consulRegistry),
-		// 集成OpenTelemetry追踪中间件
-		micro.WrapHandler(opentelemetry.NewHandlerWrapper()),
+		// 集成OpenTelemetry追踪中间件与访问控制
+		micro.WrapHandler(
+			opentelemetry.NewHandlerWrapper(),
+			microauth.NewHandlerWrapper(tokenManager, config.Server.ServiceName, handler.Policy),
+		),
 		micro.WrapClient(opentelemetry.NewClientWrapper()),
 	)
 
@@ -103,8 +121,17 @@ func main() {
 	}
 	slog.Info("服务初始化完成")
 
+	productChanged := micro.NewEvent(handler.ProductChangedTopic, service.Client())
+
+	// 商品价格可按展示币种换算
+	rates, err := exchange.New(config.ExchangeRate)
+	if err != nil {
+		slog.Error("初始化汇率来源失败", "error", err)
+		os.Exit(1)
+	}
+
 	// 注册处理器
-	if err := pb.RegisterProductHandler(service.Server(), handler.NewProductHandler(productSvc)); err != nil {
+	if err := pb.RegisterProductHandler(service.Server(), handler.NewProductHandler(productSvc, productChanged, rates)); err != nil {
 		slog.Error("注册产品处理器失败", "error", err)
 		os.Exit(1)
 	}
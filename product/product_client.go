@@ -98,7 +98,7 @@ func AddProduct() {
 	productAdd := &pb.ProductInfo{
 		ProductName:        "imooc2",
 		ProductSku:         "cap2",
-		ProductPrice:       1.1,
+		Price:              &pb.Money{Amount: 110, Currency: "USD"},
 		ProductDescription: "imooc-cap2",
 		ProductCategoryId:  2,
 		ProductImage: []*pb.ProductImage{
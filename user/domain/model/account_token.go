@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// 账号令牌用途
+const (
+	TokenPurposeVerifyEmail   = "verify_email"
+	TokenPurposeResetPassword = "reset_password"
+)
+
+// AccountToken 邮件中发出的一次性令牌，只保存 SHA-256 哈希。
+// 使用后写入 UsedAt；同一用户同一用途签发新令牌时，旧的未使用令牌一并作废。
+type AccountToken struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64      `gorm:"not null;index" json:"user_id"`
+	Purpose   string     `gorm:"type:varchar(20);not null" json:"purpose"`
+	Email     string     `gorm:"type:varchar(100);not null;index:idx_account_tokens_email" json:"email"` // 发送时的邮箱，用于频率限制
+	TokenHash string     `gorm:"type:char(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime;index:idx_account_tokens_email" json:"created_at"`
+}
+
+func (t *AccountToken) TableName() string {
+	return "user_account_tokens"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Address 收货地址，每个用户至多一个默认地址
+type Address struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     int64     `gorm:"index;not null" json:"user_id"`
+	Recipient  string    `gorm:"type:varchar(50);not null" json:"recipient"`
+	Phone      string    `gorm:"type:varchar(20);not null" json:"phone"`
+	Country    string    `gorm:"type:char(2);not null" json:"country"`     // ISO 3166-1 两位字母代码
+	Region     string    `gorm:"type:varchar(100);not null" json:"region"` // 省、州
+	City       string    `gorm:"type:varchar(100);not null" json:"city"`
+	Detail     string    `gorm:"type:varchar(255);not null" json:"detail"` // 街道、门牌号
+	PostalCode string    `gorm:"type:varchar(10);not null" json:"postal_code"`
+	IsDefault  bool      `gorm:"not null" json:"is_default"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (a *Address) TableName() string {
+	return "user_addresses"
+}
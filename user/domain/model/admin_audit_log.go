@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// 管理操作
+const (
+	AdminSearchUsers   = "search_users"
+	AdminViewUser      = "view_user"
+	AdminLockUser      = "lock_user"
+	AdminUnlockUser    = "unlock_user"
+	AdminResetPassword = "reset_password"
+	AdminUpdateStatus  = "update_status"
+	AdminUpdateRoles   = "update_roles"
+	AdminViewAuditLogs = "view_audit_logs"
+	AdminBootstrap     = "bootstrap_admin" // 启动时按配置授予首个管理员，操作人为 0
+)
+
+// AdminAuditResultOK 操作成功，失败时 Result 为错误信息
+const AdminAuditResultOK = "ok"
+
+// AdminAuditLog 管理操作审计记录，只追加不修改。
+// 管理员身份取自访问令牌，查询类操作同样记录，Detail 为操作参数的 JSON。
+type AdminAuditLog struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	AdminID        int64     `gorm:"not null;index" json:"admin_id"`
+	AdminSessionID string    `gorm:"type:varchar(32);not null" json:"admin_session_id"`
+	AdminRoles     []string  `gorm:"type:varchar(255);serializer:json" json:"admin_roles"`
+	Action         string    `gorm:"type:varchar(32);not null;index" json:"action"`
+	TargetUserID   int64     `gorm:"not null;index" json:"target_user_id"` // 搜索等不针对单个用户的操作为 0
+	Reason         string    `gorm:"type:varchar(255);not null" json:"reason"`
+	Detail         string    `gorm:"type:varchar(1000);not null" json:"detail"`
+	Result         string    `gorm:"type:varchar(255);not null" json:"result"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+func (l *AdminAuditLog) TableName() string {
+	return "user_admin_audit_logs"
+}
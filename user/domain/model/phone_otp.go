@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// PhoneOTP 手机号的短信登录验证码，每个手机号一行，发送新验证码时覆盖旧验证码。
+// 验证码只保存哈希，使用或作废后 CodeHash 置空；SendCount 为 WindowStart 起一小时内的发送次数。
+type PhoneOTP struct {
+	Phone       string     `gorm:"type:varchar(20);primaryKey" json:"phone"`
+	CodeHash    string     `gorm:"type:char(64);not null" json:"-"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	Attempts    int        `gorm:"not null" json:"attempts"` // 当前验证码已尝试的次数
+	SentAt      time.Time  `gorm:"not null" json:"sent_at"`
+	WindowStart time.Time  `gorm:"not null" json:"window_start"`
+	SendCount   int        `gorm:"not null" json:"send_count"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"` // 尝试次数用尽后锁定到该时间
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (o *PhoneOTP) TableName() string {
+	return "user_phone_otps"
+}
@@ -0,0 +1,84 @@
+package model
+
+import "time"
+
+// 隐私请求类型
+const (
+	PrivacyExport = "export" // 导出用户数据
+	PrivacyDelete = "delete" // 注销账号并删除个人数据
+)
+
+// 隐私请求与步骤状态
+const (
+	PrivacyPending   = "pending"
+	PrivacyRunning   = "running" // 仅用于请求
+	PrivacyCompleted = "completed"
+	PrivacyFailed    = "failed"
+	PrivacySkipped   = "skipped" // 仅用于步骤：该服务没有与用户关联的数据
+	PrivacyExpired   = "expired" // 仅用于导出请求：导出数据已超过保留期被清除
+)
+
+// 持有用户数据的服务
+const (
+	PrivacyServiceCart    = "cart"
+	PrivacyServiceOrder   = "order"
+	PrivacyServicePayment = "payment"
+	PrivacyServiceLottery = "lottery"
+	PrivacyServiceUser    = "user"
+)
+
+// PrivacyServices 步骤的执行顺序：支付服务依赖订单步骤返回的订单ID；
+// 用户资料放在最后，删除时其余服务都已完成才注销账号
+var PrivacyServices = []string{
+	PrivacyServiceCart,
+	PrivacyServiceOrder,
+	PrivacyServicePayment,
+	PrivacyServiceLottery,
+	PrivacyServiceUser,
+}
+
+// PrivacyRequest 用户的数据导出或删除请求，按 PrivacyServices 的顺序逐个服务执行，
+// 失败的步骤在下次处理时重试，已完成的步骤不再执行。Attempts 为处理次数。
+type PrivacyRequest struct {
+	ID          int64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      int64         `gorm:"not null;index" json:"user_id"`
+	Type        string        `gorm:"type:varchar(10);not null" json:"type"`
+	Status      string        `gorm:"type:varchar(20);not null;index" json:"status"`
+	RequestedBy int64         `gorm:"not null" json:"requested_by"` // 发起人，本人或管理员
+	Attempts    int           `gorm:"not null" json:"attempts"`
+	LastError   string        `gorm:"type:varchar(500)" json:"last_error,omitempty"`
+	CreatedAt   time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	Steps       []PrivacyStep `gorm:"foreignKey:RequestID" json:"steps"`
+}
+
+func (r *PrivacyRequest) TableName() string {
+	return "user_privacy_requests"
+}
+
+// PrivacyStep 请求在一个服务上的执行情况。Data 为导出的 JSON，导出请求过期或用户注销后清除；
+// OrderIDs 为订单步骤返回的用户订单，供支付步骤使用。
+type PrivacyStep struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	RequestID   int64      `gorm:"not null;uniqueIndex:idx_privacy_steps_service" json:"request_id"`
+	Service     string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_privacy_steps_service" json:"service"`
+	Seq         int        `gorm:"not null" json:"seq"`
+	Status      string     `gorm:"type:varchar(20);not null" json:"status"`
+	Attempts    int        `gorm:"not null" json:"attempts"`
+	LastError   string     `gorm:"type:varchar(500)" json:"last_error,omitempty"`
+	Affected    int64      `gorm:"not null" json:"affected"` // 删除或匿名化的记录数
+	Data        []byte     `gorm:"type:longblob" json:"-"`
+	OrderIDs    []int64    `gorm:"type:text;serializer:json" json:"order_ids,omitempty"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func (s *PrivacyStep) TableName() string {
+	return "user_privacy_steps"
+}
+
+// Done 步骤已完成或无需执行
+func (s *PrivacyStep) Done() bool {
+	return s.Status == PrivacyCompleted || s.Status == PrivacySkipped
+}
@@ -2,18 +2,41 @@ package model
 
 import "time"
 
+// 用户状态
+const (
+	StatusActive  = 1 // 正常
+	StatusLocked  = 2 // 已锁定，不能登录
+	StatusDeleted = 3 // 已注销，不可恢复
+)
+
 type User struct {
 	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	Username     string    `gorm:"type:varchar(50);not null" json:"username"`
-	Email        string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"email"`
-	PasswordHash string    `gorm:"type:varchar(255);not null" json:"-"`
-	Phone        string    `gorm:"type:varchar(20);uniqueIndex" json:"phone,omitempty"`
+	Email        string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"email"` // 保存为小写
+	PasswordHash string    `gorm:"type:varchar(255);not null" json:"-"`                 // bcrypt 哈希
+	Phone        *string   `gorm:"type:varchar(20);uniqueIndex" json:"phone,omitempty"` // 未绑定手机号时为 NULL，避免唯一索引冲突
 	Avatar       string    `gorm:"type:varchar(255);not null" json:"avatar,omitempty"`
 	Status       int       `gorm:"type:tinyint(1);not null" json:"status,omitempty"`
+	Roles        []string  `gorm:"type:varchar(255);serializer:json" json:"roles,omitempty"` // 角色，见 common/auth
 	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"` // 未验证时为 NULL
 }
 
 func (u *User) TableName() string {
 	return "users"
 }
+
+// PhoneNumber 返回手机号，未绑定时为空
+func (u *User) PhoneNumber() string {
+	if u.Phone == nil {
+		return ""
+	}
+	return *u.Phone
+}
+
+// EmailVerified 邮箱是否已验证
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
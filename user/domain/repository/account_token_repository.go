@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"user/domain/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrTokenUsed 令牌已被使用或已作废，并发使用同一令牌时只有一方成功
+var ErrTokenUsed = errors.New("令牌已使用")
+
+type IAccountTokenRepository interface {
+	InitTable() error
+	// CreateToken 保存新令牌，同时作废该用户同一用途的其他未使用令牌
+	CreateToken(*model.AccountToken) error
+	FindTokenByHash(hash string) (*model.AccountToken, error)
+	// ConsumeToken 将未使用的令牌标记为已使用，已使用时返回 ErrTokenUsed
+	ConsumeToken(tokenID int64, usedAt time.Time) error
+	// FindTokensSince 返回发往该邮箱、指定用途、since 之后签发的令牌，最新的在前
+	FindTokensSince(email, purpose string, since time.Time) ([]model.AccountToken, error)
+	// DeleteTokens 删除用户的全部令牌，返回删除条数
+	DeleteTokens(userID int64) (int64, error)
+}
+
+// 创建accountTokenRepository
+func NewAccountTokenRepository(db *gorm.DB) IAccountTokenRepository {
+	return &AccountTokenRepository{mysqlDb: db}
+}
+
+type AccountTokenRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (a *AccountTokenRepository) InitTable() error {
+	return a.mysqlDb.AutoMigrate(&model.AccountToken{})
+}
+
+func (a *AccountTokenRepository) CreateToken(token *model.AccountToken) error {
+	return a.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.AccountToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, token.Purpose).
+			Update("used_at", token.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (a *AccountTokenRepository) FindTokenByHash(hash string) (token *model.AccountToken, err error) {
+	token = &model.AccountToken{}
+	return token, a.mysqlDb.Where("token_hash = ?", hash).First(token).Error
+}
+
+func (a *AccountTokenRepository) ConsumeToken(tokenID int64, usedAt time.Time) error {
+	result := a.mysqlDb.Model(&model.AccountToken{}).
+		Where("id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenUsed
+	}
+	return nil
+}
+
+func (a *AccountTokenRepository) FindTokensSince(email, purpose string, since time.Time) (tokens []model.AccountToken, err error) {
+	return tokens, a.mysqlDb.Where("email = ? AND purpose = ? AND created_at > ?", email, purpose, since).
+		Order("created_at DESC").Find(&tokens).Error
+}
+
+func (a *AccountTokenRepository) DeleteTokens(userID int64) (int64, error) {
+	db := a.mysqlDb.Where("user_id = ?", userID).Delete(&model.AccountToken{})
+	return db.RowsAffected, db.Error
+}
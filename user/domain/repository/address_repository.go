@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"errors"
+	"user/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type IAddressRepository interface {
+	InitTable() error
+	// CreateAddress 新增地址，IsDefault 为 true 时同时取消该用户其他地址的默认标记
+	CreateAddress(*model.Address) (int64, error)
+	FindAddressByID(userID, addressID int64) (*model.Address, error)
+	FindDefaultAddress(userID int64) (*model.Address, error)
+	// FindAddresses 默认地址在前，其余按最近更新排列
+	FindAddresses(userID int64) ([]model.Address, error)
+	CountAddresses(userID int64) (int64, error)
+	UpdateAddress(*model.Address) error
+	// DeleteAddress 删除默认地址时将最近更新的其他地址设为默认，地址不存在时返回 gorm.ErrRecordNotFound
+	DeleteAddress(userID, addressID int64) error
+	// SetDefaultAddress 地址不存在时返回 gorm.ErrRecordNotFound
+	SetDefaultAddress(userID, addressID int64) error
+	// DeleteAddresses 删除用户的全部地址，返回删除条数
+	DeleteAddresses(userID int64) (int64, error)
+}
+
+// 创建addressRepository
+func NewAddressRepository(db *gorm.DB) IAddressRepository {
+	return &AddressRepository{mysqlDb: db}
+}
+
+type AddressRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (a *AddressRepository) InitTable() error {
+	return a.mysqlDb.AutoMigrate(&model.Address{})
+}
+
+func (a *AddressRepository) CreateAddress(address *model.Address) (int64, error) {
+	err := a.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		if address.IsDefault {
+			if err := clearDefault(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Create(address).Error
+	})
+	return address.ID, err
+}
+
+func (a *AddressRepository) FindAddressByID(userID, addressID int64) (address *model.Address, err error) {
+	address = &model.Address{}
+	return address, a.mysqlDb.Where("id = ? AND user_id = ?", addressID, userID).First(address).Error
+}
+
+func (a *AddressRepository) FindDefaultAddress(userID int64) (address *model.Address, err error) {
+	address = &model.Address{}
+	return address, a.mysqlDb.Where("user_id = ? AND is_default = ?", userID, true).First(address).Error
+}
+
+func (a *AddressRepository) FindAddresses(userID int64) (addresses []model.Address, err error) {
+	return addresses, a.mysqlDb.Where("user_id = ?", userID).
+		Order("is_default DESC, updated_at DESC, id DESC").Find(&addresses).Error
+}
+
+func (a *AddressRepository) CountAddresses(userID int64) (count int64, err error) {
+	return count, a.mysqlDb.Model(&model.Address{}).Where("user_id = ?", userID).Count(&count).Error
+}
+
+// 整体替换地址字段，只更新属于该用户的地址
+func (a *AddressRepository) UpdateAddress(address *model.Address) error {
+	return a.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		if address.IsDefault {
+			if err := clearDefault(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Model(&model.Address{}).Where("id = ? AND user_id = ?", address.ID, address.UserID).
+			Select("recipient", "phone", "country", "region", "city", "detail", "postal_code", "is_default").
+			Updates(address).Error
+	})
+}
+
+func (a *AddressRepository) DeleteAddress(userID, addressID int64) error {
+	return a.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		address := &model.Address{}
+		if err := tx.Where("id = ? AND user_id = ?", addressID, userID).First(address).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(address).Error; err != nil {
+			return err
+		}
+		if !address.IsDefault {
+			return nil
+		}
+		next := &model.Address{}
+		err := tx.Where("user_id = ?", userID).Order("updated_at DESC, id DESC").First(next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(next).Update("is_default", true).Error
+	})
+}
+
+func (a *AddressRepository) SetDefaultAddress(userID, addressID int64) error {
+	return a.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefault(tx, userID); err != nil {
+			return err
+		}
+		result := tx.Model(&model.Address{}).Where("id = ? AND user_id = ?", addressID, userID).Update("is_default", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// clearDefault 取消用户所有地址的默认标记
+func clearDefault(tx *gorm.DB, userID int64) error {
+	return tx.Model(&model.Address{}).Where("user_id = ? AND is_default = ?", userID, true).Update("is_default", false).Error
+}
+
+func (a *AddressRepository) DeleteAddresses(userID int64) (int64, error) {
+	db := a.mysqlDb.Where("user_id = ?", userID).Delete(&model.Address{})
+	return db.RowsAffected, db.Error
+}
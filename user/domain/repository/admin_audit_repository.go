@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+	"user/domain/model"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogQuery 审计记录查询条件，零值不参与筛选
+type AuditLogQuery struct {
+	AdminID      int64
+	TargetUserID int64
+	Action       string
+	Since        time.Time
+	Until        time.Time
+}
+
+type IAdminAuditRepository interface {
+	InitTable() error
+	CreateLog(*model.AdminAuditLog) error
+	// FindLogs 分页查询，最新的在前，同时返回满足条件的总数
+	FindLogs(query AuditLogQuery, offset, limit int) ([]model.AdminAuditLog, int64, error)
+}
+
+// 创建adminAuditRepository
+func NewAdminAuditRepository(db *gorm.DB) IAdminAuditRepository {
+	return &AdminAuditRepository{mysqlDb: db}
+}
+
+type AdminAuditRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (a *AdminAuditRepository) InitTable() error {
+	return a.mysqlDb.AutoMigrate(&model.AdminAuditLog{})
+}
+
+func (a *AdminAuditRepository) CreateLog(log *model.AdminAuditLog) error {
+	return a.mysqlDb.Create(log).Error
+}
+
+func (a *AdminAuditRepository) FindLogs(query AuditLogQuery, offset, limit int) (logs []model.AdminAuditLog, total int64, err error) {
+	db := a.mysqlDb.Model(&model.AdminAuditLog{})
+	if query.AdminID > 0 {
+		db = db.Where("admin_id = ?", query.AdminID)
+	}
+	if query.TargetUserID > 0 {
+		db = db.Where("target_user_id = ?", query.TargetUserID)
+	}
+	if query.Action != "" {
+		db = db.Where("action = ?", query.Action)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("created_at >= ?", query.Since)
+	}
+	if !query.Until.IsZero() {
+		db = db.Where("created_at < ?", query.Until)
+	}
+	if err = db.Count(&total).Error; err != nil || total == 0 {
+		return nil, total, err
+	}
+	return logs, total, db.Order("id DESC").Offset(offset).Limit(limit).Find(&logs).Error
+}
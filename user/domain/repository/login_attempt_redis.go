@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const loginAttemptKeyPrefix = "user:login_attempts:"
+
+// 失败次数加一，过期时间延长到 window 之后，但不早于锁定截止时间；返回 {failures, locked_until}
+var recordFailureScript = redis.NewScript(`
+local failures = redis.call('HINCRBY', KEYS[1], 'failures', 1)
+redis.call('HSET', KEYS[1], 'last_failure', ARGV[1])
+local locked = tonumber(redis.call('HGET', KEYS[1], 'locked_until') or '0')
+local expires = tonumber(ARGV[1]) + tonumber(ARGV[2])
+if locked > expires then expires = locked end
+redis.call('PEXPIREAT', KEYS[1], expires)
+return {failures, locked}
+`)
+
+// 锁定到 ARGV[1]，过期时间只延长不缩短
+var lockScript = redis.NewScript(`
+redis.call('HSET', KEYS[1], 'locked_until', ARGV[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 or ttl < tonumber(ARGV[1]) - tonumber(ARGV[2]) then
+  redis.call('PEXPIREAT', KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// RedisLoginAttemptStore 基于 Redis 的实现，多个实例共享计数；每个键为一个 Hash，时间以毫秒保存
+type RedisLoginAttemptStore struct {
+	client *redis.Client
+}
+
+func NewRedisLoginAttemptStore(client *redis.Client) *RedisLoginAttemptStore {
+	return &RedisLoginAttemptStore{client: client}
+}
+
+// Get 过期由 Redis 处理，now 不参与
+func (s *RedisLoginAttemptStore) Get(ctx context.Context, key string, _ time.Time) (*LoginAttempts, error) {
+	values, err := s.client.HGetAll(ctx, loginAttemptKeyPrefix+key).Result()
+	if err != nil {
+		return nil, err
+	}
+	failures, _ := strconv.Atoi(values["failures"])
+	return &LoginAttempts{
+		Failures:    failures,
+		LastFailure: millis(values["last_failure"]),
+		LockedUntil: millis(values["locked_until"]),
+	}, nil
+}
+
+func (s *RedisLoginAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*LoginAttempts, error) {
+	result, err := recordFailureScript.Run(ctx, s.client, []string{loginAttemptKeyPrefix + key},
+		now.UnixMilli(), window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	attempts := &LoginAttempts{Failures: int(result[0]), LastFailure: now}
+	if result[1] > 0 {
+		attempts.LockedUntil = time.UnixMilli(result[1])
+	}
+	return attempts, nil
+}
+
+func (s *RedisLoginAttemptStore) Lock(ctx context.Context, key string, now, until time.Time) error {
+	return lockScript.Run(ctx, s.client, []string{loginAttemptKeyPrefix + key},
+		until.UnixMilli(), now.UnixMilli()).Err()
+}
+
+func (s *RedisLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	return s.client.Del(ctx, loginAttemptKeyPrefix+key).Err()
+}
+
+// millis 解析毫秒时间戳，为空时返回零值
+func millis(value string) time.Time {
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ben1524/GoMall/common/cache"
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+// LoginAttempts 一个键（账号、IP 等）最近的失败记录，没有记录时为零值
+type LoginAttempts struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// ILoginAttemptStore 登录失败计数，window 内没有新的失败时记录过期
+type ILoginAttemptStore interface {
+	Get(ctx context.Context, key string, now time.Time) (*LoginAttempts, error)
+	// RecordFailure 失败次数加一并返回最新记录
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*LoginAttempts, error)
+	// Lock 锁定到 until，锁定期间记录不过期
+	Lock(ctx context.Context, key string, now, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// NewLoginAttemptStore 按 login_guard.store 创建：redis（默认）或 memory，
+// Redis 启动时连接失败或运行中读写出错都退回 memory，保证失败计数始终生效
+func NewLoginAttemptStore(cfg *config.Config) ILoginAttemptStore {
+	if strings.ToLower(cfg.LoginGuard.Store) == "memory" {
+		return NewMemoryLoginAttemptStore()
+	}
+	client, err := cache.NewRedisClient(cfg)
+	if err != nil {
+		slog.Warn("Redis 不可用，登录失败计数退回进程内存储，多实例间不共享", "error", err)
+		return NewMemoryLoginAttemptStore()
+	}
+	return NewFallbackLoginAttemptStore(NewRedisLoginAttemptStore(client), NewMemoryLoginAttemptStore())
+}
+
+// FallbackLoginAttemptStore 主存储出错时改用备用存储，避免存储故障时放开登录限制；
+// 备用存储只对本实例可见，主存储恢复后重新以主存储为准
+type FallbackLoginAttemptStore struct {
+	primary  ILoginAttemptStore
+	fallback ILoginAttemptStore
+}
+
+func NewFallbackLoginAttemptStore(primary, fallback ILoginAttemptStore) *FallbackLoginAttemptStore {
+	return &FallbackLoginAttemptStore{primary: primary, fallback: fallback}
+}
+
+func (s *FallbackLoginAttemptStore) Get(ctx context.Context, key string, now time.Time) (*LoginAttempts, error) {
+	attempts, err := s.primary.Get(ctx, key, now)
+	if err != nil {
+		slog.Warn("登录失败计数存储不可用，改用进程内存储", "error", err)
+		return s.fallback.Get(ctx, key, now)
+	}
+	return attempts, nil
+}
+
+func (s *FallbackLoginAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*LoginAttempts, error) {
+	attempts, err := s.primary.RecordFailure(ctx, key, now, window)
+	if err != nil {
+		slog.Warn("登录失败计数存储不可用，改用进程内存储", "error", err)
+		return s.fallback.RecordFailure(ctx, key, now, window)
+	}
+	return attempts, nil
+}
+
+func (s *FallbackLoginAttemptStore) Lock(ctx context.Context, key string, now, until time.Time) error {
+	if err := s.primary.Lock(ctx, key, now, until); err != nil {
+		slog.Warn("登录失败计数存储不可用，改用进程内存储", "error", err)
+		return s.fallback.Lock(ctx, key, now, until)
+	}
+	return nil
+}
+
+// Reset 两边都清除，避免主存储恢复前备用存储里残留的计数继续生效
+func (s *FallbackLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	fallbackErr := s.fallback.Reset(ctx, key)
+	if err := s.primary.Reset(ctx, key); err != nil {
+		return err
+	}
+	return fallbackErr
+}
+
+// MemoryLoginAttemptStore 进程内实现，计数只对本实例可见
+type MemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]*memoryAttempts
+}
+
+type memoryAttempts struct {
+	LoginAttempts
+	expiresAt time.Time
+}
+
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{attempts: map[string]*memoryAttempts{}}
+}
+
+func (s *MemoryLoginAttemptStore) Get(_ context.Context, key string, now time.Time) (*LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := s.find(key, now)
+	if found == nil {
+		return &LoginAttempts{}, nil
+	}
+	attempts := found.LoginAttempts
+	return &attempts, nil
+}
+
+func (s *MemoryLoginAttemptStore) RecordFailure(_ context.Context, key string, now time.Time, window time.Duration) (*LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := s.find(key, now)
+	if found == nil {
+		found = &memoryAttempts{}
+		s.attempts[key] = found
+	}
+	found.Failures++
+	found.LastFailure = now
+	found.expiresAt = laterOf(now.Add(window), found.LockedUntil)
+	attempts := found.LoginAttempts
+	return &attempts, nil
+}
+
+func (s *MemoryLoginAttemptStore) Lock(_ context.Context, key string, now, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := s.find(key, now)
+	if found == nil {
+		found = &memoryAttempts{}
+		s.attempts[key] = found
+	}
+	found.LockedUntil = until
+	found.expiresAt = laterOf(found.expiresAt, until)
+	return nil
+}
+
+func (s *MemoryLoginAttemptStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
+
+// find 查找未过期的记录，过期的顺带清除；调用方须持有锁
+func (s *MemoryLoginAttemptStore) find(key string, now time.Time) *memoryAttempts {
+	found, ok := s.attempts[key]
+	if !ok {
+		return nil
+	}
+	if !now.Before(found.expiresAt) {
+		delete(s.attempts, key)
+		return nil
+	}
+	return found
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
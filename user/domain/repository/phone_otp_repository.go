@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"user/domain/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNoAttemptsLeft 验证码的尝试次数已用尽
+var ErrNoAttemptsLeft = errors.New("验证码尝试次数已用尽")
+
+type IPhoneOTPRepository interface {
+	InitTable() error
+	FindOTP(phone string) (*model.PhoneOTP, error)
+	// SaveOTP 新增或整行覆盖
+	SaveOTP(*model.PhoneOTP) error
+	// UseAttempt 占用一次尝试机会，先计数再比较验证码，并发猜测也不会超过 maxAttempts 次
+	UseAttempt(phone string, maxAttempts int) error
+	// ConsumeOTP 验证码仍为 codeHash 时置空，已被使用或覆盖时返回 ErrTokenUsed
+	ConsumeOTP(phone, codeHash string) error
+	// LockOTP 作废当前验证码并锁定到 until
+	LockOTP(phone string, until time.Time) error
+	// DeleteOTP 删除手机号的验证码记录，返回删除条数
+	DeleteOTP(phone string) (int64, error)
+}
+
+// 创建phoneOTPRepository
+func NewPhoneOTPRepository(db *gorm.DB) IPhoneOTPRepository {
+	return &PhoneOTPRepository{mysqlDb: db}
+}
+
+type PhoneOTPRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 初始化表
+func (p *PhoneOTPRepository) InitTable() error {
+	return p.mysqlDb.AutoMigrate(&model.PhoneOTP{})
+}
+
+func (p *PhoneOTPRepository) FindOTP(phone string) (otp *model.PhoneOTP, err error) {
+	otp = &model.PhoneOTP{}
+	return otp, p.mysqlDb.Where("phone = ?", phone).First(otp).Error
+}
+
+func (p *PhoneOTPRepository) SaveOTP(otp *model.PhoneOTP) error {
+	return p.mysqlDb.Clauses(clause.OnConflict{UpdateAll: true}).Create(otp).Error
+}
+
+func (p *PhoneOTPRepository) UseAttempt(phone string, maxAttempts int) error {
+	result := p.mysqlDb.Model(&model.PhoneOTP{}).
+		Where("phone = ? AND attempts < ?", phone, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNoAttemptsLeft
+	}
+	return nil
+}
+
+func (p *PhoneOTPRepository) ConsumeOTP(phone, codeHash string) error {
+	result := p.mysqlDb.Model(&model.PhoneOTP{}).
+		Where("phone = ? AND code_hash = ?", phone, codeHash).
+		Update("code_hash", "")
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenUsed
+	}
+	return nil
+}
+
+func (p *PhoneOTPRepository) LockOTP(phone string, until time.Time) error {
+	return p.mysqlDb.Model(&model.PhoneOTP{}).Where("phone = ?", phone).
+		Updates(map[string]any{"code_hash": "", "locked_until": until}).Error
+}
+
+func (p *PhoneOTPRepository) DeleteOTP(phone string) (int64, error) {
+	db := p.mysqlDb.Where("phone = ?", phone).Delete(&model.PhoneOTP{})
+	return db.RowsAffected, db.Error
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"user/domain/model"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPrivacyRequestExists 该用户已有同类型未完成的请求
+	ErrPrivacyRequestExists = errors.New("已有未完成的同类请求")
+	// ErrPrivacyRequestBusy 请求正由其他实例处理，或已完成
+	ErrPrivacyRequestBusy = errors.New("请求正在处理中")
+)
+
+type IPrivacyRepository interface {
+	InitTable() error
+	// CreateRequest 保存请求及其步骤，该用户同类型已有待处理、处理中或失败的请求时返回 ErrPrivacyRequestExists
+	CreateRequest(*model.PrivacyRequest) error
+	// FindRequestByID 步骤按执行顺序排列
+	FindRequestByID(int64) (*model.PrivacyRequest, error)
+	// FindRequests 返回用户的全部请求，最新的在前，不含步骤
+	FindRequests(userID int64) ([]model.PrivacyRequest, error)
+	// ClaimRequest 将待处理、失败或 staleBefore 之前开始处理的请求置为处理中并计一次尝试，
+	// 未命中时返回 ErrPrivacyRequestBusy，多个实例同时处理同一请求时只有一方成功
+	ClaimRequest(requestID int64, staleBefore time.Time) error
+	FinishRequest(requestID int64, status, lastError string, completedAt *time.Time) error
+	SaveStep(*model.PrivacyStep) error
+	// FindRetryable 返回尝试次数不足 maxAttempts 的待处理、失败请求与 staleBefore 之前开始处理的请求ID
+	FindRetryable(maxAttempts int, staleBefore time.Time, limit int) ([]int64, error)
+	// ExpireExports 清除 before 之前完成的导出请求的数据并标记为过期，返回过期的请求数
+	ExpireExports(before time.Time) (int64, error)
+	// PurgeUserExports 清除用户全部已完成导出请求的数据并标记为过期，用户注销后调用
+	PurgeUserExports(userID int64) error
+}
+
+// 创建privacyRepository
+func NewPrivacyRepository(db *gorm.DB) IPrivacyRepository {
+	return &PrivacyRepository{mysqlDb: db}
+}
+
+type PrivacyRepository struct {
+	mysqlDb *gorm.DB
+}
+
+// 未完成的请求状态
+var openPrivacyStatus = []string{model.PrivacyPending, model.PrivacyRunning, model.PrivacyFailed}
+
+// 初始化表
+func (p *PrivacyRepository) InitTable() error {
+	return p.mysqlDb.AutoMigrate(&model.PrivacyRequest{}, &model.PrivacyStep{})
+}
+
+func (p *PrivacyRepository) CreateRequest(request *model.PrivacyRequest) error {
+	return p.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		var open int64
+		if err := tx.Model(&model.PrivacyRequest{}).
+			Where("user_id = ? AND type = ? AND status IN ?", request.UserID, request.Type, openPrivacyStatus).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrPrivacyRequestExists
+		}
+		return tx.Create(request).Error
+	})
+}
+
+func (p *PrivacyRepository) FindRequestByID(requestID int64) (request *model.PrivacyRequest, err error) {
+	request = &model.PrivacyRequest{}
+	return request, p.mysqlDb.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seq")
+	}).First(request, requestID).Error
+}
+
+func (p *PrivacyRepository) FindRequests(userID int64) (requests []model.PrivacyRequest, err error) {
+	return requests, p.mysqlDb.Where("user_id = ?", userID).Order("id DESC").Find(&requests).Error
+}
+
+func (p *PrivacyRepository) ClaimRequest(requestID int64, staleBefore time.Time) error {
+	db := p.mysqlDb.Model(&model.PrivacyRequest{}).
+		Where("id = ?", requestID).
+		Where("status IN ? OR (status = ? AND updated_at < ?)",
+			[]string{model.PrivacyPending, model.PrivacyFailed}, model.PrivacyRunning, staleBefore).
+		Updates(map[string]interface{}{
+			"status":   model.PrivacyRunning,
+			"attempts": gorm.Expr("attempts + 1"),
+		})
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return ErrPrivacyRequestBusy
+	}
+	return nil
+}
+
+func (p *PrivacyRepository) FinishRequest(requestID int64, status, lastError string, completedAt *time.Time) error {
+	return p.mysqlDb.Model(&model.PrivacyRequest{ID: requestID}).Updates(map[string]interface{}{
+		"status":       status,
+		"last_error":   lastError,
+		"completed_at": completedAt,
+	}).Error
+}
+
+func (p *PrivacyRepository) SaveStep(step *model.PrivacyStep) error {
+	return p.mysqlDb.Save(step).Error
+}
+
+func (p *PrivacyRepository) FindRetryable(maxAttempts int, staleBefore time.Time, limit int) (ids []int64, err error) {
+	return ids, p.mysqlDb.Model(&model.PrivacyRequest{}).
+		Where("(status IN ? AND attempts < ?) OR (status = ? AND updated_at < ?)",
+			[]string{model.PrivacyPending, model.PrivacyFailed}, maxAttempts, model.PrivacyRunning, staleBefore).
+		Order("id").Limit(limit).Pluck("id", &ids).Error
+}
+
+func (p *PrivacyRepository) ExpireExports(before time.Time) (int64, error) {
+	return p.expireExports("completed_at < ?", before)
+}
+
+func (p *PrivacyRepository) PurgeUserExports(userID int64) error {
+	_, err := p.expireExports("user_id = ?", userID)
+	return err
+}
+
+// expireExports 清除满足条件的已完成导出请求的数据并标记为过期
+func (p *PrivacyRepository) expireExports(query string, args ...interface{}) (int64, error) {
+	var expired int64
+	err := p.mysqlDb.Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+		if err := tx.Model(&model.PrivacyRequest{}).Where(query, args...).
+			Where("type = ? AND status = ?", model.PrivacyExport, model.PrivacyCompleted).
+			Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+			return err
+		}
+		if err := tx.Model(&model.PrivacyStep{}).Where("request_id IN ?", ids).Update("data", nil).Error; err != nil {
+			return err
+		}
+		db := tx.Model(&model.PrivacyRequest{}).Where("id IN ?", ids).Update("status", model.PrivacyExpired)
+		expired = db.RowsAffected
+		return db.Error
+	})
+	return expired, err
+}
@@ -1,16 +1,49 @@
 package repository
 
 import (
+	"errors"
+	"strings"
+	"time"
 	"user/domain/model"
 
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrDuplicateEmail 邮箱已被其他用户使用
+	ErrDuplicateEmail = errors.New("邮箱已被注册")
+	// ErrDuplicatePhone 手机号已被其他用户使用
+	ErrDuplicatePhone = errors.New("手机号已被注册")
+)
+
+// UserQuery 管理后台搜索用户的条件，零值不参与筛选
+type UserQuery struct {
+	Email    string // 前缀匹配，须为小写
+	Phone    string // 前缀匹配
+	Username string // 包含匹配
+	Status   int
+	Role     string
+}
+
 type IUserRepository interface {
 	InitTable() error
+	CreateUser(*model.User) (int64, error)
+	FindUserByID(int64) (*model.User, error)
+	FindUserByEmail(string) (*model.User, error)
+	FindUserByPhone(string) (*model.User, error)
+	UpdateProfile(*model.User) error
+	UpdatePasswordHash(int64, string) error
+	UpdateStatus(int64, int) error
+	UpdateRoles(int64, []string) error
+	// MarkEmailVerified 记录邮箱验证时间，已验证时不覆盖
+	MarkEmailVerified(int64, time.Time) error
+	// AnonymizeUser 用 user 覆盖全部个人字段与状态、角色，用于注销后匿名化
+	AnonymizeUser(*model.User) error
+	// SearchUsers 按条件分页查询，按ID排列，同时返回满足条件的总数；包含已注销的用户
+	SearchUsers(query UserQuery, offset, limit int) ([]model.User, int64, error)
 }
 
-// 创建orderRepository
+// 创建userRepository
 func NewUserRepository(db *gorm.DB) IUserRepository {
 	return &UserRepository{mysqlDb: db}
 }
@@ -21,5 +54,112 @@ type UserRepository struct {
 
 // 初始化表
 func (u *UserRepository) InitTable() error {
-	return u.mysqlDb.AutoMigrate(&model.User{}).Error
+	return u.mysqlDb.AutoMigrate(&model.User{})
+}
+
+// 创建用户，并发注册时唯一索引冲突，返回 ErrDuplicateEmail 或 ErrDuplicatePhone
+func (u *UserRepository) CreateUser(user *model.User) (int64, error) {
+	err := u.mysqlDb.Create(user).Error
+	if err == nil {
+		return user.ID, nil
+	}
+	return 0, u.duplicateError(user, err)
+}
+
+// 根据ID查找用户
+func (u *UserRepository) FindUserByID(userID int64) (user *model.User, err error) {
+	user = &model.User{}
+	return user, u.mysqlDb.First(user, userID).Error
+}
+
+// 根据邮箱查找用户
+func (u *UserRepository) FindUserByEmail(email string) (user *model.User, err error) {
+	user = &model.User{}
+	return user, u.mysqlDb.Where("email = ?", email).First(user).Error
+}
+
+// 根据手机号查找用户
+func (u *UserRepository) FindUserByPhone(phone string) (user *model.User, err error) {
+	user = &model.User{}
+	return user, u.mysqlDb.Where("phone = ?", phone).First(user).Error
+}
+
+// 更新资料：用户名、手机号、头像
+func (u *UserRepository) UpdateProfile(user *model.User) error {
+	err := u.mysqlDb.Model(&model.User{ID: user.ID}).Select("username", "phone", "avatar").Updates(user).Error
+	if err == nil {
+		return nil
+	}
+	return u.duplicateError(user, err)
+}
+
+// 只更新密码哈希
+func (u *UserRepository) UpdatePasswordHash(userID int64, passwordHash string) error {
+	return u.mysqlDb.Model(&model.User{ID: userID}).Update("password_hash", passwordHash).Error
+}
+
+// 更新状态
+func (u *UserRepository) UpdateStatus(userID int64, status int) error {
+	return u.mysqlDb.Model(&model.User{ID: userID}).Update("status", status).Error
+}
+
+// 整体替换角色
+func (u *UserRepository) UpdateRoles(userID int64, roles []string) error {
+	return u.mysqlDb.Model(&model.User{ID: userID}).Select("roles").Updates(&model.User{Roles: roles}).Error
+}
+
+// 记录邮箱验证时间
+func (u *UserRepository) MarkEmailVerified(userID int64, verifiedAt time.Time) error {
+	return u.mysqlDb.Model(&model.User{ID: userID}).Where("email_verified_at IS NULL").
+		Update("email_verified_at", verifiedAt).Error
+}
+
+// 覆盖个人字段，零值同样写入
+func (u *UserRepository) AnonymizeUser(user *model.User) error {
+	return u.mysqlDb.Model(&model.User{ID: user.ID}).
+		Select("username", "email", "password_hash", "phone", "avatar", "status", "roles", "email_verified_at").
+		Updates(user).Error
+}
+
+// 分页搜索，LIKE 条件中的通配符按字面匹配
+func (u *UserRepository) SearchUsers(query UserQuery, offset, limit int) (users []model.User, total int64, err error) {
+	db := u.mysqlDb.Model(&model.User{})
+	if query.Email != "" {
+		db = db.Where("email LIKE ?", escapeLike(query.Email)+"%")
+	}
+	if query.Phone != "" {
+		db = db.Where("phone LIKE ?", escapeLike(query.Phone)+"%")
+	}
+	if query.Username != "" {
+		db = db.Where("username LIKE ?", "%"+escapeLike(query.Username)+"%")
+	}
+	if query.Status != 0 {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Role != "" {
+		// 角色以 JSON 数组保存，如 ["customer","support"]
+		db = db.Where("roles LIKE ?", `%"`+escapeLike(query.Role)+`"%`)
+	}
+	if err = db.Count(&total).Error; err != nil || total == 0 {
+		return nil, total, err
+	}
+	return users, total, db.Order("id").Offset(offset).Limit(limit).Find(&users).Error
+}
+
+// escapeLike 转义 LIKE 通配符，使用 MySQL 默认的转义字符 \
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// duplicateError 写入失败时检查邮箱、手机号是否已被其他用户占用
+func (u *UserRepository) duplicateError(user *model.User, err error) error {
+	if existing, findErr := u.FindUserByEmail(user.Email); findErr == nil && existing.ID != user.ID {
+		return ErrDuplicateEmail
+	}
+	if user.Phone != nil {
+		if existing, findErr := u.FindUserByPhone(*user.Phone); findErr == nil && existing.ID != user.ID {
+			return ErrDuplicatePhone
+		}
+	}
+	return err
 }
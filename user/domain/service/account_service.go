@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"github.com/Ben1524/GoMall/common/notify"
+	"gorm.io/gorm"
+)
+
+// accountTokenBytes 令牌随机字节数，base64url 编码后放入链接
+const accountTokenBytes = 32
+
+type IAccountService interface {
+	// SendVerificationEmail 向用户当前邮箱发送验证链接，已验证时返回 ErrEmailVerified
+	SendVerificationEmail(ctx context.Context, userID int64) error
+	// VerifyEmail 校验令牌并记录邮箱已验证
+	VerifyEmail(token string) error
+	// RequestPasswordReset 发送重置链接；邮箱未注册、账号不可用或超过发送频率时同样返回成功，避免探测账号
+	RequestPasswordReset(ctx context.Context, email string) error
+	// SendPasswordReset 由客服为用户发送重置链接，账号不可用或超过发送频率时返回对应错误
+	SendPasswordReset(ctx context.Context, userID int64) error
+	// ResetPassword 校验令牌并设置新密码，返回用户ID，调用方须随后吊销用户会话
+	ResetPassword(token, newPassword string) (int64, error)
+}
+
+// 创建
+func NewAccountService(userRepository repository.IUserRepository, tokenRepository repository.IAccountTokenRepository,
+	sender notify.EmailSender, cfg config.AccountConfig) IAccountService {
+	return &AccountService{
+		UserRepository:  userRepository,
+		TokenRepository: tokenRepository,
+		Sender:          sender,
+		cfg:             cfg,
+		now:             time.Now,
+	}
+}
+
+type AccountService struct {
+	UserRepository  repository.IUserRepository
+	TokenRepository repository.IAccountTokenRepository
+	Sender          notify.EmailSender
+	cfg             config.AccountConfig
+	now             func() time.Time
+}
+
+// 发送验证邮件
+func (a *AccountService) SendVerificationEmail(ctx context.Context, userID int64) error {
+	user, err := a.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return ErrEmailVerified
+	}
+	if err := a.allowSend(user.Email, model.TokenPurposeVerifyEmail); err != nil {
+		return err
+	}
+	token, err := a.issue(user, model.TokenPurposeVerifyEmail, a.cfg.VerifyTokenTTL)
+	if err != nil {
+		return err
+	}
+	return a.Sender.SendEmail(ctx, notify.Email{
+		To:      user.Email,
+		Subject: "验证你的 GoMall 邮箱",
+		Body: fmt.Sprintf("你好 %s：\n\n请在 %s内打开以下链接完成邮箱验证：\n%s\n\n如果你没有注册 GoMall，请忽略本邮件。\n",
+			user.Username, formatTTL(a.cfg.VerifyTokenTTL), tokenURL(a.cfg.VerifyEmailURL, token)),
+	})
+}
+
+// 验证邮箱，令牌签发后邮箱已变更的视为无效
+func (a *AccountService) VerifyEmail(token string) error {
+	found, err := a.findToken(token, model.TokenPurposeVerifyEmail)
+	if err != nil {
+		return err
+	}
+	user, err := a.UserRepository.FindUserByID(found.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (user.Status == model.StatusDeleted || user.Email != found.Email)) {
+		return ErrInvalidAccountToken
+	}
+	if err != nil {
+		return err
+	}
+	now := a.now()
+	if err := a.consume(found, now); err != nil {
+		return err
+	}
+	return a.UserRepository.MarkEmailVerified(user.ID, now)
+}
+
+// 申请重置密码
+func (a *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	user, err := a.UserRepository.FindUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status != model.StatusActive) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := a.allowSend(email, model.TokenPurposeResetPassword); err != nil {
+		if errors.Is(err, ErrTooManyRequests) {
+			slog.Warn("重置密码邮件发送过于频繁，已忽略", "user_id", user.ID)
+			return nil
+		}
+		return err
+	}
+	return a.sendPasswordReset(ctx, user)
+}
+
+// 为用户发送重置链接
+func (a *AccountService) SendPasswordReset(ctx context.Context, userID int64) error {
+	user, err := a.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status == model.StatusLocked {
+		return ErrUserLocked
+	}
+	if err := a.allowSend(user.Email, model.TokenPurposeResetPassword); err != nil {
+		return err
+	}
+	return a.sendPasswordReset(ctx, user)
+}
+
+// sendPasswordReset 签发重置令牌并发送邮件，调用方须已检查发送频率
+func (a *AccountService) sendPasswordReset(ctx context.Context, user *model.User) error {
+	token, err := a.issue(user, model.TokenPurposeResetPassword, a.cfg.ResetTokenTTL)
+	if err != nil {
+		return err
+	}
+	return a.Sender.SendEmail(ctx, notify.Email{
+		To:      user.Email,
+		Subject: "重置你的 GoMall 密码",
+		Body: fmt.Sprintf("你好 %s：\n\n我们收到了重置密码的请求，请在 %s内打开以下链接设置新密码：\n%s\n\n如果这不是你的操作，请忽略本邮件，密码不会改变。\n",
+			user.Username, formatTTL(a.cfg.ResetTokenTTL), tokenURL(a.cfg.ResetPasswordURL, token)),
+	})
+}
+
+// 重置密码，能收到邮件即证明邮箱属于本人，邮箱同时标记为已验证
+func (a *AccountService) ResetPassword(token, newPassword string) (int64, error) {
+	if err := validatePassword(newPassword); err != nil {
+		return 0, err
+	}
+	found, err := a.findToken(token, model.TokenPurposeResetPassword)
+	if err != nil {
+		return 0, err
+	}
+	user, err := a.UserRepository.FindUserByID(found.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (user.Status == model.StatusDeleted || user.Email != found.Email)) {
+		return 0, ErrInvalidAccountToken
+	}
+	if err != nil {
+		return 0, err
+	}
+	if user.Status == model.StatusLocked {
+		return 0, ErrUserLocked
+	}
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+	now := a.now()
+	if err := a.consume(found, now); err != nil {
+		return 0, err
+	}
+	if err := a.UserRepository.UpdatePasswordHash(user.ID, hash); err != nil {
+		return 0, err
+	}
+	if err := a.UserRepository.MarkEmailVerified(user.ID, now); err != nil {
+		slog.Warn("记录邮箱验证失败", "user_id", user.ID, "error", err)
+	}
+	return user.ID, nil
+}
+
+// allowSend 同一邮箱同一用途两次发送至少间隔 EmailResendInterval，一小时内最多 EmailsPerHour 封
+func (a *AccountService) allowSend(email, purpose string) error {
+	now := a.now()
+	recent, err := a.TokenRepository.FindTokensSince(email, purpose, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if len(recent) > 0 && now.Sub(recent[0].CreatedAt) < a.cfg.EmailResendInterval {
+		return ErrTooManyRequests
+	}
+	if a.cfg.EmailsPerHour > 0 && len(recent) >= a.cfg.EmailsPerHour {
+		return ErrTooManyRequests
+	}
+	return nil
+}
+
+// issue 签发令牌并保存哈希，返回明文令牌
+func (a *AccountService) issue(user *model.User, purpose string, ttl time.Duration) (string, error) {
+	b := make([]byte, accountTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	now := a.now()
+	err := a.TokenRepository.CreateToken(&model.AccountToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		Email:     user.Email,
+		TokenHash: hashAccountToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	})
+	return token, err
+}
+
+// findToken 查找未使用、未过期且用途一致的令牌
+func (a *AccountService) findToken(token, purpose string) (*model.AccountToken, error) {
+	if token == "" {
+		return nil, ErrInvalidAccountToken
+	}
+	found, err := a.TokenRepository.FindTokenByHash(hashAccountToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidAccountToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if found.Purpose != purpose || found.UsedAt != nil || !a.now().Before(found.ExpiresAt) {
+		return nil, ErrInvalidAccountToken
+	}
+	return found, nil
+}
+
+// consume 标记令牌已使用，并发使用同一令牌时只有一方成功
+func (a *AccountService) consume(token *model.AccountToken, now time.Time) error {
+	err := a.TokenRepository.ConsumeToken(token.ID, now)
+	if errors.Is(err, repository.ErrTokenUsed) {
+		return ErrInvalidAccountToken
+	}
+	return err
+}
+
+// hashAccountToken 令牌本身有 256 位随机性，无需加盐或慢哈希
+func hashAccountToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenURL 将链接模板中的 %s 替换为令牌
+func tokenURL(template, token string) string {
+	return strings.Replace(template, "%s", token, 1)
+}
+
+// formatTTL 以小时或分钟表示有效期
+func formatTTL(ttl time.Duration) string {
+	if ttl >= time.Hour && ttl%time.Hour == 0 {
+		return fmt.Sprintf("%d 小时", ttl/time.Hour)
+	}
+	return fmt.Sprintf("%d 分钟", ttl/time.Minute)
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"github.com/Ben1524/GoMall/common/notify"
+	"gorm.io/gorm"
+)
+
+// memoryAccountTokenRepository 内存实现
+type memoryAccountTokenRepository struct {
+	tokens []*model.AccountToken
+}
+
+func (r *memoryAccountTokenRepository) InitTable() error { return nil }
+
+func (r *memoryAccountTokenRepository) CreateToken(token *model.AccountToken) error {
+	for _, other := range r.tokens {
+		if other.UserID == token.UserID && other.Purpose == token.Purpose && other.UsedAt == nil {
+			usedAt := token.CreatedAt
+			other.UsedAt = &usedAt
+		}
+	}
+	token.ID = int64(len(r.tokens) + 1)
+	stored := *token
+	r.tokens = append(r.tokens, &stored)
+	return nil
+}
+
+func (r *memoryAccountTokenRepository) FindTokenByHash(hash string) (*model.AccountToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryAccountTokenRepository) ConsumeToken(tokenID int64, usedAt time.Time) error {
+	token := r.tokens[tokenID-1]
+	if token.UsedAt != nil {
+		return repository.ErrTokenUsed
+	}
+	token.UsedAt = &usedAt
+	return nil
+}
+
+func (r *memoryAccountTokenRepository) FindTokensSince(email, purpose string, since time.Time) ([]model.AccountToken, error) {
+	var tokens []model.AccountToken
+	for _, token := range r.tokens {
+		if token.Email == email && token.Purpose == purpose && token.CreatedAt.After(since) {
+			tokens = append(tokens, *token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].CreatedAt.After(tokens[j].CreatedAt) })
+	return tokens, nil
+}
+
+func (r *memoryAccountTokenRepository) DeleteTokens(userID int64) (int64, error) {
+	var kept []*model.AccountToken
+	for _, token := range r.tokens {
+		if token.UserID != userID {
+			kept = append(kept, token)
+		}
+	}
+	deleted := int64(len(r.tokens) - len(kept))
+	r.tokens = kept
+	return deleted, nil
+}
+
+var linkTokenPattern = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+
+type accountFixture struct {
+	users    *memoryUserRepository
+	tokens   *memoryAccountTokenRepository
+	outbox   *notify.Outbox
+	accounts *AccountService
+	now      time.Time
+	userID   int64
+}
+
+func newAccountFixture(t *testing.T) *accountFixture {
+	t.Helper()
+	outbox, err := notify.NewOutbox(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &accountFixture{
+		users:  newMemoryUserRepository(),
+		tokens: &memoryAccountTokenRepository{},
+		outbox: outbox,
+		now:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.accounts = NewAccountService(f.users, f.tokens, outbox, config.AccountConfig{
+		VerifyEmailURL:      "https://shop.example.com/verify?token=%s",
+		ResetPasswordURL:    "https://shop.example.com/reset?token=%s",
+		VerifyTokenTTL:      24 * time.Hour,
+		ResetTokenTTL:       30 * time.Minute,
+		EmailsPerHour:       3,
+		EmailResendInterval: time.Minute,
+	}).(*AccountService)
+	f.accounts.now = func() time.Time { return f.now }
+	f.userID, err = NewUserDataService(f.users).Register(&model.User{Username: "alice", Email: "alice@example.com"}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// lastToken 取出最后一封邮件链接中的令牌
+func (f *accountFixture) lastToken(t *testing.T) string {
+	t.Helper()
+	emails, err := f.outbox.Emails()
+	if err != nil || len(emails) == 0 {
+		t.Fatalf("outbox = %v, %v", emails, err)
+	}
+	match := linkTokenPattern.FindStringSubmatch(emails[len(emails)-1].Body)
+	if match == nil {
+		t.Fatalf("邮件中没有令牌: %s", emails[len(emails)-1].Body)
+	}
+	return match[1]
+}
+
+func TestVerifyEmail(t *testing.T) {
+	f := newAccountFixture(t)
+	ctx := context.Background()
+
+	if err := f.accounts.SendVerificationEmail(ctx, f.userID); err != nil {
+		t.Fatal(err)
+	}
+	first := f.lastToken(t)
+	if f.tokens.tokens[0].TokenHash == first {
+		t.Fatal("令牌不应明文保存")
+	}
+	if err := f.accounts.SendVerificationEmail(ctx, f.userID); !errors.Is(err, ErrTooManyRequests) {
+		t.Fatalf("err = %v, want ErrTooManyRequests", err)
+	}
+
+	f.now = f.now.Add(2 * time.Minute)
+	if err := f.accounts.SendVerificationEmail(ctx, f.userID); err != nil {
+		t.Fatal(err)
+	}
+	second := f.lastToken(t)
+	if err := f.accounts.VerifyEmail(first); !errors.Is(err, ErrInvalidAccountToken) {
+		t.Fatalf("旧令牌 err = %v, want ErrInvalidAccountToken", err)
+	}
+	if err := f.accounts.VerifyEmail(second); err != nil {
+		t.Fatal(err)
+	}
+	if !f.users.users[f.userID].EmailVerified() {
+		t.Fatal("邮箱应已验证")
+	}
+	if err := f.accounts.VerifyEmail(second); !errors.Is(err, ErrInvalidAccountToken) {
+		t.Fatalf("重复使用 err = %v, want ErrInvalidAccountToken", err)
+	}
+	if err := f.accounts.SendVerificationEmail(ctx, f.userID); !errors.Is(err, ErrEmailVerified) {
+		t.Fatalf("err = %v, want ErrEmailVerified", err)
+	}
+}
+
+func TestVerifyEmailRateLimit(t *testing.T) {
+	f := newAccountFixture(t)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := f.accounts.SendVerificationEmail(ctx, f.userID); err != nil {
+			t.Fatalf("第 %d 封: %v", i+1, err)
+		}
+		f.now = f.now.Add(5 * time.Minute)
+	}
+	if err := f.accounts.SendVerificationEmail(ctx, f.userID); !errors.Is(err, ErrTooManyRequests) {
+		t.Fatalf("err = %v, want ErrTooManyRequests", err)
+	}
+	f.now = f.now.Add(time.Hour)
+	if err := f.accounts.SendVerificationEmail(ctx, f.userID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	f := newAccountFixture(t)
+	ctx := context.Background()
+	users := NewUserDataService(f.users)
+
+	if err := f.accounts.RequestPasswordReset(ctx, "nobody@example.com"); err != nil {
+		t.Fatalf("未注册邮箱 err = %v, want nil", err)
+	}
+	if emails, _ := f.outbox.Emails(); len(emails) != 0 {
+		t.Fatalf("未注册邮箱不应发送邮件: %+v", emails)
+	}
+
+	if err := f.accounts.RequestPasswordReset(ctx, "Alice@Example.com"); err != nil {
+		t.Fatal(err)
+	}
+	token := f.lastToken(t)
+	if err := f.accounts.RequestPasswordReset(ctx, "alice@example.com"); err != nil {
+		t.Fatalf("超过频率 err = %v, want nil", err)
+	}
+	if emails, _ := f.outbox.Emails(); len(emails) != 1 {
+		t.Fatalf("超过频率不应再发送: %d 封", len(emails))
+	}
+
+	if _, err := f.accounts.ResetPassword(token, "short"); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("err = %v, want ErrWeakPassword", err)
+	}
+	if _, err := f.accounts.ResetPassword(token, "new password"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.Login("alice@example.com", "new password"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.accounts.ResetPassword(token, "another password"); !errors.Is(err, ErrInvalidAccountToken) {
+		t.Fatalf("重复使用 err = %v, want ErrInvalidAccountToken", err)
+	}
+
+	f.now = f.now.Add(2 * time.Minute)
+	if err := f.accounts.RequestPasswordReset(ctx, "alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	f.now = f.now.Add(31 * time.Minute)
+	if _, err := f.accounts.ResetPassword(f.lastToken(t), "another password"); !errors.Is(err, ErrInvalidAccountToken) {
+		t.Fatalf("过期 err = %v, want ErrInvalidAccountToken", err)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+// maxAddresses 每个用户最多保存的收货地址数
+const maxAddresses = 20
+
+var (
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	// 邮政编码：3 到 10 位字母数字，中间可含空格或连字符（如 100000、SW1A 1AA、12345-6789）
+	postalCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,8}[A-Z0-9]$`)
+)
+
+type IAddressService interface {
+	// AddAddress 新增地址，用户的第一个地址自动设为默认
+	AddAddress(*model.Address) (int64, error)
+	// UpdateAddress 整体替换地址字段，默认地址不能通过更新取消默认
+	UpdateAddress(*model.Address) error
+	DeleteAddress(userID, addressID int64) error
+	FindAddress(userID, addressID int64) (*model.Address, error)
+	FindDefaultAddress(userID int64) (*model.Address, error)
+	FindAddresses(userID int64) ([]model.Address, error)
+	SetDefaultAddress(userID, addressID int64) error
+}
+
+// 创建
+func NewAddressService(addressRepository repository.IAddressRepository) IAddressService {
+	return &AddressService{AddressRepository: addressRepository}
+}
+
+type AddressService struct {
+	AddressRepository repository.IAddressRepository
+}
+
+// 新增
+func (a *AddressService) AddAddress(address *model.Address) (int64, error) {
+	if err := normalizeAddress(address); err != nil {
+		return 0, err
+	}
+	count, err := a.AddressRepository.CountAddresses(address.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if count >= maxAddresses {
+		return 0, ErrTooManyAddresses
+	}
+	address.ID = 0
+	address.IsDefault = address.IsDefault || count == 0
+	return a.AddressRepository.CreateAddress(address)
+}
+
+// 更新
+func (a *AddressService) UpdateAddress(address *model.Address) error {
+	if err := normalizeAddress(address); err != nil {
+		return err
+	}
+	existing, err := a.FindAddress(address.UserID, address.ID)
+	if err != nil {
+		return err
+	}
+	address.IsDefault = address.IsDefault || existing.IsDefault
+	return a.AddressRepository.UpdateAddress(address)
+}
+
+// 删除
+func (a *AddressService) DeleteAddress(userID, addressID int64) error {
+	return addressError(a.AddressRepository.DeleteAddress(userID, addressID))
+}
+
+// 查找，地址不属于该用户时视为不存在
+func (a *AddressService) FindAddress(userID, addressID int64) (*model.Address, error) {
+	address, err := a.AddressRepository.FindAddressByID(userID, addressID)
+	if err != nil {
+		return nil, addressError(err)
+	}
+	return address, nil
+}
+
+// 查找默认地址
+func (a *AddressService) FindDefaultAddress(userID int64) (*model.Address, error) {
+	address, err := a.AddressRepository.FindDefaultAddress(userID)
+	if err != nil {
+		return nil, addressError(err)
+	}
+	return address, nil
+}
+
+// 查找用户的全部地址
+func (a *AddressService) FindAddresses(userID int64) ([]model.Address, error) {
+	return a.AddressRepository.FindAddresses(userID)
+}
+
+// 设为默认
+func (a *AddressService) SetDefaultAddress(userID, addressID int64) error {
+	return addressError(a.AddressRepository.SetDefaultAddress(userID, addressID))
+}
+
+// normalizeAddress 去除首尾空白并校验字段，国家与邮政编码转为大写
+func normalizeAddress(address *model.Address) error {
+	address.Recipient = strings.TrimSpace(address.Recipient)
+	if length := utf8.RuneCountInString(address.Recipient); length == 0 || length > 50 {
+		return ErrInvalidRecipient
+	}
+	address.Phone = strings.TrimSpace(address.Phone)
+	if !phonePattern.MatchString(address.Phone) {
+		return ErrInvalidPhone
+	}
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+	if !countryPattern.MatchString(address.Country) {
+		return ErrInvalidCountry
+	}
+	address.Region = strings.TrimSpace(address.Region)
+	if length := utf8.RuneCountInString(address.Region); length == 0 || length > 100 {
+		return ErrInvalidRegion
+	}
+	address.City = strings.TrimSpace(address.City)
+	if utf8.RuneCountInString(address.City) > 100 {
+		return ErrInvalidCity
+	}
+	address.Detail = strings.TrimSpace(address.Detail)
+	if length := utf8.RuneCountInString(address.Detail); length == 0 || length > 255 {
+		return ErrInvalidDetail
+	}
+	address.PostalCode = strings.ToUpper(strings.TrimSpace(address.PostalCode))
+	if !postalCodePattern.MatchString(address.PostalCode) {
+		return ErrInvalidPostalCode
+	}
+	return nil
+}
+
+// addressError 记录不存在时转换为 ErrAddressNotFound
+func addressError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrAddressNotFound
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"user/domain/model"
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	valid := func() *model.Address {
+		return &model.Address{
+			Recipient:  " 张三 ",
+			Phone:      "+8613800138000",
+			Country:    "cn",
+			Region:     "北京市",
+			City:       "北京",
+			Detail:     "朝阳区建国路 1 号",
+			PostalCode: "100000",
+		}
+	}
+	address := valid()
+	if err := normalizeAddress(address); err != nil {
+		t.Fatal(err)
+	}
+	if address.Recipient != "张三" || address.Country != "CN" {
+		t.Errorf("address = %+v", address)
+	}
+
+	gb := valid()
+	gb.Country, gb.PostalCode = "GB", "sw1a 1aa"
+	if err := normalizeAddress(gb); err != nil || gb.PostalCode != "SW1A 1AA" {
+		t.Errorf("英国邮编: %v %q", err, gb.PostalCode)
+	}
+
+	cases := []struct {
+		edit func(*model.Address)
+		want error
+	}{
+		{func(a *model.Address) { a.Recipient = " " }, ErrInvalidRecipient},
+		{func(a *model.Address) { a.Phone = "138-0013" }, ErrInvalidPhone},
+		{func(a *model.Address) { a.Country = "CHN" }, ErrInvalidCountry},
+		{func(a *model.Address) { a.Region = "" }, ErrInvalidRegion},
+		{func(a *model.Address) { a.Detail = "" }, ErrInvalidDetail},
+		{func(a *model.Address) { a.PostalCode = "1" }, ErrInvalidPostalCode},
+		{func(a *model.Address) { a.PostalCode = "10000#" }, ErrInvalidPostalCode},
+	}
+	for i, c := range cases {
+		address := valid()
+		c.edit(address)
+		if err := normalizeAddress(address); !errors.Is(err, c.want) {
+			t.Errorf("case %d: err = %v, want %v", i, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode/utf8"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// maxAuditText 审计记录中原因与结果的长度上限，与列宽一致
+	maxAuditText = 255
+	// maxAuditDetail 审计详情的长度上限
+	maxAuditDetail = 1000
+)
+
+// Page 分页参数，Number 从 1 开始
+type Page struct {
+	Number int
+	Size   int
+}
+
+// Normalized 页码小于 1 时取第一页，每页条数缺省为 20、最多 100
+func (p Page) Normalized() Page {
+	if p.Number < 1 {
+		p.Number = 1
+	}
+	if p.Size <= 0 {
+		p.Size = defaultPageSize
+	}
+	if p.Size > maxPageSize {
+		p.Size = maxPageSize
+	}
+	return p
+}
+
+func (p Page) offset() int {
+	return (p.Number - 1) * p.Size
+}
+
+// AdminActor 执行管理操作的客服或管理员，身份取自访问令牌
+type AdminActor struct {
+	UserID    int64
+	SessionID string
+	Roles     []string
+}
+
+func (a AdminActor) isAdmin() bool {
+	return slices.Contains(a.Roles, auth.RoleAdmin)
+}
+
+// AdminAuditEntry 一条待记录的管理操作，Err 为操作结果
+type AdminAuditEntry struct {
+	Action       string
+	TargetUserID int64
+	Reason       string
+	Detail       interface{} // 序列化为 JSON
+	Err          error
+}
+
+type IAdminService interface {
+	// SearchUsers 分页搜索用户，包含已锁定与已注销的用户
+	SearchUsers(ctx context.Context, actor AdminActor, query repository.UserQuery, page Page) ([]model.User, int64, error)
+	// FindUser 查看用户资料与状态，已注销的用户同样可查
+	FindUser(ctx context.Context, actor AdminActor, userID int64) (*model.User, error)
+	// LockUser 锁定账号并吊销全部会话；不能锁定自己，只有管理员可以锁定管理员
+	LockUser(ctx context.Context, actor AdminActor, userID int64, reason string) error
+	// UnlockUser 清除登录失败计数与临时锁定，已锁定的账号恢复正常
+	UnlockUser(ctx context.Context, actor AdminActor, userID int64, reason string) error
+	// UpdateStatus 将账号置为锁定或正常，校验与效果同 LockUser、UnlockUser；注销须走账号删除流程
+	UpdateStatus(ctx context.Context, actor AdminActor, userID int64, status int) error
+	// ResetPassword 向用户邮箱发送重置链接；invalidate 为 true 时同时作废当前密码并吊销全部会话，用于账号被盗
+	ResetPassword(ctx context.Context, actor AdminActor, userID int64, reason string, invalidate bool) error
+	// FindAuditLogs 分页查询审计记录，查询本身同样记录
+	FindAuditLogs(ctx context.Context, actor AdminActor, query repository.AuditLogQuery, page Page) ([]model.AdminAuditLog, int64, error)
+	// Record 记录在其他服务中完成的管理操作，如变更角色；写入失败时只记录日志
+	Record(ctx context.Context, actor AdminActor, entry AdminAuditEntry)
+	// BootstrapAdmin 系统中还没有管理员时将 email 对应的账号授予 admin 角色，用于创建第一个管理员；
+	// 已有管理员或 email 为空时不做任何事，之后的角色变更须由管理员通过 UpdateRoles 完成
+	BootstrapAdmin(ctx context.Context, email string) error
+}
+
+// 创建
+func NewAdminService(userRepository repository.IUserRepository, auditRepository repository.IAdminAuditRepository,
+	accountService IAccountService, loginGuard ILoginGuard, sessions ISessionRevoker) IAdminService {
+	return &AdminService{
+		UserRepository:  userRepository,
+		AuditRepository: auditRepository,
+		AccountService:  accountService,
+		LoginGuard:      loginGuard,
+		Sessions:        sessions,
+	}
+}
+
+type AdminService struct {
+	UserRepository  repository.IUserRepository
+	AuditRepository repository.IAdminAuditRepository
+	AccountService  IAccountService
+	LoginGuard      ILoginGuard
+	Sessions        ISessionRevoker
+}
+
+// userSearchDetail 搜索条件，记录在审计详情中
+type userSearchDetail struct {
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	Username string `json:"username,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Role     string `json:"role,omitempty"`
+	Page     int    `json:"page"`
+	PageSize int    `json:"page_size"`
+}
+
+// 搜索
+func (a *AdminService) SearchUsers(ctx context.Context, actor AdminActor, query repository.UserQuery, page Page) (users []model.User, total int64, err error) {
+	page = page.Normalized()
+	query.Email = strings.ToLower(strings.TrimSpace(query.Email))
+	query.Phone = strings.TrimSpace(query.Phone)
+	query.Username = strings.TrimSpace(query.Username)
+	query.Role = strings.ToLower(strings.TrimSpace(query.Role))
+	defer func() {
+		a.Record(ctx, actor, AdminAuditEntry{Action: model.AdminSearchUsers, Err: err, Detail: &userSearchDetail{
+			Email: query.Email, Phone: query.Phone, Username: query.Username, Status: query.Status, Role: query.Role,
+			Page: page.Number, PageSize: page.Size,
+		}})
+	}()
+	if query.Status != 0 && query.Status != model.StatusActive && query.Status != model.StatusLocked && query.Status != model.StatusDeleted {
+		return nil, 0, ErrInvalidStatus
+	}
+	if query.Role != "" && !auth.ValidRole(query.Role) {
+		return nil, 0, ErrInvalidRole
+	}
+	return a.UserRepository.SearchUsers(query, page.offset(), page.Size)
+}
+
+// 查看
+func (a *AdminService) FindUser(ctx context.Context, actor AdminActor, userID int64) (user *model.User, err error) {
+	defer func() {
+		a.Record(ctx, actor, AdminAuditEntry{Action: model.AdminViewUser, TargetUserID: userID, Err: err})
+	}()
+	user, err = a.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// 锁定
+func (a *AdminService) LockUser(ctx context.Context, actor AdminActor, userID int64, reason string) (err error) {
+	defer func() {
+		a.Record(ctx, actor, AdminAuditEntry{Action: model.AdminLockUser, TargetUserID: userID, Reason: reason, Err: err})
+	}()
+	user, err := a.findTarget(actor, userID, reason)
+	if err != nil {
+		return err
+	}
+	return a.lock(ctx, user)
+}
+
+// 解锁
+func (a *AdminService) UnlockUser(ctx context.Context, actor AdminActor, userID int64, reason string) (err error) {
+	defer func() {
+		a.Record(ctx, actor, AdminAuditEntry{Action: model.AdminUnlockUser, TargetUserID: userID, Reason: reason, Err: err})
+	}()
+	if _, err := a.findTarget(actor, userID, reason); err != nil {
+		return err
+	}
+	return a.LoginGuard.Unlock(ctx, userID)
+}
+
+// 变更状态
+func (a *AdminService) UpdateStatus(ctx context.Context, actor AdminActor, userID int64, status int) (err error) {
+	defer func() {
+		a.Record(ctx, actor, AdminAuditEntry{Action: model.AdminUpdateStatus, TargetUserID: userID, Err: err,
+			Detail: map[string]int{"status": status}})
+	}()
+	switch status {
+	case model.StatusActive, model.StatusLocked:
+	case model.StatusDeleted:
+		return ErrStatusDeletion
+	default:
+		return ErrInvalidStatus
+	}
+	user, err := a.findTarget(actor, userID, "")
+	if err != nil {
+		return err
+	}
+	if status == model.StatusActive {
+		return a.LoginGuard.Unlock(ctx, userID)
+	}
+	return a.lock(ctx, user)
+}
+
+// lock 锁定账号并吊销全部会话
+func (a *AdminService) lock(ctx context.Context, user *model.User) error {
+	if user.Status != model.StatusLocked {
+		if err := a.UserRepository.UpdateStatus(user.ID, model.StatusLocked); err != nil {
+			return err
+		}
+	}
+	return a.Sessions.RevokeUser(ctx, user.ID)
+}
+
+// 重置密码，先发送邮件，发送成功后才作废当前密码，避免用户既无旧密码也收不到链接
+func (a *AdminService) ResetPassword(ctx context.Context, actor AdminActor, userID int64, reason string, invalidate bool) (err error) {
+	defer func() {
+		a.Record(ctx, actor, AdminAuditEntry{Action: model.AdminResetPassword, TargetUserID: userID, Reason: reason, Err: err,
+			Detail: map[string]bool{"invalidate_password": invalidate}})
+	}()
+	if _, err := a.findTarget(actor, userID, reason); err != nil {
+		return err
+	}
+	if err := a.AccountService.SendPasswordReset(ctx, userID); err != nil {
+		return err
+	}
+	if !invalidate {
+		return nil
+	}
+	// 空哈希与任何密码都不匹配，用户只能通过重置链接或短信验证码登录
+	if err := a.UserRepository.UpdatePasswordHash(userID, ""); err != nil {
+		return err
+	}
+	return a.Sessions.RevokeUser(ctx, userID)
+}
+
+// 查询审计记录
+func (a *AdminService) FindAuditLogs(ctx context.Context, actor AdminActor, query repository.AuditLogQuery, page Page) (logs []model.AdminAuditLog, total int64, err error) {
+	page = page.Normalized()
+	defer func() {
+		a.Record(ctx, actor, AdminAuditEntry{Action: model.AdminViewAuditLogs, TargetUserID: query.TargetUserID, Err: err,
+			Detail: map[string]interface{}{"admin_id": query.AdminID, "action": query.Action, "page": page.Number, "page_size": page.Size}})
+	}()
+	return a.AuditRepository.FindLogs(query, page.offset(), page.Size)
+}
+
+// 记录
+func (a *AdminService) Record(ctx context.Context, actor AdminActor, entry AdminAuditEntry) {
+	log := &model.AdminAuditLog{
+		AdminID:        actor.UserID,
+		AdminSessionID: actor.SessionID,
+		AdminRoles:     actor.Roles,
+		Action:         entry.Action,
+		TargetUserID:   entry.TargetUserID,
+		Reason:         truncateText(entry.Reason, maxAuditText),
+		Result:         model.AdminAuditResultOK,
+	}
+	if entry.Err != nil {
+		log.Result = truncateText(entry.Err.Error(), maxAuditText)
+	}
+	if entry.Detail != nil {
+		if detail, err := json.Marshal(entry.Detail); err == nil {
+			log.Detail = truncateText(string(detail), maxAuditDetail)
+		}
+	}
+	attrs := []interface{}{"audit", "admin_" + entry.Action, "admin_id", actor.UserID, "session_id", actor.SessionID,
+		"target_user_id", entry.TargetUserID, "reason", log.Reason, "detail", log.Detail, "result", log.Result}
+	if err := a.AuditRepository.CreateLog(log); err != nil {
+		// 审计表不可用时日志中仍保留完整记录
+		slog.ErrorContext(ctx, "写入管理操作审计记录失败", append(attrs, "error", err)...)
+		return
+	}
+	slog.InfoContext(ctx, "管理操作", attrs...)
+}
+
+// 初始化管理员。账号须已验证邮箱且状态正常，避免他人抢先用该邮箱注册后获得管理员权限
+func (a *AdminService) BootstrapAdmin(ctx context.Context, email string) (err error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil
+	}
+	_, admins, err := a.UserRepository.SearchUsers(repository.UserQuery{Role: auth.RoleAdmin}, 0, 1)
+	if err != nil || admins > 0 {
+		return err
+	}
+	user, err := a.UserRepository.FindUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !user.EmailVerified() || user.Status != model.StatusActive {
+		return ErrBootstrapAdmin
+	}
+	roles := append(slices.Clone(user.Roles), auth.RoleAdmin)
+	defer func() {
+		a.Record(ctx, AdminActor{}, AdminAuditEntry{Action: model.AdminBootstrap, TargetUserID: user.ID, Err: err,
+			Detail: map[string][]string{"roles": roles}})
+	}()
+	return a.UserRepository.UpdateRoles(user.ID, roles)
+}
+
+// findTarget 查找被操作的用户：不能操作自己，只有管理员可以操作管理员，已注销的用户视为不存在
+func (a *AdminService) findTarget(actor AdminActor, userID int64, reason string) (*model.User, error) {
+	if utf8.RuneCountInString(reason) > maxAuditText {
+		return nil, ErrInvalidReason
+	}
+	if userID == actor.UserID {
+		return nil, ErrAdminSelf
+	}
+	user, err := a.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(user.Roles, auth.RoleAdmin) && !actor.isAdmin() {
+		return nil, ErrAdminTarget
+	}
+	return user, nil
+}
+
+func truncateText(s string, max int) string {
+	if r := []rune(s); len(r) > max {
+		return string(r[:max])
+	}
+	return s
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/config"
+	"github.com/Ben1524/GoMall/common/notify"
+)
+
+// memoryAdminAuditRepository 内存实现
+type memoryAdminAuditRepository struct {
+	logs []model.AdminAuditLog
+}
+
+func (r *memoryAdminAuditRepository) InitTable() error { return nil }
+
+func (r *memoryAdminAuditRepository) CreateLog(log *model.AdminAuditLog) error {
+	log.ID = int64(len(r.logs) + 1)
+	r.logs = append(r.logs, *log)
+	return nil
+}
+
+func (r *memoryAdminAuditRepository) FindLogs(query repository.AuditLogQuery, offset, limit int) ([]model.AdminAuditLog, int64, error) {
+	var matched []model.AdminAuditLog
+	for i := len(r.logs) - 1; i >= 0; i-- {
+		log := r.logs[i]
+		if (query.AdminID == 0 || log.AdminID == query.AdminID) && (query.TargetUserID == 0 || log.TargetUserID == query.TargetUserID) &&
+			(query.Action == "" || log.Action == query.Action) {
+			matched = append(matched, log)
+		}
+	}
+	total := int64(len(matched))
+	if offset >= len(matched) {
+		return nil, total, nil
+	}
+	return matched[offset:min(offset+limit, len(matched))], total, nil
+}
+
+// recordingRevoker 记录被吊销会话的用户
+type recordingRevoker struct {
+	revoked []int64
+}
+
+func (r *recordingRevoker) RevokeUser(_ context.Context, userID int64) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+type adminFixture struct {
+	users    *memoryUserRepository
+	audit    *memoryAdminAuditRepository
+	sessions *recordingRevoker
+	outbox   *notify.Outbox
+	admin    IAdminService
+	support  AdminActor
+	alice    int64
+	bob      int64
+	root     int64
+}
+
+func newAdminFixture(t *testing.T) *adminFixture {
+	t.Helper()
+	outbox, err := notify.NewOutbox(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &adminFixture{
+		users:    newMemoryUserRepository(),
+		audit:    &memoryAdminAuditRepository{},
+		sessions: &recordingRevoker{},
+		outbox:   outbox,
+	}
+	users := NewUserDataService(f.users)
+	register := func(name, email string, roles ...string) int64 {
+		id, err := users.Register(&model.User{Username: name, Email: email}, "correct horse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(roles) > 0 {
+			if err := users.UpdateRoles(id, roles); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return id
+	}
+	f.alice = register("alice", "alice@example.com")
+	f.bob = register("bob", "bob@shop.example.com")
+	f.root = register("root", "root@shop.example.com", auth.RoleAdmin)
+	supportID := register("carol", "carol@shop.example.com", auth.RoleSupport)
+	f.support = AdminActor{UserID: supportID, SessionID: "s1", Roles: []string{auth.RoleSupport}}
+
+	accounts := NewAccountService(f.users, &memoryAccountTokenRepository{}, outbox, config.AccountConfig{
+		ResetPasswordURL:    "https://shop.example.com/reset?token=%s",
+		ResetTokenTTL:       30 * time.Minute,
+		EmailsPerHour:       3,
+		EmailResendInterval: time.Minute,
+	})
+	guard := NewLoginGuard(f.users, repository.NewMemoryLoginAttemptStore(), &recordingGuardMetrics{}, config.LoginGuardConfig{})
+	f.admin = NewAdminService(f.users, f.audit, accounts, guard, f.sessions)
+	return f
+}
+
+// lastLog 最后一条审计记录
+func (f *adminFixture) lastLog(t *testing.T) model.AdminAuditLog {
+	t.Helper()
+	if len(f.audit.logs) == 0 {
+		t.Fatal("没有审计记录")
+	}
+	return f.audit.logs[len(f.audit.logs)-1]
+}
+
+func TestAdminSearchUsers(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	users, total, err := f.admin.SearchUsers(ctx, f.support, repository.UserQuery{Email: " BOB@"}, Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(users) != 1 || users[0].ID != f.bob {
+		t.Fatalf("按邮箱前缀搜索 = %v, total = %d", users, total)
+	}
+	log := f.lastLog(t)
+	if log.Action != model.AdminSearchUsers || log.AdminID != f.support.UserID || log.AdminSessionID != "s1" ||
+		log.Result != model.AdminAuditResultOK || log.Detail != `{"email":"bob@","page":1,"page_size":20}` {
+		t.Fatalf("审计记录 = %+v", log)
+	}
+
+	// 分页：第二页每页 2 条
+	users, total, err = f.admin.SearchUsers(ctx, f.support, repository.UserQuery{}, Page{Number: 2, Size: 2})
+	if err != nil || total != 4 || len(users) != 2 || users[0].ID != f.root {
+		t.Fatalf("分页 = %v, total = %d, err = %v", users, total, err)
+	}
+	users, _, _ = f.admin.SearchUsers(ctx, f.support, repository.UserQuery{Role: auth.RoleAdmin}, Page{})
+	if len(users) != 1 || users[0].ID != f.root {
+		t.Fatalf("按角色筛选 = %v", users)
+	}
+
+	// 参数错误同样记录
+	if _, _, err := f.admin.SearchUsers(ctx, f.support, repository.UserQuery{Status: 9}, Page{}); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("非法状态: got %v", err)
+	}
+	if log := f.lastLog(t); log.Result != ErrInvalidStatus.Msg {
+		t.Fatalf("失败的审计记录 = %+v", log)
+	}
+}
+
+func TestAdminLockUnlockAndReset(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	if err := f.admin.LockUser(ctx, f.support, f.alice, "疑似盗号"); err != nil {
+		t.Fatal(err)
+	}
+	if user, _ := f.users.FindUserByID(f.alice); user.Status != model.StatusLocked {
+		t.Fatalf("status = %d, want locked", user.Status)
+	}
+	if len(f.sessions.revoked) != 1 || f.sessions.revoked[0] != f.alice {
+		t.Fatalf("吊销会话 = %v", f.sessions.revoked)
+	}
+	if log := f.lastLog(t); log.Action != model.AdminLockUser || log.TargetUserID != f.alice || log.Reason != "疑似盗号" {
+		t.Fatalf("审计记录 = %+v", log)
+	}
+
+	// 不能操作自己，客服不能操作管理员
+	if err := f.admin.LockUser(ctx, f.support, f.support.UserID, ""); !errors.Is(err, ErrAdminSelf) {
+		t.Fatalf("锁定自己: got %v", err)
+	}
+	if err := f.admin.LockUser(ctx, f.support, f.root, ""); !errors.Is(err, ErrAdminTarget) {
+		t.Fatalf("客服锁定管理员: got %v", err)
+	}
+	if log := f.lastLog(t); log.TargetUserID != f.root || log.Result != ErrAdminTarget.Msg {
+		t.Fatalf("被拒绝的操作也应记录: %+v", log)
+	}
+
+	if err := f.admin.UnlockUser(ctx, f.support, f.alice, "核实为本人"); err != nil {
+		t.Fatal(err)
+	}
+	if user, _ := f.users.FindUserByID(f.alice); user.Status != model.StatusActive {
+		t.Fatalf("status = %d, want active", user.Status)
+	}
+
+	// 作废当前密码：邮件发出，原密码无法登录，会话被吊销
+	if err := f.admin.ResetPassword(ctx, f.support, f.alice, "", true); err != nil {
+		t.Fatal(err)
+	}
+	if emails, _ := f.outbox.Emails(); len(emails) != 1 || emails[0].To != "alice@example.com" {
+		t.Fatalf("outbox = %v", emails)
+	}
+	if _, err := NewUserDataService(f.users).Login("alice@example.com", "correct horse"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("作废后用原密码登录: got %v", err)
+	}
+	if len(f.sessions.revoked) != 2 {
+		t.Fatalf("吊销会话 = %v", f.sessions.revoked)
+	}
+
+	logs, total, err := f.admin.FindAuditLogs(ctx, f.support, repository.AuditLogQuery{TargetUserID: f.alice}, Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || logs[0].Action != model.AdminResetPassword || logs[0].Detail != `{"invalidate_password":true}` {
+		t.Fatalf("审计记录 = %+v, total = %d", logs, total)
+	}
+}
+
+func TestAdminUpdateStatus(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	if err := f.admin.UpdateStatus(ctx, f.support, f.bob, model.StatusLocked); err != nil {
+		t.Fatal(err)
+	}
+	if user, _ := f.users.FindUserByID(f.bob); user.Status != model.StatusLocked {
+		t.Fatalf("status = %d, want locked", user.Status)
+	}
+	if len(f.sessions.revoked) != 1 || f.sessions.revoked[0] != f.bob {
+		t.Fatalf("吊销会话 = %v", f.sessions.revoked)
+	}
+	if log := f.lastLog(t); log.Action != model.AdminUpdateStatus || log.TargetUserID != f.bob || log.Detail != `{"status":2}` {
+		t.Fatalf("审计记录 = %+v", log)
+	}
+	if err := f.admin.UpdateStatus(ctx, f.support, f.bob, model.StatusActive); err != nil {
+		t.Fatal(err)
+	}
+	if user, _ := f.users.FindUserByID(f.bob); user.Status != model.StatusActive {
+		t.Fatalf("status = %d, want active", user.Status)
+	}
+
+	// 与锁定账号相同的目标限制，注销不能直接设置
+	if err := f.admin.UpdateStatus(ctx, f.support, f.support.UserID, model.StatusLocked); !errors.Is(err, ErrAdminSelf) {
+		t.Fatalf("变更自己的状态: got %v", err)
+	}
+	if err := f.admin.UpdateStatus(ctx, f.support, f.root, model.StatusLocked); !errors.Is(err, ErrAdminTarget) {
+		t.Fatalf("客服锁定管理员: got %v", err)
+	}
+	if err := f.admin.UpdateStatus(ctx, f.support, f.alice, model.StatusDeleted); !errors.Is(err, ErrStatusDeletion) {
+		t.Fatalf("直接注销: got %v", err)
+	}
+	if user, _ := f.users.FindUserByID(f.alice); user.Status != model.StatusActive {
+		t.Fatalf("status = %d, want active", user.Status)
+	}
+	if log := f.lastLog(t); log.TargetUserID != f.alice || log.Result != ErrStatusDeletion.Msg {
+		t.Fatalf("被拒绝的操作也应记录: %+v", log)
+	}
+}
+
+// TestAdminBootstrap 没有管理员时授予已验证邮箱的账号 admin 角色，已有管理员后不再生效
+func TestAdminBootstrap(t *testing.T) {
+	f := newAdminFixture(t)
+	ctx := context.Background()
+
+	// fixture 中已有管理员 root
+	if err := f.admin.BootstrapAdmin(ctx, "alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(f.users.users[f.alice].Roles, auth.RoleAdmin) || len(f.audit.logs) != 0 {
+		t.Fatalf("已有管理员时 roles = %v, logs = %v", f.users.users[f.alice].Roles, f.audit.logs)
+	}
+
+	f.users.users[f.root].Roles = nil
+	if err := f.admin.BootstrapAdmin(ctx, "alice@example.com"); err != ErrBootstrapAdmin {
+		t.Fatalf("邮箱未验证 err = %v, want ErrBootstrapAdmin", err)
+	}
+	if err := f.admin.BootstrapAdmin(ctx, "nobody@example.com"); err != ErrUserNotFound {
+		t.Fatalf("账号不存在 err = %v, want ErrUserNotFound", err)
+	}
+
+	if err := f.users.MarkEmailVerified(f.alice, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.admin.BootstrapAdmin(ctx, " Alice@Example.com "); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(f.users.users[f.alice].Roles, auth.RoleAdmin) {
+		t.Fatalf("roles = %v, want admin", f.users.users[f.alice].Roles)
+	}
+	log := f.lastLog(t)
+	if log.Action != model.AdminBootstrap || log.AdminID != 0 || log.TargetUserID != f.alice || log.Result != model.AdminAuditResultOK {
+		t.Fatalf("审计记录 = %+v", log)
+	}
+}
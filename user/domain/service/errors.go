@@ -0,0 +1,56 @@
+package service
+
+import "net/http"
+
+// UserError 用户业务错误，Code 与 HTTP 状态码保持一致，
+// handler 据此转换为 go-micro 错误，网关再映射为对应的 4xx 响应。
+type UserError struct {
+	Code int32
+	Msg  string
+}
+
+func (e *UserError) Error() string {
+	return e.Msg
+}
+
+var (
+	ErrUserNotFound       = &UserError{Code: http.StatusNotFound, Msg: "用户不存在"}
+	ErrInvalidUsername    = &UserError{Code: http.StatusBadRequest, Msg: "用户名长度须为1到50个字符"}
+	ErrInvalidEmail       = &UserError{Code: http.StatusBadRequest, Msg: "邮箱格式不正确"}
+	ErrInvalidPhone       = &UserError{Code: http.StatusBadRequest, Msg: "手机号格式不正确"}
+	ErrWeakPassword       = &UserError{Code: http.StatusBadRequest, Msg: "密码长度须为8到72个字节"}
+	ErrPasswordUnchanged  = &UserError{Code: http.StatusBadRequest, Msg: "新密码不能与原密码相同"}
+	ErrEmailTaken         = &UserError{Code: http.StatusConflict, Msg: "邮箱已被注册"}
+	ErrPhoneTaken         = &UserError{Code: http.StatusConflict, Msg: "手机号已被注册"}
+	ErrInvalidCredentials = &UserError{Code: http.StatusUnauthorized, Msg: "账号或密码错误"}
+	ErrUserLocked         = &UserError{Code: http.StatusForbidden, Msg: "账号已被锁定"}
+	ErrInvalidStatus      = &UserError{Code: http.StatusBadRequest, Msg: "用户状态不合法"}
+	ErrStatusTransition   = &UserError{Code: http.StatusConflict, Msg: "已注销的账号不能变更状态"}
+	ErrStatusDeletion     = &UserError{Code: http.StatusBadRequest, Msg: "注销账号须通过账号删除申请处理"}
+	ErrInvalidRole        = &UserError{Code: http.StatusBadRequest, Msg: "角色不存在"}
+	ErrAddressNotFound    = &UserError{Code: http.StatusNotFound, Msg: "收货地址不存在"}
+	ErrInvalidRecipient   = &UserError{Code: http.StatusBadRequest, Msg: "收件人长度须为1到50个字符"}
+	ErrInvalidCountry     = &UserError{Code: http.StatusBadRequest, Msg: "国家须为两位字母代码"}
+	ErrInvalidRegion      = &UserError{Code: http.StatusBadRequest, Msg: "省份或地区长度须为1到100个字符"}
+	ErrInvalidCity        = &UserError{Code: http.StatusBadRequest, Msg: "城市长度不能超过100个字符"}
+	ErrInvalidDetail      = &UserError{Code: http.StatusBadRequest, Msg: "详细地址长度须为1到255个字符"}
+	ErrInvalidPostalCode  = &UserError{Code: http.StatusBadRequest, Msg: "邮政编码格式不正确"}
+	ErrTooManyAddresses   = &UserError{Code: http.StatusConflict, Msg: "收货地址数量已达上限"}
+	// ErrInvalidAccountToken 验证或重置链接不存在、已使用、已过期或已被新链接取代
+	ErrInvalidAccountToken = &UserError{Code: http.StatusBadRequest, Msg: "链接无效或已过期"}
+	ErrEmailVerified       = &UserError{Code: http.StatusConflict, Msg: "邮箱已验证"}
+	ErrTooManyRequests     = &UserError{Code: http.StatusTooManyRequests, Msg: "发送过于频繁，请稍后再试"}
+	ErrInvalidOTP          = &UserError{Code: http.StatusUnauthorized, Msg: "验证码错误或已过期"}
+	ErrOTPLocked           = &UserError{Code: http.StatusTooManyRequests, Msg: "验证码错误次数过多，请稍后再试"}
+	ErrSessionNotFound     = &UserError{Code: http.StatusNotFound, Msg: "会话不存在或已过期"}
+	ErrAdminSelf           = &UserError{Code: http.StatusBadRequest, Msg: "不能对自己的账号执行该操作"}
+	ErrAdminTarget         = &UserError{Code: http.StatusForbidden, Msg: "只有管理员可以操作管理员账号"}
+	ErrInvalidReason       = &UserError{Code: http.StatusBadRequest, Msg: "原因不能超过255个字符"}
+	ErrBootstrapAdmin      = &UserError{Code: http.StatusConflict, Msg: "初始管理员须为邮箱已验证且状态正常的账号"}
+
+	ErrPrivacyRequestNotFound = &UserError{Code: http.StatusNotFound, Msg: "隐私请求不存在"}
+	ErrPrivacyRequestExists   = &UserError{Code: http.StatusConflict, Msg: "已有未完成的同类请求"}
+	ErrPrivacyRequestBusy     = &UserError{Code: http.StatusConflict, Msg: "请求正在处理中，请稍后再试"}
+	ErrPrivacyExportNotReady  = &UserError{Code: http.StatusConflict, Msg: "数据导出尚未完成"}
+	ErrPrivacyExportExpired   = &UserError{Code: http.StatusGone, Msg: "导出文件已过期，请重新申请"}
+)
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"gorm.io/gorm"
+)
+
+// 登录受限与锁定的范围，用作指标标签
+const (
+	GuardScopeAccount   = "account"
+	GuardScopeIP        = "ip"
+	GuardScopePermanent = "permanent" // 账号状态改为已锁定
+)
+
+// ILoginGuardMetrics 登录防暴力破解指标记录
+type ILoginGuardMetrics interface {
+	ObserveLoginFailure()
+	ObserveLoginThrottled(scope string)
+	ObserveLockout(scope string)
+}
+
+type ILoginGuard interface {
+	// Check 登录前调用，账号或 IP 处于临时锁定或等待期内时返回 429
+	Check(ctx context.Context, account, ip string) error
+	// Fail 密码错误后调用，返回因持续失败刚被改为锁定状态的用户ID，调用方据此吊销会话
+	Fail(ctx context.Context, account, ip string) (int64, error)
+	// Succeed 登录成功后清除账号的失败计数
+	Succeed(ctx context.Context, account string)
+	// Unlock 清除用户的失败计数与临时锁定，已锁定的账号恢复正常
+	Unlock(ctx context.Context, userID int64) error
+}
+
+// 创建
+func NewLoginGuard(userRepository repository.IUserRepository, store repository.ILoginAttemptStore,
+	metrics ILoginGuardMetrics, cfg config.LoginGuardConfig) ILoginGuard {
+	return &LoginGuard{
+		UserRepository: userRepository,
+		Store:          store,
+		Metrics:        metrics,
+		cfg:            cfg,
+		now:            time.Now,
+	}
+}
+
+// LoginGuard 按账号与 IP 统计密码登录失败。Redis 出错时计数由 FallbackLoginAttemptStore 转到进程内存储，限制不会因此失效
+type LoginGuard struct {
+	UserRepository repository.IUserRepository
+	Store          repository.ILoginAttemptStore
+	Metrics        ILoginGuardMetrics
+	cfg            config.LoginGuardConfig
+	now            func() time.Time
+}
+
+// 登录前检查
+func (g *LoginGuard) Check(ctx context.Context, account, ip string) error {
+	now := g.now()
+	if ip != "" {
+		attempts, err := g.Store.Get(ctx, ipKey(ip), now)
+		if err != nil {
+			slog.Error("读取登录失败计数失败", "error", err)
+			return nil
+		}
+		if now.Before(attempts.LockedUntil) {
+			g.Metrics.ObserveLoginThrottled(GuardScopeIP)
+			return throttledError("该网络登录失败次数过多，已临时限制", attempts.LockedUntil.Sub(now))
+		}
+	}
+	account = normalizeAccount(account)
+	if account == "" {
+		return nil
+	}
+	attempts, err := g.Store.Get(ctx, accountKey(account), now)
+	if err != nil {
+		slog.Error("读取登录失败计数失败", "error", err)
+		return nil
+	}
+	if now.Before(attempts.LockedUntil) {
+		g.Metrics.ObserveLoginThrottled(GuardScopeAccount)
+		return throttledError("登录失败次数过多，账号已临时锁定", attempts.LockedUntil.Sub(now))
+	}
+	if wait := attempts.LastFailure.Add(g.delay(attempts.Failures)).Sub(now); wait > 0 {
+		g.Metrics.ObserveLoginThrottled(GuardScopeAccount)
+		return throttledError("登录失败次数过多", wait)
+	}
+	return nil
+}
+
+// 记录一次密码错误，达到阈值时临时锁定，账号反复被临时锁定时改为锁定状态
+func (g *LoginGuard) Fail(ctx context.Context, account, ip string) (int64, error) {
+	g.Metrics.ObserveLoginFailure()
+	now := g.now()
+	if ip != "" {
+		attempts, err := g.Store.RecordFailure(ctx, ipKey(ip), now, g.cfg.Window)
+		if err != nil {
+			return 0, err
+		}
+		if g.cfg.IPLockThreshold > 0 && attempts.Failures >= g.cfg.IPLockThreshold {
+			if err := g.lock(ctx, ipKey(ip), now); err != nil {
+				return 0, err
+			}
+			g.Metrics.ObserveLockout(GuardScopeIP)
+			slog.Warn("登录失败次数过多，临时限制 IP", "audit", "login_lockout", "ip", ip, "failures", attempts.Failures)
+		}
+	}
+
+	account = normalizeAccount(account)
+	if account == "" {
+		return 0, nil
+	}
+	attempts, err := g.Store.RecordFailure(ctx, accountKey(account), now, g.cfg.Window)
+	if err != nil {
+		return 0, err
+	}
+	if g.cfg.AccountLockThreshold <= 0 || attempts.Failures < g.cfg.AccountLockThreshold {
+		return 0, nil
+	}
+	if err := g.lock(ctx, accountKey(account), now); err != nil {
+		return 0, err
+	}
+	g.Metrics.ObserveLockout(GuardScopeAccount)
+	slog.Warn("登录失败次数过多，临时锁定账号", "audit", "login_lockout", "account", maskAccount(account), "failures", attempts.Failures)
+
+	lockouts, err := g.Store.RecordFailure(ctx, lockoutsKey(account), now, g.cfg.PersistentWindow)
+	if err != nil {
+		return 0, err
+	}
+	if g.cfg.PermanentLockAfter <= 0 || lockouts.Failures < g.cfg.PermanentLockAfter {
+		return 0, nil
+	}
+	return g.lockUser(account)
+}
+
+// 登录成功
+func (g *LoginGuard) Succeed(ctx context.Context, account string) {
+	if account = normalizeAccount(account); account == "" {
+		return
+	}
+	if err := g.Store.Reset(ctx, accountKey(account)); err != nil {
+		slog.Warn("清除登录失败计数失败", "error", err)
+	}
+}
+
+// 解锁，邮箱与手机号两个登录账号的计数都清除
+func (g *LoginGuard) Unlock(ctx context.Context, userID int64) error {
+	user, err := g.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	for _, account := range []string{user.Email, user.PhoneNumber()} {
+		if account == "" {
+			continue
+		}
+		for _, key := range []string{accountKey(account), lockoutsKey(account)} {
+			if err := g.Store.Reset(ctx, key); err != nil {
+				return err
+			}
+		}
+	}
+	if user.Status == model.StatusLocked {
+		return g.UserRepository.UpdateStatus(userID, model.StatusActive)
+	}
+	return nil
+}
+
+// lock 临时锁定，锁定期间计数保留，解除后再失败一次即重新锁定
+func (g *LoginGuard) lock(ctx context.Context, key string, now time.Time) error {
+	return g.Store.Lock(ctx, key, now, now.Add(g.cfg.Lockout))
+}
+
+// lockUser 将账号改为锁定状态，账号不存在或已不是正常状态时忽略
+func (g *LoginGuard) lockUser(account string) (int64, error) {
+	var user *model.User
+	var err error
+	if strings.Contains(account, "@") {
+		user, err = g.UserRepository.FindUserByEmail(account)
+	} else {
+		user, err = g.UserRepository.FindUserByPhone(account)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status != model.StatusActive) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := g.UserRepository.UpdateStatus(user.ID, model.StatusLocked); err != nil {
+		return 0, err
+	}
+	g.Metrics.ObserveLockout(GuardScopePermanent)
+	slog.Warn("账号持续登录失败，已锁定", "audit", "account_locked", "user_id", user.ID)
+	return user.ID, nil
+}
+
+// delay 第 failures 次失败后下次尝试须等待的时间，超过 DelayAfter 次后从 BaseDelay 起逐次翻倍
+func (g *LoginGuard) delay(failures int) time.Duration {
+	if failures <= g.cfg.DelayAfter || g.cfg.BaseDelay <= 0 {
+		return 0
+	}
+	delay := g.cfg.BaseDelay
+	for i := g.cfg.DelayAfter + 1; i < failures && delay < g.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if g.cfg.MaxDelay > 0 && delay > g.cfg.MaxDelay {
+		delay = g.cfg.MaxDelay
+	}
+	return delay
+}
+
+// throttledError 登录受限，提示还需等待的时间
+func throttledError(reason string, wait time.Duration) error {
+	return &UserError{Code: http.StatusTooManyRequests, Msg: fmt.Sprintf("%s，请 %s后再试", reason, formatWait(wait))}
+}
+
+// formatWait 不足一分钟按秒、否则按分钟向上取整
+func formatWait(wait time.Duration) string {
+	if wait < time.Minute {
+		return fmt.Sprintf("%d 秒", (wait+time.Second-1)/time.Second)
+	}
+	return fmt.Sprintf("%d 分钟", (wait+time.Minute-1)/time.Minute)
+}
+
+// normalizeAccount 与登录时查找账号的规则一致：邮箱不区分大小写
+func normalizeAccount(account string) string {
+	return strings.ToLower(strings.TrimSpace(account))
+}
+
+func accountKey(account string) string  { return "account:" + account }
+func lockoutsKey(account string) string { return "lockouts:" + account }
+func ipKey(ip string) string            { return "ip:" + ip }
+
+// maskAccount 日志中隐藏账号的大部分字符
+func maskAccount(account string) string {
+	if local, domain, ok := strings.Cut(account, "@"); ok {
+		if len(local) > 1 {
+			local = local[:1] + "***"
+		}
+		return local + "@" + domain
+	}
+	return maskPhone(account)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+)
+
+// recordingGuardMetrics 记录各范围的受限与锁定次数
+type recordingGuardMetrics struct {
+	failures  int
+	throttled map[string]int
+	lockouts  map[string]int
+}
+
+func (m *recordingGuardMetrics) ObserveLoginFailure()               { m.failures++ }
+func (m *recordingGuardMetrics) ObserveLoginThrottled(scope string) { m.throttled[scope]++ }
+func (m *recordingGuardMetrics) ObserveLockout(scope string)        { m.lockouts[scope]++ }
+
+type guardFixture struct {
+	users   *memoryUserRepository
+	metrics *recordingGuardMetrics
+	guard   *LoginGuard
+	now     time.Time
+	userID  int64
+}
+
+func newGuardFixture(t *testing.T) *guardFixture {
+	t.Helper()
+	f := &guardFixture{
+		users:   newMemoryUserRepository(),
+		metrics: &recordingGuardMetrics{throttled: map[string]int{}, lockouts: map[string]int{}},
+		now:     time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.guard = NewLoginGuard(f.users, repository.NewMemoryLoginAttemptStore(), f.metrics, config.LoginGuardConfig{
+		Window:               15 * time.Minute,
+		DelayAfter:           2,
+		BaseDelay:            time.Second,
+		MaxDelay:             4 * time.Second,
+		AccountLockThreshold: 5,
+		IPLockThreshold:      8,
+		Lockout:              10 * time.Minute,
+		PermanentLockAfter:   2,
+		PersistentWindow:     24 * time.Hour,
+	}).(*LoginGuard)
+	f.guard.now = func() time.Time { return f.now }
+	var err error
+	f.userID, err = NewUserDataService(f.users).Register(&model.User{Username: "alice", Email: "alice@example.com"}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// fail 检查通过后记录一次失败，随后推进 1 分钟，越过所有等待期但不超过统计窗口
+func (f *guardFixture) fail(t *testing.T, account, ip string) int64 {
+	t.Helper()
+	ctx := context.Background()
+	if err := f.guard.Check(ctx, account, ip); err != nil {
+		t.Fatalf("Check = %v", err)
+	}
+	lockedUserID, err := f.guard.Fail(ctx, account, ip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.now = f.now.Add(time.Minute)
+	return lockedUserID
+}
+
+func assertThrottled(t *testing.T, err error) {
+	t.Helper()
+	var userErr *UserError
+	if !errors.As(err, &userErr) || userErr.Code != http.StatusTooManyRequests {
+		t.Fatalf("err = %v, want 429", err)
+	}
+}
+
+func TestLoginGuardDelay(t *testing.T) {
+	f := newGuardFixture(t)
+	ctx := context.Background()
+	for i, want := range []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		if got := f.guard.delay(i + 1); got != want {
+			t.Errorf("delay(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := f.guard.Fail(ctx, "Alice@example.com", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertThrottled(t, f.guard.Check(ctx, "alice@example.com", ""))
+	f.now = f.now.Add(time.Second)
+	if err := f.guard.Check(ctx, "alice@example.com", ""); err != nil {
+		t.Fatalf("等待后 err = %v", err)
+	}
+
+	f.guard.Succeed(ctx, "alice@example.com")
+	if _, err := f.guard.Fail(ctx, "alice@example.com", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.guard.Check(ctx, "alice@example.com", ""); err != nil {
+		t.Fatalf("登录成功后计数应清零, err = %v", err)
+	}
+}
+
+func TestLoginGuardLockout(t *testing.T) {
+	f := newGuardFixture(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if lockedUserID := f.fail(t, "alice@example.com", "10.0.0.1"); lockedUserID != 0 {
+			t.Fatalf("第 %d 次失败不应改为锁定状态", i+1)
+		}
+	}
+	assertThrottled(t, f.guard.Check(ctx, "alice@example.com", "10.0.0.2"))
+	if f.metrics.lockouts[GuardScopeAccount] != 1 || f.metrics.failures != 5 {
+		t.Fatalf("metrics = %+v", f.metrics)
+	}
+
+	// 临时锁定结束后再失败一次即再次锁定，达到 PermanentLockAfter 次后账号改为锁定状态
+	f.now = f.now.Add(10 * time.Minute)
+	if lockedUserID := f.fail(t, "alice@example.com", "10.0.0.1"); lockedUserID != f.userID {
+		t.Fatalf("lockedUserID = %d, want %d", lockedUserID, f.userID)
+	}
+	if f.users.users[f.userID].Status != model.StatusLocked || f.metrics.lockouts[GuardScopePermanent] != 1 {
+		t.Fatalf("status = %d, metrics = %+v", f.users.users[f.userID].Status, f.metrics)
+	}
+
+	if err := f.guard.Unlock(ctx, f.userID); err != nil {
+		t.Fatal(err)
+	}
+	if f.users.users[f.userID].Status != model.StatusActive {
+		t.Fatal("解锁后应恢复正常状态")
+	}
+	if err := f.guard.Check(ctx, "alice@example.com", ""); err != nil {
+		t.Fatalf("解锁后 err = %v", err)
+	}
+}
+
+func TestLoginGuardIPLockout(t *testing.T) {
+	f := newGuardFixture(t)
+	ctx := context.Background()
+	for i := 0; i < 8; i++ {
+		f.fail(t, "user"+string(rune('a'+i))+"@example.com", "10.0.0.1")
+	}
+	assertThrottled(t, f.guard.Check(ctx, "alice@example.com", "10.0.0.1"))
+	if err := f.guard.Check(ctx, "alice@example.com", "10.0.0.2"); err != nil {
+		t.Fatalf("其他 IP err = %v", err)
+	}
+	if f.metrics.lockouts[GuardScopeIP] != 1 || f.metrics.throttled[GuardScopeIP] != 1 {
+		t.Fatalf("metrics = %+v", f.metrics)
+	}
+}
+
+// failingAttemptStore 模拟运行中不可用的 Redis
+type failingAttemptStore struct{}
+
+func (failingAttemptStore) Get(context.Context, string, time.Time) (*repository.LoginAttempts, error) {
+	return nil, errors.New("redis down")
+}
+func (failingAttemptStore) RecordFailure(context.Context, string, time.Time, time.Duration) (*repository.LoginAttempts, error) {
+	return nil, errors.New("redis down")
+}
+func (failingAttemptStore) Lock(context.Context, string, time.Time, time.Time) error {
+	return errors.New("redis down")
+}
+func (failingAttemptStore) Reset(context.Context, string) error { return errors.New("redis down") }
+
+// TestLoginGuardStoreFallback 计数存储运行中出错时改用进程内存储，锁定照常生效
+func TestLoginGuardStoreFallback(t *testing.T) {
+	f := newGuardFixture(t)
+	f.guard.Store = repository.NewFallbackLoginAttemptStore(failingAttemptStore{}, repository.NewMemoryLoginAttemptStore())
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		f.fail(t, "alice@example.com", "10.0.0.1")
+	}
+	assertThrottled(t, f.guard.Check(ctx, "alice@example.com", "10.0.0.2"))
+	if f.metrics.lockouts[GuardScopeAccount] != 1 {
+		t.Fatalf("metrics = %+v", f.metrics)
+	}
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"github.com/Ben1524/GoMall/common/notify"
+	"gorm.io/gorm"
+)
+
+// defaultOTPLength 未配置位数时的验证码位数
+const defaultOTPLength = 6
+
+type IOTPService interface {
+	// SendLoginCode 向已注册的手机号发送登录验证码；未注册、账号不可用或发送受限（重发过快、超过每小时上限、
+	// 验证码已锁定）时同样返回成功且不发送短信，避免探测账号
+	SendLoginCode(ctx context.Context, phone string) error
+	// LoginWithCode 校验验证码并返回用户，验证码一次性有效
+	LoginWithCode(phone, code string) (*model.User, error)
+}
+
+// 创建
+func NewOTPService(userRepository repository.IUserRepository, otpRepository repository.IPhoneOTPRepository,
+	sender notify.SMSSender, cfg config.OTPConfig) IOTPService {
+	return &OTPService{
+		UserRepository: userRepository,
+		OTPRepository:  otpRepository,
+		Sender:         sender,
+		cfg:            cfg,
+		now:            time.Now,
+	}
+}
+
+type OTPService struct {
+	UserRepository repository.IUserRepository
+	OTPRepository  repository.IPhoneOTPRepository
+	Sender         notify.SMSSender
+	cfg            config.OTPConfig
+	now            func() time.Time
+}
+
+// 发送登录验证码，新验证码使旧验证码作废并重置尝试次数
+func (o *OTPService) SendLoginCode(ctx context.Context, phone string) error {
+	phone, err := normalizePhone(phone)
+	if err != nil {
+		return err
+	}
+	user, err := o.UserRepository.FindUserByPhone(phone)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status != model.StatusActive) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	otp, err := o.OTPRepository.FindOTP(phone)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		otp, err = &model.PhoneOTP{Phone: phone}, nil
+	}
+	if err != nil {
+		return err
+	}
+	now := o.now()
+	if err := o.allowSend(otp, now); err != nil {
+		// 只有已注册的手机号会受限，返回错误会暴露账号是否存在
+		slog.Warn("登录验证码发送受限，已忽略", "user_id", user.ID, "error", err)
+		return nil
+	}
+
+	code, err := newOTPCode(o.cfg.Length)
+	if err != nil {
+		return err
+	}
+	otp.CodeHash = hashOTP(phone, code)
+	otp.ExpiresAt = now.Add(o.cfg.TTL)
+	otp.Attempts = 0
+	otp.SentAt = now
+	otp.SendCount++
+	otp.LockedUntil = nil
+	if err := o.OTPRepository.SaveOTP(otp); err != nil {
+		return err
+	}
+	return o.Sender.SendSMS(ctx, notify.SMS{
+		To:   phone,
+		Body: fmt.Sprintf("【GoMall】登录验证码 %s，%s内有效，请勿泄露给他人。", code, formatTTL(o.cfg.TTL)),
+	})
+}
+
+// allowSend 校验验证码锁定、重发间隔与每小时发送上限，新的统计窗口从 now 开始
+func (o *OTPService) allowSend(otp *model.PhoneOTP, now time.Time) error {
+	if otp.LockedUntil != nil && now.Before(*otp.LockedUntil) {
+		return ErrOTPLocked
+	}
+	if now.Sub(otp.SentAt) < o.cfg.ResendInterval {
+		return ErrTooManyRequests
+	}
+	if now.Sub(otp.WindowStart) >= time.Hour {
+		otp.WindowStart, otp.SendCount = now, 0
+	}
+	if o.cfg.SendsPerHour > 0 && otp.SendCount >= o.cfg.SendsPerHour {
+		return ErrTooManyRequests
+	}
+	return nil
+}
+
+// 验证码登录，尝试次数用尽后锁定该手机号
+func (o *OTPService) LoginWithCode(phone, code string) (*model.User, error) {
+	phone, err := normalizePhone(phone)
+	if err != nil {
+		return nil, err
+	}
+	otp, err := o.OTPRepository.FindOTP(phone)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidOTP
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := o.now()
+	if otp.LockedUntil != nil && now.Before(*otp.LockedUntil) {
+		return nil, ErrOTPLocked
+	}
+	if otp.CodeHash == "" || !now.Before(otp.ExpiresAt) {
+		return nil, ErrInvalidOTP
+	}
+
+	err = o.OTPRepository.UseAttempt(phone, o.cfg.MaxAttempts)
+	if errors.Is(err, repository.ErrNoAttemptsLeft) {
+		return nil, o.lock(phone, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	hash := hashOTP(phone, strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(otp.CodeHash)) != 1 {
+		if otp.Attempts+1 >= o.cfg.MaxAttempts {
+			return nil, o.lock(phone, now)
+		}
+		return nil, ErrInvalidOTP
+	}
+	if err := o.OTPRepository.ConsumeOTP(phone, hash); err != nil {
+		if errors.Is(err, repository.ErrTokenUsed) {
+			return nil, ErrInvalidOTP
+		}
+		return nil, err
+	}
+
+	user, err := o.UserRepository.FindUserByPhone(phone)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		return nil, ErrInvalidOTP
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == model.StatusLocked {
+		return nil, ErrUserLocked
+	}
+	return user, nil
+}
+
+// lock 作废当前验证码并锁定手机号，返回 ErrOTPLocked
+func (o *OTPService) lock(phone string, now time.Time) error {
+	slog.Warn("验证码尝试次数用尽，锁定手机号", "phone", maskPhone(phone), "until", now.Add(o.cfg.Lockout))
+	if err := o.OTPRepository.LockOTP(phone, now.Add(o.cfg.Lockout)); err != nil {
+		return err
+	}
+	return ErrOTPLocked
+}
+
+// normalizePhone 校验手机号
+func normalizePhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if !phonePattern.MatchString(phone) {
+		return "", ErrInvalidPhone
+	}
+	return phone, nil
+}
+
+// newOTPCode 生成均匀分布的数字验证码
+func newOTPCode(length int) (string, error) {
+	if length <= 0 {
+		length = defaultOTPLength
+	}
+	n, err := rand.Int(rand.Reader, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n), nil
+}
+
+// hashOTP 验证码取值空间小，哈希只防止数据库泄露后直接读出，安全性依赖短有效期与尝试次数限制
+func hashOTP(phone, code string) string {
+	sum := sha256.Sum256([]byte(phone + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// maskPhone 日志中只保留手机号后四位
+func maskPhone(phone string) string {
+	if len(phone) <= 4 {
+		return "****"
+	}
+	return strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
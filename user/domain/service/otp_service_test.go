@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"github.com/Ben1524/GoMall/common/notify"
+	"gorm.io/gorm"
+)
+
+// memoryPhoneOTPRepository 内存实现
+type memoryPhoneOTPRepository struct {
+	otps map[string]*model.PhoneOTP
+}
+
+func (r *memoryPhoneOTPRepository) InitTable() error { return nil }
+
+func (r *memoryPhoneOTPRepository) FindOTP(phone string) (*model.PhoneOTP, error) {
+	if otp, ok := r.otps[phone]; ok {
+		found := *otp
+		return &found, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryPhoneOTPRepository) SaveOTP(otp *model.PhoneOTP) error {
+	stored := *otp
+	r.otps[otp.Phone] = &stored
+	return nil
+}
+
+func (r *memoryPhoneOTPRepository) UseAttempt(phone string, maxAttempts int) error {
+	otp, ok := r.otps[phone]
+	if !ok || otp.Attempts >= maxAttempts {
+		return repository.ErrNoAttemptsLeft
+	}
+	otp.Attempts++
+	return nil
+}
+
+func (r *memoryPhoneOTPRepository) ConsumeOTP(phone, codeHash string) error {
+	otp, ok := r.otps[phone]
+	if !ok || otp.CodeHash != codeHash {
+		return repository.ErrTokenUsed
+	}
+	otp.CodeHash = ""
+	return nil
+}
+
+func (r *memoryPhoneOTPRepository) LockOTP(phone string, until time.Time) error {
+	r.otps[phone].CodeHash = ""
+	r.otps[phone].LockedUntil = &until
+	return nil
+}
+
+func (r *memoryPhoneOTPRepository) DeleteOTP(phone string) (int64, error) {
+	if _, ok := r.otps[phone]; !ok {
+		return 0, nil
+	}
+	delete(r.otps, phone)
+	return 1, nil
+}
+
+var smsCodePattern = regexp.MustCompile(`验证码 ([0-9]+)`)
+
+const otpPhone = "+8613800000000"
+
+type otpFixture struct {
+	otps   *memoryPhoneOTPRepository
+	outbox *notify.Outbox
+	otp    *OTPService
+	now    time.Time
+	userID int64
+}
+
+func newOTPFixture(t *testing.T) *otpFixture {
+	t.Helper()
+	outbox, err := notify.NewOutbox(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := newMemoryUserRepository()
+	f := &otpFixture{
+		otps:   &memoryPhoneOTPRepository{otps: map[string]*model.PhoneOTP{}},
+		outbox: outbox,
+		now:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.otp = NewOTPService(users, f.otps, outbox, config.OTPConfig{
+		Length:         6,
+		TTL:            5 * time.Minute,
+		MaxAttempts:    3,
+		Lockout:        15 * time.Minute,
+		ResendInterval: time.Minute,
+		SendsPerHour:   3,
+	}).(*OTPService)
+	f.otp.now = func() time.Time { return f.now }
+	phone := otpPhone
+	f.userID, err = NewUserDataService(users).Register(&model.User{Username: "alice", Email: "alice@example.com", Phone: &phone}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// lastCode 取出最后一条短信中的验证码
+func (f *otpFixture) lastCode(t *testing.T) string {
+	t.Helper()
+	messages, err := f.outbox.SMS()
+	if err != nil || len(messages) == 0 {
+		t.Fatalf("outbox = %v, %v", messages, err)
+	}
+	match := smsCodePattern.FindStringSubmatch(messages[len(messages)-1].Body)
+	if match == nil || len(match[1]) != 6 {
+		t.Fatalf("短信中没有验证码: %s", messages[len(messages)-1].Body)
+	}
+	return match[1]
+}
+
+// assertSent 发送受限时与未注册手机号一样返回成功，但不发送短信
+func (f *otpFixture) assertSent(t *testing.T, want int) {
+	t.Helper()
+	if messages, _ := f.outbox.SMS(); len(messages) != want {
+		t.Fatalf("已发送 %d 条短信, want %d", len(messages), want)
+	}
+}
+
+// wrongCode 返回与 code 不同的验证码
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "000001"
+	}
+	return "000000"
+}
+
+func TestLoginWithCode(t *testing.T) {
+	f := newOTPFixture(t)
+	ctx := context.Background()
+
+	if err := f.otp.SendLoginCode(ctx, "+8613911111111"); err != nil {
+		t.Fatalf("未注册手机号 err = %v, want nil", err)
+	}
+	if messages, _ := f.outbox.SMS(); len(messages) != 0 {
+		t.Fatalf("未注册手机号不应发送短信: %+v", messages)
+	}
+
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatal(err)
+	}
+	code := f.lastCode(t)
+	if f.otps.otps[otpPhone].CodeHash == code {
+		t.Fatal("验证码不应明文保存")
+	}
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatalf("冷却期内 err = %v, want nil", err)
+	}
+	f.assertSent(t, 1)
+
+	if _, err := f.otp.LoginWithCode(otpPhone, wrongCode(code)); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("err = %v, want ErrInvalidOTP", err)
+	}
+	user, err := f.otp.LoginWithCode(otpPhone, code)
+	if err != nil || user.ID != f.userID {
+		t.Fatalf("LoginWithCode = %v, %v", user, err)
+	}
+	if _, err := f.otp.LoginWithCode(otpPhone, code); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("重复使用 err = %v, want ErrInvalidOTP", err)
+	}
+
+	f.now = f.now.Add(2 * time.Minute)
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatal(err)
+	}
+	f.now = f.now.Add(6 * time.Minute)
+	if _, err := f.otp.LoginWithCode(otpPhone, f.lastCode(t)); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("过期 err = %v, want ErrInvalidOTP", err)
+	}
+
+	f.now = f.now.Add(2 * time.Minute)
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatal(err)
+	}
+	f.now = f.now.Add(2 * time.Minute)
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatalf("超过每小时上限 err = %v, want nil", err)
+	}
+	f.assertSent(t, 3)
+}
+
+func TestLoginWithCodeLockout(t *testing.T) {
+	f := newOTPFixture(t)
+	ctx := context.Background()
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatal(err)
+	}
+	code := f.lastCode(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := f.otp.LoginWithCode(otpPhone, wrongCode(code)); !errors.Is(err, ErrInvalidOTP) {
+			t.Fatalf("第 %d 次 err = %v, want ErrInvalidOTP", i+1, err)
+		}
+	}
+	if _, err := f.otp.LoginWithCode(otpPhone, wrongCode(code)); !errors.Is(err, ErrOTPLocked) {
+		t.Fatalf("err = %v, want ErrOTPLocked", err)
+	}
+	if _, err := f.otp.LoginWithCode(otpPhone, code); !errors.Is(err, ErrOTPLocked) {
+		t.Fatalf("锁定期间正确验证码 err = %v, want ErrOTPLocked", err)
+	}
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatalf("锁定期间发送 err = %v, want nil", err)
+	}
+	f.assertSent(t, 1)
+
+	f.now = f.now.Add(16 * time.Minute)
+	if err := f.otp.SendLoginCode(ctx, otpPhone); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.otp.LoginWithCode(otpPhone, f.lastCode(t)); err != nil {
+		t.Fatal(err)
+	}
+}
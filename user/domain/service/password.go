@@ -0,0 +1,51 @@
+package service
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordCost bcrypt 计算强度，调高后旧哈希在用户下次登录时重新计算
+const passwordCost = 12
+
+// bcrypt 只使用前 72 字节，超出部分会被静默忽略，因此直接拒绝
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+// dummyHash 账号不存在时也做一次哈希比较，避免通过响应时间判断账号是否存在
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("gomall-dummy-password"), passwordCost)
+
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return ErrWeakPassword
+	}
+	return nil
+}
+
+func hashPassword(password string) (string, error) {
+	if err := validatePassword(password); err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	return string(hash), err
+}
+
+// checkPassword 校验密码，needsRehash 表示哈希强度低于当前配置；空哈希表示密码已被作废
+func checkPassword(hash, password string) (ok, needsRehash bool, err error) {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false, false, nil
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, err == nil && cost < passwordCost, nil
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"gorm.io/gorm"
+)
+
+const (
+	// privacyBatchSize 每轮最多处理的请求数
+	privacyBatchSize = 50
+	// maxPrivacyError 记录的错误信息长度上限，与 last_error 列一致
+	maxPrivacyError = 500
+)
+
+type IPrivacyService interface {
+	// RequestExport 创建导出请求，requestedBy 为发起人；已有未完成的导出请求时返回 ErrPrivacyRequestExists
+	RequestExport(userID, requestedBy int64) (*model.PrivacyRequest, error)
+	// RequestDeletion 创建注销请求，完成后账号匿名化且不可恢复
+	RequestDeletion(userID, requestedBy int64) (*model.PrivacyRequest, error)
+	FindRequest(requestID int64) (*model.PrivacyRequest, error)
+	FindRequests(userID int64) ([]model.PrivacyRequest, error)
+	// Process 依次执行请求未完成的步骤，遇到失败即停止，下次处理时从失败的步骤重试；
+	// 返回处理后的请求，已完成的请求原样返回，正由其他实例处理时返回 ErrPrivacyRequestBusy
+	Process(ctx context.Context, requestID int64) (*model.PrivacyRequest, error)
+	// Archive 将已完成的导出请求各步骤的数据合并为一个 JSON 文件
+	Archive(requestID int64) ([]byte, error)
+	// Run 按 interval 周期处理待处理与失败的请求并清除过期的导出数据，直到 ctx 结束
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// 创建，sources 为各服务的数据来源，没有来源的服务（如抽奖）步骤记为跳过
+func NewPrivacyService(privacyRepository repository.IPrivacyRepository, userRepository repository.IUserRepository,
+	sources map[string]IPrivacyDataSource, cfg config.PrivacyConfig) IPrivacyService {
+	return &PrivacyService{
+		PrivacyRepository: privacyRepository,
+		UserRepository:    userRepository,
+		Sources:           sources,
+		cfg:               cfg,
+		now:               time.Now,
+	}
+}
+
+type PrivacyService struct {
+	PrivacyRepository repository.IPrivacyRepository
+	UserRepository    repository.IUserRepository
+	Sources           map[string]IPrivacyDataSource
+	cfg               config.PrivacyConfig
+	now               func() time.Time
+}
+
+// 导出
+func (p *PrivacyService) RequestExport(userID, requestedBy int64) (*model.PrivacyRequest, error) {
+	return p.create(userID, requestedBy, model.PrivacyExport)
+}
+
+// 注销
+func (p *PrivacyService) RequestDeletion(userID, requestedBy int64) (*model.PrivacyRequest, error) {
+	return p.create(userID, requestedBy, model.PrivacyDelete)
+}
+
+func (p *PrivacyService) create(userID, requestedBy int64, requestType string) (*model.PrivacyRequest, error) {
+	user, err := p.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	request := &model.PrivacyRequest{
+		UserID:      userID,
+		Type:        requestType,
+		Status:      model.PrivacyPending,
+		RequestedBy: requestedBy,
+	}
+	for i, name := range model.PrivacyServices {
+		step := model.PrivacyStep{Service: name, Seq: i, Status: model.PrivacyPending}
+		if _, ok := p.Sources[name]; !ok {
+			step.Status = model.PrivacySkipped
+		}
+		request.Steps = append(request.Steps, step)
+	}
+	err = p.PrivacyRepository.CreateRequest(request)
+	if errors.Is(err, repository.ErrPrivacyRequestExists) {
+		return nil, ErrPrivacyRequestExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("隐私请求已创建", "audit", "privacy_request", "request_id", request.ID, "type", requestType,
+		"user_id", userID, "requested_by", requestedBy)
+	return request, nil
+}
+
+// 查找
+func (p *PrivacyService) FindRequest(requestID int64) (*model.PrivacyRequest, error) {
+	request, err := p.PrivacyRepository.FindRequestByID(requestID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPrivacyRequestNotFound
+	}
+	return request, err
+}
+
+// 查找用户的请求
+func (p *PrivacyService) FindRequests(userID int64) ([]model.PrivacyRequest, error) {
+	return p.PrivacyRepository.FindRequests(userID)
+}
+
+// 处理
+func (p *PrivacyService) Process(ctx context.Context, requestID int64) (*model.PrivacyRequest, error) {
+	request, err := p.FindRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status == model.PrivacyCompleted || request.Status == model.PrivacyExpired {
+		return request, nil
+	}
+	err = p.PrivacyRepository.ClaimRequest(requestID, p.now().Add(-p.cfg.StaleAfter))
+	if errors.Is(err, repository.ErrPrivacyRequestBusy) {
+		return nil, ErrPrivacyRequestBusy
+	}
+	if err != nil {
+		return nil, err
+	}
+	request.Status = model.PrivacyRunning
+	request.Attempts++
+
+	var orderIDs []int64
+	for i := range request.Steps {
+		step := &request.Steps[i]
+		if step.Done() {
+			orderIDs = append(orderIDs, step.OrderIDs...)
+			continue
+		}
+		if err := p.runStep(ctx, request, step, orderIDs); err != nil {
+			slog.Warn("隐私请求步骤失败", "request_id", request.ID, "type", request.Type, "service", step.Service,
+				"attempts", step.Attempts, "error", err)
+			return request, p.finish(request, model.PrivacyFailed, step.Service+": "+step.LastError)
+		}
+		orderIDs = append(orderIDs, step.OrderIDs...)
+	}
+	slog.Info("隐私请求已完成", "audit", "privacy_request_completed", "request_id", request.ID, "type", request.Type,
+		"user_id", request.UserID)
+	return request, p.finish(request, model.PrivacyCompleted, "")
+}
+
+// runStep 在一个服务上执行步骤并保存结果，失败时返回错误，错误信息记录在步骤上
+func (p *PrivacyService) runStep(ctx context.Context, request *model.PrivacyRequest, step *model.PrivacyStep, orderIDs []int64) error {
+	source := p.Sources[step.Service]
+	if source == nil {
+		step.Status = model.PrivacySkipped
+		return p.PrivacyRepository.SaveStep(step)
+	}
+	step.Attempts++
+	var result *PrivacyResult
+	var err error
+	if request.Type == model.PrivacyExport {
+		result, err = source.ExportUserData(ctx, request.UserID, orderIDs)
+	} else {
+		result, err = source.EraseUserData(ctx, request.UserID, orderIDs)
+	}
+	if err != nil {
+		step.Status = model.PrivacyFailed
+		step.LastError = truncateError(err.Error())
+		if saveErr := p.PrivacyRepository.SaveStep(step); saveErr != nil {
+			slog.Error("保存隐私请求步骤失败", "request_id", request.ID, "service", step.Service, "error", saveErr)
+		}
+		return err
+	}
+	completedAt := p.now()
+	step.Status = model.PrivacyCompleted
+	step.LastError = ""
+	step.Data = result.Data
+	step.Affected = result.Affected
+	step.OrderIDs = result.OrderIDs
+	step.CompletedAt = &completedAt
+	if err := p.PrivacyRepository.SaveStep(step); err != nil {
+		step.Status = model.PrivacyFailed
+		step.LastError = truncateError(err.Error())
+		return err
+	}
+	return nil
+}
+
+func (p *PrivacyService) finish(request *model.PrivacyRequest, status, lastError string) error {
+	request.Status = status
+	request.LastError = truncateError(lastError)
+	request.CompletedAt = nil
+	if status == model.PrivacyCompleted {
+		completedAt := p.now()
+		request.CompletedAt = &completedAt
+	}
+	return p.PrivacyRepository.FinishRequest(request.ID, request.Status, request.LastError, request.CompletedAt)
+}
+
+// privacyArchive 导出文件，Services 按服务名存放各服务导出的 JSON
+type privacyArchive struct {
+	RequestID   int64                      `json:"request_id"`
+	UserID      int64                      `json:"user_id"`
+	GeneratedAt time.Time                  `json:"generated_at"`
+	Services    map[string]json.RawMessage `json:"services"`
+	Skipped     []string                   `json:"skipped,omitempty"` // 没有与用户关联数据的服务
+}
+
+// 导出文件
+func (p *PrivacyService) Archive(requestID int64) ([]byte, error) {
+	request, err := p.FindRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Type != model.PrivacyExport {
+		return nil, ErrPrivacyRequestNotFound
+	}
+	switch request.Status {
+	case model.PrivacyExpired:
+		return nil, ErrPrivacyExportExpired
+	case model.PrivacyCompleted:
+	default:
+		return nil, ErrPrivacyExportNotReady
+	}
+	archive := &privacyArchive{
+		RequestID:   request.ID,
+		UserID:      request.UserID,
+		GeneratedAt: *request.CompletedAt,
+		Services:    map[string]json.RawMessage{},
+	}
+	for _, step := range request.Steps {
+		if step.Status == model.PrivacySkipped {
+			archive.Skipped = append(archive.Skipped, step.Service)
+			continue
+		}
+		archive.Services[step.Service] = step.Data
+	}
+	return json.MarshalIndent(archive, "", "  ")
+}
+
+// 周期处理
+func (p *PrivacyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.processPending(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *PrivacyService) processPending(ctx context.Context) {
+	now := p.now()
+	if n, err := p.PrivacyRepository.ExpireExports(now.Add(-p.cfg.ExportRetention)); err != nil {
+		slog.Error("清除过期导出数据失败", "error", err)
+	} else if n > 0 {
+		slog.Info("已清除过期导出数据", "requests", n)
+	}
+	ids, err := p.PrivacyRepository.FindRetryable(p.cfg.MaxAttempts, now.Add(-p.cfg.StaleAfter), privacyBatchSize)
+	if err != nil {
+		slog.Error("查询待处理的隐私请求失败", "error", err)
+		return
+	}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := p.Process(ctx, id); err != nil && !errors.Is(err, ErrPrivacyRequestBusy) {
+			slog.Error("处理隐私请求失败", "request_id", id, "error", err)
+		}
+	}
+}
+
+func truncateError(msg string) string {
+	return truncateText(msg, maxPrivacyError)
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/config"
+	"gorm.io/gorm"
+)
+
+// memoryPrivacyRepository 内存实现
+type memoryPrivacyRepository struct {
+	requests map[int64]*model.PrivacyRequest
+	stepID   int64
+}
+
+func (r *memoryPrivacyRepository) InitTable() error { return nil }
+
+func (r *memoryPrivacyRepository) CreateRequest(request *model.PrivacyRequest) error {
+	for _, other := range r.requests {
+		if other.UserID == request.UserID && other.Type == request.Type &&
+			(other.Status == model.PrivacyPending || other.Status == model.PrivacyRunning || other.Status == model.PrivacyFailed) {
+			return repository.ErrPrivacyRequestExists
+		}
+	}
+	request.ID = int64(len(r.requests) + 1)
+	for i := range request.Steps {
+		r.stepID++
+		request.Steps[i].ID, request.Steps[i].RequestID = r.stepID, request.ID
+	}
+	r.requests[request.ID] = copyPrivacyRequest(request)
+	return nil
+}
+
+func (r *memoryPrivacyRepository) FindRequestByID(requestID int64) (*model.PrivacyRequest, error) {
+	if request, ok := r.requests[requestID]; ok {
+		return copyPrivacyRequest(request), nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryPrivacyRepository) FindRequests(userID int64) ([]model.PrivacyRequest, error) {
+	var requests []model.PrivacyRequest
+	for _, request := range r.requests {
+		if request.UserID == userID {
+			requests = append(requests, *copyPrivacyRequest(request))
+		}
+	}
+	return requests, nil
+}
+
+func (r *memoryPrivacyRepository) ClaimRequest(requestID int64, staleBefore time.Time) error {
+	request := r.requests[requestID]
+	if request.Status != model.PrivacyPending && request.Status != model.PrivacyFailed &&
+		!(request.Status == model.PrivacyRunning && request.UpdatedAt.Before(staleBefore)) {
+		return repository.ErrPrivacyRequestBusy
+	}
+	request.Status = model.PrivacyRunning
+	request.Attempts++
+	return nil
+}
+
+func (r *memoryPrivacyRepository) FinishRequest(requestID int64, status, lastError string, completedAt *time.Time) error {
+	request := r.requests[requestID]
+	request.Status, request.LastError, request.CompletedAt = status, lastError, completedAt
+	return nil
+}
+
+func (r *memoryPrivacyRepository) SaveStep(step *model.PrivacyStep) error {
+	steps := r.requests[step.RequestID].Steps
+	for i := range steps {
+		if steps[i].ID == step.ID {
+			steps[i] = *step
+		}
+	}
+	return nil
+}
+
+func (r *memoryPrivacyRepository) FindRetryable(maxAttempts int, staleBefore time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	for id, request := range r.requests {
+		if (request.Status == model.PrivacyPending || request.Status == model.PrivacyFailed) && request.Attempts < maxAttempts {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memoryPrivacyRepository) ExpireExports(before time.Time) (int64, error) {
+	var expired int64
+	for _, request := range r.requests {
+		if request.Type == model.PrivacyExport && request.Status == model.PrivacyCompleted && request.CompletedAt.Before(before) {
+			request.Status = model.PrivacyExpired
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (r *memoryPrivacyRepository) PurgeUserExports(userID int64) error { return nil }
+
+func copyPrivacyRequest(request *model.PrivacyRequest) *model.PrivacyRequest {
+	copied := *request
+	copied.Steps = append([]model.PrivacyStep(nil), request.Steps...)
+	return &copied
+}
+
+// fakePrivacySource 记录调用，failures 次调用失败后才成功
+type fakePrivacySource struct {
+	data     []byte
+	orderIDs []int64
+	failures int
+
+	calls        int
+	seenOrderIDs []int64
+}
+
+func (s *fakePrivacySource) ExportUserData(_ context.Context, _ int64, orderIDs []int64) (*PrivacyResult, error) {
+	return s.call(orderIDs, &PrivacyResult{Data: s.data, OrderIDs: s.orderIDs})
+}
+
+func (s *fakePrivacySource) EraseUserData(_ context.Context, _ int64, orderIDs []int64) (*PrivacyResult, error) {
+	return s.call(orderIDs, &PrivacyResult{Affected: 1, OrderIDs: s.orderIDs})
+}
+
+func (s *fakePrivacySource) call(orderIDs []int64, result *PrivacyResult) (*PrivacyResult, error) {
+	s.calls++
+	s.seenOrderIDs = orderIDs
+	if s.failures > 0 {
+		s.failures--
+		return nil, errors.New("服务不可用")
+	}
+	return result, nil
+}
+
+type privacyFixture struct {
+	privacy *PrivacyService
+	repo    *memoryPrivacyRepository
+	sources map[string]*fakePrivacySource
+	userID  int64
+	now     time.Time
+}
+
+func newPrivacyFixture(t *testing.T) *privacyFixture {
+	t.Helper()
+	users := newMemoryUserRepository()
+	userID, err := users.CreateUser(&model.User{Username: "alice", Email: "alice@example.com", Status: model.StatusActive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &privacyFixture{
+		repo: &memoryPrivacyRepository{requests: map[int64]*model.PrivacyRequest{}},
+		sources: map[string]*fakePrivacySource{
+			model.PrivacyServiceCart:    {data: []byte(`{"carts":[]}`)},
+			model.PrivacyServiceOrder:   {data: []byte(`{"orders":[{"id":7},{"id":9}]}`), orderIDs: []int64{7, 9}},
+			model.PrivacyServicePayment: {data: []byte(`{"transactions":[]}`)},
+			model.PrivacyServiceUser:    {data: []byte(`{"profile":{"id":1}}`)},
+		},
+		userID: userID,
+		now:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	sources := map[string]IPrivacyDataSource{}
+	for name, source := range f.sources {
+		sources[name] = source
+	}
+	f.privacy = NewPrivacyService(f.repo, users, sources, config.PrivacyConfig{
+		MaxAttempts:     3,
+		StaleAfter:      10 * time.Minute,
+		ExportRetention: 24 * time.Hour,
+	}).(*PrivacyService)
+	f.privacy.now = func() time.Time { return f.now }
+	return f
+}
+
+func TestPrivacyExport(t *testing.T) {
+	f := newPrivacyFixture(t)
+	ctx := context.Background()
+
+	request, err := f.privacy.RequestExport(f.userID, f.userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.privacy.RequestExport(f.userID, f.userID); !errors.Is(err, ErrPrivacyRequestExists) {
+		t.Fatalf("重复申请: got %v", err)
+	}
+	if _, err := f.privacy.Archive(request.ID); !errors.Is(err, ErrPrivacyExportNotReady) {
+		t.Fatalf("未完成时下载: got %v", err)
+	}
+
+	processed, err := f.privacy.Process(ctx, request.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if processed.Status != model.PrivacyCompleted {
+		t.Fatalf("status = %s, want completed", processed.Status)
+	}
+	// 支付步骤按订单步骤返回的订单查找
+	if got := f.sources[model.PrivacyServicePayment].seenOrderIDs; !reflect.DeepEqual(got, []int64{7, 9}) {
+		t.Fatalf("支付步骤收到的订单 = %v", got)
+	}
+
+	content, err := f.privacy.Archive(request.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var archive struct {
+		UserID   int64                      `json:"user_id"`
+		Services map[string]json.RawMessage `json:"services"`
+		Skipped  []string                   `json:"skipped"`
+	}
+	if err := json.Unmarshal(content, &archive); err != nil {
+		t.Fatal(err)
+	}
+	var order bytes.Buffer
+	if err := json.Compact(&order, archive.Services[model.PrivacyServiceOrder]); err != nil {
+		t.Fatal(err)
+	}
+	if archive.UserID != f.userID || len(archive.Services) != 4 || order.String() != `{"orders":[{"id":7},{"id":9}]}` {
+		t.Fatalf("archive = %s", content)
+	}
+	if !reflect.DeepEqual(archive.Skipped, []string{model.PrivacyServiceLottery}) {
+		t.Fatalf("skipped = %v, want [lottery]", archive.Skipped)
+	}
+
+	// 超过保留期后清除
+	f.now = f.now.Add(25 * time.Hour)
+	f.privacy.processPending(ctx)
+	if _, err := f.privacy.Archive(request.ID); !errors.Is(err, ErrPrivacyExportExpired) {
+		t.Fatalf("过期后下载: got %v", err)
+	}
+}
+
+func TestPrivacyDeletionRetry(t *testing.T) {
+	f := newPrivacyFixture(t)
+	ctx := context.Background()
+	f.sources[model.PrivacyServicePayment].failures = 1
+
+	request, err := f.privacy.RequestDeletion(f.userID, f.userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	processed, err := f.privacy.Process(ctx, request.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if processed.Status != model.PrivacyFailed || processed.LastError == "" {
+		t.Fatalf("status = %s, last_error = %q, want failed", processed.Status, processed.LastError)
+	}
+	// 支付失败后不再执行后续步骤，账号尚未注销
+	if calls := f.sources[model.PrivacyServiceUser].calls; calls != 0 {
+		t.Fatalf("用户步骤执行了 %d 次", calls)
+	}
+
+	// 定时任务从失败的步骤重试，已完成的步骤不再执行
+	f.privacy.processPending(ctx)
+	found, err := f.privacy.FindRequest(request.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Status != model.PrivacyCompleted || found.Attempts != 2 {
+		t.Fatalf("status = %s, attempts = %d, want completed after 2 attempts", found.Status, found.Attempts)
+	}
+	for name, want := range map[string]int{
+		model.PrivacyServiceCart:    1,
+		model.PrivacyServiceOrder:   1,
+		model.PrivacyServicePayment: 2,
+		model.PrivacyServiceUser:    1,
+	} {
+		if calls := f.sources[name].calls; calls != want {
+			t.Fatalf("%s 执行了 %d 次, want %d", name, calls, want)
+		}
+	}
+	// 订单步骤的结果已保存，重试时支付步骤仍能拿到订单
+	if got := f.sources[model.PrivacyServicePayment].seenOrderIDs; !reflect.DeepEqual(got, []int64{7, 9}) {
+		t.Fatalf("重试时支付步骤收到的订单 = %v", got)
+	}
+	for _, step := range found.Steps {
+		want := model.PrivacyCompleted
+		if step.Service == model.PrivacyServiceLottery {
+			want = model.PrivacySkipped
+		}
+		if step.Status != want {
+			t.Fatalf("%s 步骤 = %s, want %s", step.Service, step.Status, want)
+		}
+	}
+	if _, err := f.privacy.Archive(request.ID); !errors.Is(err, ErrPrivacyRequestNotFound) {
+		t.Fatalf("注销请求不能下载: got %v", err)
+	}
+}
+
+func TestAnonymizedUser(t *testing.T) {
+	user := anonymizedUser(42)
+	if user.Status != model.StatusDeleted || user.Email != "deleted-42@deleted.invalid" ||
+		user.Phone != nil || user.PasswordHash != "" || user.Roles != nil || user.EmailVerifiedAt != nil {
+		t.Fatalf("anonymized user = %+v", user)
+	}
+}
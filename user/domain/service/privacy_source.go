@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/proto/privacy"
+	"gorm.io/gorm"
+)
+
+// PrivacyResult 一个服务上导出或删除的结果
+type PrivacyResult struct {
+	Data     []byte  // 导出的 JSON
+	Affected int64   // 删除或匿名化的记录数
+	OrderIDs []int64 // 订单服务返回的用户订单
+}
+
+// IPrivacyDataSource 持有用户数据的服务，导出与删除都须可重复执行；
+// orderIDs 为订单步骤返回的用户订单，没有订单步骤结果时为空
+type IPrivacyDataSource interface {
+	ExportUserData(ctx context.Context, userID int64, orderIDs []int64) (*PrivacyResult, error)
+	EraseUserData(ctx context.Context, userID int64, orderIDs []int64) (*PrivacyResult, error)
+}
+
+// NewRemotePrivacySource 通过其他服务实现的 Privacy 接口导出与删除
+func NewRemotePrivacySource(client privacy.PrivacyService) IPrivacyDataSource {
+	return &remotePrivacySource{client: client}
+}
+
+type remotePrivacySource struct {
+	client privacy.PrivacyService
+}
+
+func (r *remotePrivacySource) ExportUserData(ctx context.Context, userID int64, orderIDs []int64) (*PrivacyResult, error) {
+	rsp, err := r.client.ExportUserData(ctx, &privacy.UserDataRequest{UserId: userID, OrderIds: orderIDs})
+	if err != nil {
+		return nil, err
+	}
+	return &PrivacyResult{Data: rsp.Data, OrderIDs: rsp.OrderIds}, nil
+}
+
+func (r *remotePrivacySource) EraseUserData(ctx context.Context, userID int64, orderIDs []int64) (*PrivacyResult, error) {
+	rsp, err := r.client.EraseUserData(ctx, &privacy.UserDataRequest{UserId: userID, OrderIds: orderIDs})
+	if err != nil {
+		return nil, err
+	}
+	return &PrivacyResult{Affected: rsp.Affected, OrderIDs: rsp.OrderIds}, nil
+}
+
+// ISessionRevoker 吊销用户的全部会话，由 auth.Manager 实现
+type ISessionRevoker interface {
+	RevokeUser(ctx context.Context, userID int64) error
+}
+
+// NewUserPrivacySource 用户服务自身的数据：资料、收货地址、邮件令牌与短信验证码
+func NewUserPrivacySource(userRepository repository.IUserRepository, addressRepository repository.IAddressRepository,
+	tokenRepository repository.IAccountTokenRepository, otpRepository repository.IPhoneOTPRepository,
+	privacyRepository repository.IPrivacyRepository, sessions ISessionRevoker) IPrivacyDataSource {
+	return &UserPrivacySource{
+		UserRepository:    userRepository,
+		AddressRepository: addressRepository,
+		TokenRepository:   tokenRepository,
+		OTPRepository:     otpRepository,
+		PrivacyRepository: privacyRepository,
+		Sessions:          sessions,
+	}
+}
+
+type UserPrivacySource struct {
+	UserRepository    repository.IUserRepository
+	AddressRepository repository.IAddressRepository
+	TokenRepository   repository.IAccountTokenRepository
+	OTPRepository     repository.IPhoneOTPRepository
+	PrivacyRepository repository.IPrivacyRepository
+	Sessions          ISessionRevoker
+}
+
+// userExport 用户服务导出的数据
+type userExport struct {
+	Profile   *model.User     `json:"profile"`
+	Addresses []model.Address `json:"addresses"`
+}
+
+// 导出资料与收货地址
+func (u *UserPrivacySource) ExportUserData(ctx context.Context, userID int64, _ []int64) (*PrivacyResult, error) {
+	user, err := u.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := u.AddressRepository.FindAddresses(userID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(&userExport{Profile: user, Addresses: addresses})
+	if err != nil {
+		return nil, err
+	}
+	return &PrivacyResult{Data: data}, nil
+}
+
+// 删除地址、令牌与验证码，匿名化用户记录并吊销会话。
+// 用户记录保留ID供订单等依法保留的记录关联，状态为已注销，无法再登录。
+func (u *UserPrivacySource) EraseUserData(ctx context.Context, userID int64, _ []int64) (*PrivacyResult, error) {
+	user, err := u.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &PrivacyResult{}
+	n, err := u.AddressRepository.DeleteAddresses(userID)
+	if err != nil {
+		return nil, err
+	}
+	result.Affected += n
+	if n, err = u.TokenRepository.DeleteTokens(userID); err != nil {
+		return nil, err
+	}
+	result.Affected += n
+	if phone := user.PhoneNumber(); phone != "" {
+		if n, err = u.OTPRepository.DeleteOTP(phone); err != nil {
+			return nil, err
+		}
+		result.Affected += n
+	}
+	if err := u.UserRepository.AnonymizeUser(anonymizedUser(userID)); err != nil {
+		return nil, err
+	}
+	result.Affected++
+	if err := u.Sessions.RevokeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	// 已生成的导出文件同样包含个人数据
+	if err := u.PrivacyRepository.PurgeUserExports(userID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// anonymizedUser 注销后的用户记录：邮箱改为占位地址以释放唯一索引，密码哈希置空使密码登录永远失败
+func anonymizedUser(userID int64) *model.User {
+	return &model.User{
+		ID:       userID,
+		Username: "已注销用户",
+		Email:    fmt.Sprintf("deleted-%d@deleted.invalid", userID),
+		Status:   model.StatusDeleted,
+	}
+}
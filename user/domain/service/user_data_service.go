@@ -0,0 +1,229 @@
+package service
+
+import (
+	"errors"
+	"log/slog"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// 手机号：可带 + 前缀的 6 到 15 位数字（E.164）
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{6,15}$`)
+
+type IUserDataService interface {
+	// Register 注册，邮箱与手机号唯一，密码只保存哈希
+	Register(user *model.User, password string) (int64, error)
+	// Login 以邮箱或手机号登录
+	Login(account, password string) (*model.User, error)
+	FindUserByID(int64) (*model.User, error)
+	// UpdateProfile 更新用户名、手机号与头像
+	UpdateProfile(*model.User) error
+	ChangePassword(userID int64, oldPassword, newPassword string) error
+	UpdateStatus(userID int64, status int) error
+	// UpdateRoles 整体替换角色，调用方须随后吊销用户会话使新角色生效
+	UpdateRoles(userID int64, roles []string) error
+}
+
+// 创建
+func NewUserDataService(userRepository repository.IUserRepository) IUserDataService {
+	return &UserDataService{UserRepository: userRepository}
+}
+
+type UserDataService struct {
+	UserRepository repository.IUserRepository
+}
+
+// 注册，新用户为正常状态，角色为顾客
+func (u *UserDataService) Register(user *model.User, password string) (int64, error) {
+	if err := normalizeProfile(user); err != nil {
+		return 0, err
+	}
+	email, err := normalizeEmail(user.Email)
+	if err != nil {
+		return 0, err
+	}
+	user.Email = email
+	if user.PasswordHash, err = hashPassword(password); err != nil {
+		return 0, err
+	}
+	user.ID = 0
+	user.Status = model.StatusActive
+	user.Roles = []string{auth.RoleCustomer}
+	userID, err := u.UserRepository.CreateUser(user)
+	return userID, userError(err)
+}
+
+// 登录，账号不存在、已注销或密码错误时统一返回 ErrInvalidCredentials
+func (u *UserDataService) Login(account, password string) (*model.User, error) {
+	user, err := u.findByAccount(strings.TrimSpace(account))
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	ok, needsRehash, err := checkPassword(user.PasswordHash, password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	if user.Status == model.StatusLocked {
+		return nil, ErrUserLocked
+	}
+	if needsRehash {
+		if hash, err := hashPassword(password); err == nil {
+			if err := u.UserRepository.UpdatePasswordHash(user.ID, hash); err != nil {
+				slog.Warn("更新密码哈希失败", "user_id", user.ID, "error", err)
+			}
+		}
+	}
+	return user, nil
+}
+
+// 查找，已注销的用户视为不存在
+func (u *UserDataService) FindUserByID(userID int64) (*model.User, error) {
+	user, err := u.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Status == model.StatusDeleted) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// 更新资料，邮箱与密码不在此修改
+func (u *UserDataService) UpdateProfile(user *model.User) error {
+	if _, err := u.FindUserByID(user.ID); err != nil {
+		return err
+	}
+	if err := normalizeProfile(user); err != nil {
+		return err
+	}
+	return userError(u.UserRepository.UpdateProfile(user))
+}
+
+// 修改密码，需校验原密码
+func (u *UserDataService) ChangePassword(userID int64, oldPassword, newPassword string) error {
+	user, err := u.FindUserByID(userID)
+	if err != nil {
+		return err
+	}
+	ok, _, err := checkPassword(user.PasswordHash, oldPassword)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidCredentials
+	}
+	if oldPassword == newPassword {
+		return ErrPasswordUnchanged
+	}
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	return u.UserRepository.UpdatePasswordHash(userID, hash)
+}
+
+// 变更状态，注销后不可恢复
+func (u *UserDataService) UpdateStatus(userID int64, status int) error {
+	if status != model.StatusActive && status != model.StatusLocked && status != model.StatusDeleted {
+		return ErrInvalidStatus
+	}
+	user, err := u.UserRepository.FindUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status == status {
+		return nil
+	}
+	if user.Status == model.StatusDeleted {
+		return ErrStatusTransition
+	}
+	return u.UserRepository.UpdateStatus(userID, status)
+}
+
+// 变更角色，已注销的账号不能变更
+func (u *UserDataService) UpdateRoles(userID int64, roles []string) error {
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		role = strings.ToLower(strings.TrimSpace(role))
+		if !auth.ValidRole(role) {
+			return ErrInvalidRole
+		}
+		normalized = append(normalized, role)
+	}
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	user, err := u.FindUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if slices.Equal(user.Roles, normalized) {
+		return nil
+	}
+	return u.UserRepository.UpdateRoles(userID, normalized)
+}
+
+// findByAccount 账号含 @ 时按邮箱查找，否则按手机号查找
+func (u *UserDataService) findByAccount(account string) (*model.User, error) {
+	if strings.Contains(account, "@") {
+		return u.UserRepository.FindUserByEmail(strings.ToLower(account))
+	}
+	return u.UserRepository.FindUserByPhone(account)
+}
+
+// normalizeProfile 校验用户名与手机号，空手机号保存为 NULL
+func normalizeProfile(user *model.User) error {
+	user.Username = strings.TrimSpace(user.Username)
+	if length := utf8.RuneCountInString(user.Username); length == 0 || length > 50 {
+		return ErrInvalidUsername
+	}
+	if user.Phone != nil {
+		phone := strings.TrimSpace(*user.Phone)
+		if phone == "" {
+			user.Phone = nil
+		} else if !phonePattern.MatchString(phone) {
+			return ErrInvalidPhone
+		} else {
+			user.Phone = &phone
+		}
+	}
+	user.Avatar = strings.TrimSpace(user.Avatar)
+	return nil
+}
+
+// normalizeEmail 校验邮箱并转为小写，只接受不带显示名的地址
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > 100 {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// userError 将仓储层的唯一约束错误转换为业务错误
+func userError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrDuplicateEmail):
+		return ErrEmailTaken
+	case errors.Is(err, repository.ErrDuplicatePhone):
+		return ErrPhoneTaken
+	}
+	return err
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// memoryUserRepository 内存实现，唯一约束与数据库一致
+type memoryUserRepository struct {
+	users map[int64]*model.User
+}
+
+func newMemoryUserRepository() *memoryUserRepository {
+	return &memoryUserRepository{users: map[int64]*model.User{}}
+}
+
+func (r *memoryUserRepository) InitTable() error { return nil }
+
+func (r *memoryUserRepository) CreateUser(user *model.User) (int64, error) {
+	if err := r.checkUnique(user); err != nil {
+		return 0, err
+	}
+	user.ID = int64(len(r.users) + 1)
+	stored := *user
+	r.users[user.ID] = &stored
+	return user.ID, nil
+}
+
+func (r *memoryUserRepository) FindUserByID(userID int64) (*model.User, error) {
+	if user, ok := r.users[userID]; ok {
+		found := *user
+		return &found, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUserRepository) FindUserByEmail(email string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return r.FindUserByID(user.ID)
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUserRepository) FindUserByPhone(phone string) (*model.User, error) {
+	for _, user := range r.users {
+		if user.PhoneNumber() == phone {
+			return r.FindUserByID(user.ID)
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUserRepository) UpdateProfile(user *model.User) error {
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+	stored := r.users[user.ID]
+	stored.Username, stored.Phone, stored.Avatar = user.Username, user.Phone, user.Avatar
+	return nil
+}
+
+func (r *memoryUserRepository) UpdatePasswordHash(userID int64, passwordHash string) error {
+	r.users[userID].PasswordHash = passwordHash
+	return nil
+}
+
+func (r *memoryUserRepository) UpdateStatus(userID int64, status int) error {
+	r.users[userID].Status = status
+	return nil
+}
+
+func (r *memoryUserRepository) UpdateRoles(userID int64, roles []string) error {
+	r.users[userID].Roles = roles
+	return nil
+}
+
+func (r *memoryUserRepository) MarkEmailVerified(userID int64, verifiedAt time.Time) error {
+	if r.users[userID].EmailVerifiedAt == nil {
+		r.users[userID].EmailVerifiedAt = &verifiedAt
+	}
+	return nil
+}
+
+func (r *memoryUserRepository) AnonymizeUser(user *model.User) error {
+	stored := *user
+	r.users[user.ID] = &stored
+	return nil
+}
+
+func (r *memoryUserRepository) SearchUsers(query repository.UserQuery, offset, limit int) ([]model.User, int64, error) {
+	var matched []model.User
+	for _, user := range r.users {
+		if strings.HasPrefix(user.Email, query.Email) && strings.HasPrefix(user.PhoneNumber(), query.Phone) &&
+			strings.Contains(user.Username, query.Username) && (query.Status == 0 || user.Status == query.Status) &&
+			(query.Role == "" || slices.Contains(user.Roles, query.Role)) {
+			matched = append(matched, *user)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	total := int64(len(matched))
+	if offset >= len(matched) {
+		return nil, total, nil
+	}
+	return matched[offset:min(offset+limit, len(matched))], total, nil
+}
+
+func (r *memoryUserRepository) checkUnique(user *model.User) error {
+	for _, other := range r.users {
+		if other.ID == user.ID {
+			continue
+		}
+		if user.Email != "" && other.Email == user.Email {
+			return repository.ErrDuplicateEmail
+		}
+		if user.Phone != nil && other.PhoneNumber() == *user.Phone {
+			return repository.ErrDuplicatePhone
+		}
+	}
+	return nil
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	repo := newMemoryUserRepository()
+	users := NewUserDataService(repo)
+	phone := "+8613800000000"
+
+	userID, err := users.Register(&model.User{Username: " alice ", Email: "Alice@Example.com", Phone: &phone}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := repo.users[userID]
+	if stored.Email != "alice@example.com" || stored.Username != "alice" || stored.Status != model.StatusActive {
+		t.Fatalf("stored = %+v", stored)
+	}
+	if stored.PasswordHash == "correct horse" {
+		t.Fatal("密码不应明文保存")
+	}
+
+	if _, err := users.Register(&model.User{Username: "bob", Email: "alice@example.com"}, "another pass"); !errors.Is(err, ErrEmailTaken) {
+		t.Fatalf("err = %v, want ErrEmailTaken", err)
+	}
+	if _, err := users.Register(&model.User{Username: "bob", Email: "bob@example.com", Phone: &phone}, "another pass"); !errors.Is(err, ErrPhoneTaken) {
+		t.Fatalf("err = %v, want ErrPhoneTaken", err)
+	}
+	if _, err := users.Register(&model.User{Username: "bob", Email: "Bob <bob@example.com>"}, "another pass"); !errors.Is(err, ErrInvalidEmail) {
+		t.Fatalf("err = %v, want ErrInvalidEmail", err)
+	}
+	if _, err := users.Register(&model.User{Username: "bob", Email: "bob@example.com"}, "short"); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("err = %v, want ErrWeakPassword", err)
+	}
+
+	for _, account := range []string{"ALICE@example.com", phone} {
+		if found, err := users.Login(account, "correct horse"); err != nil || found.ID != userID {
+			t.Fatalf("Login(%s) = %v, %v", account, found, err)
+		}
+	}
+	if _, err := users.Login("alice@example.com", "wrong password"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+	}
+	if _, err := users.Login("nobody@example.com", "correct horse"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestStatusAndPassword(t *testing.T) {
+	repo := newMemoryUserRepository()
+	users := NewUserDataService(repo)
+	userID, err := users.Register(&model.User{Username: "alice", Email: "alice@example.com"}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := users.ChangePassword(userID, "wrong password", "new password"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+	}
+	if err := users.ChangePassword(userID, "correct horse", "new password"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.Login("alice@example.com", "new password"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := users.UpdateStatus(userID, model.StatusLocked); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.Login("alice@example.com", "new password"); !errors.Is(err, ErrUserLocked) {
+		t.Fatalf("err = %v, want ErrUserLocked", err)
+	}
+	if err := users.UpdateStatus(userID, model.StatusDeleted); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.Login("alice@example.com", "new password"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+	}
+	if err := users.UpdateStatus(userID, model.StatusActive); !errors.Is(err, ErrStatusTransition) {
+		t.Fatalf("err = %v, want ErrStatusTransition", err)
+	}
+}
+
+// TestLoginRehash 低强度的旧哈希在登录成功后按当前强度重新计算
+func TestUpdateRoles(t *testing.T) {
+	repo := newMemoryUserRepository()
+	users := NewUserDataService(repo)
+	userID, err := users.Register(&model.User{Username: "bob", Email: "bob@example.com"}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.users[userID].Roles; !reflect.DeepEqual(got, []string{auth.RoleCustomer}) {
+		t.Fatalf("注册后角色 = %v", got)
+	}
+
+	if err := users.UpdateRoles(userID, []string{"Support", "customer", "support"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.users[userID].Roles; !reflect.DeepEqual(got, []string{auth.RoleCustomer, auth.RoleSupport}) {
+		t.Errorf("角色 = %v", got)
+	}
+	if err := users.UpdateRoles(userID, []string{"root"}); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("err = %v, want ErrInvalidRole", err)
+	}
+	if err := users.UpdateRoles(999, nil); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestLoginRehash(t *testing.T) {
+	repo := newMemoryUserRepository()
+	users := NewUserDataService(repo)
+	hash, _ := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	repo.users[1] = &model.User{ID: 1, Username: "alice", Email: "alice@example.com", PasswordHash: string(hash), Status: model.StatusActive}
+
+	if _, err := users.Login("alice@example.com", "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	if cost, _ := bcrypt.Cost([]byte(repo.users[1].PasswordHash)); cost != passwordCost {
+		t.Fatalf("cost = %d, want %d", cost, passwordCost)
+	}
+}
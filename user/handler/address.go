@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"user/domain/model"
+	user "user/proto/user"
+)
+
+// 新增收货地址
+func (e *User) AddAddress(ctx context.Context, request *user.AddressInfo, response *user.AddressID) (err error) {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	response.AddressId, err = e.AddressService.AddAddress(toAddress(request))
+	return toMicroError(err)
+}
+
+// 更新收货地址
+func (e *User) UpdateAddress(ctx context.Context, request *user.AddressInfo, response *user.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	return toMicroError(e.AddressService.UpdateAddress(toAddress(request)))
+}
+
+// 删除收货地址
+func (e *User) DeleteAddress(ctx context.Context, request *user.AddressRequest, response *user.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	return toMicroError(e.AddressService.DeleteAddress(request.UserId, request.AddressId))
+}
+
+// 根据ID查询收货地址
+func (e *User) FindAddressByID(ctx context.Context, request *user.AddressRequest, response *user.AddressInfo) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	address, err := e.AddressService.FindAddress(request.UserId, request.AddressId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillAddressInfo(address, response)
+	return nil
+}
+
+// 查询默认收货地址
+func (e *User) FindDefaultAddress(ctx context.Context, request *user.UserID, response *user.AddressInfo) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	address, err := e.AddressService.FindDefaultAddress(request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillAddressInfo(address, response)
+	return nil
+}
+
+// 查询用户的全部收货地址
+func (e *User) FindAddresses(ctx context.Context, request *user.UserID, response *user.AddressList) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	addresses, err := e.AddressService.FindAddresses(request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range addresses {
+		info := &user.AddressInfo{}
+		fillAddressInfo(&addresses[i], info)
+		response.Addresses = append(response.Addresses, info)
+	}
+	return nil
+}
+
+// 设为默认收货地址
+func (e *User) SetDefaultAddress(ctx context.Context, request *user.AddressRequest, response *user.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	return toMicroError(e.AddressService.SetDefaultAddress(request.UserId, request.AddressId))
+}
+
+func toAddress(info *user.AddressInfo) *model.Address {
+	return &model.Address{
+		ID:         info.Id,
+		UserID:     info.UserId,
+		Recipient:  info.Recipient,
+		Phone:      info.Phone,
+		Country:    info.Country,
+		Region:     info.Region,
+		City:       info.City,
+		Detail:     info.Detail,
+		PostalCode: info.PostalCode,
+		IsDefault:  info.IsDefault,
+	}
+}
+
+func fillAddressInfo(address *model.Address, info *user.AddressInfo) {
+	info.Id = address.ID
+	info.UserId = address.UserID
+	info.Recipient = address.Recipient
+	info.Phone = address.Phone
+	info.Country = address.Country
+	info.Region = address.Region
+	info.City = address.City
+	info.Detail = address.Detail
+	info.PostalCode = address.PostalCode
+	info.IsDefault = address.IsDefault
+	info.CreatedAt = address.CreatedAt.Unix()
+	info.UpdatedAt = address.UpdatedAt.Unix()
+}
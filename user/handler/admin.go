@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"time"
+	"user/domain/model"
+	"user/domain/repository"
+	"user/domain/service"
+	user "user/proto/user"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// 搜索用户
+func (e *User) AdminSearchUsers(ctx context.Context, request *user.AdminUserSearchRequest, response *user.UserList) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	page := service.Page{Number: int(request.Page), Size: int(request.PageSize)}.Normalized()
+	users, total, err := e.AdminService.SearchUsers(ctx, actor, repository.UserQuery{
+		Email:    request.Email,
+		Phone:    request.Phone,
+		Username: request.Username,
+		Status:   int(request.Status),
+		Role:     request.Role,
+	}, page)
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range users {
+		info := &user.UserInfo{}
+		fillUserInfo(&users[i], info)
+		response.Users = append(response.Users, info)
+	}
+	response.Total = total
+	response.Page = int32(page.Number)
+	response.PageSize = int32(page.Size)
+	return nil
+}
+
+// 查看用户
+func (e *User) AdminFindUser(ctx context.Context, request *user.UserID, response *user.UserInfo) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	found, err := e.AdminService.FindUser(ctx, actor, request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillUserInfo(found, response)
+	return nil
+}
+
+// 锁定账号
+func (e *User) AdminLockUser(ctx context.Context, request *user.AdminActionRequest, response *user.Response) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	if err := e.AdminService.LockUser(ctx, actor, request.UserId, request.Reason); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "账号已锁定"
+	return nil
+}
+
+// 解锁账号
+func (e *User) AdminUnlockUser(ctx context.Context, request *user.AdminActionRequest, response *user.Response) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	if err := e.AdminService.UnlockUser(ctx, actor, request.UserId, request.Reason); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "账号已解锁"
+	return nil
+}
+
+// 重置密码
+func (e *User) AdminResetPassword(ctx context.Context, request *user.AdminResetPasswordRequest, response *user.Response) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	if err := e.AdminService.ResetPassword(ctx, actor, request.UserId, request.Reason, request.InvalidatePassword); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "重置密码链接已发送"
+	if request.InvalidatePassword {
+		response.Msg = "原密码已作废，重置密码链接已发送"
+	}
+	return nil
+}
+
+// 查询审计记录
+func (e *User) FindAdminAuditLogs(ctx context.Context, request *user.AuditLogRequest, response *user.AuditLogList) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	query := repository.AuditLogQuery{
+		AdminID:      request.AdminId,
+		TargetUserID: request.TargetUserId,
+		Action:       request.Action,
+	}
+	if request.Since > 0 {
+		query.Since = time.Unix(request.Since, 0)
+	}
+	if request.Until > 0 {
+		query.Until = time.Unix(request.Until, 0)
+	}
+	page := service.Page{Number: int(request.Page), Size: int(request.PageSize)}.Normalized()
+	logs, total, err := e.AdminService.FindAuditLogs(ctx, actor, query, page)
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range logs {
+		response.Logs = append(response.Logs, auditLogInfo(&logs[i]))
+	}
+	response.Total = total
+	response.Page = int32(page.Number)
+	response.PageSize = int32(page.Size)
+	return nil
+}
+
+// adminActor 调用方身份，策略已要求登录，此处只防御未经包装器的调用
+func adminActor(ctx context.Context) (service.AdminActor, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return service.AdminActor{}, microerrors.Unauthorized(serviceID, "%s", auth.ErrUnauthenticated.Error())
+	}
+	return service.AdminActor{UserID: claims.UserID(), SessionID: claims.SessionID, Roles: claims.Roles}, nil
+}
+
+func auditLogInfo(log *model.AdminAuditLog) *user.AuditLogInfo {
+	return &user.AuditLogInfo{
+		Id:             log.ID,
+		AdminId:        log.AdminID,
+		AdminSessionId: log.AdminSessionID,
+		AdminRoles:     log.AdminRoles,
+		Action:         log.Action,
+		TargetUserId:   log.TargetUserID,
+		Reason:         log.Reason,
+		Detail:         log.Detail,
+		Result:         log.Result,
+		CreatedAt:      log.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"errors"
+	"user/domain/service"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// 返回给调用方的 go-micro 错误 ID
+const serviceID = "go.micro.service.user"
+
+// toMicroError 将用户业务错误与令牌错误转换为带状态码的 go-micro 错误，其余错误记录后原样返回
+func toMicroError(err error) error {
+	var userErr *service.UserError
+	if errors.As(err, &userErr) {
+		return microerrors.New(serviceID, userErr.Msg, userErr.Code)
+	}
+	if auth.IsAuthError(err) {
+		return microerrors.Unauthorized(serviceID, "%s", err.Error())
+	}
+	ErrorHandle(err)
+	return err
+}
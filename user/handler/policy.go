@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+// Policy 用户服务的访问策略，由 microauth 包装器在处理请求前校验，未列入的端点一律拒绝；
+// 只要求登录的端点在处理器中再用 authorizeUser 校验是否为本人
+var Policy = auth.Policy{
+	"User.Register":             auth.PermPublic,
+	"User.Login":                auth.PermPublic,
+	"User.SendLoginCode":        auth.PermPublic,
+	"User.LoginWithCode":        auth.PermPublic,
+	"User.RefreshToken":         auth.PermPublic,
+	"User.Logout":               auth.PermPublic,
+	"User.VerifyEmail":          auth.PermPublic,
+	"User.RequestPasswordReset": auth.PermPublic,
+	"User.ResetPassword":        auth.PermPublic,
+
+	"User.FindUserByID":   auth.PermAuthenticated,
+	"User.UpdateProfile":  auth.PermAuthenticated,
+	"User.ChangePassword": auth.PermAuthenticated,
+	"User.UpdateStatus":   auth.PermUserManage,
+	"User.UpdateRoles":    auth.PermRoleAssign,
+	"User.UnlockUser":     auth.PermUserManage,
+
+	"User.AdminSearchUsers":   auth.PermUserManage,
+	"User.AdminFindUser":      auth.PermUserManage,
+	"User.AdminLockUser":      auth.PermUserManage,
+	"User.AdminUnlockUser":    auth.PermUserManage,
+	"User.AdminResetPassword": auth.PermUserManage,
+	"User.FindAdminAuditLogs": auth.PermAuditRead,
+
+	"User.ListSessions":      auth.PermAuthenticated,
+	"User.RevokeSession":     auth.PermAuthenticated,
+	"User.RevokeAllSessions": auth.PermAuthenticated,
+
+	"User.SendVerificationEmail": auth.PermAuthenticated,
+
+	"User.AddAddress":         auth.PermAuthenticated,
+	"User.UpdateAddress":      auth.PermAuthenticated,
+	"User.DeleteAddress":      auth.PermAuthenticated,
+	"User.FindAddressByID":    auth.PermAuthenticated,
+	"User.FindDefaultAddress": auth.PermAuthenticated,
+	"User.FindAddresses":      auth.PermAuthenticated,
+	"User.SetDefaultAddress":  auth.PermAuthenticated,
+
+	"User.RequestDataExport":      auth.PermAuthenticated,
+	"User.RequestAccountDeletion": auth.PermAuthenticated,
+	"User.FindPrivacyRequests":    auth.PermAuthenticated,
+	"User.FindPrivacyRequest":     auth.PermAuthenticated,
+	"User.DownloadDataExport":     auth.PermAuthenticated,
+	"User.RetryPrivacyRequest":    auth.PermUserManage,
+}
+
+// authorizeUser 只允许本人或拥有 user:manage 权限的调用方操作该用户
+func authorizeUser(ctx context.Context, userID int64) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return microerrors.Unauthorized(serviceID, "%s", auth.ErrUnauthenticated.Error())
+	}
+	if claims.UserID() == userID || claims.Can(auth.PermUserManage) {
+		return nil
+	}
+	slog.WarnContext(ctx, "拒绝访问", "audit", "access_denied", "target", "user", "owner_id", userID,
+		"user_id", claims.UserID(), "session_id", claims.SessionID, "roles", claims.Roles)
+	return microerrors.Forbidden(serviceID, "%s", auth.ErrForbidden.Error())
+}
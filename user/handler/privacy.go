@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"user/domain/model"
+	"user/domain/service"
+	user "user/proto/user"
+
+	"github.com/Ben1524/GoMall/common/auth"
+)
+
+// 申请导出数据
+func (e *User) RequestDataExport(ctx context.Context, request *user.UserID, response *user.PrivacyRequestInfo) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	created, err := e.PrivacyService.RequestExport(request.UserId, requesterID(ctx))
+	if err != nil {
+		return toMicroError(err)
+	}
+	e.processPrivacy(created.ID)
+	fillPrivacyRequestInfo(created, response)
+	return nil
+}
+
+// 申请注销账号
+func (e *User) RequestAccountDeletion(ctx context.Context, request *user.UserID, response *user.PrivacyRequestInfo) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	created, err := e.PrivacyService.RequestDeletion(request.UserId, requesterID(ctx))
+	if err != nil {
+		return toMicroError(err)
+	}
+	e.processPrivacy(created.ID)
+	fillPrivacyRequestInfo(created, response)
+	return nil
+}
+
+// 查询用户的隐私请求
+func (e *User) FindPrivacyRequests(ctx context.Context, request *user.UserID, response *user.PrivacyRequestList) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	requests, err := e.PrivacyService.FindRequests(request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	for i := range requests {
+		info := &user.PrivacyRequestInfo{}
+		fillPrivacyRequestInfo(&requests[i], info)
+		response.Requests = append(response.Requests, info)
+	}
+	return nil
+}
+
+// 查询隐私请求及各步骤的进度
+func (e *User) FindPrivacyRequest(ctx context.Context, request *user.PrivacyRequestID, response *user.PrivacyRequestInfo) error {
+	found, err := e.findPrivacyRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+	fillPrivacyRequestInfo(found, response)
+	return nil
+}
+
+// 下载导出文件
+func (e *User) DownloadDataExport(ctx context.Context, request *user.PrivacyRequestID, response *user.DataExport) error {
+	found, err := e.findPrivacyRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+	if response.Content, err = e.PrivacyService.Archive(found.ID); err != nil {
+		return toMicroError(err)
+	}
+	response.FileName = fmt.Sprintf("gomall-export-%d-%d.json", found.UserID, found.ID)
+	slog.InfoContext(ctx, "下载数据导出", "audit", "privacy_export_download", "request_id", found.ID,
+		"user_id", found.UserID, "requested_by", requesterID(ctx))
+	return nil
+}
+
+// 重试隐私请求
+func (e *User) RetryPrivacyRequest(ctx context.Context, request *user.PrivacyRequestID, response *user.PrivacyRequestInfo) error {
+	found, err := e.findPrivacyRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+	processed, err := e.PrivacyService.Process(ctx, found.ID)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillPrivacyRequestInfo(processed, response)
+	return nil
+}
+
+// findPrivacyRequest 只返回属于 request.UserId 的请求，本人或拥有 user:manage 权限的调用方可查
+func (e *User) findPrivacyRequest(ctx context.Context, request *user.PrivacyRequestID) (*model.PrivacyRequest, error) {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return nil, err
+	}
+	found, err := e.PrivacyService.FindRequest(request.RequestId)
+	if err == nil && found.UserID != request.UserId {
+		err = service.ErrPrivacyRequestNotFound
+	}
+	if err != nil {
+		return nil, toMicroError(err)
+	}
+	return found, nil
+}
+
+// processPrivacy 在后台立即处理新请求，失败的步骤由定时任务重试；
+// 不沿用请求的 context，避免随 RPC 结束而取消，也不向下游转发调用方的令牌，下游步骤以用户服务的服务令牌调用
+func (e *User) processPrivacy(requestID int64) {
+	go func() {
+		if _, err := e.PrivacyService.Process(context.Background(), requestID); err != nil {
+			slog.Warn("处理隐私请求失败，稍后重试", "request_id", requestID, "error", err)
+		}
+	}()
+}
+
+// requesterID 发起请求的用户，未认证时为 0
+func requesterID(ctx context.Context) int64 {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return claims.UserID()
+	}
+	return 0
+}
+
+func fillPrivacyRequestInfo(request *model.PrivacyRequest, info *user.PrivacyRequestInfo) {
+	info.Id = request.ID
+	info.UserId = request.UserID
+	info.Type = request.Type
+	info.Status = request.Status
+	info.RequestedBy = request.RequestedBy
+	info.Attempts = int32(request.Attempts)
+	info.LastError = request.LastError
+	info.CreatedAt = request.CreatedAt.Unix()
+	info.UpdatedAt = request.UpdatedAt.Unix()
+	if request.CompletedAt != nil {
+		info.CompletedAt = request.CompletedAt.Unix()
+	}
+	for _, step := range request.Steps {
+		info.Steps = append(info.Steps, &user.PrivacyStepInfo{
+			Service:   step.Service,
+			Status:    step.Status,
+			Attempts:  int32(step.Attempts),
+			LastError: step.LastError,
+			Affected:  step.Affected,
+			UpdatedAt: step.UpdatedAt.Unix(),
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"user/domain/service"
+	user "user/proto/user"
+
+	"github.com/Ben1524/GoMall/common/auth"
+)
+
+// 列出登录设备
+func (e *User) ListSessions(ctx context.Context, request *user.UserID, response *user.SessionList) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	sessions, err := e.Tokens.Sessions(ctx, request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	current := currentSessionID(ctx)
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, &user.SessionInfo{
+			SessionId:  session.ID,
+			Device:     session.Device,
+			Ip:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt.Unix(),
+			LastSeenAt: session.LastSeenAt.Unix(),
+			ExpiresAt:  session.ExpiresAt.Unix(),
+			Current:    session.ID == current,
+		})
+	}
+	return nil
+}
+
+// 吊销一个会话
+func (e *User) RevokeSession(ctx context.Context, request *user.SessionRequest, response *user.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	err := e.Tokens.RevokeSession(ctx, request.UserId, request.SessionId)
+	if errors.Is(err, auth.ErrSessionNotFound) {
+		err = service.ErrSessionNotFound
+	}
+	if err != nil {
+		return toMicroError(err)
+	}
+	slog.InfoContext(ctx, "吊销会话", "audit", "session_revoke", "user_id", request.UserId,
+		"session_id", request.SessionId, "requested_by", requesterID(ctx))
+	response.Msg = "已退出该设备"
+	return nil
+}
+
+// 吊销全部会话
+func (e *User) RevokeAllSessions(ctx context.Context, request *user.RevokeSessionsRequest, response *user.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	keep := ""
+	if request.KeepCurrent {
+		keep = currentSessionID(ctx)
+	}
+	revoked, err := e.Tokens.RevokeUserExcept(ctx, request.UserId, keep)
+	if err != nil {
+		return toMicroError(err)
+	}
+	slog.InfoContext(ctx, "吊销全部会话", "audit", "session_revoke_all", "user_id", request.UserId,
+		"kept_session_id", keep, "revoked", revoked, "requested_by", requesterID(ctx))
+	response.Msg = fmt.Sprintf("已退出 %d 个设备", revoked)
+	return nil
+}
+
+// currentSessionID 调用方当前所在的会话，未认证时为空
+func currentSessionID(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return claims.SessionID
+	}
+	return ""
+}
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"strings"
+	"user/domain/model"
+	"user/domain/service"
+	user "user/proto/user"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"go-micro.dev/v5/metadata"
+)
+
+type User struct {
+	UserDataService service.IUserDataService
+	AddressService  service.IAddressService
+	AccountService  service.IAccountService
+	OTPService      service.IOTPService
+	LoginGuard      service.ILoginGuard
+	PrivacyService  service.IPrivacyService
+	AdminService    service.IAdminService
+	Tokens          *auth.Manager
+}
+
+func NewUserHandler(userService service.IUserDataService, addressService service.IAddressService,
+	accountService service.IAccountService, otpService service.IOTPService, loginGuard service.ILoginGuard,
+	privacyService service.IPrivacyService, adminService service.IAdminService, tokens *auth.Manager) *User {
+	return &User{
+		UserDataService: userService,
+		AddressService:  addressService,
+		AccountService:  accountService,
+		OTPService:      otpService,
+		LoginGuard:      loginGuard,
+		PrivacyService:  privacyService,
+		AdminService:    adminService,
+		Tokens:          tokens,
+	}
+}
+
+// 注册，成功后发送验证邮件，发送失败只记录日志，用户可稍后重新发送
+func (e *User) Register(ctx context.Context, request *user.RegisterRequest, response *user.UserID) (err error) {
+	response.UserId, err = e.UserDataService.Register(&model.User{
+		Username: request.Username,
+		Email:    request.Email,
+		Phone:    optionalPhone(request.Phone),
+		Avatar:   request.Avatar,
+	}, request.Password)
+	if err != nil {
+		return toMicroError(err)
+	}
+	if err := e.AccountService.SendVerificationEmail(ctx, response.UserId); err != nil {
+		slog.Warn("发送验证邮件失败", "user_id", response.UserId, "error", err)
+	}
+	return nil
+}
+
+// 登录，密码错误计入账号与 IP 的失败次数
+func (e *User) Login(ctx context.Context, request *user.LoginRequest, response *user.LoginResponse) error {
+	ip := clientIP(ctx, request.ClientIp)
+	if err := e.LoginGuard.Check(ctx, request.Account, ip); err != nil {
+		return toMicroError(err)
+	}
+	found, err := e.UserDataService.Login(request.Account, request.Password)
+	if errors.Is(err, service.ErrInvalidCredentials) {
+		lockedUserID, guardErr := e.LoginGuard.Fail(ctx, request.Account, ip)
+		if guardErr != nil {
+			slog.Error("记录登录失败失败", "error", guardErr)
+		}
+		if lockedUserID != 0 {
+			e.revokeSessions(ctx, lockedUserID)
+		}
+	}
+	if err != nil {
+		return toMicroError(err)
+	}
+	e.LoginGuard.Succeed(ctx, request.Account)
+	return e.issueLogin(ctx, found, auth.ClientInfo{Device: request.Device, IP: ip, UserAgent: request.UserAgent}, response)
+}
+
+// 发送短信登录验证码
+func (e *User) SendLoginCode(ctx context.Context, request *user.PhoneRequest, response *user.Response) error {
+	if err := e.OTPService.SendLoginCode(ctx, request.Phone); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "如果该手机号已注册，验证码将发送到该手机号"
+	return nil
+}
+
+// 短信验证码登录
+func (e *User) LoginWithCode(ctx context.Context, request *user.CodeLoginRequest, response *user.LoginResponse) error {
+	found, err := e.OTPService.LoginWithCode(request.Phone, request.Code)
+	if err != nil {
+		return toMicroError(err)
+	}
+	return e.issueLogin(ctx, found, auth.ClientInfo{
+		Device:    request.Device,
+		IP:        clientIP(ctx, request.ClientIp),
+		UserAgent: request.UserAgent,
+	}, response)
+}
+
+// issueLogin 为登录成功的用户创建会话并填充响应，client 记录在会话上
+func (e *User) issueLogin(ctx context.Context, found *model.User, client auth.ClientInfo, response *user.LoginResponse) error {
+	pair, err := e.Tokens.Issue(ctx, found.ID, found.Roles, client)
+	if err != nil {
+		return toMicroError(err)
+	}
+	response.User = &user.UserInfo{}
+	fillUserInfo(found, response.User)
+	response.Token = &user.TokenPair{}
+	fillTokenPair(pair, response.Token)
+	return nil
+}
+
+// 刷新令牌，用户已锁定或注销时拒绝并吊销会话
+func (e *User) RefreshToken(ctx context.Context, request *user.RefreshRequest, response *user.TokenPair) error {
+	pair, err := e.Tokens.Refresh(ctx, request.RefreshToken, auth.ClientInfo{
+		IP:        clientIP(ctx, request.ClientIp),
+		UserAgent: request.UserAgent,
+	})
+	if err != nil {
+		return toMicroError(err)
+	}
+	found, err := e.UserDataService.FindUserByID(pair.UserID)
+	if err == nil && found.Status == model.StatusLocked {
+		err = service.ErrUserLocked
+	}
+	if err != nil {
+		e.revokeSessions(ctx, pair.UserID)
+		return toMicroError(err)
+	}
+	fillTokenPair(pair, response)
+	return nil
+}
+
+// 登出
+func (e *User) Logout(ctx context.Context, request *user.LogoutRequest, response *user.Response) error {
+	if err := e.Tokens.Revoke(ctx, request.Token); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "已登出"
+	return nil
+}
+
+// 根据ID查询用户
+func (e *User) FindUserByID(ctx context.Context, request *user.UserID, response *user.UserInfo) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	found, err := e.UserDataService.FindUserByID(request.UserId)
+	if err != nil {
+		return toMicroError(err)
+	}
+	fillUserInfo(found, response)
+	return nil
+}
+
+// 更新资料
+func (e *User) UpdateProfile(ctx context.Context, request *user.UserInfo, response *user.Response) error {
+	if err := authorizeUser(ctx, request.Id); err != nil {
+		return err
+	}
+	return toMicroError(e.UserDataService.UpdateProfile(&model.User{
+		ID:       request.Id,
+		Username: request.Username,
+		Phone:    optionalPhone(request.Phone),
+		Avatar:   request.Avatar,
+	}))
+}
+
+// 修改密码
+func (e *User) ChangePassword(ctx context.Context, request *user.ChangePasswordRequest, response *user.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	if err := e.UserDataService.ChangePassword(request.UserId, request.OldPassword, request.NewPassword); err != nil {
+		return toMicroError(err)
+	}
+	e.revokeSessions(ctx, request.UserId)
+	return nil
+}
+
+// 变更状态，只能锁定或恢复正常，校验与锁定、解锁账号相同
+func (e *User) UpdateStatus(ctx context.Context, request *user.StatusRequest, response *user.Response) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	return toMicroError(e.AdminService.UpdateStatus(ctx, actor, request.UserId, int(request.Status)))
+}
+
+// 变更角色
+func (e *User) UpdateRoles(ctx context.Context, request *user.RolesRequest, response *user.Response) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	err = e.UserDataService.UpdateRoles(request.UserId, request.Roles)
+	e.AdminService.Record(ctx, actor, service.AdminAuditEntry{Action: model.AdminUpdateRoles, TargetUserID: request.UserId,
+		Detail: map[string][]string{"roles": request.Roles}, Err: err})
+	if err != nil {
+		return toMicroError(err)
+	}
+	e.revokeSessions(ctx, request.UserId)
+	return nil
+}
+
+// 发送验证邮件
+func (e *User) SendVerificationEmail(ctx context.Context, request *user.UserID, response *user.Response) error {
+	if err := authorizeUser(ctx, request.UserId); err != nil {
+		return err
+	}
+	if err := e.AccountService.SendVerificationEmail(ctx, request.UserId); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "验证邮件已发送"
+	return nil
+}
+
+// 验证邮箱
+func (e *User) VerifyEmail(ctx context.Context, request *user.TokenRequest, response *user.Response) error {
+	if err := e.AccountService.VerifyEmail(request.Token); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "邮箱已验证"
+	return nil
+}
+
+// 申请重置密码，不透露邮箱是否已注册
+func (e *User) RequestPasswordReset(ctx context.Context, request *user.PasswordResetRequest, response *user.Response) error {
+	if err := e.AccountService.RequestPasswordReset(ctx, request.Email); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "如果该邮箱已注册，重置链接将发送到该邮箱"
+	return nil
+}
+
+// 重置密码
+func (e *User) ResetPassword(ctx context.Context, request *user.ResetPasswordRequest, response *user.Response) error {
+	userID, err := e.AccountService.ResetPassword(request.Token, request.NewPassword)
+	if err != nil {
+		return toMicroError(err)
+	}
+	e.revokeSessions(ctx, userID)
+	response.Msg = "密码已重置，请重新登录"
+	return nil
+}
+
+// 解锁账号，与 AdminUnlockUser 相同但不带原因
+func (e *User) UnlockUser(ctx context.Context, request *user.UserID, response *user.Response) error {
+	actor, err := adminActor(ctx)
+	if err != nil {
+		return err
+	}
+	if err := e.AdminService.UnlockUser(ctx, actor, request.UserId, ""); err != nil {
+		return toMicroError(err)
+	}
+	response.Msg = "账号已解锁"
+	return nil
+}
+
+// revokeSessions 吊销失败只记录日志，变更本身已经生效
+func (e *User) revokeSessions(ctx context.Context, userID int64) {
+	if err := e.Tokens.RevokeUser(ctx, userID); err != nil {
+		slog.Error("吊销用户会话失败", "user_id", userID, "error", err)
+	}
+}
+
+func fillTokenPair(pair *auth.TokenPair, info *user.TokenPair) {
+	info.AccessToken = pair.AccessToken
+	info.RefreshToken = pair.RefreshToken
+	info.TokenType = "Bearer"
+	info.AccessExpiresAt = pair.AccessExpiresAt.Unix()
+	info.RefreshExpiresAt = pair.RefreshExpiresAt.Unix()
+	info.SessionId = pair.SessionID
+}
+
+func fillUserInfo(found *model.User, info *user.UserInfo) {
+	info.Id = found.ID
+	info.Username = found.Username
+	info.Email = found.Email
+	info.Phone = found.PhoneNumber()
+	info.Avatar = found.Avatar
+	info.Status = int32(found.Status)
+	info.CreatedAt = found.CreatedAt.Unix()
+	info.UpdatedAt = found.UpdatedAt.Unix()
+	info.Roles = found.Roles
+	info.EmailVerified = found.EmailVerified()
+}
+
+// clientIP 调用方持服务令牌（即网关）时使用其填写的客户端 IP，否则取 go-micro 记录的调用方地址。
+// 登录等公开端点任何人都可以调用，普通调用方填写的 IP 不可信，不能用于失败计数
+func clientIP(ctx context.Context, forwarded string) string {
+	if claims, ok := auth.FromContext(ctx); ok && claims.IsService() {
+		if ip := net.ParseIP(strings.TrimSpace(forwarded)); ip != nil {
+			return ip.String()
+		}
+	}
+	remote, _ := metadata.Get(ctx, "Remote")
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
+
+// optionalPhone 空字符串表示未绑定手机号
+func optionalPhone(phone string) *string {
+	if phone == "" {
+		return nil
+	}
+	return &phone
+}
@@ -0,0 +1,256 @@
+// go
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"user/domain/model"
+	"user/domain/repository"
+	srv "user/domain/service"
+	"user/handler"
+	"user/metrics"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/microauth"
+	config "github.com/Ben1524/GoMall/common/config"
+	"github.com/Ben1524/GoMall/common/db"
+	"github.com/Ben1524/GoMall/common/notify"
+	"github.com/Ben1524/GoMall/common/otel"
+	"github.com/Ben1524/GoMall/common/proto/privacy"
+	"go-micro.dev/v5"
+	"go-micro.dev/v5/client"
+	"go-micro.dev/v5/registry"
+	"go-micro.dev/v5/registry/consul"
+	"go-micro.dev/v5/server"
+	"go-micro.dev/v5/wrapper/trace/opentelemetry"
+	ratelimit3 "go.uber.org/ratelimit"
+	"golang.org/x/time/rate"
+
+	pb "user/proto/user"
+
+	// 限流器（Uber 令牌桶）
+	ratelimit "github.com/micro/plugins/v5/wrapper/ratelimiter/uber"
+)
+
+// QPS 限流阈值（按需调整）
+const qps = 500 // QPS是指每秒的访问量
+
+// 自定义限流器中间件（非阻塞模式，超过阈值直接拒绝）
+func NewNonBlockingLimiter(qps int) server.HandlerWrapper {
+	limiter := rate.NewLimiter(rate.Limit(qps), qps*2) // 桶容量=QPS
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		return func(ctx context.Context, req server.Request, rsp interface{}) error {
+			// 尝试获取令牌，不等待
+			if !limiter.Allow() {
+				// 没有令牌，直接返回错误（可自定义错误类型）
+				return fmt.Errorf("请求过于频繁，请稍后再试")
+			}
+			// 有令牌，继续处理请求
+			return h(ctx, req, rsp)
+		}
+	}
+}
+
+func main() {
+	cfg, err := config.Load("D:\\GolandProjects\\GoMall\\user\\config.example.yaml")
+	if err != nil {
+		slog.Error("config加载失败", "error", err)
+		panic(err)
+	}
+	slog.Info("config加载成功", "path", "user/config.example.yaml")
+
+	promMetrics := metrics.New(cfg.Server.ServiceName, cfg.Metrics.Enabled)
+	if cfg.Metrics.Enabled {
+		promMetrics.StartHTTPServer(cfg.Metrics.Host, cfg.Metrics.Port, cfg.Metrics.Path)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	// OTel/Jaeger
+	tp, err := otel.InitTracerProvider(ctx, cfg)
+	if err != nil {
+		slog.Error("初始化Jaeger追踪失败", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if tp != nil {
+			if err := tp.Shutdown(ctx); err != nil {
+				slog.Warn("关闭Jaeger追踪器失败", "error", err)
+			} else {
+				slog.Info("Jaeger追踪器已关闭")
+			}
+		}
+	}()
+
+	// MySQL
+	mysqlDB, err := db.NewMysqlGorm(cfg)
+	if err != nil {
+		slog.Error("初始化MySQL连接失败", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := mysqlDB.Close(); err != nil {
+			slog.Warn("关闭MySQL连接失败", "error", err)
+		} else {
+			slog.Info("MySQL连接已关闭")
+		}
+	}()
+
+	userRepository := repository.NewUserRepository(mysqlDB)
+
+	if err := userRepository.InitTable(); err != nil {
+		slog.Error("init table error")
+		panic(err)
+	}
+	userService := srv.NewUserDataService(userRepository)
+
+	addressRepository := repository.NewAddressRepository(mysqlDB)
+	if err := addressRepository.InitTable(); err != nil {
+		slog.Error("init address table error")
+		panic(err)
+	}
+	addressService := srv.NewAddressService(addressRepository)
+
+	// 邮箱验证与密码重置
+	accountTokenRepository := repository.NewAccountTokenRepository(mysqlDB)
+	if err := accountTokenRepository.InitTable(); err != nil {
+		slog.Error("init account token table error")
+		panic(err)
+	}
+	emailSender, err := notify.NewEmailSender(cfg.Notify)
+	if err != nil {
+		slog.Error("初始化邮件发送失败", "error", err)
+		os.Exit(1)
+	}
+	accountService := srv.NewAccountService(userRepository, accountTokenRepository, emailSender, cfg.Account)
+
+	// 短信验证码登录
+	phoneOTPRepository := repository.NewPhoneOTPRepository(mysqlDB)
+	if err := phoneOTPRepository.InitTable(); err != nil {
+		slog.Error("init phone otp table error")
+		panic(err)
+	}
+	smsSender, err := notify.NewSMSSender(cfg.Notify)
+	if err != nil {
+		slog.Error("初始化短信发送失败", "error", err)
+		os.Exit(1)
+	}
+	otpService := srv.NewOTPService(userRepository, phoneOTPRepository, smsSender, cfg.OTP)
+
+	// 登录防暴力破解
+	loginGuard := srv.NewLoginGuard(userRepository, repository.NewLoginAttemptStore(cfg), promMetrics, cfg.LoginGuard)
+
+	// 会话存储与令牌签发
+	sessionStore, err := auth.NewStore(cfg)
+	if err != nil {
+		slog.Error("初始化会话存储失败", "error", err)
+		os.Exit(1)
+	}
+	tokenManager, err := auth.NewManager(cfg.JWT, sessionStore)
+	if err != nil {
+		slog.Error("初始化令牌管理失败", "error", err)
+		os.Exit(1)
+	}
+
+	consulRegistry := consul.NewConsulRegistry(registry.Addrs("127.0.0.1:8500"))
+
+	slog.Info(cfg.Metrics.Host + ":" + cfg.Metrics.Port)
+
+	consulRegistry.Register(&registry.Service{
+		Name: cfg.Server.ServiceName,
+		Nodes: []*registry.Node{
+			{
+				Metadata: map[string]string{
+					"prometheus-monitor": "true",     // 必须和Prometheus的过滤规则匹配
+					"service-type":       "go-micro", // 可选，自定义标签
+				},
+				Address: cfg.Metrics.Host + ":" + cfg.Metrics.Port,
+			}},
+	})
+
+	handlerWrappers := []server.HandlerWrapper{
+		ratelimit.NewHandlerWrapper(qps, ratelimit3.WithSlack(3*qps)),
+		opentelemetry.NewHandlerWrapper(),
+		microauth.NewHandlerWrapper(tokenManager, cfg.Server.ServiceName, handler.Policy),
+	}
+	if cfg.Metrics.Enabled {
+		handlerWrappers = append([]server.HandlerWrapper{promMetrics.ServerWrapper()}, handlerWrappers...)
+	}
+
+	clientWrappers := []client.Wrapper{
+		ratelimit.NewClientWrapper(qps, ratelimit3.WithSlack(3*qps)),
+		opentelemetry.NewClientWrapper(),
+	}
+	if cfg.Metrics.Enabled {
+		// 注意promMetrics.ClientWrapper()必须放在最前面,作为最外层的Wrapper
+		clientWrappers = append([]client.Wrapper{promMetrics.ClientWrapper()}, clientWrappers...)
+	}
+
+	serviceMetadata := map[string]string{
+		"service-type":       "go-micro",
+		"prometheus-monitor": fmt.Sprintf("%t", cfg.Metrics.Enabled),
+		"address":            fmt.Sprintf("%s:%s", cfg.Metrics.Host, cfg.Metrics.Port),
+	}
+	if cfg.Metrics.Enabled {
+		serviceMetadata["metrics_host"] = cfg.Metrics.Host
+		serviceMetadata["metrics_port"] = cfg.Metrics.Port
+		serviceMetadata["metrics_path"] = cfg.Metrics.Path
+	}
+
+	serviceOptions := []micro.Option{
+		micro.Name(cfg.Server.ServiceName),
+		micro.Version("latest"),
+		micro.Registry(consulRegistry),
+		micro.Metadata(serviceMetadata),
+		micro.WrapHandler(handlerWrappers...),
+		micro.WrapClient(clientWrappers...),
+	}
+
+	service := micro.NewService(serviceOptions...)
+	service.Init()
+
+	// 数据导出与注销：逐个调用各服务的 Privacy 接口，抽奖服务没有与用户关联的数据，步骤记为跳过
+	privacyRepository := repository.NewPrivacyRepository(mysqlDB)
+	if err := privacyRepository.InitTable(); err != nil {
+		slog.Error("init privacy table error")
+		panic(err)
+	}
+	// 各服务的 Privacy 接口只接受服务令牌，调用时以用户服务身份签发
+	privacyClient := microauth.NewServiceClient(service.Client(), tokenManager, cfg.Server.ServiceName)
+	privacySources := map[string]srv.IPrivacyDataSource{
+		model.PrivacyServiceCart:    srv.NewRemotePrivacySource(privacy.NewPrivacyService("go.micro.service.cart", privacyClient)),
+		model.PrivacyServiceOrder:   srv.NewRemotePrivacySource(privacy.NewPrivacyService("go.micro.service.order", privacyClient)),
+		model.PrivacyServicePayment: srv.NewRemotePrivacySource(privacy.NewPrivacyService("go.micro.service.payment", privacyClient)),
+		model.PrivacyServiceUser: srv.NewUserPrivacySource(userRepository, addressRepository, accountTokenRepository,
+			phoneOTPRepository, privacyRepository, tokenManager),
+	}
+	privacyService := srv.NewPrivacyService(privacyRepository, userRepository, privacySources, cfg.Privacy)
+	go privacyService.Run(ctx, cfg.Privacy.Interval)
+
+	adminAuditRepository := repository.NewAdminAuditRepository(mysqlDB)
+	if err := adminAuditRepository.InitTable(); err != nil {
+		slog.Error("init admin audit table error")
+		panic(err)
+	}
+	adminService := srv.NewAdminService(userRepository, adminAuditRepository, accountService, loginGuard, tokenManager)
+	// 首个管理员：没有管理员时按配置授予，失败不影响启动
+	if err := adminService.BootstrapAdmin(context.Background(), cfg.Account.BootstrapAdminEmail); err != nil {
+		slog.Error("初始化管理员失败", "email", cfg.Account.BootstrapAdminEmail, "error", err)
+	}
+
+	userHandler := handler.NewUserHandler(userService, addressService, accountService, otpService, loginGuard,
+		privacyService, adminService, tokenManager)
+	if err := pb.RegisterUserHandler(service.Server(), userHandler); err != nil {
+		slog.Error("注册User处理器失败", "error", err)
+		os.Exit(1)
+	}
+
+	if err := service.Run(); err != nil {
+		slog.Error("服务运行失败", "error", err)
+	}
+}
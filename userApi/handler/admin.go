@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"userApi/proto/user"
+
+	"github.com/gin-gonic/gin"
+	"go-micro.dev/v5/client"
+)
+
+// RegisterAdminRoutes 将客服后台路由注册到给定路由组，权限由路由组上的策略校验，
+// 操作人取自转发的访问令牌，每次调用都由用户服务写入审计记录
+func (e *UserApi) RegisterAdminRoutes(group *gin.RouterGroup) {
+	group.GET("/users", e.handleSearchUsers)
+	group.GET("/users/:id", e.handleFindUser)
+	group.POST("/users/:id/lock", e.handleLockUser)
+	group.POST("/users/:id/unlock", e.handleUnlockUser)
+	group.POST("/users/:id/password-reset", e.handleResetPassword)
+	group.GET("/audit-logs", e.handleFindAuditLogs)
+}
+
+// adminActionRequest 管理操作的原因，写入审计记录
+type adminActionRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// adminResetPasswordRequest invalidate_password 为 true 时同时作废当前密码并退出全部设备
+type adminResetPasswordRequest struct {
+	Reason             string `json:"reason" binding:"max=255"`
+	InvalidatePassword bool   `json:"invalidate_password"`
+}
+
+// handleSearchUsers 按邮箱、手机号前缀或用户名搜索用户，?status=&role=&page=&page_size=
+func (e *UserApi) handleSearchUsers(ctx *gin.Context) {
+	status, ok := parseIntQuery(ctx, "status")
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePage(ctx)
+	if !ok {
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.UserService.AdminSearchUsers(requestCtx, &user.AdminUserSearchRequest{
+		Email:    ctx.Query("email"),
+		Phone:    ctx.Query("phone"),
+		Username: ctx.Query("username"),
+		Status:   int32(status),
+		Role:     ctx.Query("role"),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	items := make([]gin.H, 0, len(resp.GetUsers()))
+	for _, info := range resp.GetUsers() {
+		items = append(items, userJSON(info))
+	}
+	ctx.JSON(http.StatusOK, gin.H{"users": items, "total": resp.GetTotal(), "page": resp.GetPage(), "page_size": resp.GetPageSize()})
+}
+
+// handleFindUser 查看用户资料与状态，已注销的用户同样可见
+func (e *UserApi) handleFindUser(ctx *gin.Context) {
+	userID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	info, err := e.UserService.AdminFindUser(requestCtx, &user.UserID{UserId: userID})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, userJSON(info))
+}
+
+// handleLockUser 锁定账号并退出全部设备
+func (e *UserApi) handleLockUser(ctx *gin.Context) {
+	e.handleAdminAction(ctx, e.UserService.AdminLockUser)
+}
+
+// handleUnlockUser 解除管理员锁定与登录失败锁定
+func (e *UserApi) handleUnlockUser(ctx *gin.Context) {
+	e.handleAdminAction(ctx, e.UserService.AdminUnlockUser)
+}
+
+func (e *UserApi) handleAdminAction(ctx *gin.Context,
+	call func(context.Context, *user.AdminActionRequest, ...client.CallOption) (*user.Response, error)) {
+	userID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	var body adminActionRequest
+	if err := bindOptionalJSON(ctx, &body); err != nil {
+		respondBadRequest(ctx, "invalid request payload", err)
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := call(requestCtx, &user.AdminActionRequest{UserId: userID, Reason: body.Reason})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": resp.GetMsg()})
+}
+
+// handleResetPassword 向用户邮箱发送重置链接
+func (e *UserApi) handleResetPassword(ctx *gin.Context) {
+	userID, ok := parseIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	var body adminResetPasswordRequest
+	if err := bindOptionalJSON(ctx, &body); err != nil {
+		respondBadRequest(ctx, "invalid request payload", err)
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.UserService.AdminResetPassword(requestCtx, &user.AdminResetPasswordRequest{
+		UserId:             userID,
+		Reason:             body.Reason,
+		InvalidatePassword: body.InvalidatePassword,
+	})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": resp.GetMsg()})
+}
+
+// handleFindAuditLogs 查询审计记录，?admin_id=&target_user_id=&action=&since=&until=&page=&page_size=，时间为 Unix 秒
+func (e *UserApi) handleFindAuditLogs(ctx *gin.Context) {
+	request := &user.AuditLogRequest{Action: ctx.Query("action")}
+	for key, target := range map[string]*int64{
+		"admin_id":       &request.AdminId,
+		"target_user_id": &request.TargetUserId,
+		"since":          &request.Since,
+		"until":          &request.Until,
+	} {
+		value, ok := parseIntQuery(ctx, key)
+		if !ok {
+			return
+		}
+		*target = value
+	}
+	var ok bool
+	if request.Page, request.PageSize, ok = parsePage(ctx); !ok {
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.UserService.FindAdminAuditLogs(requestCtx, request)
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	items := make([]gin.H, 0, len(resp.GetLogs()))
+	for _, log := range resp.GetLogs() {
+		items = append(items, gin.H{
+			"id":               log.GetId(),
+			"admin_id":         log.GetAdminId(),
+			"admin_session_id": log.GetAdminSessionId(),
+			"admin_roles":      log.GetAdminRoles(),
+			"action":           log.GetAction(),
+			"target_user_id":   log.GetTargetUserId(),
+			"reason":           log.GetReason(),
+			"detail":           log.GetDetail(),
+			"result":           log.GetResult(),
+			"created_at":       log.GetCreatedAt(),
+		})
+	}
+	ctx.JSON(http.StatusOK, gin.H{"logs": items, "total": resp.GetTotal(), "page": resp.GetPage(), "page_size": resp.GetPageSize()})
+}
+
+func userJSON(info *user.UserInfo) gin.H {
+	return gin.H{
+		"id":             info.GetId(),
+		"username":       info.GetUsername(),
+		"email":          info.GetEmail(),
+		"phone":          info.GetPhone(),
+		"status":         info.GetStatus(),
+		"roles":          info.GetRoles(),
+		"email_verified": info.GetEmailVerified(),
+		"created_at":     info.GetCreatedAt(),
+		"updated_at":     info.GetUpdatedAt(),
+	}
+}
+
+// bindOptionalJSON 请求体可省略
+func bindOptionalJSON(ctx *gin.Context, body interface{}) error {
+	if ctx.Request.ContentLength == 0 {
+		return nil
+	}
+	return ctx.ShouldBindJSON(body)
+}
+
+func parseIDParam(ctx *gin.Context, key string) (int64, bool) {
+	value, err := strconv.ParseInt(ctx.Param(key), 10, 64)
+	if err != nil || value <= 0 {
+		respondBadRequest(ctx, fmt.Sprintf("invalid %s", key), err)
+		return 0, false
+	}
+	return value, true
+}
+
+// parseIntQuery 解析可选的整数查询参数，缺省为 0
+func parseIntQuery(ctx *gin.Context, key string) (int64, bool) {
+	raw := ctx.Query(key)
+	if raw == "" {
+		return 0, true
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value < 0 {
+		respondBadRequest(ctx, fmt.Sprintf("invalid %s", key), err)
+		return 0, false
+	}
+	return value, true
+}
+
+// parsePage 页码与每页条数，缺省与上限由用户服务决定
+func parsePage(ctx *gin.Context) (page, pageSize int32, ok bool) {
+	values := [2]int64{}
+	for i, key := range []string{"page", "page_size"} {
+		if values[i], ok = parseIntQuery(ctx, key); !ok {
+			return 0, 0, false
+		}
+		if values[i] > 1<<31-1 {
+			respondBadRequest(ctx, fmt.Sprintf("invalid %s", key), nil)
+			return 0, 0, false
+		}
+	}
+	return int32(values[0]), int32(values[1]), true
+}
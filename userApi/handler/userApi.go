@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"userApi/proto/user"
+
+	"github.com/Ben1524/GoMall/common/auth/ginauth"
+	"github.com/gin-gonic/gin"
+	microerrors "go-micro.dev/v5/errors"
+)
+
+type UserApi struct {
+	UserService user.UserService
+}
+
+const defaultRequestTimeout = 5 * time.Second
+
+func NewUserApiHandler(userService user.UserService) *UserApi {
+	return &UserApi{UserService: userService}
+}
+
+// RegisterRoutes 将当前登录用户的路由注册到给定路由组。
+func (e *UserApi) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/sessions", e.handleListSessions)
+	group.DELETE("/sessions/:id", e.handleRevokeSession)
+	group.DELETE("/sessions", e.handleRevokeAllSessions)
+}
+
+// handleListSessions 列出当前用户的登录设备，current 标记发起请求的设备
+func (e *UserApi) handleListSessions(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.UserService.ListSessions(requestCtx, &user.UserID{UserId: userID})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	items := make([]gin.H, 0, len(resp.GetSessions()))
+	for _, session := range resp.GetSessions() {
+		items = append(items, gin.H{
+			"id":           session.GetSessionId(),
+			"device":       session.GetDevice(),
+			"ip":           session.GetIp(),
+			"user_agent":   session.GetUserAgent(),
+			"created_at":   session.GetCreatedAt(),
+			"last_seen_at": session.GetLastSeenAt(),
+			"expires_at":   session.GetExpiresAt(),
+			"current":      session.GetCurrent(),
+		})
+	}
+	ctx.JSON(http.StatusOK, gin.H{"sessions": items})
+}
+
+// handleRevokeSession 退出一个设备，该设备的令牌在下一个请求即失效
+func (e *UserApi) handleRevokeSession(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.UserService.RevokeSession(requestCtx, &user.SessionRequest{UserId: userID, SessionId: ctx.Param("id")})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": resp.GetMsg()})
+}
+
+// handleRevokeAllSessions 退出全部设备，?keep_current=true 时保留当前设备
+func (e *UserApi) handleRevokeAllSessions(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+	keepCurrent, err := strconv.ParseBool(ctx.DefaultQuery("keep_current", "false"))
+	if err != nil {
+		respondBadRequest(ctx, "invalid keep_current", err)
+		return
+	}
+	requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), defaultRequestTimeout)
+	defer cancel()
+
+	resp, err := e.UserService.RevokeAllSessions(requestCtx, &user.RevokeSessionsRequest{UserId: userID, KeepCurrent: keepCurrent})
+	if err != nil {
+		respondServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": resp.GetMsg()})
+}
+
+// currentUserID 当前登录用户，须在 RequireUser 之后调用
+func currentUserID(ctx *gin.Context) (int64, bool) {
+	userID, ok := ginauth.UserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthenticated"})
+	}
+	return userID, ok
+}
+
+func respondBadRequest(ctx *gin.Context, message string, err error) {
+	payload := gin.H{"error": message}
+	if err != nil {
+		payload["details"] = err.Error()
+	}
+	ctx.JSON(http.StatusBadRequest, payload)
+}
+
+// respondServiceError 用户服务返回的业务错误（4xx）原样透出状态码，其余按 500 处理
+func respondServiceError(ctx *gin.Context, err error) {
+	merr := microerrors.FromError(err)
+	code := int(merr.Code)
+	if code < http.StatusBadRequest || code > 599 {
+		code = http.StatusInternalServerError
+	}
+	if code >= http.StatusInternalServerError {
+		ErrorHandle(err)
+	}
+	message := merr.Detail
+	if message == "" || code == http.StatusInternalServerError {
+		message = strings.ToLower(http.StatusText(code))
+	}
+	ctx.JSON(code, gin.H{"error": message})
+}
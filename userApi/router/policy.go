@@ -0,0 +1,13 @@
+package router
+
+import "github.com/Ben1524/GoMall/common/auth"
+
+// adminPolicy 客服后台接口的访问策略，键为 "方法 路由模板"；用户服务按 RPC 再校验一次
+var adminPolicy = auth.Policy{
+	"GET /api/v1/admin/users":                     auth.PermUserManage,
+	"GET /api/v1/admin/users/:id":                 auth.PermUserManage,
+	"POST /api/v1/admin/users/:id/lock":           auth.PermUserManage,
+	"POST /api/v1/admin/users/:id/unlock":         auth.PermUserManage,
+	"POST /api/v1/admin/users/:id/password-reset": auth.PermUserManage,
+	"GET /api/v1/admin/audit-logs":                auth.PermAuditRead,
+}
@@ -0,0 +1,49 @@
+package router
+
+import (
+	"net/http"
+	"userApi/handler"
+
+	"github.com/Ben1524/GoMall/common/auth"
+	"github.com/Ben1524/GoMall/common/auth/ginauth"
+	"github.com/Ben1524/GoMall/common/config"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultAPIPrefix = "/api/v1"
+
+// New 创建并初始化 Gin 引擎，注册网关路由。
+// - cfg:      全局配置（用于设置运行模式等）
+// - verifier: 访问令牌校验，每个请求都查询共享的会话存储，会话吊销后下一个请求即返回 401
+// - h:        具体业务处理器
+func New(cfg *config.Config, verifier auth.Verifier, h *handler.UserApi) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(gin.Logger())
+
+	// 健康检查
+	r.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	// 当前登录用户的接口，如 GET /api/v1/sessions
+	requireUser := ginauth.RequireUser(verifier)
+	h.RegisterRoutes(r.Group(defaultAPIPrefix, requireUser))
+
+	// 客服后台接口，如 GET /api/v1/admin/users，权限见 adminPolicy
+	h.RegisterAdminRoutes(r.Group(defaultAPIPrefix+"/admin", requireUser, ginauth.Authorize(adminPolicy)))
+
+	// 404 处理
+	r.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not found",
+			"path":    c.Request.URL.Path,
+			"method":  c.Request.Method,
+			"service": "userApi-gateway",
+		})
+	})
+
+	return r
+}